@@ -60,6 +60,21 @@ The build scope is context-aware based on your current directory:
 By default, all builds continue even if one fails. Use --fail-fast for CI environments
 where you want to stop immediately on the first failure.
 
+A cached build is only reported as cached when its outputs are on disk: each
+successful build snapshots bin/ (or bin/<goos>_<goarch> under --target) into a
+local artifact store, and a cache hit restores any binaries that are missing
+or differ from that snapshot. A hit with no snapshot rebuilds.
+
+Dependent projects build in waves by default. --schedule critical-path starts
+each project as soon as its own dependencies finish, longest remaining chain
+first (estimated from previous run durations); with --dry-run it prints the
+predicted critical path and wall-clock time.
+
+--watch keeps the build running: after the first run, each change under a
+project (ignoring bin/ and .git) rebuilds that project and its dependents,
+with the other projects' last results kept on screen. Press 'r' (or Enter on
+stdin in --json mode) to rebuild everything.
+
 This command replaces the root 'make build' for a faster and more informative build experience.`
 
 	cmd.RunE = func(cmd *cobra.Command, args []string) error {
@@ -80,7 +95,7 @@ This command replaces the root 'make build' for a faster and more informative bu
 
 type projectStatus struct {
 	name     string
-	status   string // "pending", "running", "success", "failed", "cached"
+	status   string // "pending", "running", "success", "failed", "cached", "skipped", "quarantined"
 	output   string
 	duration time.Duration
 }
@@ -106,10 +121,18 @@ type tuiModel struct {
 	successCount  int
 	failCount     int
 	skipCount     int
+	quarantined   int
 	runningCount  int
 	eventsChan    <-chan orch.TaskEvent
 	jobIndexMap   map[string]int
 	viewport      viewport.Model
+
+	// --watch state: the session, how many runs have started, whether a
+	// waitCmd is outstanding, and a rerun that arrived mid-run.
+	watch    *taskWatch
+	runs     int
+	watching bool
+	queued   []orch.TaskJob
 }
 
 type buildsStartedMsg struct {
@@ -117,45 +140,11 @@ type buildsStartedMsg struct {
 	jobIndexMap map[string]int
 }
 
-func runTuiBuild(o *orch.Orchestrator, verb string, jobs []orch.TaskJob) error {
-	var projects []projectStatus
-	for _, job := range jobs {
-		projects = append(projects, projectStatus{name: job.Name, status: "pending"})
-	}
-
-	s := spinner.New()
-	s.Spinner = spinner.Dot
-	s.Style = theme.DefaultTheme.Highlight
+// buildsDoneMsg reports that the current run's event stream closed.
+type buildsDoneMsg struct{}
 
-	items := make([]list.Item, len(projects))
-	for i, p := range projects {
-		items[i] = p
-	}
-
-	l := list.New(items, list.NewDefaultDelegate(), 0, len(projects)+4)
-	l.Title = ""
-	l.SetShowTitle(false)
-	l.SetShowStatusBar(false)
-	l.SetShowPagination(false)
-	l.SetShowHelp(false)
-
-	logViewport := viewport.New(0, 0)
-	logViewport.SetContent("Waiting for build output...")
-
-	m := tuiModel{
-		verb:         verb,
-		projects:     projects,
-		orchestrator: o,
-		jobs:         jobs,
-		list:         l,
-		spinner:      s,
-		logViewport:  logViewport,
-		maxLogLines:  200,
-		viewMode:     "list",
-		interactive:  buildInteractive,
-	}
-
-	p := tea.NewProgram(m)
+func runTuiBuild(o *orch.Orchestrator, verb string, jobs []orch.TaskJob) error {
+	p := tea.NewProgram(newTuiModel(o, verb, jobs))
 	finalModel, err := p.Run()
 	if err != nil {
 		return err
@@ -196,14 +185,66 @@ func runTuiBuild(o *orch.Orchestrator, verb string, jobs []orch.TaskJob) error {
 	return nil
 }
 
+// runTuiWatch is runTuiBuild for --watch: the TUI stays up between runs,
+// keeping each project's last result visible while impacted ones rerun.
+// Quitting ends the session without a failure summary.
+func runTuiWatch(o *orch.Orchestrator, verb string, jobs []orch.TaskJob, w *taskWatch) error {
+	m := newTuiModel(o, verb, jobs)
+	m.watch = w
+	_, err := tea.NewProgram(m).Run()
+	return err
+}
+
+func newTuiModel(o *orch.Orchestrator, verb string, jobs []orch.TaskJob) tuiModel {
+	var projects []projectStatus
+	for _, job := range jobs {
+		projects = append(projects, projectStatus{name: job.Name, status: "pending"})
+	}
+
+	s := spinner.New()
+	s.Spinner = spinner.Dot
+	s.Style = theme.DefaultTheme.Highlight
+
+	items := make([]list.Item, len(projects))
+	for i, p := range projects {
+		items[i] = p
+	}
+
+	l := list.New(items, list.NewDefaultDelegate(), 0, len(projects)+4)
+	l.Title = ""
+	l.SetShowTitle(false)
+	l.SetShowStatusBar(false)
+	l.SetShowPagination(false)
+	l.SetShowHelp(false)
+
+	logViewport := viewport.New(0, 0)
+	logViewport.SetContent("Waiting for build output...")
+
+	return tuiModel{
+		verb:         verb,
+		projects:     projects,
+		orchestrator: o,
+		jobs:         jobs,
+		list:         l,
+		spinner:      s,
+		logViewport:  logViewport,
+		maxLogLines:  200,
+		viewMode:     "list",
+		interactive:  buildInteractive,
+		runs:         1,
+	}
+}
+
 func (m tuiModel) Init() tea.Cmd {
-	return tea.Batch(m.spinner.Tick, m.startBuildsCmd())
+	return tea.Batch(m.spinner.Tick, m.startBuildsCmd(m.jobs))
 }
 
-func (m tuiModel) startBuildsCmd() tea.Cmd {
+// startBuildsCmd runs jobs (all of m.jobs, or a watch rerun's subset); the
+// index map always covers every project so untouched rows keep their result.
+func (m tuiModel) startBuildsCmd(jobs []orch.TaskJob) tea.Cmd {
 	return func() tea.Msg {
 		ctx := context.Background()
-		eventsChan := m.orchestrator.RunWithEvents(ctx, m.jobs)
+		eventsChan := m.orchestrator.RunWithEvents(ctx, jobs)
 
 		jobIndexMap := make(map[string]int)
 		for i, job := range m.jobs {
@@ -221,7 +262,7 @@ func (m tuiModel) waitForBuildEventCmd() tea.Cmd {
 	return func() tea.Msg {
 		event, ok := <-m.eventsChan
 		if !ok {
-			return nil
+			return buildsDoneMsg{}
 		}
 		return event
 	}
@@ -278,9 +319,14 @@ func (m tuiModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		switch msg.String() {
 		case "q", "ctrl+c":
 			return m, tea.Quit
+		case "r":
+			if m.watch != nil && m.finished {
+				return m.startRerun(watchTriggerMsg{jobs: m.jobs})
+			}
+			return m, nil
 		case "enter":
 			if i := m.list.SelectedItem(); i != nil {
-				if p, ok := i.(projectStatus); ok && (p.status == "failed" || p.status == "success") {
+				if p, ok := i.(projectStatus); ok && (p.status == "failed" || p.status == "success" || p.status == "quarantined") {
 					m.viewMode = "logs"
 					m.viewport.SetContent(p.output)
 					m.viewport.GotoTop()
@@ -294,6 +340,32 @@ func (m tuiModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.jobIndexMap = msg.jobIndexMap
 		return m, m.waitForBuildEventCmd()
 
+	case buildsDoneMsg:
+		if m.watch == nil {
+			return m, nil
+		}
+		m.finished = true
+		if len(m.queued) > 0 {
+			queued := m.queued
+			m.queued = nil
+			return m.startRerun(watchTriggerMsg{jobs: queued})
+		}
+		return m, m.waitForChangesCmd()
+
+	case watchTriggerMsg:
+		m.watching = false
+		if !m.finished {
+			// A change landed mid-run: fold it into one follow-up run.
+			m.queued = mergeJobs(m.queued, msg.jobs)
+			return m, m.waitForChangesCmd()
+		}
+		return m.startRerun(msg)
+
+	case watchErrMsg:
+		m.appendLog(theme.DefaultTheme.Warning.Render(fmt.Sprintf("watch: %v", msg.err)))
+		m.watching = false
+		return m, m.waitForChangesCmd()
+
 	case orch.TaskEvent:
 		var cmds []tea.Cmd
 
@@ -315,7 +387,7 @@ func (m tuiModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				items[index] = m.projects[index]
 				cmds = append(cmds, m.list.SetItems(items))
 
-				if m.successCount+m.failCount+m.skipCount == len(m.projects) {
+				if m.watch == nil && m.doneCount() == len(m.projects) {
 					m.finished = true
 					if !m.interactive {
 						cmds = append(cmds, tea.Quit)
@@ -332,6 +404,9 @@ func (m tuiModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				if result.Skipped {
 					m.projects[index].status = "skipped"
 					m.skipCount++
+				} else if result.Quarantined {
+					m.projects[index].status = "quarantined"
+					m.quarantined++
 				} else if result.Err != nil {
 					m.projects[index].status = "failed"
 					m.failCount++
@@ -343,7 +418,7 @@ func (m tuiModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				items[index] = m.projects[index]
 				cmds = append(cmds, m.list.SetItems(items))
 
-				if m.successCount+m.failCount+m.skipCount == len(m.projects) {
+				if m.watch == nil && m.doneCount() == len(m.projects) {
 					m.finished = true
 					if !m.interactive {
 						cmds = append(cmds, tea.Quit)
@@ -351,16 +426,23 @@ func (m tuiModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				}
 			}
 
+		case "retry", "timeout":
+			// The next attempt emits its own "start"; keep the running
+			// count per project, not per attempt.
+			if msg.Type == "retry" {
+				m.runningCount--
+			}
+			if _, ok := m.jobIndexMap[msg.Job.Name]; ok {
+				wsStyle := getWorkspaceStyle(msg.Job.Name)
+				line := fmt.Sprintf("%s %s", wsStyle.Render(fmt.Sprintf("[%s]", msg.Job.Name)), theme.DefaultTheme.Warning.Render(msg.OutputLine))
+				m.appendLog(line)
+			}
+
 		case "output":
 			if _, ok := m.jobIndexMap[msg.Job.Name]; ok {
 				wsStyle := getWorkspaceStyle(msg.Job.Name)
 				line := fmt.Sprintf("%s %s", wsStyle.Render(fmt.Sprintf("[%s]", msg.Job.Name)), msg.OutputLine)
-				m.logLines = append(m.logLines, line)
-				if len(m.logLines) > m.maxLogLines {
-					m.logLines = m.logLines[len(m.logLines)-m.maxLogLines:]
-				}
-				m.logViewport.SetContent(strings.Join(m.logLines, "\n"))
-				m.logViewport.GotoBottom()
+				m.appendLog(line)
 			}
 		}
 
@@ -380,6 +462,91 @@ func (m tuiModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, cmd
 }
 
+// startRerun resets the rerun's projects to pending (every other row keeps
+// its last result), logs what triggered it and starts the run.
+func (m tuiModel) startRerun(msg watchTriggerMsg) (tea.Model, tea.Cmd) {
+	m.watch.prepareRerun()
+	rerun := make(map[string]bool, len(msg.jobs))
+	for _, job := range msg.jobs {
+		rerun[job.Name] = true
+	}
+	items := m.list.Items()
+	for i := range m.projects {
+		if rerun[m.projects[i].name] {
+			m.projects[i].status = "pending"
+			m.projects[i].output = ""
+			m.projects[i].duration = 0
+			items[i] = m.projects[i]
+		}
+	}
+	m.recount()
+	m.finished = false
+	m.runs++
+
+	reason := "full run"
+	if len(msg.changed) > 0 {
+		reason = "changed: " + strings.Join(msg.changed, ", ")
+	}
+	m.appendLog(theme.DefaultTheme.Muted.Render(fmt.Sprintf("── run %d (%s) ──", m.runs, reason)))
+	return m, tea.Batch(m.list.SetItems(items), m.startBuildsCmd(msg.jobs))
+}
+
+// waitForChangesCmd waits for the watcher unless a wait is already pending.
+func (m *tuiModel) waitForChangesCmd() tea.Cmd {
+	if m.watching {
+		return nil
+	}
+	m.watching = true
+	return m.watch.waitCmd()
+}
+
+// recount recomputes the status counters from the project rows (watch reruns
+// reset some rows back to pending).
+func (m *tuiModel) recount() {
+	m.successCount, m.failCount, m.skipCount, m.quarantined, m.runningCount = 0, 0, 0, 0, 0
+	for _, p := range m.projects {
+		switch p.status {
+		case "success", "cached":
+			m.successCount++
+		case "failed":
+			m.failCount++
+		case "skipped":
+			m.skipCount++
+		case "quarantined":
+			m.quarantined++
+		}
+	}
+}
+
+func (m *tuiModel) appendLog(line string) {
+	m.logLines = append(m.logLines, line)
+	if len(m.logLines) > m.maxLogLines {
+		m.logLines = m.logLines[len(m.logLines)-m.maxLogLines:]
+	}
+	m.logViewport.SetContent(strings.Join(m.logLines, "\n"))
+	m.logViewport.GotoBottom()
+}
+
+// mergeJobs appends the jobs of b not already in a.
+func mergeJobs(a, b []orch.TaskJob) []orch.TaskJob {
+	seen := make(map[string]bool, len(a))
+	for _, job := range a {
+		seen[job.Name] = true
+	}
+	for _, job := range b {
+		if !seen[job.Name] {
+			a = append(a, job)
+			seen[job.Name] = true
+		}
+	}
+	return a
+}
+
+// doneCount is the number of projects with a final status.
+func (m tuiModel) doneCount() int {
+	return m.successCount + m.failCount + m.skipCount + m.quarantined
+}
+
 func (m tuiModel) View() string {
 	if m.viewMode == "logs" {
 		header := theme.DefaultTheme.Muted.Render("Press ESC to return to list")
@@ -394,13 +561,19 @@ func (m tuiModel) View() string {
 	})
 
 	label := strings.ToUpper(m.verb[:1]) + m.verb[1:]
-	header := fmt.Sprintf("Running %s on %d projects... Running: %d, Success: %d, Skipped: %d, Failed: %d",
-		m.verb, len(m.projects), m.runningCount, m.successCount, m.skipCount, m.failCount)
-	if m.finished {
+	quarantined := ""
+	if m.quarantined > 0 {
+		quarantined = fmt.Sprintf(", Quarantined: %d", m.quarantined)
+	}
+	header := fmt.Sprintf("Running %s on %d projects... Running: %d, Success: %d, Skipped: %d, Failed: %d%s",
+		m.verb, len(m.projects), m.runningCount, m.successCount, m.skipCount, m.failCount, quarantined)
+	if m.finished && m.watch != nil {
+		header = fmt.Sprintf("%s run %d finished. Success: %d, Skipped: %d, Failed: %d%s — watching for changes (r: rerun all, enter: logs, q: quit)", label, m.runs, m.successCount, m.skipCount, m.failCount, quarantined)
+	} else if m.finished {
 		if m.interactive {
-			header = fmt.Sprintf("%s finished! Success: %d, Skipped: %d, Failed: %d (Press 'q' to quit, 'enter' to view logs)", label, m.successCount, m.skipCount, m.failCount)
+			header = fmt.Sprintf("%s finished! Success: %d, Skipped: %d, Failed: %d%s (Press 'q' to quit, 'enter' to view logs)", label, m.successCount, m.skipCount, m.failCount, quarantined)
 		} else {
-			header = fmt.Sprintf("%s finished! Success: %d, Skipped: %d, Failed: %d", label, m.successCount, m.skipCount, m.failCount)
+			header = fmt.Sprintf("%s finished! Success: %d, Skipped: %d, Failed: %d%s", label, m.successCount, m.skipCount, m.failCount, quarantined)
 		}
 	}
 
@@ -474,6 +647,9 @@ func (d projectDelegate) Render(w io.Writer, m list.Model, index int, item list.
 	case "skipped":
 		statusIcon = theme.DefaultTheme.Warning.Render("⊘")
 		durationStr = theme.DefaultTheme.Warning.Render("(skipped)")
+	case "quarantined":
+		statusIcon = theme.DefaultTheme.Warning.Render(theme.IconError)
+		durationStr = theme.DefaultTheme.Warning.Render("(failed, quarantined)")
 	case "failed":
 		statusIcon = theme.DefaultTheme.Error.Render(theme.IconError)
 		durationStr = theme.DefaultTheme.Muted.Render(fmt.Sprintf("(%v)", p.duration.Round(time.Millisecond)))
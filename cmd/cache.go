@@ -0,0 +1,167 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	"github.com/grovetools/core/cli"
+	"github.com/grovetools/core/config"
+	"github.com/grovetools/core/pkg/paths"
+	"github.com/spf13/cobra"
+
+	"github.com/grovetools/grove/pkg/cacheserver"
+	orch "github.com/grovetools/grove/pkg/orchestrator"
+)
+
+// taskCacheConfig is the grove.toml [cache] table.
+type taskCacheConfig struct {
+	Remote remoteCacheConfig `yaml:"remote"`
+}
+
+// remoteCacheConfig is [cache.remote]: the shared task-result/artifact cache
+// consulted by build/test/check. Reading and writing are separately opt-in —
+// a machine can consume a teammate's results without publishing its own.
+//
+//	[cache.remote]
+//	url = "http://buildbox.lan:7788"
+//	read = true
+//	write = false
+//	token_env = "GROVE_CACHE_TOKEN"
+//	timeout = "30s"
+type remoteCacheConfig struct {
+	URL   string `yaml:"url"`
+	Read  bool   `yaml:"read"`
+	Write bool   `yaml:"write"`
+	// TokenEnv names the environment variable holding the bearer token; the
+	// token itself never lives in grove.toml.
+	TokenEnv string `yaml:"token_env"`
+	Timeout  string `yaml:"timeout"`
+}
+
+// loadRemoteCache resolves [cache.remote] from the layered grove config into a
+// client and policy. No URL, or neither read nor write, means no remote cache.
+func loadRemoteCache() (orch.RemoteCache, orch.RemoteCachePolicy, error) {
+	cfg, err := config.LoadDefault()
+	if err != nil {
+		return nil, orch.RemoteCachePolicy{}, nil
+	}
+	return remoteCacheFromConfig(cfg)
+}
+
+func remoteCacheFromConfig(cfg *config.Config) (orch.RemoteCache, orch.RemoteCachePolicy, error) {
+	var tc taskCacheConfig
+	if err := cfg.UnmarshalExtension("cache", &tc); err != nil {
+		return nil, orch.RemoteCachePolicy{}, fmt.Errorf("parse [cache.remote]: %w", err)
+	}
+	rc := tc.Remote
+	policy := orch.RemoteCachePolicy{Read: rc.Read, Write: rc.Write}
+	if rc.URL == "" || (!policy.Read && !policy.Write) {
+		return nil, orch.RemoteCachePolicy{}, nil
+	}
+	var timeout time.Duration
+	if rc.Timeout != "" {
+		d, err := time.ParseDuration(rc.Timeout)
+		if err != nil {
+			return nil, orch.RemoteCachePolicy{}, fmt.Errorf("parse [cache.remote] timeout %q: %w", rc.Timeout, err)
+		}
+		timeout = d
+	}
+	token := ""
+	if rc.TokenEnv != "" {
+		token = os.Getenv(rc.TokenEnv)
+	}
+	return orch.NewHTTPRemoteCache(rc.URL, token, timeout), policy, nil
+}
+
+// withRemoteCache attaches the configured remote cache to o. A malformed
+// [cache.remote] is a warning, not a failure: the build works without it.
+func withRemoteCache(o *orch.Orchestrator) *orch.Orchestrator {
+	remote, policy, err := loadRemoteCache()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "warning: remote cache disabled: %v\n", err)
+		return o
+	}
+	o.Remote = remote
+	o.RemotePolicy = policy
+	return o
+}
+
+func newCacheCmd() *cobra.Command {
+	cmd := cli.NewStandardCommand("cache", "Manage the shared task-result cache")
+	cmd.Long = `Tools for the remote task cache configured under [cache.remote] in grove.toml.
+
+grove build/test/check consult the remote cache on a local miss (read = true)
+and publish successful results and build artifacts to it (write = true).`
+	cmd.AddCommand(newCacheServeCmd())
+	return cmd
+}
+
+func newCacheServeCmd() *cobra.Command {
+	var (
+		addr     string
+		dir      string
+		tokenEnv string
+	)
+	cmd := &cobra.Command{
+		Use:   "serve",
+		Short: "Serve a remote task cache over HTTP",
+		Long: `Run a file-backed remote task cache on this machine — a LAN box, a
+satellite, or localhost for testing. Point other machines at it with:
+
+  [cache.remote]
+  url = "http://<this-host>:7788"
+  read = true
+  write = true
+
+Blobs are stored under --dir. When the --token-env variable is set, every
+request must carry it as a bearer token.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if dir == "" {
+				dir = filepath.Join(paths.CacheDir(), "remote-cache")
+			}
+			if err := os.MkdirAll(dir, 0o755); err != nil {
+				return err
+			}
+			token := ""
+			if tokenEnv != "" {
+				token = os.Getenv(tokenEnv)
+			}
+			srv := &http.Server{
+				Addr:              addr,
+				Handler:           cacheserver.New(dir, token),
+				ReadHeaderTimeout: 10 * time.Second,
+			}
+
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+			go func() {
+				<-ctx.Done()
+				shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+				defer cancel()
+				_ = srv.Shutdown(shutdownCtx)
+			}()
+
+			auth := "no auth"
+			if token != "" {
+				auth = "bearer token from $" + tokenEnv
+			}
+			fmt.Printf("Serving task cache from %s on %s (%s)\n", dir, addr, auth)
+			if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				return err
+			}
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&addr, "addr", "127.0.0.1:7788", "Listen address (use 0.0.0.0:7788 to serve the LAN)")
+	cmd.Flags().StringVar(&dir, "dir", "", "Blob directory (default: <grove cache dir>/remote-cache)")
+	cmd.Flags().StringVar(&tokenEnv, "token-env", "GROVE_CACHE_TOKEN", "Environment variable holding the required bearer token (unset variable = no auth)")
+	return cmd
+}
@@ -12,9 +12,11 @@ import (
 	"github.com/grovetools/core/git"
 	"github.com/grovetools/core/pkg/workspace"
 	"github.com/spf13/cobra"
+	"golang.org/x/mod/modfile"
 
 	"github.com/grovetools/grove/pkg/depsgraph"
 	"github.com/grovetools/grove/pkg/discovery"
+	"github.com/grovetools/grove/pkg/project"
 )
 
 func newDepsCmd() *cobra.Command {
@@ -27,6 +29,9 @@ func newDepsCmd() *cobra.Command {
 	cmd.AddCommand(newDepsBumpCmd())
 	cmd.AddCommand(newDepsSyncCmd())
 	cmd.AddCommand(newDepsTreeCmd())
+	cmd.AddCommand(newDepsOutdatedCmd())
+	cmd.AddCommand(newDepsUpgradeCmd())
+	cmd.AddCommand(newDepsSkewCmd())
 	return cmd
 }
 
@@ -72,10 +77,14 @@ func newDepsSyncCmd() *cobra.Command {
 		Short: "Update all Grove dependencies to their latest versions",
 		Long: `Synchronize all Grove dependencies across all submodules.
 
-This command automatically discovers all Grove dependencies (github.com/grovetools/*)
-in each submodule and updates them to their latest versions. This is useful for
+This command automatically discovers all ecosystem dependencies in each
+submodule and updates them to their latest versions. This is useful for
 keeping the entire ecosystem in sync after multiple tools have been released.
 
+Ecosystem modules are those under the [ecosystem] module_prefixes of the
+ecosystem's grove.toml, or else under the namespaces of its go.work members
+(github.com/grovetools/* by default).
+
 Examples:
   grove deps sync
   grove deps sync --commit
@@ -105,9 +114,16 @@ func runDepsBump(moduleSpec string, commit, push bool) error {
 		version = parts[1]
 	}
 
+	// The ecosystem root decides which module namespaces are private
+	rootDir, err := workspace.FindEcosystemRoot("")
+	if err != nil {
+		return fmt.Errorf("failed to discover workspaces: %w", err)
+	}
+	prefixes := project.LoadModulePrefixes(rootDir)
+
 	// Resolve version if needed
 	if version == "" || version == "latest" {
-		resolvedVersion, err := getLatestModuleVersion(modulePath)
+		resolvedVersion, err := getLatestModuleVersion(modulePath, prefixes)
 		if err != nil {
 			return fmt.Errorf("failed to resolve latest version for %s: %w", modulePath, err)
 		}
@@ -128,12 +144,6 @@ func runDepsBump(moduleSpec string, commit, push bool) error {
 		workspaces = append(workspaces, p.Path)
 	}
 
-	// Also need the root dir for context
-	rootDir, err := workspace.FindEcosystemRoot("")
-	if err != nil {
-		return fmt.Errorf("failed to discover workspaces: %w", err)
-	}
-
 	// Track results
 	var updated []string
 	var skipped []string
@@ -175,14 +185,14 @@ func runDepsBump(moduleSpec string, commit, push bool) error {
 		fmt.Printf("UPDATING  %s...", wsName)
 
 		// Run go get
-		if err := runGoGet(ws, modulePath, version); err != nil {
+		if err := runGoGet(ws, modulePath, version, prefixes); err != nil {
 			fmt.Printf(" FAILED: %v\n", err)
 			failed = append(failed, wsName)
 			continue
 		}
 
 		// Run go mod tidy
-		if err := runGoModTidy(ws); err != nil {
+		if err := runGoModTidy(ws, prefixes); err != nil {
 			fmt.Printf(" FAILED: %v\n", err)
 			failed = append(failed, wsName)
 			continue
@@ -223,16 +233,27 @@ func runDepsBump(moduleSpec string, commit, push bool) error {
 	return nil
 }
 
-func getLatestModuleVersion(modulePath string) (string, error) {
+// goModuleEnv is the environment for go commands resolving ecosystem modules:
+// fetched straight from their origin, skipping the proxy and checksum DB.
+func goModuleEnv(prefixes project.ModulePrefixes) []string {
+	env := append(os.Environ(),
+		"GOPRIVATE="+prefixes.GoPrivate(),
+		"GOPROXY=direct",
+		"GOWORK=off",
+	)
+	if activeRehearsal != nil {
+		// Resolve rehearsed tags from the sandbox, not the real remotes
+		env = append(env, activeRehearsal.GoEnv()...)
+	}
+	return env
+}
+
+func getLatestModuleVersion(modulePath string, prefixes project.ModulePrefixes) (string, error) {
 	// Use go list to get module info
 	cmd := exec.Command("go", "list", "-m", "-json", modulePath+"@latest")
 
 	// Set up environment for private modules
-	cmd.Env = append(os.Environ(),
-		"GOPRIVATE=github.com/grovetools/*",
-		"GOPROXY=direct",
-		"GOWORK=off",
-	)
+	cmd.Env = goModuleEnv(prefixes)
 
 	output, err := cmd.Output()
 	if err != nil {
@@ -254,16 +275,12 @@ func getLatestModuleVersion(modulePath string) (string, error) {
 	return modInfo.Version, nil
 }
 
-func getLatestPrereleaseModuleVersion(modulePath string) (string, error) {
+func getLatestPrereleaseModuleVersion(modulePath string, prefixes project.ModulePrefixes) (string, error) {
 	// Use go list to get all versions
 	cmd := exec.Command("go", "list", "-m", "-versions", "-json", modulePath)
 
 	// Set up environment for private modules
-	cmd.Env = append(os.Environ(),
-		"GOPRIVATE=github.com/grovetools/*",
-		"GOPROXY=direct",
-		"GOWORK=off",
-	)
+	cmd.Env = goModuleEnv(prefixes)
 
 	output, err := cmd.Output()
 	if err != nil {
@@ -296,16 +313,12 @@ func getLatestPrereleaseModuleVersion(modulePath string) (string, error) {
 	return latestPrerelease, nil
 }
 
-func runGoGet(workspacePath, modulePath, version string) error {
+func runGoGet(workspacePath, modulePath, version string, prefixes project.ModulePrefixes) error {
 	cmd := exec.Command("go", "get", modulePath+"@"+version)
 	cmd.Dir = workspacePath
 
 	// Set up environment for private modules
-	cmd.Env = append(os.Environ(),
-		"GOPRIVATE=github.com/grovetools/*",
-		"GOPROXY=direct",
-		"GOWORK=off",
-	)
+	cmd.Env = goModuleEnv(prefixes)
 
 	output, err := cmd.CombinedOutput()
 	if err != nil {
@@ -314,16 +327,12 @@ func runGoGet(workspacePath, modulePath, version string) error {
 	return nil
 }
 
-func runGoModTidy(workspacePath string) error {
+func runGoModTidy(workspacePath string, prefixes project.ModulePrefixes) error {
 	cmd := exec.Command("go", "mod", "tidy")
 	cmd.Dir = workspacePath
 
 	// Set up environment for private modules
-	cmd.Env = append(os.Environ(),
-		"GOPRIVATE=github.com/grovetools/*",
-		"GOPROXY=direct",
-		"GOWORK=off",
-	)
+	cmd.Env = goModuleEnv(prefixes)
 
 	if err := cmd.Run(); err != nil {
 		return err
@@ -392,6 +401,7 @@ func runDepsSync(commit, push bool) error {
 	if err != nil {
 		return fmt.Errorf("failed to discover workspaces: %w", err)
 	}
+	prefixes := project.LoadModulePrefixes(rootDir)
 
 	// Track Grove dependencies by workspace
 	type workspaceDeps struct {
@@ -420,15 +430,10 @@ func runDepsSync(commit, push bool) error {
 			continue
 		}
 
-		// Check if this is a Grove module itself
+		// Check if this is an ecosystem module itself
 		var isGroveModule string
-		if match := strings.Contains(string(goModContent), "module github.com/grovetools/"); match {
-			for _, line := range strings.Split(string(goModContent), "\n") {
-				if strings.HasPrefix(strings.TrimSpace(line), "module github.com/grovetools/") {
-					isGroveModule = strings.Fields(line)[1]
-					break
-				}
-			}
+		if modulePath := modfile.ModulePath(goModContent); prefixes.Match(modulePath) {
+			isGroveModule = modulePath
 		}
 
 		// Parse dependencies
@@ -448,15 +453,13 @@ func runDepsSync(commit, push bool) error {
 			}
 
 			if inRequire || strings.HasPrefix(line, "require ") {
-				// Extract Grove dependencies
-				if strings.Contains(line, "github.com/grovetools/") {
-					parts := strings.Fields(line)
-					if len(parts) >= 1 {
-						dep := parts[0]
-						if strings.HasPrefix(dep, "github.com/grovetools/") && dep != isGroveModule {
-							wsDeps = append(wsDeps, dep)
-							uniqueDeps[dep] = true
-						}
+				// Extract ecosystem dependencies
+				parts := strings.Fields(strings.TrimPrefix(line, "require "))
+				if len(parts) >= 1 {
+					dep := parts[0]
+					if prefixes.Match(dep) && dep != isGroveModule {
+						wsDeps = append(wsDeps, dep)
+						uniqueDeps[dep] = true
 					}
 				}
 			}
@@ -480,7 +483,7 @@ func runDepsSync(commit, push bool) error {
 	depVersions := make(map[string]string)
 	fmt.Printf("Resolving versions for %d unique Grove dependencies...\n", len(uniqueDeps))
 	for dep := range uniqueDeps {
-		version, err := getLatestModuleVersion(dep)
+		version, err := getLatestModuleVersion(dep, prefixes)
 		if err != nil {
 			fmt.Printf("  WARNING: Failed to resolve %s: %v\n", dep, err)
 			continue
@@ -506,7 +509,7 @@ func runDepsSync(commit, push bool) error {
 			}
 
 			fmt.Printf("  %s -> %s\n", dep, version)
-			if err := runGoGet(ws.path, dep, version); err != nil {
+			if err := runGoGet(ws.path, dep, version, prefixes); err != nil {
 				fmt.Printf("    ERROR: %v\n", err)
 				failedWorkspaces = append(failedWorkspaces, ws.name)
 				continue
@@ -516,7 +519,7 @@ func runDepsSync(commit, push bool) error {
 
 		if hasUpdates {
 			// Run go mod tidy
-			if err := runGoModTidy(ws.path); err != nil {
+			if err := runGoModTidy(ws.path, prefixes); err != nil {
 				fmt.Printf("  ERROR running go mod tidy: %v\n", err)
 				failedWorkspaces = append(failedWorkspaces, ws.name)
 			} else {
@@ -0,0 +1,145 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/grovetools/core/pkg/workspace"
+	"github.com/spf13/cobra"
+
+	"github.com/grovetools/grove/pkg/depsgraph"
+	"github.com/grovetools/grove/pkg/discovery"
+)
+
+func newDepsSkewCmd() *cobra.Command {
+	var jsonOutput bool
+	var indirect bool
+	var unmaskedOnly bool
+
+	cmd := &cobra.Command{
+		Use:   "skew",
+		Short: "Report modules required at different versions across workspaces",
+		Long: `Report every Go module that the workspaces' go.mod files require at more
+than one version (core v0.6.1 here, v0.6.3 there), which workspace pins which
+version, and whether the ecosystem's go.work masks the difference locally.
+
+A skew is masked when local builds do not see it:
+  use          the module is a go.work member; every pin resolves to the checkout
+  replace      go.work replaces the module
+  build-list   every pinning workspace is a go.work member, so they share one
+               build list that selects the highest version
+
+Builds with GOWORK=off (releases, single-repo CI) still see masked skews.
+
+The command exits non-zero when it finds skew, so it can gate CI or
+'grove check --skew'.
+
+Examples:
+  grove deps skew
+  grove deps skew --unmasked-only   # only fail on skew local builds also see
+  grove deps skew --json`,
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runDepsSkew(jsonOutput, indirect, unmaskedOnly)
+		},
+	}
+
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output as JSON")
+	cmd.Flags().BoolVar(&indirect, "indirect", false, "Include // indirect requirements")
+	cmd.Flags().BoolVar(&unmaskedOnly, "unmasked-only", false, "Ignore skews that go.work masks locally")
+
+	return cmd
+}
+
+func runDepsSkew(jsonOutput, indirect, unmaskedOnly bool) error {
+	projects, err := discovery.DiscoverProjects()
+	if err != nil {
+		return fmt.Errorf("failed to discover workspaces: %w", err)
+	}
+	rootDir, err := workspace.FindEcosystemRoot("")
+	if err != nil {
+		return fmt.Errorf("failed to find workspace root: %w", err)
+	}
+	var workspaces []string
+	for _, p := range projects {
+		if p.Path != rootDir {
+			workspaces = append(workspaces, p.Path)
+		}
+	}
+
+	graph, err := depsgraph.BuildGraph(rootDir, workspaces)
+	if err != nil {
+		return fmt.Errorf("failed to build dependency graph: %w", err)
+	}
+	goWork, err := depsgraph.LoadGoWork(rootDir)
+	if err != nil {
+		return err
+	}
+	skews, err := graph.Skew(rootDir, goWork, indirect)
+	if err != nil {
+		return err
+	}
+	if unmaskedOnly {
+		var unmasked []depsgraph.Skew
+		for _, s := range skews {
+			if s.MaskedBy == "" {
+				unmasked = append(unmasked, s)
+			}
+		}
+		skews = unmasked
+	}
+
+	if jsonOutput {
+		if skews == nil {
+			skews = []depsgraph.Skew{}
+		}
+		if err := printJSON(skews); err != nil {
+			return err
+		}
+	} else {
+		displayDepsSkew(skews)
+	}
+
+	if len(skews) > 0 {
+		return fmt.Errorf("%d modules are required at more than one version", len(skews))
+	}
+	return nil
+}
+
+func displayDepsSkew(skews []depsgraph.Skew) {
+	if len(skews) == 0 {
+		fmt.Println("No version skew: every module is required at a single version.")
+		return
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	for i, s := range skews {
+		if i > 0 {
+			fmt.Fprintln(w)
+		}
+		masked := "not masked by go.work"
+		if s.MaskedBy != "" {
+			masked = "masked by go.work (" + s.MaskedBy + ")"
+		}
+		kind := "external"
+		if s.Internal {
+			kind = "ecosystem"
+		}
+		fmt.Fprintf(w, "%s (%s; %s)\n", s.Module, kind, masked)
+		for _, p := range s.Pins {
+			note := ""
+			if p.Version != s.Latest() {
+				note = "behind " + s.Latest()
+			}
+			if p.Indirect {
+				note = strings.TrimSpace(note + " indirect")
+			}
+			fmt.Fprintf(w, "  %s\t%s\t%s\n", p.Workspace, p.Version, note)
+		}
+	}
+	w.Flush()
+	fmt.Printf("\n%d modules are required at more than one version.\n", len(skews))
+}
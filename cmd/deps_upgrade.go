@@ -0,0 +1,444 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/grovetools/core/config"
+	"github.com/grovetools/core/pkg/workspace"
+	"github.com/spf13/cobra"
+
+	"github.com/grovetools/grove/pkg/depsgraph"
+	"github.com/grovetools/grove/pkg/depsupgrade"
+	"github.com/grovetools/grove/pkg/discovery"
+	orch "github.com/grovetools/grove/pkg/orchestrator"
+	"github.com/grovetools/grove/pkg/project"
+)
+
+func newDepsOutdatedCmd() *cobra.Command {
+	var jsonOutput bool
+	var showAll bool
+	var indirect bool
+
+	cmd := &cobra.Command{
+		Use:   "outdated",
+		Short: "List third-party Go dependencies with newer versions",
+		Long: `List every third-party (non-ecosystem) Go module required by the ecosystem's
+workspaces, with the newest versions available.
+
+Columns:
+  CURRENT        the highest version any workspace requires
+  MIN USED       the lowest version any workspace requires
+  LATEST MINOR   the newest release within the current major version
+  LATEST MAJOR   the newest release of a later major version (a new module path)
+
+Only modules that are behind or required at different versions are listed
+unless --all is given. Versions are looked up through GOPROXY.
+
+Examples:
+  grove deps outdated
+  grove deps outdated --all --indirect
+  grove deps outdated --json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runDepsOutdated(jsonOutput, showAll, indirect)
+		},
+	}
+
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output as JSON")
+	cmd.Flags().BoolVar(&showAll, "all", false, "Include modules that are up to date")
+	cmd.Flags().BoolVar(&indirect, "indirect", false, "Include // indirect requirements")
+
+	return cmd
+}
+
+func newDepsUpgradeCmd() *cobra.Command {
+	var planOnly bool
+	var jsonOutput bool
+	var indirect bool
+	var verify []string
+
+	cmd := &cobra.Command{
+		Use:   "upgrade",
+		Short: "Upgrade third-party Go dependencies to one version per module",
+		Long: `Upgrade the third-party Go modules required across the ecosystem.
+
+Each module is moved to the newest release of its current major version, and
+every workspace requiring it is raised to that same version, so the ecosystem
+ends up with a single version per module. New major versions change the
+import path and are only reported.
+
+The upgrades are grouped into one step per workspace, dependencies first.
+After each step (go get + go mod tidy) the workspace is built and tested
+through the task orchestrator; a step that fails is reverted and the
+remaining steps continue. Once all steps apply, the go.mod files are read
+again: modules that go get raised in only some workspaces are aligned in a
+follow-up round, and any module still at more than one version fails the
+run.
+
+Examples:
+  grove deps upgrade --plan          # Show the ordered upgrade steps
+  grove deps upgrade                 # Apply them, verifying each step
+  grove deps upgrade --verify build  # Only build after each step`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runDepsUpgrade(planOnly, jsonOutput, indirect, verify)
+		},
+	}
+
+	cmd.Flags().BoolVar(&planOnly, "plan", false, "Print the upgrade plan without applying it")
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output as JSON")
+	cmd.Flags().BoolVar(&indirect, "indirect", false, "Include // indirect requirements")
+	cmd.Flags().StringSliceVar(&verify, "verify", []string{"build", "test"}, "Task verbs run after each step")
+
+	return cmd
+}
+
+// depsInventory is what outdated and upgrade share: the ecosystem's Go
+// workspaces (by name) and its third-party modules.
+type depsInventory struct {
+	rootDir      string
+	prefixes     project.ModulePrefixes
+	workspaces   map[string]string
+	goWorkspaces []depsupgrade.Workspace
+	indirect     bool
+	order        []string
+	modules      []depsupgrade.Module
+}
+
+func loadDepsInventory(indirect bool) (*depsInventory, error) {
+	projects, err := discovery.DiscoverProjects()
+	if err != nil {
+		return nil, fmt.Errorf("failed to discover workspaces: %w", err)
+	}
+	rootDir, err := workspace.FindEcosystemRoot("")
+	if err != nil {
+		return nil, fmt.Errorf("failed to find workspace root: %w", err)
+	}
+	inv := &depsInventory{
+		rootDir:    rootDir,
+		prefixes:   project.LoadModulePrefixes(rootDir),
+		workspaces: make(map[string]string),
+		indirect:   indirect,
+	}
+
+	var paths []string
+	for _, p := range projects {
+		if p.Path == rootDir {
+			continue
+		}
+		name := filepath.Base(p.Path)
+		inv.workspaces[name] = p.Path
+		paths = append(paths, p.Path)
+		inv.goWorkspaces = append(inv.goWorkspaces, depsupgrade.Workspace{Name: name, Path: p.Path})
+	}
+
+	reqs, err := depsupgrade.Collect(inv.goWorkspaces, inv.prefixes.Match, indirect)
+	if err != nil {
+		return nil, err
+	}
+
+	// Upgrade libraries before the workspaces that depend on them
+	if graph, err := depsgraph.BuildGraph(rootDir, paths); err == nil {
+		if levels, err := graph.TopologicalSort(); err == nil {
+			for _, level := range levels {
+				inv.order = append(inv.order, level...)
+			}
+		}
+	}
+
+	fmt.Fprintf(os.Stderr, "Looking up versions for %d requirements...\n", len(reqs))
+	inv.modules = depsupgrade.Analyze(context.Background(), reqs, depsupgrade.GoList{Env: thirdPartyGoEnv(inv.prefixes)})
+	return inv, nil
+}
+
+// splitModules re-reads every workspace's go.mod and returns the third-party
+// modules now required at more than one version.
+func (inv *depsInventory) splitModules() ([]depsupgrade.Module, error) {
+	reqs, err := depsupgrade.Collect(inv.goWorkspaces, inv.prefixes.Match, inv.indirect)
+	if err != nil {
+		return nil, err
+	}
+	var split []depsupgrade.Module
+	for _, m := range depsupgrade.Group(reqs) {
+		if m.Split() {
+			split = append(split, m)
+		}
+	}
+	return split, nil
+}
+
+// thirdPartyGoEnv is the environment for go commands resolving third-party
+// modules: the regular GOPROXY, with ecosystem modules still private.
+func thirdPartyGoEnv(prefixes project.ModulePrefixes) []string {
+	return append(os.Environ(),
+		"GOPRIVATE="+prefixes.GoPrivate(),
+		"GOWORK=off",
+	)
+}
+
+func runDepsOutdated(jsonOutput, showAll, indirect bool) error {
+	inv, err := loadDepsInventory(indirect)
+	if err != nil {
+		return err
+	}
+
+	var rows []depsupgrade.Module
+	for _, m := range inv.modules {
+		if showAll || m.Outdated() || m.HasNewMajor() || m.Error != "" {
+			rows = append(rows, m)
+		}
+	}
+
+	if jsonOutput {
+		if rows == nil {
+			rows = []depsupgrade.Module{}
+		}
+		return printJSON(rows)
+	}
+
+	if len(rows) == 0 {
+		fmt.Printf("All %d third-party modules are up to date.\n", len(inv.modules))
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "MODULE\tCURRENT\tMIN USED\tLATEST MINOR\tLATEST MAJOR\tUSED BY")
+	for _, m := range rows {
+		latestMinor, latestMajor := orDash(m.LatestMinor), orDash(m.LatestMajor)
+		if m.Error != "" {
+			latestMinor, latestMajor = "?", "?"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%d\n", m.Path, m.Current, m.MinUsed, latestMinor, latestMajor, len(m.UsedBy))
+	}
+	w.Flush()
+
+	for _, m := range rows {
+		if m.Error != "" {
+			fmt.Printf("\nWARNING: could not look up %s: %s\n", m.Path, m.Error)
+		}
+	}
+	return nil
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
+
+// depsUpgradeResult is the outcome of one applied step.
+type depsUpgradeResult struct {
+	depsupgrade.Step
+	Status string `json:"status"` // upgraded, reverted or failed
+	Error  string `json:"error,omitempty"`
+}
+
+func runDepsUpgrade(planOnly, jsonOutput, indirect bool, verify []string) error {
+	inv, err := loadDepsInventory(indirect)
+	if err != nil {
+		return err
+	}
+	plan := depsupgrade.BuildPlan(inv.modules, inv.order)
+
+	if planOnly {
+		if jsonOutput {
+			return printJSON(plan)
+		}
+		displayDepsUpgradePlan(plan)
+		return nil
+	}
+
+	if len(plan.Steps) == 0 {
+		if jsonOutput {
+			return printJSON([]depsUpgradeResult{})
+		}
+		fmt.Println("Nothing to upgrade.")
+		return nil
+	}
+
+	var results []depsUpgradeResult
+	failures := 0
+	for i, step := range plan.Steps {
+		if !jsonOutput {
+			fmt.Printf("\n[%d/%d] %s\n", i+1, len(plan.Steps), step.Workspace)
+		}
+		result := applyDepsUpgradeStep(inv, step, verify, jsonOutput)
+		if result.Status != "upgraded" {
+			failures++
+		}
+		results = append(results, result)
+	}
+
+	// go get raises transitive requirements by MVS, which can leave a module
+	// newer in one workspace than in the rest. Re-read the go.mod files and,
+	// when every step applied, align the stragglers in a follow-up round;
+	// anything still split fails the run.
+	split, err := inv.splitModules()
+	if err != nil {
+		return err
+	}
+	if len(split) > 0 && failures == 0 {
+		followUp := depsupgrade.BuildPlan(split, inv.order)
+		for i, step := range followUp.Steps {
+			if !jsonOutput {
+				fmt.Printf("\n[follow-up %d/%d] %s\n", i+1, len(followUp.Steps), step.Workspace)
+			}
+			result := applyDepsUpgradeStep(inv, step, verify, jsonOutput)
+			if result.Status != "upgraded" {
+				failures++
+			}
+			results = append(results, result)
+		}
+		if split, err = inv.splitModules(); err != nil {
+			return err
+		}
+	}
+
+	if jsonOutput {
+		if err := printJSON(results); err != nil {
+			return err
+		}
+	} else {
+		fmt.Println("\nSummary:")
+		for _, r := range results {
+			fmt.Printf("  %-9s %s (%d modules)\n", strings.ToUpper(r.Status), r.Workspace, len(r.Upgrades))
+		}
+	}
+
+	if failures > 0 {
+		return fmt.Errorf("%d of %d upgrade steps did not apply", failures, len(results))
+	}
+	if len(split) > 0 {
+		var paths []string
+		for _, m := range split {
+			paths = append(paths, fmt.Sprintf("%s (%s..%s)", m.Path, m.MinUsed, m.Current))
+		}
+		return fmt.Errorf("%d modules are still required at more than one version: %s", len(split), strings.Join(paths, ", "))
+	}
+	return nil
+}
+
+func displayDepsUpgradePlan(plan depsupgrade.Plan) {
+	if len(plan.Steps) == 0 {
+		fmt.Println("Nothing to upgrade: every third-party module is at its latest minor version, at one version across the ecosystem.")
+	} else {
+		fmt.Printf("Upgrade plan (%d steps):\n", len(plan.Steps))
+		for i, step := range plan.Steps {
+			fmt.Printf("\n%d. %s\n", i+1, step.Workspace)
+			for _, u := range step.Upgrades {
+				fmt.Printf("     %s %s -> %s\n", u.Module, u.From, u.To)
+			}
+		}
+	}
+
+	if len(plan.Majors) > 0 {
+		fmt.Println("\nNew major versions (import path changes, not planned):")
+		for _, m := range plan.Majors {
+			fmt.Printf("  %s@%s -> %s@%s\n", m.Path, m.Current, m.LatestMajorPath, m.LatestMajor)
+		}
+	}
+}
+
+// applyDepsUpgradeStep upgrades one workspace, then verifies it through the
+// orchestrator. go.mod and go.sum are restored when any part fails.
+func applyDepsUpgradeStep(inv *depsInventory, step depsupgrade.Step, verify []string, quiet bool) depsUpgradeResult {
+	result := depsUpgradeResult{Step: step}
+	wsPath := inv.workspaces[step.Workspace]
+
+	backup := make(map[string][]byte)
+	for _, name := range []string{"go.mod", "go.sum"} {
+		if data, err := os.ReadFile(filepath.Join(wsPath, name)); err == nil {
+			backup[name] = data
+		}
+	}
+	revert := func(reason error) depsUpgradeResult {
+		result.Status, result.Error = "reverted", reason.Error()
+		for _, name := range []string{"go.mod", "go.sum"} {
+			path := filepath.Join(wsPath, name)
+			data, ok := backup[name]
+			var err error
+			if ok {
+				err = os.WriteFile(path, data, 0o644)
+			} else {
+				err = os.Remove(path)
+			}
+			if err != nil && !os.IsNotExist(err) {
+				result.Status = "failed"
+				result.Error += fmt.Sprintf("; failed to restore %s: %v", name, err)
+			}
+		}
+		if !quiet {
+			fmt.Printf("  REVERTED: %s\n", result.Error)
+		}
+		return result
+	}
+
+	args := []string{"get"}
+	for _, u := range step.Upgrades {
+		if !quiet {
+			fmt.Printf("  %s %s -> %s\n", u.Module, u.From, u.To)
+		}
+		args = append(args, u.Module+"@"+u.To)
+	}
+	getCmd := exec.Command("go", args...)
+	getCmd.Dir = wsPath
+	getCmd.Env = thirdPartyGoEnv(inv.prefixes)
+	if output, err := getCmd.CombinedOutput(); err != nil {
+		return revert(fmt.Errorf("go get: %s", tailLines(string(output), 5)))
+	}
+	if err := runGoModTidy(wsPath, inv.prefixes); err != nil {
+		return revert(fmt.Errorf("go mod tidy: %w", err))
+	}
+
+	if len(verify) > 0 {
+		if !quiet {
+			fmt.Printf("  verifying (%s)...\n", strings.Join(verify, ", "))
+		}
+		if err := verifyDepsUpgradeStep(wsPath, verify); err != nil {
+			return revert(err)
+		}
+	}
+
+	result.Status = "upgraded"
+	if !quiet {
+		fmt.Println("  upgraded")
+	}
+	return result
+}
+
+// verifyDepsUpgradeStep runs the verify verbs as a pipeline on one workspace.
+// The cache is bypassed: the go.mod edit has not been committed yet.
+func verifyDepsUpgradeStep(wsPath string, verify []string) error {
+	workspaces := []string{wsPath}
+	name := filepath.Base(wsPath)
+	taskJobs := []orch.TaskJob{{Name: name, Path: wsPath}}
+	configMap := make(map[string]*config.Config)
+	if cfg, err := config.LoadFrom(wsPath); err == nil {
+		configMap[name] = cfg
+	}
+	o := newTaskOrchestrator(orch.OrchestratorOptions{
+		Pipeline: verify,
+		Strategy: orch.StrategyFlat,
+		NoCache:  true,
+		Jobs:     1,
+		FailFast: true,
+	}, workspaces, taskJobs, configMap)
+
+	results, err := o.RunWithResults(context.Background(), taskJobs)
+	if err != nil {
+		return err
+	}
+	for _, r := range results {
+		if r.Failed() {
+			return fmt.Errorf("%s failed: %v\n%s", r.Verb, r.Err, tailLines(string(r.Output), 10))
+		}
+	}
+	return nil
+}
@@ -26,6 +26,7 @@ These commands are distinct from 'grove version' which manages official releases
 	cmd.AddCommand(newDevWorkspaceCmd())
 	cmd.AddCommand(newDevPointCmd())
 	cmd.AddCommand(newDevSecretsCmd())
+	cmd.AddCommand(newDevProfileCmd())
 
 	return cmd
 }
@@ -7,6 +7,7 @@ import (
 	"github.com/grovetools/core/cli"
 	"github.com/spf13/cobra"
 
+	"github.com/grovetools/grove/pkg/devlinks"
 	"github.com/grovetools/grove/pkg/reconciler"
 	"github.com/grovetools/grove/pkg/sdk"
 )
@@ -42,6 +43,14 @@ This command shows the layered status where development links override released
 			return fmt.Errorf("failed to create reconciler: %w", err)
 		}
 
+		if config, err := devlinks.LoadConfig(); err == nil && config.ActiveProfile != "" {
+			drift := ""
+			if config.ActiveProfileDrifted() {
+				drift = " (modified since applied)"
+			}
+			fmt.Printf("Active profile: %s%s\n\n", config.ActiveProfile, drift)
+		}
+
 		fmt.Println("Tool Status:")
 
 		// Get all tools and sort them
@@ -62,6 +71,7 @@ This command shows the layered status where development links override released
 
 			fmt.Println("\nUse 'grove dev use <binary> <alias>' to activate a dev version")
 			fmt.Println("Use 'grove dev use <binary> --release' to switch back to release")
+			fmt.Println("Use 'grove dev profile apply <name>' to switch a saved set of links")
 		}
 
 		return nil
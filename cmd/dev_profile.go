@@ -0,0 +1,279 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/grovetools/core/cli"
+	"github.com/spf13/cobra"
+
+	"github.com/grovetools/grove/pkg/devlinks"
+	"github.com/grovetools/grove/pkg/reconciler"
+	"github.com/grovetools/grove/pkg/sdk"
+)
+
+func newDevProfileCmd() *cobra.Command {
+	cmd := cli.NewStandardCommand("profile", "Manage named sets of dev links that switch together")
+
+	cmd.Long = `Dev-link profiles name a set of local development links, one alias per binary,
+so a cross-repo feature can be switched on and off as a unit instead of running
+'grove dev use' once per tool.
+
+Applying a profile flips all of its symlinks at once; 'clear' restores the
+links that were active before the first profile was applied. The active
+profile is shown by 'grove dev current' and in the starship prompt.`
+
+	cmd.Example = `  # Save the currently active dev links as a profile
+  grove dev profile save feature-x
+
+  # Or spell the links out
+  grove dev profile save feature-x flow=wt-a cx=wt-b core-tools=wt-a
+
+  # See what applying it would change, then apply it
+  grove dev profile diff feature-x
+  grove dev profile apply feature-x
+
+  # Go back to the links active before
+  grove dev profile clear`
+
+	cmd.AddCommand(newDevProfileSaveCmd())
+	cmd.AddCommand(newDevProfileApplyCmd())
+	cmd.AddCommand(newDevProfileDiffCmd())
+	cmd.AddCommand(newDevProfileClearCmd())
+	cmd.AddCommand(newDevProfileListCmd())
+	cmd.AddCommand(newDevProfileDeleteCmd())
+
+	return cmd
+}
+
+func newDevProfileSaveCmd() *cobra.Command {
+	cmd := cli.NewStandardCommand("save", "Save dev links as a named profile")
+	cmd.Use = "save <name> [binary=alias...]"
+	cmd.Long = `Saves a profile. Without binary=alias pairs, the profile is a snapshot of the
+currently active dev links. Saving over an existing profile replaces it.`
+	cmd.Args = cobra.MinimumNArgs(1)
+
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		name := args[0]
+		config, err := devlinks.LoadConfig()
+		if err != nil {
+			return fmt.Errorf("failed to load dev config: %w", err)
+		}
+
+		links := config.CurrentLinks()
+		if len(args) > 1 {
+			links = make(map[string]string)
+			for _, pair := range args[1:] {
+				binary, alias, ok := strings.Cut(pair, "=")
+				if !ok || binary == "" || alias == "" {
+					return fmt.Errorf("invalid link '%s': expected binary=alias", pair)
+				}
+				links[binary] = alias
+			}
+		}
+
+		if err := config.SaveProfile(name, links); err != nil {
+			return err
+		}
+		if err := devlinks.SaveConfig(config); err != nil {
+			return fmt.Errorf("failed to save config: %w", err)
+		}
+
+		fmt.Printf("Saved profile '%s':\n", name)
+		displayProfileLinks(links)
+		return nil
+	}
+
+	return cmd
+}
+
+func newDevProfileApplyCmd() *cobra.Command {
+	cmd := cli.NewStandardCommand("apply", "Activate every dev link of a profile")
+	cmd.Use = "apply <name>"
+	cmd.Args = cobra.ExactArgs(1)
+
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		name := args[0]
+		changed, err := switchDevLinks(func(config *devlinks.Config) ([]string, error) {
+			return config.ApplyProfile(name)
+		})
+		if err != nil {
+			return err
+		}
+
+		if len(changed) == 0 {
+			fmt.Printf("Profile '%s' is active (all links were already in place)\n", name)
+		} else {
+			fmt.Printf("Applied profile '%s' (%d links switched)\n", name, len(changed))
+		}
+		return nil
+	}
+
+	return cmd
+}
+
+func newDevProfileDiffCmd() *cobra.Command {
+	cmd := cli.NewStandardCommand("diff", "Show what applying a profile would change")
+	cmd.Use = "diff <name>"
+	cmd.Args = cobra.ExactArgs(1)
+
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		config, err := devlinks.LoadConfig()
+		if err != nil {
+			return fmt.Errorf("failed to load dev config: %w", err)
+		}
+		changes, err := config.DiffProfile(args[0])
+		if err != nil {
+			return err
+		}
+
+		if len(changes) == 0 {
+			fmt.Printf("No changes: every link of profile '%s' is active\n", args[0])
+			return nil
+		}
+		for _, c := range changes {
+			fmt.Printf("  %s: %s -> %s\n", c.Binary, linkLabel(c.Current), c.Profile)
+		}
+		return nil
+	}
+
+	return cmd
+}
+
+func newDevProfileClearCmd() *cobra.Command {
+	cmd := cli.NewStandardCommand("clear", "Deactivate the active profile and restore the previous links")
+	cmd.Args = cobra.NoArgs
+
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		var name string
+		changed, err := switchDevLinks(func(config *devlinks.Config) ([]string, error) {
+			name = config.ActiveProfile
+			return config.ClearProfile()
+		})
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("Cleared profile '%s' (%d links restored)\n", name, len(changed))
+		return nil
+	}
+
+	return cmd
+}
+
+func newDevProfileListCmd() *cobra.Command {
+	cmd := cli.NewStandardCommand("list", "List saved profiles")
+	cmd.Args = cobra.NoArgs
+
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		config, err := devlinks.LoadConfig()
+		if err != nil {
+			return fmt.Errorf("failed to load dev config: %w", err)
+		}
+		if len(config.Profiles) == 0 {
+			fmt.Println("No profiles saved yet.")
+			fmt.Println("Use 'grove dev profile save <name>' to save the active dev links.")
+			return nil
+		}
+
+		var names []string
+		for name := range config.Profiles {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			prefix := "  "
+			if name == config.ActiveProfile {
+				prefix = "* "
+			}
+			fmt.Printf("%s%s (saved %s)\n", prefix, name, config.Profiles[name].SavedAt)
+			displayProfileLinks(config.Profiles[name].Links)
+		}
+		return nil
+	}
+
+	return cmd
+}
+
+func newDevProfileDeleteCmd() *cobra.Command {
+	cmd := cli.NewStandardCommand("delete", "Delete a saved profile")
+	cmd.Use = "delete <name>"
+	cmd.Long = `Deletes a saved profile. The active dev links are left as they are.`
+	cmd.Args = cobra.ExactArgs(1)
+
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		config, err := devlinks.LoadConfig()
+		if err != nil {
+			return fmt.Errorf("failed to load dev config: %w", err)
+		}
+		if err := config.DeleteProfile(args[0]); err != nil {
+			return err
+		}
+		if err := devlinks.SaveConfig(config); err != nil {
+			return fmt.Errorf("failed to save config: %w", err)
+		}
+		fmt.Printf("Deleted profile '%s'\n", args[0])
+		return nil
+	}
+
+	return cmd
+}
+
+// switchDevLinks applies change to the dev config, saves it, and flips the
+// symlinks of the binaries it changed in one reconciler pass. If the symlinks
+// cannot be switched the previous config is restored.
+func switchDevLinks(change func(*devlinks.Config) ([]string, error)) ([]string, error) {
+	previous, err := devlinks.LoadConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load dev config: %w", err)
+	}
+	config, err := devlinks.LoadConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load dev config: %w", err)
+	}
+
+	changed, err := change(config)
+	if err != nil {
+		return nil, err
+	}
+	if err := devlinks.SaveConfig(config); err != nil {
+		return nil, fmt.Errorf("failed to save config: %w", err)
+	}
+
+	tv, err := sdk.LoadToolVersions()
+	if err != nil {
+		tv = &sdk.ToolVersions{Versions: make(map[string]string)}
+	}
+	r, err := reconciler.NewWithToolVersions(tv)
+	if err == nil {
+		err = r.ReconcileAtomic(changed)
+	}
+	if err != nil {
+		if restoreErr := devlinks.SaveConfig(previous); restoreErr != nil {
+			return nil, fmt.Errorf("failed to switch symlinks: %w (and failed to restore dev config: %v)", err, restoreErr)
+		}
+		return nil, fmt.Errorf("failed to switch symlinks: %w", err)
+	}
+	return changed, nil
+}
+
+func displayProfileLinks(links map[string]string) {
+	var binaries []string
+	for binary := range links {
+		binaries = append(binaries, binary)
+	}
+	sort.Strings(binaries)
+	for _, binary := range binaries {
+		fmt.Printf("    %s: %s\n", binary, links[binary])
+	}
+}
+
+// linkLabel names an active alias, or the released version for "".
+func linkLabel(alias string) string {
+	if alias == "" {
+		return "(release)"
+	}
+	return alias
+}
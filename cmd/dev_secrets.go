@@ -1,9 +1,9 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"os"
-	"os/exec"
 	"strings"
 	"sync"
 
@@ -13,13 +13,18 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/grovetools/grove/pkg/discovery"
+	"github.com/grovetools/grove/pkg/forge"
 )
 
 func newDevSecretsCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "secrets",
-		Short: "Manage GitHub repository secrets across all workspaces",
-		Long:  "Set, update, or delete GitHub repository secrets for all discovered workspaces using the GitHub CLI",
+		Short: "Manage CI secrets across all workspaces",
+		Long: `Set, update, or delete CI secrets for all discovered workspaces.
+
+Each repository's forge is picked from its origin remote: GitHub through the gh
+CLI, GitLab (CI/CD variables) with GITLAB_TOKEN, and Gitea with GITEA_TOKEN.
+Self-hosted instances are configured under [forge.hosts] in grove.toml.`,
 	}
 
 	cmd.AddCommand(newDevSecretsSetCmd())
@@ -33,7 +38,7 @@ func newDevSecretsSetCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "set SECRET_NAME [SECRET_VALUE]",
 		Short: "Set a secret across all workspace repositories",
-		Long: `Set a GitHub repository secret across all discovered workspace repositories.
+		Long: `Set a CI secret across all discovered workspace repositories.
 If SECRET_VALUE is not provided, the secret will be read from stdin.`,
 		Args: cobra.RangeArgs(1, 2),
 		RunE: runDevSecretsSet,
@@ -50,7 +55,7 @@ func newDevSecretsDeleteCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "delete SECRET_NAME",
 		Short: "Delete a secret from all workspace repositories",
-		Long:  "Delete a GitHub repository secret from all discovered workspace repositories",
+		Long:  "Delete a CI secret from all discovered workspace repositories",
 		Args:  cobra.ExactArgs(1),
 		RunE:  runDevSecretsDelete,
 	}
@@ -65,7 +70,7 @@ func newDevSecretsListCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "list",
 		Short: "List secrets for all workspace repositories",
-		Long:  "List GitHub repository secrets for all discovered workspace repositories",
+		Long:  "List CI secret names for all discovered workspace repositories",
 		Args:  cobra.NoArgs,
 		RunE:  runDevSecretsList,
 	}
@@ -143,40 +148,14 @@ func runDevSecretsSet(cmd *cobra.Command, args []string) error {
 			defer wg.Done()
 			wsName := discovery.GetWorkspaceName(wsPath, rootDir)
 
-			// Get the repository URL
-			cmd := exec.Command("git", "config", "--get", "remote.origin.url")
-			cmd.Dir = wsPath
-			output, err := cmd.Output()
+			// Resolve the forge hosting the repository from its origin remote
+			f, repo, err := forge.ForRepo(wsPath)
 			if err != nil {
-				resultChan <- result{workspace: wsName, success: false, err: fmt.Errorf("failed to get repository URL")}
+				resultChan <- result{workspace: wsName, success: false, err: err}
 				return
 			}
 
-			// Extract owner/repo from URL
-			repoURL := strings.TrimSpace(string(output))
-			var owner, repo string
-			if strings.HasPrefix(repoURL, "git@github.com:") {
-				parts := strings.Split(strings.TrimPrefix(repoURL, "git@github.com:"), "/")
-				if len(parts) == 2 {
-					owner = parts[0]
-					repo = strings.TrimSuffix(parts[1], ".git")
-				}
-			} else if strings.HasPrefix(repoURL, "https://github.com/") {
-				parts := strings.Split(strings.TrimPrefix(repoURL, "https://github.com/"), "/")
-				if len(parts) == 2 {
-					owner = parts[0]
-					repo = strings.TrimSuffix(parts[1], ".git")
-				}
-			}
-
-			if owner == "" || repo == "" {
-				resultChan <- result{workspace: wsName, success: false, err: fmt.Errorf("could not parse repository URL")}
-				return
-			}
-
-			// Set the secret using gh CLI
-			cmd = exec.Command("gh", "secret", "set", secretName, "--body", secretValue, "--repo", fmt.Sprintf("%s/%s", owner, repo))
-			err = cmd.Run()
+			err = f.SetSecret(context.Background(), repo, secretName, secretValue)
 
 			resultChan <- result{
 				workspace: wsName,
@@ -274,40 +253,14 @@ func runDevSecretsDelete(cmd *cobra.Command, args []string) error {
 			defer wg.Done()
 			wsName := discovery.GetWorkspaceName(wsPath, rootDir)
 
-			// Get the repository URL
-			cmd := exec.Command("git", "config", "--get", "remote.origin.url")
-			cmd.Dir = wsPath
-			output, err := cmd.Output()
+			// Resolve the forge hosting the repository from its origin remote
+			f, repo, err := forge.ForRepo(wsPath)
 			if err != nil {
-				resultChan <- result{workspace: wsName, success: false, err: fmt.Errorf("failed to get repository URL")}
+				resultChan <- result{workspace: wsName, success: false, err: err}
 				return
 			}
 
-			// Extract owner/repo from URL
-			repoURL := strings.TrimSpace(string(output))
-			var owner, repo string
-			if strings.HasPrefix(repoURL, "git@github.com:") {
-				parts := strings.Split(strings.TrimPrefix(repoURL, "git@github.com:"), "/")
-				if len(parts) == 2 {
-					owner = parts[0]
-					repo = strings.TrimSuffix(parts[1], ".git")
-				}
-			} else if strings.HasPrefix(repoURL, "https://github.com/") {
-				parts := strings.Split(strings.TrimPrefix(repoURL, "https://github.com/"), "/")
-				if len(parts) == 2 {
-					owner = parts[0]
-					repo = strings.TrimSuffix(parts[1], ".git")
-				}
-			}
-
-			if owner == "" || repo == "" {
-				resultChan <- result{workspace: wsName, success: false, err: fmt.Errorf("could not parse repository URL")}
-				return
-			}
-
-			// Delete the secret using gh CLI
-			cmd = exec.Command("gh", "secret", "delete", secretName, "--repo", fmt.Sprintf("%s/%s", owner, repo))
-			err = cmd.Run()
+			err = f.DeleteSecret(context.Background(), repo, secretName)
 
 			resultChan <- result{
 				workspace: wsName,
@@ -381,45 +334,24 @@ func runDevSecretsList(cmd *cobra.Command, args []string) error {
 	for _, ws := range workspaces {
 		wsName := discovery.GetWorkspaceName(ws, rootDir)
 
-		// Get the repository URL
-		cmd := exec.Command("git", "config", "--get", "remote.origin.url")
-		cmd.Dir = ws
-		output, err := cmd.Output()
+		// Resolve the forge hosting the repository from its origin remote
+		f, repo, err := forge.ForRepo(ws)
 		if err != nil {
-			fmt.Printf("\n%s %s: failed to get repository URL\n", theme.DefaultTheme.Error.Render("x"), wsName)
-			continue
-		}
-
-		// Extract owner/repo from URL
-		repoURL := strings.TrimSpace(string(output))
-		var owner, repo string
-		if strings.HasPrefix(repoURL, "git@github.com:") {
-			parts := strings.Split(strings.TrimPrefix(repoURL, "git@github.com:"), "/")
-			if len(parts) == 2 {
-				owner = parts[0]
-				repo = strings.TrimSuffix(parts[1], ".git")
-			}
-		} else if strings.HasPrefix(repoURL, "https://github.com/") {
-			parts := strings.Split(strings.TrimPrefix(repoURL, "https://github.com/"), "/")
-			if len(parts) == 2 {
-				owner = parts[0]
-				repo = strings.TrimSuffix(parts[1], ".git")
-			}
-		}
-
-		if owner == "" || repo == "" {
-			fmt.Printf("\n%s %s: could not parse repository URL\n", theme.DefaultTheme.Error.Render("x"), wsName)
+			fmt.Printf("\n%s %s: %v\n", theme.DefaultTheme.Error.Render("x"), wsName, err)
 			continue
 		}
 
-		// List secrets using gh CLI
-		fmt.Printf("\n%s:\n", theme.DefaultTheme.Bold.Render(wsName))
-		cmd = exec.Command("gh", "secret", "list", "--repo", fmt.Sprintf("%s/%s", owner, repo))
-		cmd.Stdout = os.Stdout
-		cmd.Stderr = os.Stderr
-		err = cmd.Run()
+		fmt.Printf("\n%s (%s):\n", theme.DefaultTheme.Bold.Render(wsName), f.Kind())
+		names, err := f.ListSecrets(context.Background(), repo)
 		if err != nil {
 			fmt.Printf("  %s Failed to list secrets: %v\n", theme.DefaultTheme.Error.Render("x"), err)
+			continue
+		}
+		if len(names) == 0 {
+			fmt.Println("  (none)")
+		}
+		for _, name := range names {
+			fmt.Printf("  %s\n", name)
 		}
 	}
 
@@ -86,7 +86,7 @@ With the --release flag, switches the binary back to the currently active releas
 // switchToRelease switches a binary back to the currently active released version
 func switchToRelease(binaryName string) error {
 	// Get the active released version for this tool
-	sdkManager, err := sdk.NewManager()
+	sdkManager, err := newSDKManager()
 	if err != nil {
 		return fmt.Errorf("failed to create SDK manager: %w", err)
 	}
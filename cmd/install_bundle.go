@@ -0,0 +1,134 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/grovetools/core/logging"
+	"github.com/grovetools/core/tui/theme"
+	"github.com/spf13/cobra"
+
+	"github.com/grovetools/grove/pkg/reconciler"
+	"github.com/grovetools/grove/pkg/sdk"
+)
+
+// addInstallBundleFlags registers the offline bundle flags on install.
+func addInstallBundleFlags(cmd *cobra.Command) {
+	cmd.Flags().String("export-bundle", "", "Download the tools (and their dependencies) into an offline install bundle (.tar) instead of installing them")
+	cmd.Flags().StringSlice("platform", nil, "Platforms to pack into --export-bundle as os/arch (repeatable; default: this machine)")
+	cmd.Flags().String("from-bundle", "", "Install every tool in an offline bundle for this platform, with no network access")
+}
+
+// installArgs validates install's positional args: --from-bundle takes none,
+// everything else needs at least one tool.
+func installArgs(cmd *cobra.Command, args []string) error {
+	if from, _ := cmd.Flags().GetString("from-bundle"); from != "" {
+		if to, _ := cmd.Flags().GetString("export-bundle"); to != "" {
+			return fmt.Errorf("--from-bundle and --export-bundle are mutually exclusive")
+		}
+		if len(args) > 0 {
+			return fmt.Errorf("--from-bundle installs the whole bundle; drop the tool arguments")
+		}
+		return nil
+	}
+	return cobra.MinimumNArgs(1)(cmd, args)
+}
+
+// bundlePaths reads --export-bundle and --from-bundle; cmd may be nil.
+func bundlePaths(cmd *cobra.Command) (exportTo, from string) {
+	if cmd == nil || cmd.Flags().Lookup("export-bundle") == nil {
+		return "", ""
+	}
+	exportTo, _ = cmd.Flags().GetString("export-bundle")
+	from, _ = cmd.Flags().GetString("from-bundle")
+	return exportTo, from
+}
+
+// runExportBundle resolves args like install does ("all", "all@nightly",
+// tool[@version] plus dependencies) and packs the release binaries for each
+// --platform into bundlePath.
+func runExportBundle(cmd *cobra.Command, manager *sdk.Manager, args []string, bundlePath string) error {
+	var platforms []sdk.Platform
+	values, _ := cmd.Flags().GetStringSlice("platform")
+	for _, v := range values {
+		p, err := sdk.ParsePlatform(v)
+		if err != nil {
+			return err
+		}
+		platforms = append(platforms, p)
+	}
+
+	var specs []string
+	if len(args) == 1 && (args[0] == "all" || strings.HasPrefix(args[0], "all@")) {
+		_, version, _ := strings.Cut(args[0], "@")
+		for _, tool := range sdk.GetAllTools() {
+			if version != "" {
+				tool += "@" + version
+			}
+			specs = append(specs, tool)
+		}
+	} else {
+		resolved, err := manager.ResolveDependencies(args)
+		if err != nil {
+			return fmt.Errorf("failed to resolve dependencies: %w", err)
+		}
+		specs = resolved
+	}
+
+	manifest, err := manager.ExportBundle(bundlePath, specs, platforms, func(e sdk.BundleEntry) {
+		fmt.Printf("%s %s %s (%s/%s)\n",
+			theme.DefaultTheme.Muted.Render("Bundled"),
+			theme.DefaultTheme.Bold.Render(e.Repo),
+			theme.DefaultTheme.Info.Render(e.Version),
+			e.OS, e.Arch)
+	})
+	if err != nil {
+		return err
+	}
+	fmt.Println(theme.DefaultTheme.Success.Render(fmt.Sprintf("%s Wrote %s: %d binaries for %s",
+		theme.IconSuccess, bundlePath, len(manifest.Tools), strings.Join(manifest.Platforms(), ", "))))
+	fmt.Printf("Install it offline with: grove install --from-bundle %s\n", bundlePath)
+	return nil
+}
+
+// runInstallFromBundle installs and activates every tool in the bundle for
+// this platform. Nothing here touches the network: the binaries come from the
+// bundle and activation is the same version-file + reconciler step install
+// uses.
+func runInstallFromBundle(manager *sdk.Manager, bundlePath string) error {
+	logger := logging.NewLogger("install")
+
+	installed, err := manager.InstallFromBundle(bundlePath)
+	if err != nil {
+		return fmt.Errorf("install from %s: %w", bundlePath, err)
+	}
+
+	for _, e := range installed {
+		if err := manager.UseToolVersion(e.Repo, e.Version); err != nil {
+			logger.WithError(err).Warnf("Failed to activate %s %s", e.Repo, e.Version)
+			continue
+		}
+		if err := clearDevLinkForTool(e.Repo); err != nil {
+			logger.WithError(err).Debugf("Failed to clear dev link for %s", e.Repo)
+		}
+		tv, err := sdk.LoadToolVersions()
+		if err != nil {
+			logger.WithError(err).Warn("Could not load tool versions for reconciliation")
+			tv = &sdk.ToolVersions{Versions: make(map[string]string)}
+		}
+		r, err := reconciler.NewWithToolVersions(tv)
+		if err != nil {
+			logger.WithError(err).Warnf("Could not create reconciler, skipping symlink update for %s", e.Repo)
+			continue
+		}
+		if err := r.Reconcile(e.Repo); err != nil {
+			logger.WithError(err).Warnf("Failed to reconcile symlink for %s", e.Repo)
+			continue
+		}
+		fmt.Printf("%s %s %s installed from bundle and active\n",
+			theme.DefaultTheme.Success.Render(theme.IconSuccess),
+			theme.DefaultTheme.Bold.Render(e.Repo),
+			theme.DefaultTheme.Info.Render(e.Version))
+	}
+	return nil
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/grovetools/grove/pkg/devlinks"
+	"github.com/grovetools/grove/pkg/project"
 	"github.com/grovetools/grove/pkg/reconciler"
 	"github.com/grovetools/grove/pkg/sdk"
 )
@@ -38,14 +39,31 @@ Examples:
   grove install cx nb flow   # Install multiple tools
   grove install all          # Install all available tools
   grove install all@nightly  # Install latest RC builds of all tools
-  grove install --use-gh cx  # Use gh CLI for private repo access`,
-		Args: cobra.MinimumNArgs(1),
+  grove install --use-gh cx  # Use gh CLI for private repo access
+
+Each downloaded binary is checked against the release's checksums.txt before
+it is installed; a mismatch refuses the install. Releases without a
+checksums.txt (older ones) install with a warning. Listing minisign public keys
+under [install] trusted_keys in grove.toml also requires checksums.txt to be
+signed (checksums.txt.minisig) by one of them.
+
+For machines without network access, --export-bundle <file.tar> resolves the
+tools and their dependencies like a normal install but packs the verified
+release binaries (for each --platform os/arch) into a tar with a manifest.
+Copy it over and run 'grove install --from-bundle <file.tar>' to populate the
+versions directory and activate every tool, with no network access at all.
+
+  grove install all --export-bundle grove.tar --platform linux/amd64 --platform linux/arm64
+  grove install --from-bundle grove.tar`,
+		Args: installArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runInstall(cmd, args, useGH)
 		},
 	}
 
 	cmd.Flags().BoolVar(&useGH, "use-gh", false, "Use gh CLI for downloading (supports private repos)")
+	addInstallVerifyFlags(cmd)
+	addInstallBundleFlags(cmd)
 
 	return cmd
 }
@@ -53,6 +71,21 @@ Examples:
 func runInstall(cmd *cobra.Command, args []string, useGH bool) error {
 	logger := logging.NewLogger("install")
 
+	// A bundle install is fully offline: no gh probe, no GitHub API.
+	exportTo, fromBundle := bundlePaths(cmd)
+	if fromBundle != "" {
+		manager, err := newSDKManager()
+		if err != nil {
+			return fmt.Errorf("failed to create SDK manager: %w", err)
+		}
+		verifyOpts, err := installVerifyOptions(cmd)
+		if err != nil {
+			return err
+		}
+		manager.SetVerifyOptions(verifyOpts)
+		return runInstallFromBundle(manager, fromBundle)
+	}
+
 	// Auto-detect gh CLI if not explicitly set
 	if !useGH && checkGHAuth() {
 		useGH = true
@@ -60,7 +93,7 @@ func runInstall(cmd *cobra.Command, args []string, useGH bool) error {
 	}
 
 	// Create SDK manager
-	manager, err := sdk.NewManager()
+	manager, err := newSDKManager()
 	if err != nil {
 		return fmt.Errorf("failed to create SDK manager: %w", err)
 	}
@@ -68,6 +101,16 @@ func runInstall(cmd *cobra.Command, args []string, useGH bool) error {
 	// Set the download method
 	manager.SetUseGH(useGH)
 
+	verifyOpts, err := installVerifyOptions(cmd)
+	if err != nil {
+		return err
+	}
+	manager.SetVerifyOptions(verifyOpts)
+
+	if exportTo != "" {
+		return runExportBundle(cmd, manager, args, exportTo)
+	}
+
 	// Ensure directory structure exists
 	if err := manager.EnsureDirs(); err != nil {
 		return fmt.Errorf("failed to create directory structure: %w", err)
@@ -379,3 +422,16 @@ func clearDevLinkForTool(toolName string) error {
 
 	return nil
 }
+
+// newSDKManager creates the SDK manager, pointed at the GitHub org the
+// current ecosystem releases its tools under (see project.EcosystemConfig).
+func newSDKManager() (*sdk.Manager, error) {
+	manager, err := sdk.NewManager()
+	if err != nil {
+		return nil, err
+	}
+	if cwd, err := os.Getwd(); err == nil {
+		manager.SetOwner(project.LoadEcosystemConfig(cwd).ReleaseOwner())
+	}
+	return manager, nil
+}
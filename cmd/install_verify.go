@@ -0,0 +1,53 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/grovetools/core/config"
+	"github.com/grovetools/core/tui/theme"
+	"github.com/spf13/cobra"
+
+	"github.com/grovetools/grove/pkg/sdk"
+)
+
+// installConfig is the grove.toml [install] table. Configuring trusted keys
+// makes signatures mandatory: every release installed must carry a
+// checksums.txt.minisig from one of them.
+//
+//	[install]
+//	trusted_keys = ["RWQf6LRCGA9i53mlYecO4IzT51TGPpvWucNSCh1CBM0QTaLn73Y7GFO3"]
+type installConfig struct {
+	// TrustedKeys are minisign public keys (the base64 line of a .pub file).
+	TrustedKeys []string `yaml:"trusted_keys"`
+}
+
+// addInstallVerifyFlags registers the verification escape hatch shared by
+// install, update and self-update.
+func addInstallVerifyFlags(cmd *cobra.Command) {
+	cmd.Flags().Bool("insecure-skip-verify", false, "Install release binaries without checking checksums.txt or its signature")
+}
+
+// installVerifyOptions resolves download verification from [install] and
+// --insecure-skip-verify. cmd may be nil (onboarding installs), which keeps
+// the defaults.
+func installVerifyOptions(cmd *cobra.Command) (sdk.VerifyOptions, error) {
+	opts := sdk.VerifyOptions{
+		Warn: func(msg string) {
+			fmt.Fprintln(os.Stderr, theme.DefaultTheme.Warning.Render("warning: "+msg))
+		},
+	}
+	if cmd != nil {
+		opts.Skip, _ = cmd.Flags().GetBool("insecure-skip-verify")
+	}
+	cfg, err := config.LoadDefault()
+	if err != nil {
+		return opts, nil
+	}
+	var ic installConfig
+	if err := cfg.UnmarshalExtension("install", &ic); err != nil {
+		return opts, fmt.Errorf("parse [install]: %w", err)
+	}
+	opts.TrustedKeys = ic.TrustedKeys
+	return opts, nil
+}
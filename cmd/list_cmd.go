@@ -64,7 +64,7 @@ func runList(cmd *cobra.Command, args []string) error {
 	logger := logging.NewLogger("list")
 
 	// Create SDK manager
-	manager, err := sdk.NewManager()
+	manager, err := newSDKManager()
 	if err != nil {
 		return fmt.Errorf("failed to create SDK manager: %w", err)
 	}
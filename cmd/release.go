@@ -21,7 +21,7 @@ import (
 	"golang.org/x/mod/modfile"
 
 	"github.com/grovetools/grove/pkg/depsgraph"
-	"github.com/grovetools/grove/pkg/gh"
+	"github.com/grovetools/grove/pkg/forge"
 	"github.com/grovetools/grove/pkg/project"
 	"github.com/grovetools/grove/pkg/release"
 )
@@ -157,6 +157,8 @@ Examples:
 	cmd.AddCommand(newReleaseClearPlanCmd())
 	cmd.AddCommand(newReleaseUndoTagCmd())
 	cmd.AddCommand(newReleaseRollbackCmd())
+	cmd.AddCommand(newReleaseHistoryCmd())
+	cmd.AddCommand(newReleaseShowCmd())
 	cmd.AddCommand(newChangelogCmd())
 
 	return cmd
@@ -781,6 +783,7 @@ func orchestrateRelease(ctx context.Context, rootDir string, releaseLevels [][]s
 				}
 
 				wsPath := node.Dir
+				clock := newStageClock(plan, repo)
 
 				ulog.Info("Releasing module").
 					Field("repo", repo).
@@ -840,9 +843,17 @@ func orchestrateRelease(ctx context.Context, rootDir string, releaseLevels [][]s
 					}
 					logger.WithField("repo", repo).Info("[orchestrateRelease] updateDependencies completed successfully")
 
+					// Projects that keep their version in a manifest (package.json)
+					// get it bumped before the tag is cut
+					stamped, err := stampManifestVersion(ctx, wsPath, version, logger)
+					if err != nil {
+						errChan <- fmt.Errorf("failed to stamp version for %s: %w", repo, err)
+						return
+					}
+
 					// After updating dependencies, check for changes and push them
 					status, _ := git.GetStatus(wsPath)
-					if status.IsDirty {
+					if status.IsDirty || stamped {
 						// Determine target branch based on release type
 						targetBranch := "main"
 						if plan.Type == "rc" {
@@ -857,7 +868,7 @@ func orchestrateRelease(ctx context.Context, rootDir string, releaseLevels [][]s
 						// CI gating is opt-in (--ci). Default: don't wait.
 						if releaseCI {
 							displayInfo(fmt.Sprintf("Waiting for CI to pass for %s after dependency updates (--ci)...", repo))
-							if err := gh.WaitForCIWorkflow(ctx, wsPath); err != nil {
+							if err := waitForCIWorkflow(ctx, wsPath, repo); err != nil {
 								errChan <- fmt.Errorf("CI workflow for %s failed after dependency update: %w", repo, err)
 								return
 							}
@@ -866,6 +877,7 @@ func orchestrateRelease(ctx context.Context, rootDir string, releaseLevels [][]s
 						}
 					}
 				}
+				clock.lap("dependencies")
 
 				// Publish step (full releases only): promote the reviewed docs +
 				// changelog into the working tree and push them as ONE commit
@@ -912,14 +924,14 @@ func orchestrateRelease(ctx context.Context, rootDir string, releaseLevels [][]s
 							if repoPlan, ok := plan.Repos[repo]; ok {
 								repoPlan.ChangelogPushed = true
 								repoPlan.LastFailedOperation = "ci_wait"
-								_ = release.SavePlan(plan)
+								saveReleaseProgress(plan)
 							}
 						}
 
 						// CI gating is OPT-IN (--ci). Default: proceed without waiting.
 						if releaseCI {
 							displayInfo(fmt.Sprintf("Waiting for CI to pass for %s after publish (--ci)...", repo))
-							if err := gh.WaitForCIWorkflow(ctx, wsPath); err != nil {
+							if err := waitForCIWorkflow(ctx, wsPath, repo); err != nil {
 								errChan <- fmt.Errorf("CI workflow for %s failed after publish: %w", repo, err)
 								return
 							}
@@ -931,13 +943,14 @@ func orchestrateRelease(ctx context.Context, rootDir string, releaseLevels [][]s
 							if repoPlan, ok := plan.Repos[repo]; ok {
 								repoPlan.CIPassed = true
 								repoPlan.LastFailedOperation = ""
-								_ = release.SavePlan(plan)
+								saveReleaseProgress(plan)
 							}
 						}
 					}
 				}
 
 			waitForCI:
+				clock.lap("publish")
 				// Resume target: docs+changelog were published in a previous
 				// attempt but the CI gate (opt-in) hadn't cleared. Only meaningful
 				// when --ci is set; otherwise we just mark CI passed and continue.
@@ -945,7 +958,7 @@ func orchestrateRelease(ctx context.Context, rootDir string, releaseLevels [][]s
 					if repoPlan, ok := plan.Repos[repo]; ok && repoPlan.ChangelogPushed && !repoPlan.CIPassed {
 						if releaseCI {
 							displayInfo(fmt.Sprintf("Waiting for CI to pass for %s (docs+changelog already published)...", repo))
-							if err := gh.WaitForCIWorkflow(ctx, wsPath); err != nil {
+							if err := waitForCIWorkflow(ctx, wsPath, repo); err != nil {
 								errChan <- fmt.Errorf("CI workflow for %s failed: %w", repo, err)
 								return
 							}
@@ -954,11 +967,12 @@ func orchestrateRelease(ctx context.Context, rootDir string, releaseLevels [][]s
 						}
 						repoPlan.CIPassed = true
 						repoPlan.LastFailedOperation = ""
-						_ = release.SavePlan(plan)
+						saveReleaseProgress(plan)
 					}
 				}
 
 			createTag:
+				clock.lap("ci_wait")
 				// Check for tag conflicts before creating
 				tagExistsLocal, _ := tagExists(ctx, wsPath, version)
 				if tagExistsLocal {
@@ -978,7 +992,7 @@ func orchestrateRelease(ctx context.Context, rootDir string, releaseLevels [][]s
 				if plan != nil && plan.Repos != nil {
 					if repoPlan, ok := plan.Repos[repo]; ok {
 						repoPlan.LastFailedOperation = "tag_creation"
-						_ = release.SavePlan(plan)
+						saveReleaseProgress(plan)
 					}
 				}
 
@@ -1010,24 +1024,37 @@ func orchestrateRelease(ctx context.Context, rootDir string, releaseLevels [][]s
 					if repoPlan, ok := plan.Repos[repo]; ok {
 						repoPlan.TagPushed = true
 						repoPlan.LastFailedOperation = "" // Clear any previous failures
-						_ = release.SavePlan(plan)
+						saveReleaseProgress(plan)
 					}
 				}
 
 			releaseWorkflow:
+				clock.lap("tag")
 
 				// Wait for CI workflow to complete (skip in dry-run mode)
 				if !releaseDryRun {
-					// Check if the project has a .github directory with workflows.
-					// The post-tag Release-workflow wait (binary publishing) is
-					// default-ON but can be disabled with --no-release-workflow-wait.
-					githubDir := filepath.Join(wsPath, ".github")
-					if _, err := os.Stat(githubDir); err == nil && !releaseNoReleaseWorkflowWait {
-						// .github directory exists, wait for workflows
-						logger.Infof("Waiting for CI release of %s@%s to complete (timeout: 60 minutes)...", repo, version)
+					// Wait for the Release workflow/pipeline on whichever forge hosts
+					// the repo. The post-tag wait (binary publishing) is default-ON
+					// but can be disabled with --no-release-workflow-wait.
+					releaseForge, remote, forgeErr := forge.ForRepo(wsPath)
+					switch {
+					case activeRehearsal != nil:
+						if forge.HasCIConfig(wsPath) {
+							// Nothing builds rehearsal tags; record the wait the real run makes
+							activeRehearsal.Note(repo, fmt.Sprintf("skipped waiting for the Release workflow of %s", version))
+							displayInfo(fmt.Sprintf("Rehearsal: not waiting for the Release workflow of %s@%s", repo, version))
+						}
+					case releaseNoReleaseWorkflowWait:
+						logger.Infof("Skipping release-workflow wait for %s (--no-release-workflow-wait)", repo)
+						displayInfo(fmt.Sprintf("Skipping release-workflow wait for %s (--no-release-workflow-wait)", repo))
+					case forgeErr != nil && forge.HasCIConfig(wsPath):
+						errChan <- fmt.Errorf("cannot wait for the release workflow of %s@%s: %w", repo, version, forgeErr)
+						return
+					case forgeErr == nil && releaseForge.CIConfigured(wsPath):
+						logger.Infof("Waiting for CI release of %s@%s on %s to complete...", repo, version, releaseForge.Kind())
 						displayInfo(fmt.Sprintf("Waiting for CI release of %s@%s to complete (timeout: 60 minutes)...", repo, version))
 
-						if err := gh.WaitForReleaseWorkflow(ctx, wsPath, version); err != nil {
+						if err := releaseForge.WaitForRelease(ctx, remote, version); err != nil {
 							// This is a critical failure
 							errChan <- fmt.Errorf("release workflow for %s@%s failed: %w", repo, version, err)
 							return
@@ -1038,14 +1065,12 @@ func orchestrateRelease(ctx context.Context, rootDir string, releaseLevels [][]s
 							Field("version", version).
 							Pretty(fmt.Sprintf("  %s CI release for %s@%s successful", theme.IconSuccess, repo, version)).
 							Log(ctx)
-					} else if releaseNoReleaseWorkflowWait {
-						logger.Infof("Skipping release-workflow wait for %s (--no-release-workflow-wait)", repo)
-						displayInfo(fmt.Sprintf("Skipping release-workflow wait for %s (--no-release-workflow-wait)", repo))
-					} else {
-						// No .github directory, skip CI workflow monitoring
-						logger.Infof("No .github directory found for %s, skipping CI workflow monitoring", repo)
-						displayInfo(fmt.Sprintf("No .github directory found for %s, skipping CI workflow monitoring", repo))
+					default:
+						// No CI configuration, skip CI workflow monitoring
+						logger.Infof("No CI configuration found for %s, skipping CI workflow monitoring", repo)
+						displayInfo(fmt.Sprintf("No CI configuration found for %s, skipping CI workflow monitoring", repo))
 					}
+					clock.lap("release_workflow")
 
 					// Check if we need to wait for module availability (skip for template projects)
 					needsModuleCheck, err := shouldWaitForModuleAvailability(wsPath)
@@ -1062,9 +1087,18 @@ func orchestrateRelease(ctx context.Context, rootDir string, releaseLevels [][]s
 							errChan <- fmt.Errorf("failed waiting for %s@%s: %w", node.Path, version, err)
 							return
 						}
+						if activeRehearsal != nil {
+							// Let dependents resolve the rehearsed tag
+							if err := activeRehearsal.PublishModule(ctx, node.Path, version, wsPath); err != nil {
+								errChan <- fmt.Errorf("failed to publish %s@%s to the rehearsal proxy: %w", node.Path, version, err)
+								return
+							}
+						}
 					} else {
 						displayInfo(fmt.Sprintf("Skipping module availability check for %s (not a Go module)", repo))
 					}
+					clock.lap("module_availability")
+					saveReleaseProgress(plan)
 
 					displayComplete(fmt.Sprintf("%s successfully released", repo))
 				}
@@ -1095,25 +1129,15 @@ func updateDependencies(ctx context.Context, modulePath string, releasedVersions
 		"planType":         planType,
 	}).Info("[updateDependencies] Starting dependency update")
 
-	// Load grove.yml to get project type
-	groveYmlPath := filepath.Join(modulePath, "grove.yml")
-	cfg, err := config.Load(groveYmlPath)
-	if err != nil {
-		// If no grove.yml, assume Go project for backward compatibility
-		return updateGoDependencies(ctx, modulePath, releasedVersions, graph, logger)
+	projectType, ok := workspaceProjectType(modulePath)
+	if !ok || projectType == project.TypeGo {
+		// Go modules (and workspaces without a grove config) bump every
+		// ecosystem requirement in go.mod, test-only and indirect ones too
+		return updateGoDependencies(ctx, modulePath, releasedVersions, graph, planType, logger)
 	}
 
-	// Get project type
-	var projectTypeStr string
-	if err := cfg.UnmarshalExtension("type", &projectTypeStr); err != nil || projectTypeStr == "" {
-		// Default to Go for backward compatibility
-		projectTypeStr = string(project.TypeGo)
-	}
-
-	projectType := project.Type(projectTypeStr)
-
 	// Get appropriate handler
-	registry := project.NewRegistry()
+	registry := project.NewRegistryFor(graph.ModulePrefixes())
 	handler, err := registry.Get(projectType)
 	if err != nil {
 		logger.WithError(err).Warnf("No handler for project type %s, skipping dependency update", projectType)
@@ -1136,73 +1160,34 @@ func updateDependencies(ctx context.Context, modulePath string, releasedVersions
 			continue
 		}
 
-		// Find the workspace name for this dependency
+		// Find the workspace name for this dependency: map the module path
+		// or package name to its workspace
 		var depWorkspaceName string
-		if projectType == project.TypeGo {
-			// For Go projects, map module path to workspace name
-			for name, node := range graph.GetAllNodes() {
-				if node.Path == dep.Name {
-					depWorkspaceName = name
-					break
-				}
+		for name, node := range graph.GetAllNodes() {
+			if node.Path != "" && node.Path == dep.Name {
+				depWorkspaceName = name
+				break
 			}
-		} else {
-			// For other project types, dependency name should match workspace name
-			depWorkspaceName = dep.Name
 		}
-
 		if depWorkspaceName == "" {
-			continue
+			// Otherwise the dependency name should match the workspace name
+			depWorkspaceName = dep.Name
 		}
 
 		// Determine the target version for this dependency
 		var targetVersion string
 
-		// First check if this dependency is being released in the current batch
-		if newVersion, hasNewVersion := releasedVersions[depWorkspaceName]; hasNewVersion {
-			targetVersion = newVersion
-			logger.WithFields(logrus.Fields{
-				"dep":       dep.Name,
-				"workspace": depWorkspaceName,
-				"version":   newVersion,
-			}).Info("Using version from current release batch")
-		} else {
-			// Dependency is not in current release batch, fetch latest version
-			// Only fetch latest for Go projects (others don't have a module proxy)
-			if projectType == project.TypeGo {
-				var latestVersion string
-				var err error
-
-				// For RC releases, fetch latest prerelease version; otherwise fetch latest stable
-				if planType == "rc" {
-					latestVersion, err = getLatestPrereleaseModuleVersion(dep.Name)
-					if err != nil {
-						logger.WithError(err).Warnf("Failed to get latest prerelease version for %s, keeping current version", dep.Name)
-						continue
-					}
-					logger.WithFields(logrus.Fields{
-						"dep":       dep.Name,
-						"workspace": depWorkspaceName,
-						"version":   latestVersion,
-					}).Info("Using latest prerelease version from module proxy")
-				} else {
-					latestVersion, err = getLatestModuleVersion(dep.Name)
-					if err != nil {
-						logger.WithError(err).Warnf("Failed to get latest version for %s, keeping current version", dep.Name)
-						continue
-					}
-					logger.WithFields(logrus.Fields{
-						"dep":       dep.Name,
-						"workspace": depWorkspaceName,
-						"version":   latestVersion,
-					}).Info("Using latest version from module proxy")
-				}
-				targetVersion = latestVersion
-			} else {
-				// For non-Go projects, skip if not in release batch
-				continue
-			}
+		// Only dependencies released in the current batch are bumped: these
+		// ecosystems have no module proxy to ask for the latest version
+		targetVersion, inBatch := releasedVersions[depWorkspaceName]
+		if !inBatch {
+			continue
 		}
+		logger.WithFields(logrus.Fields{
+			"dep":       dep.Name,
+			"workspace": depWorkspaceName,
+			"version":   targetVersion,
+		}).Info("Using version from current release batch")
 
 		// Check if update is needed
 		if dep.Version == targetVersion {
@@ -1236,23 +1221,21 @@ func updateDependencies(ctx context.Context, modulePath string, releasedVersions
 		}
 
 		if status.IsDirty {
-			// Commit the dependency updates
+			// Commit only the manifests and lockfile the handler writes, so
+			// unrelated edits in the workspace stay out of the release
 			commitFiles := []string{}
-			if projectType == project.TypeGo {
-				commitFiles = []string{"go.mod", "go.sum"}
-			} else if projectType == project.TypeMaturin {
+			if projectType == project.TypeMaturin {
 				commitFiles = []string{"pyproject.toml"}
+			} else if lister, ok := handler.(project.ManifestLister); ok {
+				// Manifests across monorepo or Cargo workspace members plus the lockfile
+				if commitFiles, err = lister.ManifestFiles(modulePath); err != nil {
+					return fmt.Errorf("failed to list manifests: %w", err)
+				}
 			}
 
 			if len(commitFiles) > 0 {
-				if err := executeGitCommand(ctx, modulePath, append([]string{"add"}, commitFiles...),
-					"Stage dependency updates", logger); err != nil {
-					return err
-				}
-
-				commitMsg := "chore(deps): update Grove dependencies to latest versions"
-				if err := executeGitCommand(ctx, modulePath, []string{"commit", "-m", commitMsg},
-					"Commit dependency updates", logger); err != nil {
+				if _, err := commitReleaseFiles(ctx, modulePath, commitFiles,
+					"chore(deps): update Grove dependencies to latest versions", "dependency updates", logger); err != nil {
 					return err
 				}
 			}
@@ -1262,8 +1245,29 @@ func updateDependencies(ctx context.Context, modulePath string, releasedVersions
 	return nil
 }
 
+// commitReleaseFiles stages files (relative to workspacePath) and commits
+// exactly them, leaving any other staged or unstaged change alone. It
+// reports whether there was anything to commit.
+func commitReleaseFiles(ctx context.Context, workspacePath string, files []string, message, what string, logger *logrus.Logger) (bool, error) {
+	pathspec := append([]string{"--"}, files...)
+	if err := executeGitCommand(ctx, workspacePath, append([]string{"add"}, pathspec...),
+		"Stage "+what, logger); err != nil {
+		return false, err
+	}
+	diffCmd := exec.CommandContext(ctx, "git", append([]string{"diff", "--staged", "--quiet"}, pathspec...)...)
+	diffCmd.Dir = workspacePath
+	if diffCmd.Run() == nil {
+		return false, nil
+	}
+	if err := executeGitCommand(ctx, workspacePath, append([]string{"commit", "-m", message}, pathspec...),
+		"Commit "+what, logger); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
 // Keep the original function for backward compatibility
-func updateGoDependencies(ctx context.Context, modulePath string, releasedVersions map[string]string, graph *depsgraph.Graph, logger *logrus.Logger) error {
+func updateGoDependencies(ctx context.Context, modulePath string, releasedVersions map[string]string, graph *depsgraph.Graph, planType string, logger *logrus.Logger) error {
 	logger.WithFields(logrus.Fields{
 		"modulePath":       modulePath,
 		"releasedVersions": releasedVersions,
@@ -1285,11 +1289,12 @@ func updateGoDependencies(ctx context.Context, modulePath string, releasedVersio
 	// Track if we made any updates
 	hasUpdates := false
 	updatedDeps := []string{}
+	prefixes := graph.ModulePrefixes()
 
 	// Check each dependency
 	for _, req := range modFile.Require {
-		// Only update Grove ecosystem dependencies
-		if !strings.HasPrefix(req.Mod.Path, "github.com/grovetools/") {
+		// Only update ecosystem dependencies
+		if !prefixes.Match(req.Mod.Path) {
 			continue
 		}
 
@@ -1318,8 +1323,13 @@ func updateGoDependencies(ctx context.Context, modulePath string, releasedVersio
 				"version": newVersion,
 			}).Info("[updateGoDependencies] Using version from current release batch")
 		} else {
-			// Dependency is not in current release batch, fetch latest version
-			latestVersion, err := getLatestModuleVersion(req.Mod.Path)
+			// Dependency is not in current release batch, fetch latest version;
+			// RC releases take the latest prerelease
+			fetchLatest := getLatestModuleVersion
+			if planType == "rc" {
+				fetchLatest = getLatestPrereleaseModuleVersion
+			}
+			latestVersion, err := fetchLatest(req.Mod.Path, prefixes)
 			if err != nil {
 				logger.WithError(err).Warnf("[updateGoDependencies] Failed to get latest version for %s, keeping current version", req.Mod.Path)
 				continue
@@ -1351,11 +1361,7 @@ func updateGoDependencies(ctx context.Context, modulePath string, releasedVersio
 		// Update to target version
 		cmd := exec.CommandContext(ctx, "go", "get", fmt.Sprintf("%s@%s", req.Mod.Path, targetVersion))
 		cmd.Dir = modulePath
-		cmd.Env = append(os.Environ(),
-			"GOPRIVATE=github.com/grovetools/*",
-			"GOPROXY=direct",
-			"GOWORK=off",
-		)
+		cmd.Env = goModuleEnv(prefixes)
 
 		output, err := cmd.CombinedOutput()
 		if err != nil {
@@ -1374,11 +1380,7 @@ func updateGoDependencies(ctx context.Context, modulePath string, releasedVersio
 		// Run go mod tidy
 		cmd := exec.CommandContext(ctx, "go", "mod", "tidy")
 		cmd.Dir = modulePath
-		cmd.Env = append(os.Environ(),
-			"GOPRIVATE=github.com/grovetools/*",
-			"GOPROXY=direct",
-			"GOWORK=off",
-		)
+		cmd.Env = goModuleEnv(prefixes)
 
 		if output, err := cmd.CombinedOutput(); err != nil {
 			return fmt.Errorf("go mod tidy failed: %w (output: %s)", err, output)
@@ -1535,6 +1537,7 @@ func createReleaseCommitMessage(versions map[string]string, hasChanges map[strin
 
 func checkForOutdatedDependencies(ctx context.Context, rootDir string, workspaces []string, logger *logrus.Logger) error {
 	outdatedDeps := make(map[string]map[string]string) // workspace -> dep -> current version
+	prefixes := project.LoadModulePrefixes(rootDir)
 
 	for _, ws := range workspaces {
 		// Skip the root workspace
@@ -1564,26 +1567,24 @@ func checkForOutdatedDependencies(ctx context.Context, rootDir string, workspace
 			}
 
 			if inRequire || strings.HasPrefix(line, "require ") {
-				if strings.Contains(line, "github.com/grovetools/") {
-					parts := strings.Fields(line)
-					if len(parts) >= 2 {
-						dep := parts[0]
-						if strings.HasPrefix(dep, "github.com/grovetools/") {
-							currentVersion := parts[1]
-
-							// Get latest version
-							latestVersion, err := getLatestModuleVersion(dep)
-							if err != nil {
-								continue // Skip if we can't get latest version
-							}
+				parts := strings.Fields(strings.TrimPrefix(line, "require "))
+				if len(parts) >= 2 {
+					dep := parts[0]
+					if prefixes.Match(dep) {
+						currentVersion := parts[1]
+
+						// Get latest version
+						latestVersion, err := getLatestModuleVersion(dep, prefixes)
+						if err != nil {
+							continue // Skip if we can't get latest version
+						}
 
-							// Check if outdated
-							if currentVersion != latestVersion {
-								if outdatedDeps[wsName] == nil {
-									outdatedDeps[wsName] = make(map[string]string)
-								}
-								outdatedDeps[wsName][dep] = fmt.Sprintf("%s → %s", currentVersion, latestVersion)
+						// Check if outdated
+						if currentVersion != latestVersion {
+							if outdatedDeps[wsName] == nil {
+								outdatedDeps[wsName] = make(map[string]string)
 							}
+							outdatedDeps[wsName][dep] = fmt.Sprintf("%s → %s", currentVersion, latestVersion)
 						}
 					}
 				}
@@ -1612,32 +1613,71 @@ func checkForOutdatedDependencies(ctx context.Context, rootDir string, workspace
 // shouldWaitForModuleAvailability determines if a project needs module availability checking
 // Template projects and other non-Go modules should skip this check
 func shouldWaitForModuleAvailability(workspacePath string) (bool, error) {
-	// Load grove.yml to determine project type
-	groveConfigPath := filepath.Join(workspacePath, "grove.yml")
-	cfg, err := config.Load(groveConfigPath)
-	if err != nil {
-		// If no grove.yml, assume it's a Go project for backward compatibility
-		return true, nil
-	}
-
-	// Get project type from grove.yml
-	var projectTypeStr string
-	if err := cfg.UnmarshalExtension("type", &projectTypeStr); err != nil || projectTypeStr == "" {
-		// Default to Go for backward compatibility
+	projectType, ok := workspaceProjectType(workspacePath)
+	if !ok {
+		// If no grove config, assume it's a Go project for backward compatibility
 		return true, nil
 	}
 
-	projectType := project.Type(projectTypeStr)
-
 	// Only Go modules need module availability checking
-	// Template, Maturin, and Node projects are not published to Go module proxy
+	// Template, Maturin, Node and Cargo projects are not published to Go module proxy
 	switch projectType {
 	case project.TypeGo:
 		return true, nil
-	case project.TypeTemplate, project.TypeMaturin, project.TypeNode:
+	case project.TypeTemplate, project.TypeMaturin, project.TypeNode, project.TypeCargo:
 		return false, nil
 	default:
 		// Unknown project type, default to checking for safety
 		return true, nil
 	}
 }
+
+// workspaceProjectType returns the `type` declared in the workspace's grove
+// config (grove.toml or grove.yml), defaulting to Go. ok is false when the
+// workspace has no readable config.
+func workspaceProjectType(workspacePath string) (project.Type, bool) {
+	configPath, err := config.FindConfigFile(workspacePath)
+	if err != nil || filepath.Dir(configPath) != filepath.Clean(workspacePath) {
+		return "", false
+	}
+	cfg, err := config.Load(configPath)
+	if err != nil {
+		return "", false
+	}
+	var projectTypeStr string
+	if err := cfg.UnmarshalExtension("type", &projectTypeStr); err != nil || projectTypeStr == "" {
+		// Default to Go for backward compatibility
+		return project.TypeGo, true
+	}
+	return project.Type(projectTypeStr), true
+}
+
+// stampManifestVersion writes the release version into the workspace's
+// manifest for project types that keep one (package.json for Node,
+// Cargo.toml for Cargo) and commits it, so the tag points at a manifest
+// carrying the same version. It reports whether a commit was made.
+func stampManifestVersion(ctx context.Context, workspacePath, version string, logger *logrus.Logger) (bool, error) {
+	projectType, ok := workspaceProjectType(workspacePath)
+	if !ok || (projectType != project.TypeNode && projectType != project.TypeCargo) {
+		return false, nil
+	}
+	handler, err := project.NewRegistry().Get(projectType)
+	if err != nil {
+		return false, err
+	}
+	if current, err := handler.GetVersion(workspacePath); err == nil && current == strings.TrimPrefix(version, "v") {
+		return false, nil
+	}
+	if err := handler.SetVersion(workspacePath, version); err != nil {
+		return false, fmt.Errorf("failed to set version: %w", err)
+	}
+	// The root manifest, plus Cargo.lock when the handler refreshed it
+	files := []string{"package.json"}
+	if projectType == project.TypeCargo {
+		files = []string{"Cargo.toml"}
+		if _, err := os.Stat(filepath.Join(workspacePath, "Cargo.lock")); err == nil {
+			files = append(files, "Cargo.lock")
+		}
+	}
+	return commitReleaseFiles(ctx, workspacePath, files, fmt.Sprintf("chore(release): %s", version), "version bump", logger)
+}
@@ -13,12 +13,15 @@ import (
 	"strings"
 	"time"
 
+	"github.com/Masterminds/semver/v3"
 	"github.com/grovetools/core/config"
 	"github.com/grovetools/core/logging"
 	"github.com/grovetools/core/util/delegation"
 	grovecontext "github.com/grovetools/cx/pkg/context"
 	docgenconfig "github.com/grovetools/docgen/pkg/config"
 	"github.com/grovetools/grove-anthropic/pkg/anthropic"
+
+	"github.com/grovetools/grove/pkg/release"
 )
 
 // Logger for changelog LLM operations
@@ -240,14 +243,12 @@ func buildChangelogGitContext(repoPath string, settings changelogSettings, quiet
 		fmt.Println("Analyzing all commits (no previous tag found)")
 	}
 
-	logCmd := exec.Command("git", "log", commitRange, "--pretty=format:commit %H (%h)%nAuthor: %an <%ae>%nDate: %ad%nCommit: %cn <%ce>%nCommitDate: %cd%n%n    %s%n%n%b%n")
-	logCmd.Dir = repoPath
-	logOutput, err := logCmd.CombinedOutput()
+	decision, err := analyzeCommits(repoPath, lastTag, tagVersion(lastTag))
 	if err != nil {
-		return "", fmt.Errorf("failed to get git log: %w\n%s", err, string(logOutput))
+		return "", fmt.Errorf("failed to read commits: %w", err)
 	}
 
-	gitContext := fmt.Sprintf("GIT LOG:\n%s", string(logOutput))
+	gitContext := fmt.Sprintf("COMMITS:\n%s", release.FormatCommits(decision))
 
 	switch settings.Diff {
 	case "none":
@@ -281,6 +282,17 @@ func buildChangelogGitContext(repoPath string, settings changelogSettings, quiet
 	return gitContext, nil
 }
 
+// tagVersion is the current version a repo's last tag stands for, as
+// calculateNextVersions reads it: the tag parsed as semver, v0.0.0 when there
+// is no tag or it is not a version.
+func tagVersion(tag string) string {
+	v, err := semver.NewVersion(tag)
+	if tag == "" || err != nil {
+		return "v0.0.0"
+	}
+	return "v" + v.String()
+}
+
 // runGitDiff returns the git diff for commitRange; stat=true yields --stat.
 func runGitDiff(repoPath, commitRange string, stat bool) (string, error) {
 	args := []string{"diff"}
@@ -607,7 +619,7 @@ func generateChangelogResultWithUsage(gitContext, newVersion, repoPath string, s
 // it can ride after a cached context prefix for claude models.
 func buildChangelogPrompt(gitContext, newVersion, currentDate string) string {
 	return fmt.Sprintf(`You are a technical writer responsible for creating release notes and suggesting semantic version bumps.
-Based on the provided git log and diff, analyze the changes and generate a JSON object with three fields: "suggestion", "justification", and "changelog".
+Based on the provided commit list and diff, analyze the changes and generate a JSON object with three fields: "suggestion", "justification", and "changelog".
 
 **JSON Schema:**
 {
@@ -617,6 +629,7 @@ Based on the provided git log and diff, analyze the changes and generate a JSON
 }
 
 **Instructions for Analysis:**
+- The commits are already parsed as Conventional Commits and grouped by impact. The "SEMVER ANALYSIS" line is the bump the repository's own rules give; follow it unless the diff clearly shows otherwise, and say why in the justification if you deviate.
 - **major:** Suggest if there are breaking changes (e.g., commits with "!" like "feat!:", or "BREAKING CHANGE:" in the body).
 - **minor:** Suggest if new features are added without breaking changes (e.g., "feat:" commits).
 - **patch:** Suggest for bug fixes, performance improvements, or chores (e.g., "fix:", "perf:", "chore:").
@@ -632,8 +645,9 @@ Based on the provided git log and diff, analyze the changes and generate a JSON
    Example: "Add workspace list command with JSON output support (a1b2c3d)"
    GitHub will automatically convert these 7-character hashes into clickable links.
 6. At the very end, include a "### File Changes" section with the provided git diff stat in a code block.
-7. Do not include any preamble or explanation.
-8. Do not use any emojis anywhere in the changelog.
+7. Leave out commits listed as reverted within this release, and commits ignored by the bump rules unless they matter to users.
+8. Do not include any preamble or explanation.
+9. Do not use any emojis anywhere in the changelog.
 
 **Context from Git:**
 ---
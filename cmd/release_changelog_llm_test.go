@@ -101,3 +101,17 @@ func TestFixChangelogHeader(t *testing.T) {
 		})
 	}
 }
+
+func TestTagVersion(t *testing.T) {
+	for tag, want := range map[string]string{
+		"":            "v0.0.0",
+		"v1.4.2":      "v1.4.2",
+		"0.3.0":       "v0.3.0",
+		"v2.0.0-rc.1": "v2.0.0-rc.1",
+		"nightly":     "v0.0.0",
+	} {
+		if got := tagVersion(tag); got != want {
+			t.Errorf("tagVersion(%q) = %q, want %q", tag, got, want)
+		}
+	}
+}
@@ -0,0 +1,230 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/grovetools/core/tui/theme"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/grovetools/grove/pkg/release"
+)
+
+// stageClock attributes the wall-clock time of one repo's apply to stages.
+// Each lap charges the time since the previous lap to the named stage.
+type stageClock struct {
+	last     time.Time
+	repoPlan *release.RepoReleasePlan
+}
+
+func newStageClock(plan *release.ReleasePlan, repo string) *stageClock {
+	c := &stageClock{last: time.Now()}
+	if plan != nil && plan.Repos != nil {
+		c.repoPlan = plan.Repos[repo]
+	}
+	return c
+}
+
+func (c *stageClock) lap(stage string) {
+	now := time.Now()
+	if c.repoPlan != nil {
+		c.repoPlan.AddStageTiming(stage, now.Sub(c.last))
+	}
+	c.last = now
+}
+
+// beginReleaseHistory starts the ledger entry for applying plan, recording
+// each selected repo's HEAD so a later rollback can return to it.
+func beginReleaseHistory(ctx context.Context, plan *release.ReleasePlan) *release.HistoryEntry {
+	entry := release.NewHistoryEntry(plan, time.Now())
+	for name, repo := range entry.Repos {
+		repo.HeadBefore = gitRevParse(ctx, filepath.Join(plan.RootDir, name), "HEAD")
+	}
+	return entry
+}
+
+// recordReleaseHistory completes entry with the outcome of orchestrating plan
+// and appends it to the ledger. Failing to write the ledger never fails the
+// release.
+func recordReleaseHistory(ctx context.Context, plan *release.ReleasePlan, entry *release.HistoryEntry, applyErr error, logger *logrus.Logger) {
+	entry.RecordOutcomes(plan, applyErr, time.Now())
+	for name, repo := range entry.Repos {
+		if repo.Outcome == release.OutcomeReleased {
+			repo.ReleasedCommit = gitRevParse(ctx, filepath.Join(plan.RootDir, name), repo.Version+"^{commit}")
+		}
+	}
+	if err := release.AppendHistory(entry); err != nil {
+		logger.WithError(err).Warn("Failed to record release history")
+	}
+}
+
+// gitRevParse resolves rev in dir, or returns "" when it cannot.
+func gitRevParse(ctx context.Context, dir, rev string) string {
+	cmd := exec.CommandContext(ctx, "git", "rev-parse", "--verify", "--quiet", rev)
+	cmd.Dir = dir
+	out, err := cmd.Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(out))
+}
+
+func newReleaseHistoryCmd() *cobra.Command {
+	var limit int
+	var jsonOutput bool
+
+	cmd := &cobra.Command{
+		Use:   "history",
+		Short: "List past release applies from the release history ledger",
+		Long: `List the releases recorded in the local release history ledger, newest first.
+
+Every 'grove release apply' (except --dry-run and --rehearse) appends one entry
+with the plan it applied and what became of each repository. The ledger lives
+next to the release plan and survives 'grove release clear-plan'.
+
+Examples:
+  grove release history             # Last 20 releases
+  grove release history --limit 0   # All of them
+  grove release history --json      # Machine-readable`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			entries, err := release.LoadHistory()
+			if err != nil {
+				return fmt.Errorf("failed to load release history: %w", err)
+			}
+			// Newest first
+			for i, j := 0, len(entries)-1; i < j; i, j = i+1, j-1 {
+				entries[i], entries[j] = entries[j], entries[i]
+			}
+			if limit > 0 && len(entries) > limit {
+				entries = entries[:limit]
+			}
+			if jsonOutput {
+				return printJSON(entries)
+			}
+			if len(entries) == 0 {
+				fmt.Println("No releases recorded yet.")
+				return nil
+			}
+
+			w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+			fmt.Fprintln(w, "VERSION\tTYPE\tSTARTED\tDURATION\tOUTCOME\tREPOS\tGEN COST")
+			for _, e := range entries {
+				repos := fmt.Sprintf("%d released", e.Count(release.OutcomeReleased))
+				if failed := e.Count(release.OutcomeFailed); failed > 0 {
+					repos += fmt.Sprintf(", %d failed", failed)
+				}
+				if skipped := e.Count(release.OutcomeNotReleased); skipped > 0 {
+					repos += fmt.Sprintf(", %d not released", skipped)
+				}
+				fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t$%.2f\n",
+					e.ParentVersion, e.Type, e.StartedAt.Local().Format("2006-01-02 15:04"),
+					e.Duration().Round(time.Second), e.Outcome, repos, e.GenCostUSD())
+			}
+			return w.Flush()
+		},
+	}
+
+	cmd.Flags().IntVar(&limit, "limit", 20, "Number of releases to show (0 for all)")
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output as JSON")
+	return cmd
+}
+
+func newReleaseShowCmd() *cobra.Command {
+	var jsonOutput bool
+
+	cmd := &cobra.Command{
+		Use:   "show <parent-version>",
+		Short: "Show one past release from the release history ledger",
+		Long: `Show the per-repository versions, bumps, outcomes, stage durations and
+generation costs of a past release. When a parent version was applied more
+than once (for example after a failed attempt), the latest attempt is shown.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			entries, err := release.LoadHistory()
+			if err != nil {
+				return fmt.Errorf("failed to load release history: %w", err)
+			}
+			entry := release.FindHistory(entries, args[0])
+			if entry == nil {
+				return fmt.Errorf("no release %s in the release history", args[0])
+			}
+			if jsonOutput {
+				return printJSON(entry)
+			}
+			displayHistoryEntry(entry)
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output as JSON")
+	return cmd
+}
+
+func displayHistoryEntry(e *release.HistoryEntry) {
+	fmt.Printf("%s Release %s", theme.IconArchive, e.ParentVersion)
+	if e.ParentPreviousVersion != "" {
+		fmt.Printf(" (from %s)", e.ParentPreviousVersion)
+	}
+	fmt.Printf(" — %s\n", e.Outcome)
+	fmt.Printf("  Type:     %s\n", e.Type)
+	fmt.Printf("  Root:     %s\n", e.RootDir)
+	fmt.Printf("  Started:  %s\n", e.StartedAt.Local().Format(time.RFC1123))
+	fmt.Printf("  Duration: %s\n", e.Duration().Round(time.Second))
+	if e.Error != "" {
+		fmt.Printf("  Error:    %s\n", e.Error)
+	}
+
+	var cacheWrite, cacheRead int64
+	for _, name := range e.RepoNames() {
+		repo := e.Repos[name]
+		cacheWrite += repo.CacheWriteTokens
+		cacheRead += repo.CacheReadTokens
+
+		icon := theme.IconSuccess
+		switch repo.Outcome {
+		case release.OutcomeFailed:
+			icon = theme.IconError
+		case release.OutcomeNotReleased:
+			icon = theme.IconWarning
+		}
+		fmt.Printf("\n  %s %s %s %s %s (%s)\n", icon, name, repo.PreviousVersion, theme.IconArrow, repo.Version, repo.Outcome)
+		if repo.SelectedBump != "" {
+			bump := repo.SelectedBump
+			if repo.SuggestedBump != "" && repo.SuggestedBump != repo.SelectedBump {
+				bump += fmt.Sprintf(" (suggested %s)", repo.SuggestedBump)
+			}
+			fmt.Printf("      bump:    %s\n", bump)
+		}
+		if repo.SuggestionReasoning != "" {
+			fmt.Printf("      reason:  %s\n", repo.SuggestionReasoning)
+		}
+		if repo.FailedOperation != "" {
+			fmt.Printf("      failed:  %s\n", repo.FailedOperation)
+		}
+		if repo.ReleasedCommit != "" {
+			fmt.Printf("      commit:  %s\n", shortSHA(repo.ReleasedCommit))
+		}
+		if len(repo.Stages) > 0 {
+			stages := make([]string, 0, len(repo.Stages))
+			for _, s := range repo.Stages {
+				stages = append(stages, fmt.Sprintf("%s %s", s.Name, (time.Duration(s.Seconds*float64(time.Second))).Round(time.Second)))
+			}
+			fmt.Printf("      stages:  %s\n", strings.Join(stages, ", "))
+		}
+		if repo.GenEstCostUSD > 0 {
+			fmt.Printf("      cost:    $%.4f\n", repo.GenEstCostUSD)
+		}
+	}
+
+	fmt.Printf("\n  Released %d, failed %d, not released %d\n",
+		e.Count(release.OutcomeReleased), e.Count(release.OutcomeFailed), e.Count(release.OutcomeNotReleased))
+	fmt.Printf("  Generation cost: $%.4f (cache write %d tokens, cache read %d tokens)\n", e.GenCostUSD(), cacheWrite, cacheRead)
+}
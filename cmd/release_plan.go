@@ -6,9 +6,11 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"sort"
 	"strings"
 	"time"
 
+	"github.com/grovetools/core/config"
 	"github.com/grovetools/core/git"
 	grovelogging "github.com/grovetools/core/logging"
 	"github.com/grovetools/core/pkg/paths"
@@ -210,13 +212,11 @@ func runReleasePlan(ctx context.Context, isRC bool) (*release.ReleasePlan, error
 				commitRange = fmt.Sprintf("%s..HEAD", lastTag)
 			}
 
-			// Gather git context with commit hashes
-			// Format includes both full and short hash for easy reference
-			logCmd := exec.Command("git", "log", commitRange, "--pretty=format:commit %H (%h)%nAuthor: %an <%ae>%nDate: %ad%nCommit: %cn <%ce>%nCommitDate: %cd%n%n    %s%n%n%b%n")
-			logCmd.Dir = wsPath
-			logOutput, err := logCmd.CombinedOutput()
+			// The LLM sees the parsed commit list (with the rule-based bump
+			// it implies) rather than raw git log output.
+			decision, err := analyzeCommits(wsPath, lastTag, currentVersion)
 			if err != nil {
-				logger.WithField("repo", repo).WithError(err).Warn("Failed to get git log")
+				logger.WithField("repo", repo).WithError(err).Warn("Failed to analyze commits")
 			}
 
 			diffCmd := exec.Command("git", "diff", "--stat", commitRange)
@@ -226,15 +226,14 @@ func runReleasePlan(ctx context.Context, isRC bool) (*release.ReleasePlan, error
 				logger.WithField("repo", repo).WithError(err).Warn("Failed to get git diff")
 			}
 
-			gitContext := fmt.Sprintf("GIT LOG:\n%s\n\nGIT DIFF STAT:\n%s", string(logOutput), string(diffOutput))
+			gitContext := fmt.Sprintf("COMMITS:\n%s\n\nGIT DIFF STAT:\n%s", release.FormatCommits(decision), string(diffOutput))
 
 			// Generate changelog with LLM
 			result, err := generateChangelogWithLLM(gitContext, nextVersion, wsPath)
 			if err != nil {
 				logger.WithField("repo", repo).WithError(err).Warn("Failed to generate LLM changelog")
-				// Fall back to conventional commits
-				suggestedBump = "patch"
-				suggestionReasoning = "Failed to get LLM suggestion, defaulting to patch"
+				// Fall back to the conventional commit analysis
+				suggestedBump, suggestionReasoning = bumpSuggestion(decision, nil)
 			} else {
 				suggestedBump = result.Suggestion
 				suggestionReasoning = result.Justification
@@ -243,8 +242,11 @@ func runReleasePlan(ctx context.Context, isRC bool) (*release.ReleasePlan, error
 		} else if hasRepoChanges {
 			// Use conventional commits analysis for repos with changes
 			lastTag, _ := getLastTag(wsPath)
-			suggestedBump = determineVersionBumpFromCommits(wsPath, lastTag)
-			suggestionReasoning = "Based on conventional commit analysis"
+			decision, err := analyzeCommits(wsPath, lastTag, currentVersion)
+			if err != nil {
+				logger.WithField("repo", repo).WithError(err).Warn("Failed to analyze commits")
+			}
+			suggestedBump, suggestionReasoning = bumpSuggestion(decision, err)
 		} else {
 			// No changes - keep current version
 			suggestedBump = "-"
@@ -375,36 +377,46 @@ func getCurrentParentVersion(rootDir string) string {
 	return strings.TrimSpace(string(output))
 }
 
-// Helper function to determine version bump from conventional commits
-func determineVersionBumpFromCommits(repoPath, lastTag string) string {
-	// Simple implementation - can be enhanced
-	commitRange := "HEAD"
-	if lastTag != "" {
-		commitRange = fmt.Sprintf("%s..HEAD", lastTag)
-	}
+// releaseBumpConfig is the `[release]` table of a repo's grove.toml, as far
+// as version bumps are concerned. See release.BumpRules for the keys.
+type releaseBumpConfig struct {
+	Bump release.BumpRules `yaml:"bump"`
+}
 
-	logCmd := exec.Command("git", "log", commitRange, "--pretty=%s")
-	logCmd.Dir = repoPath
-	output, err := logCmd.Output()
+// releaseBumpRules returns the repo's [release.bump] rules, or the defaults
+// when the repo has no (readable) grove.toml.
+func releaseBumpRules(repoPath string) release.BumpRules {
+	cfg, err := config.LoadFrom(repoPath)
 	if err != nil {
-		return "patch"
+		return release.DefaultBumpRules()
 	}
+	var rc releaseBumpConfig
+	if err := cfg.UnmarshalExtension("release", &rc); err != nil {
+		return release.DefaultBumpRules()
+	}
+	return rc.Bump.WithDefaults()
+}
 
-	commits := strings.Split(string(output), "\n")
-	hasFeat := false
-	for _, commit := range commits {
-		if strings.Contains(commit, "BREAKING") || strings.Contains(commit, "!:") {
-			return "major"
-		}
-		if strings.HasPrefix(commit, "feat:") || strings.HasPrefix(commit, "feat(") {
-			hasFeat = true
-		}
+// analyzeCommits parses the commits since lastTag and decides the bump on
+// top of currentVersion under the repo's bump rules.
+func analyzeCommits(repoPath, lastTag, currentVersion string) (release.BumpDecision, error) {
+	commits, err := release.LoadCommits(repoPath, lastTag)
+	if err != nil {
+		return release.BumpDecision{}, err
 	}
+	return release.DecideBump(commits, currentVersion, releaseBumpRules(repoPath)), nil
+}
 
-	if hasFeat {
-		return "minor"
+// bumpSuggestion turns a commit analysis into the plan's suggested bump and
+// reasoning. An analysis that failed still suggests a patch, but says why.
+func bumpSuggestion(decision release.BumpDecision, err error) (string, string) {
+	if err != nil {
+		return release.BumpPatch, fmt.Sprintf("Could not read commits (%v), defaulting to patch", err)
 	}
-	return "patch"
+	if decision.Bump == "" {
+		return release.BumpPatch, "No commit analysis available, defaulting to patch"
+	}
+	return decision.Bump, "Conventional commits: " + decision.Reasoning
 }
 
 // Helper function to check if a slice contains a string
@@ -417,59 +429,80 @@ func contains(slice []string, item string) bool {
 	return false
 }
 
-// runReleaseApply executes a previously generated release plan
-func runReleaseApply(ctx context.Context) error {
-	// Create logger directly with proper name
-	logger := grovelogging.NewLogger("grove-meta").Logger
-
-	displayPhase("Applying Release Plan")
-
-	// Load the release plan
-	plan, err := release.LoadPlan()
-	if err != nil {
-		return fmt.Errorf("failed to load release plan: %w", err)
-	}
+// applyInputs is what apply derives from a release plan before orchestrating.
+type applyInputs struct {
+	workspaces      []string
+	graph           *depsgraph.Graph
+	versions        map[string]string // selected repos only
+	currentVersions map[string]string
+	hasChanges      map[string]bool
+	selectedRepos   []string
+}
 
+// loadApplyInputs rebuilds the dependency graph and the version maps for the
+// repos selected in plan.
+func loadApplyInputs(plan *release.ReleasePlan) (*applyInputs, error) {
 	// Reconstruct the dependency graph
 	projects, err := discovery.DiscoverProjects()
 	if err != nil {
-		return fmt.Errorf("failed to discover workspaces: %w", err)
+		return nil, fmt.Errorf("failed to discover workspaces: %w", err)
+	}
+	in := &applyInputs{
+		versions:        make(map[string]string),
+		currentVersions: make(map[string]string),
+		hasChanges:      make(map[string]bool),
 	}
-	var workspaces []string
 	for _, p := range projects {
-		workspaces = append(workspaces, p.Path)
+		in.workspaces = append(in.workspaces, p.Path)
 	}
 
-	graph, err := depsgraph.BuildGraph(plan.RootDir, workspaces)
+	in.graph, err = depsgraph.BuildGraph(plan.RootDir, in.workspaces)
 	if err != nil {
-		return fmt.Errorf("failed to build dependency graph: %w", err)
+		return nil, fmt.Errorf("failed to build dependency graph: %w", err)
 	}
 
-	// Prepare version and hasChanges maps from plan
-	versions := make(map[string]string)
-	currentVersions := make(map[string]string)
-	hasChanges := make(map[string]bool)
-
 	// Only include selected repos in the release
-	var selectedRepos []string
 	for repo, repoPlan := range plan.Repos {
 		if repoPlan.Selected {
-			versions[repo] = repoPlan.NextVersion
-			currentVersions[repo] = repoPlan.CurrentVersion
-			hasChanges[repo] = true
-			selectedRepos = append(selectedRepos, repo)
+			in.versions[repo] = repoPlan.NextVersion
+			in.currentVersions[repo] = repoPlan.CurrentVersion
+			in.hasChanges[repo] = true
+			in.selectedRepos = append(in.selectedRepos, repo)
 		} else {
 			// Keep current version for unselected repos
-			currentVersions[repo] = repoPlan.CurrentVersion
+			in.currentVersions[repo] = repoPlan.CurrentVersion
 		}
 	}
+	sort.Strings(in.selectedRepos)
 
 	// Display selected repos summary
-	if len(selectedRepos) > 0 {
-		displayInfo(fmt.Sprintf("%s Releasing %d selected repositories: %s", theme.IconArchive, len(selectedRepos), strings.Join(selectedRepos, ", ")))
-	} else {
-		return fmt.Errorf("no repositories selected for release")
+	if len(in.selectedRepos) == 0 {
+		return nil, fmt.Errorf("no repositories selected for release")
 	}
+	displayInfo(fmt.Sprintf("%s Releasing %d selected repositories: %s", theme.IconArchive, len(in.selectedRepos), strings.Join(in.selectedRepos, ", ")))
+	return in, nil
+}
+
+// runReleaseApply executes a previously generated release plan
+func runReleaseApply(ctx context.Context) error {
+	// Create logger directly with proper name
+	logger := grovelogging.NewLogger("grove-meta").Logger
+
+	displayPhase("Applying Release Plan")
+
+	// Load the release plan
+	plan, err := release.LoadPlan()
+	if err != nil {
+		return fmt.Errorf("failed to load release plan: %w", err)
+	}
+
+	in, err := loadApplyInputs(plan)
+	if err != nil {
+		return err
+	}
+	workspaces, graph := in.workspaces, in.graph
+	versions, currentVersions, hasChanges := in.versions, in.currentVersions, in.hasChanges
+	selectedRepos := in.selectedRepos
 
 	// Auto-commit grove-ecosystem changes if needed - DISABLED to avoid submodule conflicts
 	// if err := autoCommitEcosystemChanges(ctx, plan.RootDir, hasChanges, logger); err != nil {
@@ -494,8 +527,17 @@ func runReleaseApply(ctx context.Context) error {
 	// Note: Changelogs are already written to repositories by the 'w' command in the TUI
 	// The orchestrateRelease function will detect and commit these existing changelogs
 
-	// Execute dependency-aware release orchestration
-	if err := orchestrateRelease(ctx, plan.RootDir, plan.ReleaseLevels, versions, currentVersions, hasChanges, graph, logger, false, plan); err != nil {
+	// Execute dependency-aware release orchestration, recording the attempt
+	// in the release history ledger whether or not it succeeds
+	var history *release.HistoryEntry
+	if !releaseDryRun {
+		history = beginReleaseHistory(ctx, plan)
+	}
+	err = orchestrateRelease(ctx, plan.RootDir, plan.ReleaseLevels, versions, currentVersions, hasChanges, graph, logger, false, plan)
+	if history != nil {
+		recordReleaseHistory(ctx, plan, history, err, logger)
+	}
+	if err != nil {
 		return fmt.Errorf("failed to orchestrate release: %w", err)
 	}
 
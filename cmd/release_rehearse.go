@@ -0,0 +1,172 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	grovelogging "github.com/grovetools/core/logging"
+	"github.com/grovetools/core/tui/theme"
+
+	"github.com/grovetools/grove/pkg/forge"
+	"github.com/grovetools/grove/pkg/release"
+)
+
+var (
+	releaseRehearse bool // --rehearse: run apply against sandbox clones with local bare remotes
+
+	// activeRehearsal is set while `apply --rehearse` orchestrates; the apply
+	// path consults it to stub out CI waits, skip plan checkpoints and route
+	// go commands to the rehearsal's module proxy.
+	activeRehearsal *release.Rehearsal
+)
+
+// runReleaseRehearsal runs the apply path for plan against throwaway clones
+// of the selected repos whose "origin" is a local bare repository, then
+// reports the commits, tags and manifest edits the real apply would push.
+// The real checkouts, remotes and the saved plan are left untouched. The
+// sandbox is removed afterwards unless the rehearsal failed, in which case it
+// is kept for inspection.
+func runReleaseRehearsal(ctx context.Context, plan *release.ReleasePlan) error {
+	logger := grovelogging.NewLogger("grove-meta").Logger
+
+	displayPhase("Rehearsing Release Plan")
+
+	in, err := loadApplyInputs(plan)
+	if err != nil {
+		return err
+	}
+
+	// The real run's pre-flight checks, against the real checkouts
+	parentVersion := determineParentVersion(plan.RootDir, in.versions, in.hasChanges)
+	selectedWorkspaces := make([]string, 0, len(in.selectedRepos))
+	for _, repo := range in.selectedRepos {
+		selectedWorkspaces = append(selectedWorkspaces, filepath.Join(plan.RootDir, repo))
+	}
+	if err := runPreflightChecks(ctx, plan.RootDir, parentVersion, selectedWorkspaces, logger); err != nil {
+		return err
+	}
+	if err := checkForOutdatedDependencies(ctx, plan.RootDir, in.workspaces, logger); err != nil {
+		displayWarning("Failed to check for outdated dependencies: " + err.Error())
+	}
+
+	displaySection(theme.IconArchive + " Preparing Rehearsal Sandbox")
+	rehearsal, err := release.NewRehearsal(ctx, plan.RootDir, in.selectedRepos)
+	if err != nil {
+		return fmt.Errorf("failed to prepare rehearsal: %w", err)
+	}
+	displayInfo(fmt.Sprintf("Cloned %d repositories into %s", len(in.selectedRepos), rehearsal.Dir))
+
+	// Point the graph at the clones and work on a copy of the plan
+	for _, repo := range in.selectedRepos {
+		if node, ok := in.graph.GetNode(repo); ok {
+			node.Dir = rehearsal.RepoDir(repo)
+		}
+	}
+	rehearsalPlan, err := plan.Clone()
+	if err != nil {
+		return err
+	}
+	rehearsalPlan.RootDir = rehearsal.Dir
+
+	activeRehearsal = rehearsal
+	orchestrateErr := orchestrateRelease(ctx, rehearsal.Dir, plan.ReleaseLevels, in.versions, in.currentVersions, in.hasChanges, in.graph, logger, false, rehearsalPlan)
+	activeRehearsal = nil
+
+	results, err := rehearsal.Report(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to compare rehearsal remotes: %w", err)
+	}
+	displayRehearsalReport(results)
+
+	if orchestrateErr != nil {
+		displayError(fmt.Sprintf("Rehearsal failed; the sandbox is kept at %s", rehearsal.Dir))
+		return fmt.Errorf("release rehearsal failed: %w", orchestrateErr)
+	}
+	if err := rehearsal.Cleanup(); err != nil {
+		logger.WithError(err).Warnf("Failed to remove rehearsal sandbox %s", rehearsal.Dir)
+	}
+	displaySuccess("Rehearsal completed; nothing was pushed and the release plan is unchanged")
+	return nil
+}
+
+// displayRehearsalReport prints, per repository, what the real apply would
+// push.
+func displayRehearsalReport(results []release.RehearsalResult) {
+	displaySection(theme.IconBullet + " Rehearsal Report")
+
+	var released []string
+	for _, result := range results {
+		fmt.Printf("\n  %s %s\n", theme.IconRepo, result.Repo)
+		if !result.Changed() {
+			fmt.Println("    nothing would be pushed")
+		}
+		for _, branch := range result.Branches {
+			action := "push to"
+			if branch.Created {
+				action = "create branch"
+			}
+			fmt.Printf("    %s %s %s (%d commit(s))\n", theme.IconArrow, action, branch.Branch, len(branch.Commits))
+			for _, commit := range branch.Commits {
+				fmt.Printf("        %s\n", commit)
+			}
+		}
+		for _, tag := range result.Tags {
+			fmt.Printf("    %s tag %s at %s\n", theme.IconArrow, tag.Name, tag.Commit)
+		}
+		manifests := make([]string, 0, len(result.Manifests))
+		for manifest := range result.Manifests {
+			manifests = append(manifests, manifest)
+		}
+		sort.Strings(manifests)
+		for _, manifest := range manifests {
+			fmt.Printf("    %s %s\n", theme.IconArrow, manifest)
+			for _, line := range result.Manifests[manifest] {
+				fmt.Printf("        %s\n", line)
+			}
+		}
+		for _, note := range result.Notes {
+			fmt.Printf("    %s %s\n", theme.IconInfo, note)
+		}
+		if result.Changed() {
+			released = append(released, result.Repo)
+		}
+	}
+	fmt.Println()
+
+	if releaseSkipParent {
+		displayInfo("Parent superrepo finalize would be skipped (--skip-parent)")
+	} else if len(released) > 0 {
+		displayInfo(fmt.Sprintf("Parent superrepo would commit and push a gitlink bump for: %s", strings.Join(released, ", ")))
+	}
+}
+
+// saveReleaseProgress checkpoints the plan between apply stages. Rehearsals
+// work on a copy of the plan and never persist it.
+func saveReleaseProgress(plan *release.ReleasePlan) {
+	if activeRehearsal != nil {
+		return
+	}
+	_ = release.SavePlan(plan)
+}
+
+// waitForCIWorkflow gates on CI for the commit just pushed from wsPath, on
+// whichever forge hosts the repo. A rehearsal has no CI to wait for and
+// records the gate instead.
+func waitForCIWorkflow(ctx context.Context, wsPath, repo string) error {
+	if activeRehearsal != nil {
+		activeRehearsal.Note(repo, "skipped a CI wait (--ci)")
+		return nil
+	}
+	f, remote, err := forge.ForRepo(wsPath)
+	if err != nil {
+		return err
+	}
+	sha := gitRevParse(ctx, wsPath, "HEAD")
+	if sha == "" {
+		return fmt.Errorf("failed to resolve HEAD of %s", repo)
+	}
+	return f.WaitForCI(ctx, remote, sha)
+}
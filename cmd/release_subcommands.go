@@ -8,6 +8,7 @@ import (
 	"os/exec"
 	"path/filepath"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -112,7 +113,13 @@ This command will:
 3. Create tags and push changes if configured
 4. Clear the plan upon successful completion
 
-Use --dry-run to preview what would be done without making changes.`,
+Use --dry-run to preview what would be done without making changes.
+
+Use --rehearse to run the whole apply path (dependency updates, publish
+commit, tagging, module-availability wait) against temporary clones whose
+"origin" is a local bare repository. CI waits are stubbed out, nothing is
+pushed to the real remotes and the plan is left as it is. The command ends
+with a report of the commits, tags and go.mod edits the real run would make.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ctx := context.Background()
 
@@ -130,13 +137,20 @@ Use --dry-run to preview what would be done without making changes.`,
 			// is actually staged (docs generated or changelog staged) are
 			// auto-approved; anything else still requires manual review. The
 			// output is loud and explicit about what it is about to publish.
+			if releaseRehearse && releaseDryRun {
+				return fmt.Errorf("--rehearse and --dry-run cannot be combined")
+			}
+
 			if releaseAutoApprove {
 				autoApproved := autoApproveStagedRepos(plan)
 				if len(autoApproved) == 0 {
 					return fmt.Errorf("--auto-approve: no repos have staged gen output (run 'grove release gen' first)")
 				}
-				if err := release.SavePlan(plan); err != nil {
-					return fmt.Errorf("failed to save plan after auto-approve: %w", err)
+				// A rehearsal approves in memory only
+				if !releaseRehearse {
+					if err := release.SavePlan(plan); err != nil {
+						return fmt.Errorf("failed to save plan after auto-approve: %w", err)
+					}
 				}
 				fmt.Printf("\n%s --auto-approve: publishing staged docs + changelog for %d repo(s) WITHOUT review:\n", theme.IconWarning, len(autoApproved))
 				for _, name := range autoApproved {
@@ -159,12 +173,17 @@ Use --dry-run to preview what would be done without making changes.`,
 				return fmt.Errorf("no repositories are approved for release - run 'grove release tui' to review and approve (or --auto-approve to publish staged gen output)")
 			}
 
+			if releaseRehearse {
+				return runReleaseRehearsal(ctx, plan)
+			}
+
 			// Execute the release
 			return runReleaseApply(ctx)
 		},
 	}
 
 	cmd.Flags().BoolVar(&releaseDryRun, "dry-run", false, "Print commands without executing them")
+	cmd.Flags().BoolVar(&releaseRehearse, "rehearse", false, "Rehearse the full apply against sandbox clones with local bare remotes and report what it would push")
 	cmd.Flags().BoolVar(&releasePush, "push", true, "Push changes to remote repositories (default: true)")
 	cmd.Flags().BoolVar(&releaseSkipParent, "skip-parent", false, "Skip parent superrepo gitlink bump/commit/push")
 	cmd.Flags().BoolVar(&releaseResume, "resume", false, "Only process repos that haven't completed successfully")
@@ -502,6 +521,7 @@ func newReleaseRollbackCmd() *cobra.Command {
 	var mode string
 	var push bool
 	var forcePush bool
+	var fromRelease string
 
 	cmd := &cobra.Command{
 		Use:   "rollback",
@@ -512,52 +532,85 @@ This command helps recover from failed releases by resetting repositories
 to a previous state. It reads the release plan to know which repositories
 to operate on.
 
+With --release, the repositories and reset targets come from the release
+history ledger instead: every repository that release tagged is reset to the
+commit it was at before 'grove release apply' touched it, however many
+commits the release added. No release plan is needed. A repository is only
+reset while its HEAD is still the released commit, so work committed after
+the release is never discarded; its release tag is deleted locally, and on
+origin too with --push --force.
+
 Examples:
   grove release rollback                    # Rollback 1 commit (mixed mode)
   grove release rollback --commits 2        # Rollback 2 commits
   grove release rollback --hard             # Hard reset (loses changes)
   grove release rollback --soft --push      # Soft reset and push
-  grove release rollback --push --force     # Force push after rollback`,
+  grove release rollback --push --force     # Force push after rollback
+  grove release rollback --release v0.8.0   # Undo the commits of release v0.8.0`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ctx := context.Background()
 
-			// Load the release plan
-			plan, err := release.LoadPlan()
-			if err != nil {
-				if os.IsNotExist(err) {
-					return fmt.Errorf("no release plan found - rollback requires a plan to know which repos to operate on")
-				}
-				return fmt.Errorf("failed to load release plan: %w", err)
-			}
-
 			// Validate mode
 			validModes := map[string]bool{"hard": true, "soft": true, "mixed": true}
 			if !validModes[mode] {
 				return fmt.Errorf("invalid mode %q - must be hard, soft, or mixed", mode)
 			}
+			if fromRelease != "" && cmd.Flags().Changed("commits") {
+				return fmt.Errorf("--release and --commits cannot be used together")
+			}
+
+			var targets []rollbackTarget
+			if fromRelease != "" {
+				entries, err := release.LoadHistory()
+				if err != nil {
+					return fmt.Errorf("failed to load release history: %w", err)
+				}
+				entry := release.FindHistory(entries, fromRelease)
+				if entry == nil {
+					return fmt.Errorf("no release %s in the release history", fromRelease)
+				}
+				var skipped []string
+				targets, skipped = historyRollbackTargets(entry)
+				for _, note := range skipped {
+					fmt.Printf("%s Skipping %s\n", theme.IconNote, note)
+				}
+
+				fmt.Printf("%s This will reset the repositories of release %s to their pre-release commits using %s reset\n", theme.IconWarning, fromRelease, mode)
+			} else {
+				// Load the release plan
+				plan, err := release.LoadPlan()
+				if err != nil {
+					if os.IsNotExist(err) {
+						return fmt.Errorf("no release plan found - rollback requires a plan to know which repos to operate on (or use --release)")
+					}
+					return fmt.Errorf("failed to load release plan: %w", err)
+				}
+				targets = planRollbackTargets(plan, commits)
+
+				fmt.Printf("%s This will rollback %d commit(s) using %s reset\n", theme.IconWarning, commits, mode)
+			}
 
 			// Warn about destructive operation
-			fmt.Printf("%s This will rollback %d commit(s) using %s reset\n", theme.IconWarning, commits, mode)
 			if mode == "hard" {
 				fmt.Println("   WARNING: Hard reset will LOSE all uncommitted changes!")
 			}
 
 			// List affected repositories
 			fmt.Println("\nAffected repositories:")
-			repoCount := 0
-			for repoName, repo := range plan.Repos {
-				if repo.Selected {
-					fmt.Printf("  - %s", repoName)
-					if repo.NextVersion != "" {
-						fmt.Printf(" (was releasing %s)", repo.NextVersion)
-					}
-					fmt.Println()
-					repoCount++
+			for _, target := range targets {
+				fmt.Printf("  - %s", target.repo)
+				if target.note != "" {
+					fmt.Printf(" (%s)", target.note)
 				}
+				fmt.Println()
 			}
 
-			if repoCount == 0 {
-				fmt.Println("No repositories selected in the plan")
+			if len(targets) == 0 {
+				if fromRelease != "" {
+					fmt.Printf("No repositories of release %s can be rolled back\n", fromRelease)
+				} else {
+					fmt.Println("No repositories selected in the plan")
+				}
 				return nil
 			}
 
@@ -582,13 +635,8 @@ Examples:
 			rollbackState := make(map[string]string) // Track what we rolled back
 
 			// Process each repository
-			for repoName, repo := range plan.Repos {
-				if !repo.Selected {
-					continue
-				}
-
-				// Find the repository path
-				repoPath := findRepositoryPath(repoName)
+			for _, target := range targets {
+				repoName, repoPath := target.repo, target.path
 				if repoPath == "" {
 					errors = append(errors, fmt.Sprintf("%s: could not find repository path", repoName))
 					failCount++
@@ -597,6 +645,17 @@ Examples:
 
 				fmt.Printf("\nProcessing %s...\n", repoName)
 
+				// A ledger reset drops everything after the pre-release commit,
+				// so it is only safe while nothing was committed on top of the
+				// release.
+				if target.releasedCommit != "" {
+					if head := gitRevParse(ctx, repoPath, "HEAD"); head != target.releasedCommit {
+						errors = append(errors, fmt.Sprintf("%s: HEAD %s is not the released commit %s - commits were made after the release; refusing to reset", repoName, shortSHA(head), shortSHA(target.releasedCommit)))
+						failCount++
+						continue
+					}
+				}
+
 				// Create backup tag first
 				backupRepoTag := fmt.Sprintf("%s-%s", backupTag, repoName)
 				tagCmd := exec.CommandContext(ctx, "git", "tag", backupRepoTag, "-m", "Backup before rollback")
@@ -619,8 +678,27 @@ Examples:
 					}
 				}
 
+				// Count what the reset drops; a ledger target may already be in place
+				dropped := commits
+				if fromRelease != "" {
+					countCmd := exec.CommandContext(ctx, "git", "rev-list", "--count", target.ref+"..HEAD")
+					countCmd.Dir = repoPath
+					countOutput, err := countCmd.Output()
+					if err != nil {
+						errors = append(errors, fmt.Sprintf("%s: pre-release commit %s not found", repoName, shortSHA(target.ref)))
+						failCount++
+						continue
+					}
+					dropped, _ = strconv.Atoi(strings.TrimSpace(string(countOutput)))
+					if dropped == 0 {
+						fmt.Printf("  * Already at %s, nothing to roll back\n", shortSHA(target.ref))
+						successCount++
+						continue
+					}
+				}
+
 				// Perform the reset
-				resetArgs := []string{"reset", "--" + mode, fmt.Sprintf("HEAD~%d", commits)}
+				resetArgs := []string{"reset", "--" + mode, target.ref}
 				resetCmd := exec.CommandContext(ctx, "git", resetArgs...)
 				resetCmd.Dir = repoPath
 				output, err := resetCmd.CombinedOutput()
@@ -637,7 +715,18 @@ Examples:
 					rollbackState[repoName] = strings.TrimSpace(string(headOutput))
 				}
 
-				fmt.Printf("  * Rolled back %d commit(s) (%s mode)\n", commits, mode)
+				fmt.Printf("  * Rolled back %d commit(s) (%s mode)\n", dropped, mode)
+
+				// The release tag now points past HEAD; the backup tag keeps its commit
+				if target.tag != "" {
+					tagDelCmd := exec.CommandContext(ctx, "git", "tag", "-d", target.tag)
+					tagDelCmd.Dir = repoPath
+					if output, err := tagDelCmd.CombinedOutput(); err != nil {
+						fmt.Printf("  %s Could not delete release tag %s: %s\n", theme.IconWarning, target.tag, strings.TrimSpace(string(output)))
+					} else {
+						fmt.Printf("  * Deleted release tag %s\n", target.tag)
+					}
+				}
 
 				// Push if requested
 				if push {
@@ -649,7 +738,7 @@ Examples:
 					statusCmd.Dir = repoPath
 					if statusOutput, err := statusCmd.Output(); err == nil {
 						// If we've rolled back, we'll likely be behind and need force
-						if strings.Contains(string(statusOutput), "behind") || commits > 0 {
+						if strings.Contains(string(statusOutput), "behind") || dropped > 0 {
 							needsForce = true
 						}
 					}
@@ -684,6 +773,19 @@ Examples:
 					} else {
 						fmt.Printf("  * Pushed to origin\n")
 					}
+
+					if target.tag != "" {
+						tagPushCmd := exec.CommandContext(ctx, "git", "push", "origin", "--delete", "refs/tags/"+target.tag)
+						tagPushCmd.Dir = repoPath
+						if output, err := tagPushCmd.CombinedOutput(); err != nil {
+							errors = append(errors, fmt.Sprintf("%s: could not delete release tag %s on origin: %s", repoName, target.tag, strings.TrimSpace(string(output))))
+							failCount++
+							continue
+						}
+						fmt.Printf("  * Deleted release tag %s on origin\n", target.tag)
+					}
+				} else if target.tag != "" {
+					fmt.Printf("  %s Release tag %s is still on origin: rerun with --push --force, or run `git push origin --delete refs/tags/%s`\n", theme.IconWarning, target.tag, target.tag)
 				}
 
 				successCount++
@@ -718,6 +820,7 @@ Examples:
 	cmd.Flags().StringVar(&mode, "mode", "mixed", "Reset mode: hard, soft, or mixed")
 	cmd.Flags().BoolVar(&push, "push", false, "Push the rollback to origin")
 	cmd.Flags().BoolVar(&forcePush, "force", false, "Allow force push if needed")
+	cmd.Flags().StringVar(&fromRelease, "release", "", "Reset the repositories of this past parent version (from the release history) to their pre-release commits")
 
 	// Add shortcuts for modes
 	cmd.Flags().Bool("hard", false, "Shortcut for --mode=hard")
@@ -739,6 +842,70 @@ Examples:
 	return cmd
 }
 
+// rollbackTarget is one repository rollback resets, and the revision it
+// resets to.
+type rollbackTarget struct {
+	repo string
+	path string // "" when the repository could not be found
+	ref  string
+	note string
+	// releasedCommit, for a ledger target, is the commit HEAD must still be
+	// at; tag is the release tag the reset orphans.
+	releasedCommit string
+	tag            string
+}
+
+// planRollbackTargets resets each repo selected in plan by commits commits.
+func planRollbackTargets(plan *release.ReleasePlan, commits int) []rollbackTarget {
+	var targets []rollbackTarget
+	for repoName, repo := range plan.Repos {
+		if !repo.Selected {
+			continue
+		}
+		target := rollbackTarget{
+			repo: repoName,
+			path: findRepositoryPath(repoName),
+			ref:  fmt.Sprintf("HEAD~%d", commits),
+		}
+		if repo.NextVersion != "" {
+			target.note = fmt.Sprintf("was releasing %s", repo.NextVersion)
+		}
+		targets = append(targets, target)
+	}
+	sort.Slice(targets, func(i, j int) bool { return targets[i].repo < targets[j].repo })
+	return targets
+}
+
+// historyRollbackTargets resets each repo a recorded release tagged to the
+// commit it was at before the release was applied. Repos the release did not
+// tag, or whose commits were not recorded, are returned as skipped notes.
+func historyRollbackTargets(entry *release.HistoryEntry) (targets []rollbackTarget, skipped []string) {
+	for _, repoName := range entry.RepoNames() {
+		repo := entry.Repos[repoName]
+		switch {
+		case repo.Outcome != release.OutcomeReleased:
+			skipped = append(skipped, fmt.Sprintf("%s: %s in this release", repoName, repo.Outcome))
+			continue
+		case repo.HeadBefore == "" || repo.ReleasedCommit == "":
+			skipped = append(skipped, fmt.Sprintf("%s: the ledger has no pre-release or released commit", repoName))
+			continue
+		}
+		path := filepath.Join(entry.RootDir, repoName)
+		if _, err := os.Stat(filepath.Join(path, ".git")); err != nil {
+			path = findRepositoryPath(repoName)
+		}
+		targets = append(targets, rollbackTarget{
+			repo:           repoName,
+			path:           path,
+			ref:            repo.HeadBefore,
+			note:           fmt.Sprintf("%s %s, back to %s", repo.Outcome, repo.Version, shortSHA(repo.HeadBefore)),
+			releasedCommit: repo.ReleasedCommit,
+			tag:            repo.Version,
+		})
+	}
+	return targets, skipped
+}
+
 // saveRollbackState saves information about the rollback for potential recovery
 func saveRollbackState(state map[string]string) error {
 	home, err := os.UserHomeDir()
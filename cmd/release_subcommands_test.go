@@ -0,0 +1,33 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/grovetools/grove/pkg/release"
+)
+
+// TestHistoryRollbackTargets: only repos the release tagged are reset, each
+// pinned to its released commit and carrying the tag the reset orphans.
+func TestHistoryRollbackTargets(t *testing.T) {
+	entry := &release.HistoryEntry{
+		RootDir: t.TempDir(),
+		Repos: map[string]*release.HistoryRepo{
+			"core":  {Version: "v0.8.0", Outcome: release.OutcomeReleased, HeadBefore: "aaaa", ReleasedCommit: "bbbb"},
+			"flow":  {Version: "v0.3.0", Outcome: release.OutcomeFailed, HeadBefore: "cccc"},
+			"nb":    {Version: "v0.2.0", Outcome: release.OutcomeNotReleased, HeadBefore: "dddd"},
+			"tend":  {Version: "v1.1.0", Outcome: release.OutcomeReleased, HeadBefore: "eeee"},
+			"skill": {Version: "v0.1.0", Outcome: release.OutcomeReleased, ReleasedCommit: "ffff"},
+		},
+	}
+	targets, skipped := historyRollbackTargets(entry)
+	if len(targets) != 1 {
+		t.Fatalf("targets = %+v", targets)
+	}
+	if got := targets[0]; got.repo != "core" || got.ref != "aaaa" || got.releasedCommit != "bbbb" || got.tag != "v0.8.0" {
+		t.Errorf("core target = %+v", got)
+	}
+	if len(skipped) != 4 || !strings.Contains(strings.Join(skipped, "\n"), "flow: failed") {
+		t.Errorf("skipped = %q", skipped)
+	}
+}
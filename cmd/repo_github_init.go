@@ -12,25 +12,37 @@ import (
 var (
 	repoGitHubInitVisibility string
 	repoGitHubInitDryRun     bool
+	repoGitHubInitHost       string
+	repoGitHubInitOwner      string
 )
 
 func newRepoGitHubInitCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "github-init",
-		Short: "Add GitHub integration to an existing local repository",
-		Long: `Add GitHub integration to an existing local Grove repository.
+		Short: "Add forge integration (GitHub, GitLab, Gitea) to an existing local repository",
+		Long: `Add forge integration to an existing local Grove repository.
 
 This command must be run from within a Grove repository (directory with grove.yml).
 It will:
-1. Create a GitHub repository (public or private)
+1. Create a repository on the forge (public or private)
 2. Add the remote origin
 3. Push existing commits and tags
-4. Set up GitHub Actions workflows
+4. Watch the release pipeline, if the repository defines CI
 5. Configure repository secrets (for private repos, requires GROVE_PAT)
 
+The forge defaults to GitHub. Use --host to target a GitLab or Gitea host;
+the kind of forge is detected from well-known hosts (gitlab.com,
+codeberg.org, ...) or configured in grove.toml:
+
+  [forge.hosts."git.example.com"]
+  type = "gitea"                # github, gitlab or gitea
+  api_url = "https://git.example.com/api/v1"
+  token_env = "EXAMPLE_TOKEN"   # default GITLAB_TOKEN or GITEA_TOKEN
+
 Prerequisites:
 - Must be run from within a Grove repository
-- GitHub CLI (gh) must be installed and authenticated
+- GitHub: GitHub CLI (gh) must be installed and authenticated
+- GitLab/Gitea: an API token in GITLAB_TOKEN/GITEA_TOKEN (or token_env)
 - No existing 'origin' remote (use 'git remote remove origin' first if needed)
 - GROVE_PAT environment variable for private repositories
 
@@ -42,6 +54,9 @@ Examples:
   # Initialize as public
   grove repo github-init --visibility=public
 
+  # Create the repository under a GitLab group
+  grove repo github-init --host gitlab.com --owner my-group
+
   # Preview what would happen
   grove repo github-init --dry-run`,
 		RunE: runRepoGitHubInit,
@@ -49,6 +64,8 @@ Examples:
 
 	cmd.Flags().StringVar(&repoGitHubInitVisibility, "visibility", "private", "Repository visibility: public or private")
 	cmd.Flags().BoolVar(&repoGitHubInitDryRun, "dry-run", false, "Preview operations without executing")
+	cmd.Flags().StringVar(&repoGitHubInitHost, "host", "github.com", "Forge host to create the repository on")
+	cmd.Flags().StringVar(&repoGitHubInitOwner, "owner", "grovetools", "User, organization or group to create the repository under")
 
 	return cmd
 }
@@ -66,9 +83,11 @@ func runRepoGitHubInit(cmd *cobra.Command, args []string) error {
 	opts := repository.GitHubInitOptions{
 		Visibility: repoGitHubInitVisibility,
 		DryRun:     repoGitHubInitDryRun,
+		Host:       repoGitHubInitHost,
+		Owner:      repoGitHubInitOwner,
 	}
 
-	logger.Info("Initializing GitHub integration...")
+	logger.Info("Initializing forge integration...")
 
 	return creator.InitializeGitHub(opts)
 }
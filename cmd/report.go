@@ -0,0 +1,111 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	orch "github.com/grovetools/grove/pkg/orchestrator"
+)
+
+// reportSpec is a parsed --report value: output paths per report format.
+type reportSpec struct {
+	JUnit string
+	SARIF string
+}
+
+// parseReportSpec parses "junit=<path>,sarif=<path>" (either part optional).
+func parseReportSpec(value string) (reportSpec, error) {
+	var spec reportSpec
+	for _, part := range strings.Split(value, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		format, path, ok := strings.Cut(part, "=")
+		if !ok || path == "" {
+			return spec, fmt.Errorf("invalid --report entry %q (want <format>=<path>)", part)
+		}
+		switch format {
+		case "junit":
+			spec.JUnit = path
+		case "sarif":
+			spec.SARIF = path
+		default:
+			return spec, fmt.Errorf("unknown --report format %q (want junit or sarif)", format)
+		}
+	}
+	return spec, nil
+}
+
+// reportRun attaches a result collector to o when --report is set. Like
+// traceRun, the returned func wraps the run's error: it writes the requested
+// reports from every collected result (local or remote exec alike) and hands
+// the run's error back. name titles the JUnit document; verbs are the run's
+// verbs, used to expand whole-job cache hits.
+func reportRun(cmd *cobra.Command, o *orch.Orchestrator, name string, verbs []string) (func(error) error, error) {
+	value, _ := cmd.Flags().GetString("report")
+	spec, err := parseReportSpec(value)
+	if err != nil {
+		return nil, err
+	}
+	if spec.JUnit == "" && spec.SARIF == "" {
+		return func(err error) error { return err }, nil
+	}
+	collector := &orch.ResultCollector{}
+	o.Results = collector
+	return func(runErr error) error {
+		results := collector.Results()
+		var writeErr error
+		if spec.JUnit != "" {
+			writeErr = writeOutputFile(spec.JUnit, func(w io.Writer) error {
+				return orch.WriteJUnit(w, name, verbs, results)
+			})
+		}
+		if spec.SARIF != "" && writeErr == nil {
+			writeErr = writeOutputFile(spec.SARIF, func(w io.Writer) error {
+				return orch.WriteSARIF(w, results)
+			})
+		}
+		if writeErr != nil {
+			if runErr != nil {
+				fmt.Fprintf(os.Stderr, "warning: write report: %v\n", writeErr)
+				return runErr
+			}
+			return fmt.Errorf("write report: %w", writeErr)
+		}
+		return runErr
+	}, nil
+}
+
+// writeOutputFile writes a --report or --trace file atomically (tmp file +
+// rename), so a reader never sees a partial file.
+func writeOutputFile(path string, write func(io.Writer) error) error {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(dir, "."+filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	tmpName := tmp.Name()
+	if err := write(tmp); err != nil {
+		tmp.Close()
+		os.Remove(tmpName)
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmpName)
+		return err
+	}
+	if err := os.Rename(tmpName, path); err != nil {
+		os.Remove(tmpName)
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	return nil
+}
@@ -45,6 +45,7 @@ func init() {
 	// Add subcommands
 	rootCmd.AddCommand(newBootstrapCmd())
 	rootCmd.AddCommand(newBuildCmd())
+	rootCmd.AddCommand(newCacheCmd())
 	rootCmd.AddCommand(newCheckCmd())
 	rootCmd.AddCommand(newDepsCmd())
 	rootCmd.AddCommand(newExposeCmd())
@@ -85,6 +85,9 @@ type satelliteConfigEntry struct {
 	// return checks, including flag-only selections that are absent from config.
 	RecordWorkspaces    string `yaml:"-" json:"record_workspaces,omitempty"`
 	RecordAllWorkspaces bool   `yaml:"-" json:"record_all_workspaces,omitempty"`
+	// CreatedAt is when `up` first provisioned the machine — the start of
+	// its ttl (see satellite_reap.go). A re-up of the same machine keeps it.
+	CreatedAt time.Time `yaml:"-" json:"created_at,omitzero"`
 }
 
 // Satellite kinds (the satelliteConfigEntry.Kind axis) — CLI-local mirror of
@@ -208,7 +211,10 @@ resources via terraform.`
 	cmd.AddCommand(newSatelliteConfigCmd())
 	cmd.AddCommand(newSatelliteAuthCmd())
 	cmd.AddCommand(newSatelliteArtifactsCmd())
+	cmd.AddCommand(newSatelliteSnapshotCmd())
+	cmd.AddCommand(newSatelliteRestoreCmd())
 	cmd.AddCommand(newSatelliteDownCmd())
+	cmd.AddCommand(newSatelliteReapCmd())
 	cmd.AddCommand(newSatelliteStatusCmd())
 	cmd.AddCommand(newSatelliteListCmd())
 	return cmd
@@ -229,6 +235,7 @@ func newSatelliteUpCmd() *cobra.Command {
 		kind           string
 		tfDir          string
 		identityFile   string
+		address        string
 		image          string
 		tartHome       string
 		assumeYes      bool
@@ -279,6 +286,26 @@ provisioning residue); one you authored is never edited or removed:
   ssh_user = "grovedev"
   cidr = "203.0.113.7/32"
 
+Adopting an existing machine: --target ssh provisions nothing. It reaches a
+host that already runs sshd at --address (host[:port], default port 22) as
+--ssh-user, pins its host key, and installs the locally cross-built stack
+(--prebuilt is implied; pass --prebuilt-target for a non-amd64 host). The
+login user needs passwordless sudo. 'down' only deregisters it — the host is
+never modified or destroyed:
+
+  [satellites.homelab.infra]
+  target = "ssh"
+  address = "192.168.1.20"
+  ssh_user = "me"
+
+Local VMs on Linux: --target qemu boots an Ubuntu cloud image (or --image, a
+URL or local qcow2/raw file) under qemu/KVM with a cloud-init seed carrying
+the satellite's dedicated key, and reaches it through a loopback port
+forward. The base image is downloaded once into the grove cache, verified
+against the SHA256SUMS published next to it (or [satellites.<name>.infra]
+image_sha256); each satellite gets its own overlay disk, which 'down'
+removes. --prebuilt is implied and targets the host's arch.
+
 Optional provisioning inputs (GitHub auth, Claude Code, dotfiles, service
 account) come from a [satellites.<name>.provision] block in the same grove
 config the registry lives in; the matching flags override the block:
@@ -335,7 +362,8 @@ trades it for a cheap, replaceable node.`
 	cmd.Flags().StringVar(&kind, "kind", "", "Satellite kind: \"full\" wires the whole stack (groved dial, socket probe, note sync); \"exec\" registers an sshd+binaries endpoint with no groved dial or sync wiring (default: the target's default — gcp: full)")
 	cmd.Flags().StringVar(&tfDir, "tf-dir", "", tfDirFlagHelp)
 	cmd.Flags().StringVar(&identityFile, "identity-file", "", "SSH private key for the satellite (written to the registry as identity_file; default: [satellites.<name>.infra] identity_file, else agent-only auth)")
-	cmd.Flags().StringVar(&image, "image", "", "Guest image override: the OCI image the tart provider clones (default "+defaultTartImage+") or the docker image the docker provider runs instead of building the embedded one (default: [satellites.<name>.infra] image; tart/docker targets only)")
+	cmd.Flags().StringVar(&address, "address", "", "host[:port] of the existing machine the ssh target adopts (default: [satellites.<name>.infra] address; ssh target only)")
+	cmd.Flags().StringVar(&image, "image", "", "Guest image override: the OCI image the tart provider clones (default "+defaultTartImage+"), the cloud image URL or local file the qemu provider boots (default: Ubuntu 24.04 for the host arch), or the docker image the docker provider runs instead of building the embedded one (default: [satellites.<name>.infra] image; tart/qemu/docker targets only)")
 	cmd.Flags().StringVar(&tartHome, "tart-home", "", tartHomeFlagHelp)
 	cmd.Flags().BoolVar(&assumeYes, "yes", false, "Skip the billable-resource confirmation prompt")
 	cmd.Flags().StringVar(&ghTokenCmd, "gh-token-cmd", "", "Local command whose stdout is the GitHub token piped to bootstrap (overrides provision config; empty disables)")
@@ -376,6 +404,7 @@ trades it for a cheap, replaceable node.`
 			SSHUser: sshUser, SSHUserSet: cmd.Flags().Changed("ssh-user"),
 			CIDR: cidr, CIDRSet: cmd.Flags().Changed("cidr"),
 			IdentityFile: identityFile, IdentitySet: cmd.Flags().Changed("identity-file"),
+			Address: address, AddressSet: cmd.Flags().Changed("address"),
 			Target: target, TargetSet: cmd.Flags().Changed("target"),
 			Image: image, ImageSet: cmd.Flags().Changed("image"),
 			TartHome: tartHome, TartHomeSet: cmd.Flags().Changed("tart-home"),
@@ -418,10 +447,10 @@ trades it for a cheap, replaceable node.`
 		// Providers without a bootstrap script (tart) are provisioned
 		// client-side: --prebuilt is implied (bare image + locally
 		// cross-built stack; spec decision), the default cross-build target
-		// is the guest's arch. Tart full also uses this path, then runs the
-		// shared bootstrap; exec Tart and Docker still skip that bootstrap.
+		// is the guest's arch. Tart, qemu and ssh full also use this path, then
+		// run the shared bootstrap; their exec kinds and Docker skip it.
 		usesBootstrap := provider.UsesBootstrapScript(resolvedKind)
-		impliesPrebuilt := !usesBootstrap || provider.Kind() == tartSatelliteTarget
+		impliesPrebuilt := !usesBootstrap || provider.Kind() == tartSatelliteTarget || provider.Kind() == qemuSatelliteTarget || provider.Kind() == sshSatelliteTarget
 		if bare {
 			// --bare SUPPRESSES the tart-implied --prebuilt rather than being
 			// contradicted by it: the machine and pinned transport come up,
@@ -587,7 +616,7 @@ trades it for a cheap, replaceable node.`
 		// sanity check) — validation only, still BEFORE the confirm prompt
 		// and machine creation, same fail-fast order as always.
 		var capacityPlan satelliteCapacityPlan
-		if provider.Kind() == tartSatelliteTarget && !execKind {
+		if satelliteTargetPlansCapacity(provider.Kind()) && !execKind {
 			capacityPlan, err = calculateFullTartCapacityPlan(sourceAbs)
 			if err != nil {
 				return err
@@ -603,6 +632,9 @@ trades it for a cheap, replaceable node.`
 			ServiceAccountEmail: prov.ServiceAccountEmail,
 			Bare:                bare,
 		}
+		if prebuilt {
+			upOpts.PrebuiltTarget = prebuiltXTarget.String()
+		}
 		if err := provider.PrepareUp(upOpts); err != nil {
 			return err
 		}
@@ -694,7 +726,7 @@ trades it for a cheap, replaceable node.`
 			if err := waitForSatelliteSSHAuth(ssh, 3*time.Minute); err != nil {
 				return err
 			}
-			if provider.Kind() == tartSatelliteTarget && !execKind {
+			if satelliteTargetPlansCapacity(provider.Kind()) && !execKind {
 				if err := validateFullTartGuestCapacity(ssh, capacityPlan.Guest); err != nil {
 					return err
 				}
@@ -845,6 +877,14 @@ trades it for a cheap, replaceable node.`
 		// later plain `up` because this entry is assembled from scratch (the
 		// promote path — the ship above just installed the stack).
 		entry.Bare = bare
+		// The ttl clock starts with the machine: a re-up of the same machine
+		// (same provider handle) keeps the recorded creation time.
+		entry.CreatedAt = time.Now().UTC()
+		if prior, err := loadSatelliteState(); err == nil {
+			if p := prior[name]; p.ProviderRef == entry.ProviderRef && !p.CreatedAt.IsZero() {
+				entry.CreatedAt = p.CreatedAt
+			}
+		}
 		// Sync forward fields (fixed M2 contract with the daemon side): the
 		// daemon binds 127.0.0.1:<sync_local_port> and forwards to the VM's
 		// syncd over the pinned SSH connection. 0 = forward off (fields
@@ -889,6 +929,9 @@ trades it for a cheap, replaceable node.`
 		if err := upsertSatelliteState(name, entry); err != nil {
 			return fmt.Errorf("write satellite state entry: %w", err)
 		}
+		if err := recordSatelliteReap(name, nil); err != nil {
+			fmt.Printf("warning: could not clear the satellite's reap record: %v\n", err)
+		}
 
 		// 5c'. Infra inputs write-back, config-respecting: when the merged
 		// config ALREADY carries a [satellites.<name>.infra] block (e.g. in a
@@ -1161,11 +1204,20 @@ func newSatelliteDownCmd() *cobra.Command {
 				return nil
 			},
 		}
+		snapshotter, canSnapshot := provider.(satelliteSnapshotter)
+		if canSnapshot {
+			if records, _ := loadSatelliteSnapshots(name); len(records) > 0 {
+				fmt.Printf("Satellite %q has %d snapshot(s); they are deleted with it.\n", name, len(records))
+			}
+		}
 		providerStart := time.Now()
 		if err := provider.Down(cmd.Context(), downOpts); err != nil {
 			return err
 		}
 		report.phase("provider_down", providerStart)
+		if canSnapshot {
+			deleteSatelliteSnapshots(cmd.Context(), name, snapshotter, &satelliteSnapshotOptions{Name: name, Entry: entry, Infra: infraCfg})
+		}
 
 		// 3. Remove the satellite's provisioning state entry, plus any LEGACY
 		// flat [satellites.<name>] entry an older `up` wrote into the global
@@ -1207,7 +1259,11 @@ func newSatelliteDownCmd() *cobra.Command {
 		//    satellite's connection, sync port-forward, status entry, and
 		//    federated rows immediately. Soft-fail to the manual instruction
 		//    when the daemon isn't running or predates the endpoint.
-		fmt.Printf("\nSatellite %q destroyed and deregistered.\n", name)
+		if provider.Kind() == sshSatelliteTarget {
+			fmt.Printf("\nSatellite %q deregistered (the host was left running).\n", name)
+		} else {
+			fmt.Printf("\nSatellite %q destroyed and deregistered.\n", name)
+		}
 		summary, reloaded := reloadDaemonSatelliteRegistry()
 		report.reloaded = reloaded
 		if reloaded {
@@ -1668,9 +1724,15 @@ func renderSatelliteInfraTOML(name string, infra satelliteInfraConfig) string {
 	if infra.IdentityFile != "" {
 		fmt.Fprintf(&table, "identity_file = %q\n", infra.IdentityFile)
 	}
+	if infra.Address != "" {
+		fmt.Fprintf(&table, "address = %q\n", infra.Address)
+	}
 	if infra.Image != "" {
 		fmt.Fprintf(&table, "image = %q\n", infra.Image)
 	}
+	if infra.ImageSHA256 != "" {
+		fmt.Fprintf(&table, "image_sha256 = %q\n", infra.ImageSHA256)
+	}
 	if infra.TartHome != "" {
 		fmt.Fprintf(&table, "tart_home = %q\n", infra.TartHome)
 	}
@@ -1700,7 +1762,9 @@ func satelliteInfraDriftMessage(name string, fromConfig, resolved satelliteInfra
 		{"ssh_user", fromConfig.SSHUser, resolved.SSHUser},
 		{"cidr", fromConfig.CIDR, resolved.CIDR},
 		{"identity_file", fromConfig.IdentityFile, resolved.IdentityFile},
+		{"address", fromConfig.Address, resolved.Address},
 		{"image", fromConfig.Image, resolved.Image},
+		{"image_sha256", fromConfig.ImageSHA256, resolved.ImageSHA256},
 		{"tart_home", fromConfig.TartHome, resolved.TartHome},
 		{"tart_volume_identity", fromConfig.TartVolumeIdentity, resolved.TartVolumeIdentity},
 	} {
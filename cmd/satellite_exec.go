@@ -26,7 +26,12 @@ import (
 // rather than trusted on first use.
 
 func newSatelliteExecCmd() *cobra.Command {
-	var remoteDir string
+	var (
+		remoteDir string
+		all       bool
+		selectArg string
+		failFast  bool
+	)
 	cmd := cli.NewStandardCommand("exec <name> -- <command>...", "Run a command on a satellite over its pinned SSH connection")
 	cmd.Long = `Run one command on a satellite and exit with the command's own exit status.
 
@@ -42,13 +47,44 @@ stdout and stderr are the caller's, making this usable in a pipeline:
 
 The connection pins the registry's host key (never TOFU). Secrets belong on
 stdin, never in the command words — argv is visible in the guest's process
-list.`
+list.
+
+Fleet mode runs the command on many satellites at once — every registered one
+with --all, or those matching --select (comma-separated key=value filters on
+kind, target and name; a repeated key matches any of its values, name takes a
+glob). No satellite name is given:
+
+  grove satellite exec --all -- grove version
+  grove satellite exec --select kind=full,target=tart -- df -h /
+  grove satellite exec --select name='ci-*' --fail-fast --json -- make test
+
+Each host's stdout and stderr lines are prefixed with its name. stdin is not
+forwarded. --fail-fast stops the remaining hosts once one fails. --json prints
+a per-host summary (exit code, duration, captured output) instead of
+streaming. The exit status is 0 only when the command succeeded everywhere.`
 	// One arg is accepted so the missing-command case gets the explanatory
 	// error below rather than Cobra's "requires at least 2 arg(s)".
 	cmd.Args = cobra.MinimumNArgs(1)
 	cmd.SilenceUsage = true
 	cmd.Flags().StringVar(&remoteDir, "dir", "", "Working directory on the satellite the command runs in (default: the login shell's)")
+	cmd.Flags().BoolVar(&all, "all", false, "Run on every registered satellite")
+	cmd.Flags().StringVar(&selectArg, "select", "", "Run on the satellites matching key=value filters (kind, target, name)")
+	cmd.Flags().BoolVar(&failFast, "fail-fast", false, "Fleet mode: stop the remaining satellites once one fails")
+	cmd.MarkFlagsMutuallyExclusive("all", "select")
 	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		if all || selectArg != "" {
+			if cmd.ArgsLenAtDash() > 0 {
+				return fmt.Errorf("--all/--select pick the satellites — give only the command after `--`, e.g. `grove satellite exec --all -- grove version`")
+			}
+			selector, err := parseSatelliteSelector(selectArg)
+			if err != nil {
+				return err
+			}
+			return runSatelliteFleetExec(cmd.Context(), selector, args, buildSatelliteRemoteCommand(remoteDir, args), failFast, satelliteJSONRequested(cmd))
+		}
+		if failFast {
+			return fmt.Errorf("--fail-fast applies to fleet mode (--all or --select)")
+		}
 		name := args[0]
 		remote := args[1:]
 		if len(remote) == 0 {
@@ -96,9 +132,6 @@ func runSatelliteRemote(name, command string, tty bool) error {
 	if !ok {
 		return fmt.Errorf("satellite %q not found in the registry (config or state) — run `grove satellite up %s` first", name, name)
 	}
-	if satelliteEntryIsPartial(entry) {
-		return fmt.Errorf("satellite %q is only partially provisioned (no pinned endpoint): %s", name, satellitePartialUpRemediation(name))
-	}
 	// F3's ssh/exec half: status consults the local-provider machine-state
 	// probe, but dialing never did — so an exec against a stopped/deleted VM
 	// sank into the NAT black hole instead of failing. Probe just this entry
@@ -106,8 +139,8 @@ func runSatelliteRemote(name, command string, tty bool) error {
 	// running; "" (gcp/full, probe timeout, provider missing) proceeds to
 	// dial exactly as before — the probe only ever makes failure faster.
 	machineState := probeSatelliteMachineStates(map[string]satelliteConfigEntry{name: entry})[name]
-	if msg := satelliteRemoteRefusal(machineState, satelliteProviderRefTarget(entry.ProviderRef), name); msg != "" {
-		return errors.New(msg)
+	if err := satelliteRemotePreflight(name, entry, machineState); err != nil {
+		return err
 	}
 	tmpDir, err := os.MkdirTemp("", "grove-satellite-exec-")
 	if err != nil {
@@ -127,6 +160,19 @@ func runSatelliteRemote(name, command string, tty bool) error {
 	return nil
 }
 
+// satelliteRemotePreflight refuses, before dialing, an entry no remote verb
+// can reach: a partial up (no pinned endpoint), or a machine the probe knows
+// is not running.
+func satelliteRemotePreflight(name string, entry satelliteConfigEntry, machineState string) error {
+	if satelliteEntryIsPartial(entry) {
+		return fmt.Errorf("satellite %q is only partially provisioned (no pinned endpoint): %s", name, satellitePartialUpRemediation(name))
+	}
+	if msg := satelliteRemoteRefusal(machineState, satelliteProviderRefTarget(entry.ProviderRef), name); msg != "" {
+		return errors.New(msg)
+	}
+	return nil
+}
+
 // satelliteRemoteRefusal decides whether a reach-the-guest verb should refuse
 // before dialing, given the entry's probed machine_state and provider. "" means
 // proceed. Only the two knowably-dead states refuse; "running" and "" (the
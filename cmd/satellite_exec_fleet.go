@@ -0,0 +1,235 @@
+package cmd
+
+// Fleet mode for `grove satellite exec` (--all / --select): one command run
+// concurrently over each selected satellite's own pinned transport. Output is
+// streamed line by line with a [name] prefix (runner.StreamOutput, the
+// workspace runner's prefixing), or captured per host for the --json summary.
+// Hosts that cannot be reached are refused before dialing with the same
+// preflight the single-host verb uses, and count as failures.
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/grovetools/grove/pkg/runner"
+)
+
+// satelliteSelector is a parsed --select: key → accepted values. An empty
+// selector (--all) matches every satellite.
+type satelliteSelector map[string][]string
+
+// satelliteSelectorKeys are the --select keys.
+var satelliteSelectorKeys = []string{"kind", "target", "name"}
+
+func parseSatelliteSelector(s string) (satelliteSelector, error) {
+	sel := satelliteSelector{}
+	if strings.TrimSpace(s) == "" {
+		return sel, nil
+	}
+	for _, term := range strings.Split(s, ",") {
+		key, value, ok := strings.Cut(strings.TrimSpace(term), "=")
+		key, value = strings.TrimSpace(key), strings.TrimSpace(value)
+		if !ok || value == "" {
+			return nil, fmt.Errorf("invalid --select term %q: want key=value (keys: %s)", term, strings.Join(satelliteSelectorKeys, ", "))
+		}
+		switch key {
+		case "kind":
+			if value != satelliteKindFull && value != satelliteKindExec {
+				return nil, fmt.Errorf("invalid --select kind %q: want %s or %s", value, satelliteKindFull, satelliteKindExec)
+			}
+		case "target":
+		case "name":
+			if _, err := path.Match(value, ""); err != nil {
+				return nil, fmt.Errorf("invalid --select name pattern %q: %w", value, err)
+			}
+		default:
+			return nil, fmt.Errorf("unknown --select key %q (keys: %s)", key, strings.Join(satelliteSelectorKeys, ", "))
+		}
+		sel[key] = append(sel[key], value)
+	}
+	return sel, nil
+}
+
+// matches reports whether a satellite passes every key of the selector;
+// target is the satellite's resolved provider.
+func (sel satelliteSelector) matches(name string, entry satelliteConfigEntry, target string) bool {
+	for key, values := range sel {
+		hit := false
+		for _, v := range values {
+			switch key {
+			case "kind":
+				hit = entry.effectiveKind() == v
+			case "target":
+				hit = target == v
+			case "name":
+				hit, _ = path.Match(v, name)
+			}
+			if hit {
+				break
+			}
+		}
+		if !hit {
+			return false
+		}
+	}
+	return true
+}
+
+// satelliteEntryTarget is the provider a satellite runs on for --select:
+// provider_ref first, then the infra block's target, then the default.
+func satelliteEntryTarget(name string, entry satelliteConfigEntry) string {
+	if recorded := satelliteProviderRefTarget(entry.ProviderRef); recorded != "" {
+		return recorded
+	}
+	if infra, _, err := loadSatelliteInfra(name); err == nil && infra.Target != "" {
+		return infra.Target
+	}
+	return defaultSatelliteTarget
+}
+
+// satelliteExecResult is one host's --json summary row. Flat-keyed like the
+// rest of the satellite --json contract: every field is always present.
+type satelliteExecResult struct {
+	Name string `json:"name"`
+	OK   bool   `json:"ok"`
+	// ExitCode is the remote command's exit status (ssh's own failures are
+	// 255), or -1 when it never ran to completion (refused, cancelled).
+	ExitCode   int    `json:"exit_code"`
+	Error      string `json:"error"`
+	DurationMS int64  `json:"duration_ms"`
+	Stdout     string `json:"stdout"`
+	Stderr     string `json:"stderr"`
+}
+
+type satelliteExecSummary struct {
+	Schema  string                `json:"schema"`
+	Command []string              `json:"command"`
+	OK      bool                  `json:"ok"`
+	Results []satelliteExecResult `json:"results"`
+}
+
+// runSatelliteFleetExec runs command on every selected satellite at once.
+// argv is the caller's command words, echoed in the --json summary.
+func runSatelliteFleetExec(ctx context.Context, sel satelliteSelector, argv []string, command string, failFast, jsonOutput bool) error {
+	entries := loadMergedSatellites()
+	selected := map[string]satelliteConfigEntry{}
+	var names []string
+	for name, entry := range entries {
+		if sel.matches(name, entry, satelliteEntryTarget(name, entry)) {
+			selected[name] = entry
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	if len(names) == 0 {
+		return fmt.Errorf("no registered satellite matches the selection")
+	}
+	machineStates := probeSatelliteMachineStates(selected)
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	results := make([]satelliteExecResult, len(names))
+	var wg sync.WaitGroup
+	for i, name := range names {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = runSatelliteFleetHost(ctx, name, selected[name], machineStates[name], command, jsonOutput)
+			if !results[i].OK && failFast {
+				cancel()
+			}
+		}()
+	}
+	wg.Wait()
+
+	var failed []string
+	for i := range results {
+		if ctx.Err() != nil && results[i].ExitCode == -1 && results[i].Error == "" {
+			results[i].Error = "cancelled"
+		}
+		if !results[i].OK {
+			failed = append(failed, results[i].Name)
+		}
+	}
+	if jsonOutput {
+		if err := writeSatelliteJSON(os.Stdout, satelliteExecSummary{
+			Schema:  satelliteExecSchema,
+			Command: argv,
+			OK:      len(failed) == 0,
+			Results: results,
+		}); err != nil {
+			return err
+		}
+	} else {
+		fmt.Println()
+		for _, r := range results {
+			switch {
+			case r.OK:
+				fmt.Printf("%s: ok (%s)\n", r.Name, time.Duration(r.DurationMS)*time.Millisecond)
+			case r.Error != "":
+				fmt.Printf("%s: FAILED — %s\n", r.Name, r.Error)
+			default:
+				fmt.Printf("%s: FAILED — exit %d\n", r.Name, r.ExitCode)
+			}
+		}
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("command failed on %d of %d satellite(s): %s", len(failed), len(results), strings.Join(failed, ", "))
+	}
+	return nil
+}
+
+// runSatelliteFleetHost runs command on one satellite, streaming its output
+// prefixed with the name (or capturing it under --json).
+func runSatelliteFleetHost(ctx context.Context, name string, entry satelliteConfigEntry, machineState, command string, capture bool) (res satelliteExecResult) {
+	res = satelliteExecResult{Name: name, ExitCode: -1}
+	start := time.Now()
+	defer func() { res.DurationMS = time.Since(start).Milliseconds() }()
+	if err := satelliteRemotePreflight(name, entry, machineState); err != nil {
+		res.Error = err.Error()
+		return res
+	}
+	tmpDir, err := os.MkdirTemp("", "grove-satellite-exec-")
+	if err != nil {
+		res.Error = err.Error()
+		return res
+	}
+	defer func() { _ = os.RemoveAll(tmpDir) }()
+	ssh, err := newSatelliteSSH(entry, tmpDir)
+	if err != nil {
+		res.Error = err.Error()
+		return res
+	}
+
+	cmd := ssh.remoteCommand(ctx, command)
+	if capture {
+		var stdout, stderr bytes.Buffer
+		cmd.Stdout, cmd.Stderr = &stdout, &stderr
+		err = cmd.Run()
+		res.Stdout, res.Stderr = stdout.String(), stderr.String()
+	} else {
+		err = runner.StreamOutput(cmd, name, os.Stdout)
+	}
+	if ctx.Err() != nil && err != nil {
+		return res // cancelled: the summary names it
+	}
+	var ee *exec.ExitError
+	switch {
+	case err == nil:
+		res.OK, res.ExitCode = true, 0
+	case errors.As(err, &ee):
+		res.ExitCode = ee.ExitCode()
+	default:
+		res.Error = err.Error()
+	}
+	return res
+}
@@ -0,0 +1,79 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseSatelliteSelector(t *testing.T) {
+	sel, err := parseSatelliteSelector(" kind=full, target=tart,target=qemu ,name=ci-*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(sel["kind"], ",") != "full" || strings.Join(sel["target"], ",") != "tart,qemu" || strings.Join(sel["name"], ",") != "ci-*" {
+		t.Errorf("selector = %v", sel)
+	}
+	if sel, err := parseSatelliteSelector(""); err != nil || len(sel) != 0 {
+		t.Errorf("empty selector = %v, %v", sel, err)
+	}
+	for bad, want := range map[string]string{
+		"kind":         "want key=value",
+		"kind=":        "want key=value",
+		"kind=vm":      "want full or exec",
+		"zone=us":      "unknown --select key",
+		"name=[oops":   "name pattern",
+		"target=tart,": "want key=value",
+	} {
+		if _, err := parseSatelliteSelector(bad); err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("parseSatelliteSelector(%q) = %v, want %q", bad, err, want)
+		}
+	}
+}
+
+// TestSatelliteSelectorMatches: keys AND together, a repeated key ORs its
+// values, and an empty kind is the full default.
+func TestSatelliteSelectorMatches(t *testing.T) {
+	full := satelliteConfigEntry{}
+	exec := satelliteConfigEntry{Kind: satelliteKindExec}
+	sel, err := parseSatelliteSelector("kind=full,target=tart,target=qemu")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tc := range []struct {
+		name   string
+		entry  satelliteConfigEntry
+		target string
+		want   bool
+	}{
+		{"a", full, tartSatelliteTarget, true},
+		{"b", full, qemuSatelliteTarget, true},
+		{"c", full, "gcp", false},
+		{"d", exec, tartSatelliteTarget, false},
+	} {
+		if got := sel.matches(tc.name, tc.entry, tc.target); got != tc.want {
+			t.Errorf("matches(%s, kind=%q, %s) = %v, want %v", tc.name, tc.entry.Kind, tc.target, got, tc.want)
+		}
+	}
+	byName, _ := parseSatelliteSelector("name=ci-*")
+	if !byName.matches("ci-1", exec, "docker") || byName.matches("dev", exec, "docker") {
+		t.Error("name glob mismatch")
+	}
+	if !(satelliteSelector{}).matches("any", exec, "gcp") {
+		t.Error("--all selector must match everything")
+	}
+}
+
+// TestSatelliteFleetHostPreflight: a host the preflight refuses fails without
+// dialing, with exit_code -1 and the refusal as its error.
+func TestSatelliteFleetHostPreflight(t *testing.T) {
+	partial := satelliteConfigEntry{ProviderRef: "tart:grove-sat-x"}
+	res := runSatelliteFleetHost(t.Context(), "x", partial, "", "true", true)
+	if res.OK || res.ExitCode != -1 || !strings.Contains(res.Error, "partially provisioned") {
+		t.Errorf("partial host result = %+v", res)
+	}
+	stopped := satelliteConfigEntry{SSHAddr: "127.0.0.1:2222", HostKey: "ssh-ed25519 AAAA", ProviderRef: "docker:grove-sat-y"}
+	res = runSatelliteFleetHost(t.Context(), "y", stopped, satelliteMachineStopped, "true", true)
+	if res.OK || !strings.Contains(res.Error, "is stopped") {
+		t.Errorf("stopped host result = %+v", res)
+	}
+}
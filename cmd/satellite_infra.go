@@ -42,12 +42,20 @@ type satelliteInfraConfig struct {
 	// IdentityFile is the SSH private key recorded in the registry entry
 	// (empty = agent-only auth).
 	IdentityFile string `yaml:"identity_file"`
-	// Image is the guest image override (tart/docker targets only): the OCI
-	// image the tart provider clones (empty resolves to defaultTartImage),
-	// or the docker image the docker provider runs as-is instead of building
-	// its embedded Dockerfile (empty resolves to the grove-owned
-	// content-hash tag).
+	// Address is the host[:port] of the existing machine the ssh target
+	// adopts (port defaults to 22).
+	Address string `yaml:"address"`
+	// Image is the guest image override (tart/qemu/docker targets only): the
+	// OCI image the tart provider clones (empty resolves to defaultTartImage),
+	// the cloud image URL or local file the qemu provider boots (empty
+	// resolves to defaultQemuImageURL for the host arch), or the docker
+	// image the docker provider runs as-is instead of building its embedded
+	// Dockerfile (empty resolves to the grove-owned content-hash tag).
 	Image string `yaml:"image"`
+	// ImageSHA256 pins the sha256 of a qemu image URL (qemu target only).
+	// Empty verifies the download against the SHA256SUMS file next to the
+	// image instead, which mirrors without one cannot offer.
+	ImageSHA256 string `yaml:"image_sha256"`
 	// TartHome relocates tart's storage (the TART_HOME env var) for every
 	// tart command grove runs (tart target only). `up` resolves the EFFECTIVE
 	// value (--tart-home, else this block, else the process TART_HOME, else
@@ -109,6 +117,8 @@ type infraFlagOverrides struct {
 	CIDRSet      bool
 	IdentityFile string
 	IdentitySet  bool
+	Address      string
+	AddressSet   bool
 	Image        string
 	ImageSet     bool
 	TartHome     string
@@ -138,6 +148,9 @@ func mergeInfra(cfg satelliteInfraConfig, f infraFlagOverrides) satelliteInfraCo
 	if f.IdentitySet {
 		out.IdentityFile = f.IdentityFile
 	}
+	if f.AddressSet {
+		out.Address = f.Address
+	}
 	if f.ImageSet {
 		out.Image = f.Image
 	}
@@ -186,9 +199,15 @@ func writeSatelliteInfra(name string, infra satelliteInfraConfig) error {
 	if infra.IdentityFile != "" {
 		values["identity_file"] = infra.IdentityFile
 	}
+	if infra.Address != "" {
+		values["address"] = infra.Address
+	}
 	if infra.Image != "" {
 		values["image"] = infra.Image
 	}
+	if infra.ImageSHA256 != "" {
+		values["image_sha256"] = infra.ImageSHA256
+	}
 	if infra.TartHome != "" {
 		values["tart_home"] = infra.TartHome
 	}
@@ -21,11 +21,12 @@ import (
 // field" instead of "this satellite happens not to have one".
 //
 // Every verb's payload embeds the same satelliteJSON object, so one parser
-// serves status, list, up and down.
+// serves status, list, up and down (fleet exec reports per-host results).
 const (
 	satelliteStatusSchema = "grove.satellite.status/v1"
 	satelliteUpSchema     = "grove.satellite.up/v1"
 	satelliteDownSchema   = "grove.satellite.down/v1"
+	satelliteExecSchema   = "grove.satellite.exec/v1"
 )
 
 // satelliteJSON is the machine view of one satellite: the registry entry's
@@ -9,8 +9,8 @@ package cmd
 // agent driving from --json cannot tell a usable satellite from a dead one
 // short of attempting an exec and reading an ssh timeout. This probe closes the
 // gap by asking each LOCAL provider that actually owns a satellite here for its
-// inventory in ONE cheap, read-only subprocess (`tart list` / `docker ps -a`)
-// and mapping every satellite's provider_ref handle to a machine_state the
+// inventory in ONE cheap, read-only subprocess (`tart list` / `docker ps -a`;
+// qemu's inventory is its provider dir, read without one) and mapping every satellite's provider_ref handle to a machine_state the
 // --json contract and the human table both surface.
 //
 // It never blocks status. Each provider probe is capped by a short timeout, so
@@ -62,7 +62,7 @@ func probeSatelliteMachineStates(configured map[string]satelliteConfigEntry) map
 // probeSatelliteMachineStatesWithin is probeSatelliteMachineStates with an
 // explicit per-provider timeout, so tests can drive the probe fast.
 func probeSatelliteMachineStatesWithin(configured map[string]satelliteConfigEntry, timeout time.Duration) map[string]string {
-	var tartNames, dockerNames []string
+	var tartNames, dockerNames, qemuNames []string
 	for name, entry := range configured {
 		// provider_ref is the authoritative provider identity (33739db); the
 		// config target is only a fallback the daemon-side merge does not see.
@@ -71,6 +71,8 @@ func probeSatelliteMachineStatesWithin(configured map[string]satelliteConfigEntr
 			tartNames = append(tartNames, name)
 		case dockerSatelliteTarget:
 			dockerNames = append(dockerNames, name)
+		case qemuSatelliteTarget:
+			qemuNames = append(qemuNames, name)
 		}
 	}
 	out := map[string]string{}
@@ -80,6 +82,12 @@ func probeSatelliteMachineStatesWithin(configured map[string]satelliteConfigEntr
 	if len(dockerNames) > 0 {
 		probeDockerMachineStates(configured, dockerNames, timeout, out)
 	}
+	// qemu needs no subprocess: the provider dir's pid file and disk are the
+	// inventory.
+	for _, name := range qemuNames {
+		vmName := strings.TrimPrefix(configured[name].ProviderRef, qemuSatelliteTarget+":")
+		out[name] = qemuMachineState(name, vmName)
+	}
 	return out
 }
 
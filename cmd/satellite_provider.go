@@ -7,9 +7,11 @@ package cmd
 // config push, repo mirror, sync finishing, daemon reload. Infra targets
 // resolve through the provider registry below; gcp (terraform, full-stack
 // default), tart (local Apple-Silicon VMs, exec by default with gated full),
-// and docker (local containers, exec-only, no bootstrap script) are the
-// providers today. The gcp embedded-terraform target resolution is a
-// gcp-private detail behind the seam.
+// qemu (local cloud-image VMs on Linux hosts, exec by default), docker (local
+// containers, exec-only, no bootstrap script), and ssh (adopts an existing
+// sshd host; never creates or destroys it) are the providers today. The gcp
+// embedded-terraform target resolution is a gcp-private detail behind the
+// seam.
 
 import (
 	"context"
@@ -51,7 +53,8 @@ type satelliteUpOptions struct {
 	// for kind-specific preparation without changing their default kind.
 	SatelliteKind string
 	// CapacityPlan is calculated by the shared verb before provider creation.
-	// Full Tart consumes both observations; other targets intentionally ignore it.
+	// Full Tart and qemu consume both observations (qemu also sizes its disk
+	// from the guest budget); other targets intentionally ignore it.
 	CapacityPlan satelliteCapacityPlan
 	// Infra is the merged [satellites.<name>.infra] block, flags already
 	// applied.
@@ -64,6 +67,10 @@ type satelliteUpOptions struct {
 	// ServiceAccountEmail is the provision block's service account (gcp:
 	// terraform var service_account_email).
 	ServiceAccountEmail string
+	// PrebuiltTarget is the resolved <goos>/<goarch> the shared verb
+	// cross-builds the stack for (empty when nothing ships). The ssh
+	// provider checks an adopted host's platform against it.
+	PrebuiltTarget string
 	// Bare is `up --bare`: create the machine and pin the transport, and
 	// write nothing grove-shaped onto the guest (no bin dir, no PATH prep;
 	// the shared verb separately ships no stack, config, or repos). Tart
@@ -126,6 +133,72 @@ type satelliteProvider interface {
 	Down(ctx context.Context, opts *satelliteDownOptions) error
 }
 
+// satelliteSnapshotOptions carries what a snapshotter needs: the satellite's
+// registry entry (provider_ref, pinned transport for a graceful guest stop),
+// its merged infra block, and the snapshot's label and provider handle.
+type satelliteSnapshotOptions struct {
+	Name  string
+	Entry satelliteConfigEntry
+	Infra satelliteInfraConfig
+	Label string
+	// Handle is the provider's name for the snapshot: returned by Snapshot,
+	// passed to Restore and DeleteSnapshot.
+	Handle string
+}
+
+// satelliteSnapshotter is the OPTIONAL snapshot/restore capability. The
+// `snapshot`/`restore` verbs type-assert the provider; targets without it
+// (ssh — an adopted host is not grove's to roll back) are refused by name.
+// Docker commits the container to an image, tart clones the VM, qemu takes a
+// qcow2 internal snapshot, gcp snapshots the boot disk.
+type satelliteSnapshotter interface {
+	// Snapshot captures the satellite's machine under opts.Label and returns
+	// the provider handle. A machine stopped for an offline copy is started
+	// again before it returns.
+	Snapshot(ctx context.Context, opts *satelliteSnapshotOptions) (string, error)
+	// Restore replaces the machine's disk with snapshot opts.Handle, leaves
+	// it running, and returns how to reach it now — the address may change;
+	// the shared verb re-pins the host key and records the endpoint.
+	Restore(ctx context.Context, opts *satelliteSnapshotOptions) (satelliteEndpoint, error)
+	// DeleteSnapshot removes snapshot opts.Handle. One already gone (its
+	// machine was destroyed with it) is not an error.
+	DeleteSnapshot(ctx context.Context, opts *satelliteSnapshotOptions) error
+}
+
+// satelliteStopper is the OPTIONAL power-off capability the reaper uses for
+// an idle satellite: the machine and its disk survive, and a later `up`
+// starts it again (gcp: `gcloud compute instances start` first — terraform
+// does not start a stopped instance). ssh does not implement it; an adopted
+// host is not grove's to power off.
+type satelliteStopper interface {
+	// Stop powers the satellite's machine off, gracefully where the provider
+	// can. An already stopped machine is not an error.
+	Stop(ctx context.Context, name string, entry satelliteConfigEntry, infra satelliteInfraConfig) error
+}
+
+// resolveRecordedSatelliteProvider resolves the provider that created a
+// registered satellite — provider_ref first, the infra target as fallback,
+// the recorded TART_HOME applied (the resolution `down` uses) — with the
+// merged entry and infra block the provider's methods take.
+func resolveRecordedSatelliteProvider(name string) (satelliteProvider, satelliteConfigEntry, satelliteInfraConfig, error) {
+	entry, ok := loadMergedSatellites()[name]
+	if !ok {
+		return nil, entry, satelliteInfraConfig{}, fmt.Errorf("satellite %q not found in the registry (config or state) — run `grove satellite up %s` first", name, name)
+	}
+	infra, _, err := loadSatelliteInfra(name)
+	if err != nil {
+		return nil, entry, infra, err
+	}
+	if recorded := satelliteProviderRefTarget(entry.ProviderRef); recorded != "" {
+		infra.Target = recorded
+	}
+	if entry.ProviderTartHome != "" {
+		infra.TartHome = entry.ProviderTartHome
+	}
+	provider, err := satelliteProviderFor(infra.Target)
+	return provider, entry, infra, err
+}
+
 // satelliteProviderRegistry maps infra target names to provider
 // constructors. Target validity IS registry membership now; the
 // embedded-terraform-target check that used to gate targets
@@ -134,6 +207,8 @@ var satelliteProviderRegistry = map[string]func(target string) satelliteProvider
 	defaultSatelliteTarget: newGCPSatelliteProvider,
 	tartSatelliteTarget:    newTartSatelliteProvider,
 	dockerSatelliteTarget:  newDockerSatelliteProvider,
+	sshSatelliteTarget:     newSSHSatelliteProvider,
+	qemuSatelliteTarget:    newQemuSatelliteProvider,
 }
 
 // satelliteProviderFor resolves an infra target name (empty = the gcp
@@ -34,6 +34,7 @@ import (
 	"os/exec"
 	"path/filepath"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -299,6 +300,116 @@ func (p *dockerSatelliteProvider) Down(_ context.Context, opts *satelliteDownOpt
 	return nil
 }
 
+// --- snapshots (docker commit) ---
+
+// dockerSnapshotImageRepo is the repository snapshot images are committed to;
+// the tag is "<satellite>-<label>".
+const dockerSnapshotImageRepo = "grove-satellite-snapshot"
+
+// dockerSnapshotImage is the image a satellite's snapshot is committed as.
+func dockerSnapshotImage(satName, label string) string {
+	return dockerSnapshotImageRepo + ":" + satName + "-" + label
+}
+
+// Stop stops the satellite's container (docker sends SIGTERM, then SIGKILL
+// after its grace period); `up` starts it again with its port mapping.
+func (p *dockerSatelliteProvider) Stop(_ context.Context, name string, entry satelliteConfigEntry, _ satelliteInfraConfig) error {
+	container := dockerSnapshotContainer(&satelliteSnapshotOptions{Name: name, Entry: entry})
+	if out, err := exec.Command("docker", "stop", container).CombinedOutput(); err != nil { //nolint:gosec // G204: recorded container name
+		return fmt.Errorf("docker stop %s: %w: %s", container, err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// dockerSnapshotContainer is the satellite's recorded container name.
+func dockerSnapshotContainer(opts *satelliteSnapshotOptions) string {
+	if strings.HasPrefix(opts.Entry.ProviderRef, dockerSatelliteTarget+":") {
+		return strings.TrimPrefix(opts.Entry.ProviderRef, dockerSatelliteTarget+":")
+	}
+	return dockerContainerName(opts.Name)
+}
+
+// Snapshot commits the container's filesystem to a snapshot image (paused for
+// the commit, so the copy is consistent). The sshd host keys live in the
+// container filesystem, so a restored container keeps the pinned key; the
+// bind-mounted authorized_keys is not part of the commit.
+func (p *dockerSatelliteProvider) Snapshot(_ context.Context, opts *satelliteSnapshotOptions) (string, error) {
+	container := dockerSnapshotContainer(opts)
+	st, err := dockerContainerState(container)
+	if err != nil {
+		return "", err
+	}
+	if !st.exists {
+		return "", fmt.Errorf("docker container %q does not exist — re-provision it with `grove satellite up %s --target docker`", container, opts.Name)
+	}
+	image := dockerSnapshotImage(opts.Name, opts.Label)
+	if out, err := exec.Command("docker", "commit", "--pause=true", container, image).CombinedOutput(); err != nil { //nolint:gosec // G204: internal name, validated label
+		return "", fmt.Errorf("docker commit %s %s: %w: %s", container, image, err, strings.TrimSpace(string(out)))
+	}
+	return image, nil
+}
+
+// Restore replaces the container with a fresh one created from the snapshot
+// image — same name, same authorized_keys mount, and the previously published
+// port when it is still free.
+func (p *dockerSatelliteProvider) Restore(ctx context.Context, opts *satelliteSnapshotOptions) (satelliteEndpoint, error) {
+	container := dockerSnapshotContainer(opts)
+	if err := exec.Command("docker", "image", "inspect", opts.Handle).Run(); err != nil { //nolint:gosec // G204: recorded snapshot image
+		return satelliteEndpoint{}, fmt.Errorf("snapshot image %s is missing from the docker daemon (removed out of band?)", opts.Handle)
+	}
+	keyPath, err := ensureDockerSatelliteKey(opts.Name)
+	if err != nil {
+		return satelliteEndpoint{}, err
+	}
+	akPath, err := writeDockerAuthorizedKeys(opts.Name, keyPath)
+	if err != nil {
+		return satelliteEndpoint{}, err
+	}
+	if out, err := exec.Command("docker", "rm", "-f", container).CombinedOutput(); err != nil && !strings.Contains(strings.ToLower(string(out)), "no such container") {
+		return satelliteEndpoint{}, fmt.Errorf("docker rm -f %s: %w: %s", container, err, strings.TrimSpace(string(out)))
+	}
+	port := 0
+	if _, old, err := net.SplitHostPort(opts.Entry.SSHAddr); err == nil {
+		if n, err := strconv.Atoi(old); err == nil && localhostPortFree(n) {
+			port = n
+		}
+	}
+	if port == 0 {
+		if port, err = pickFreeLocalhostPort(); err != nil {
+			return satelliteEndpoint{}, fmt.Errorf("pick a free local port for the container's sshd: %w", err)
+		}
+	}
+	fmt.Printf("Recreating docker container %q from %s (sshd published on 127.0.0.1:%d)...\n", container, opts.Handle, port)
+	if out, err := exec.Command("docker", dockerCreateArgs(container, port, akPath, opts.Handle)...).CombinedOutput(); err != nil {
+		return satelliteEndpoint{}, fmt.Errorf("docker create %s: %w: %s", container, err, strings.TrimSpace(string(out)))
+	}
+	if out, err := exec.Command("docker", "start", container).CombinedOutput(); err != nil {
+		return satelliteEndpoint{}, fmt.Errorf("docker start %s: %w: %s", container, err, strings.TrimSpace(string(out)))
+	}
+	addr, err := dockerMappedSSHAddr(container)
+	if err != nil {
+		return satelliteEndpoint{}, err
+	}
+	if err := waitForTCPPort(ctx, addr, 2*time.Minute); err != nil {
+		return satelliteEndpoint{}, fmt.Errorf("docker container %q: %s never accepted a connection (check `docker logs %s`): %w", container, addr, container, err)
+	}
+	return satelliteEndpoint{
+		SSHAddr:      addr,
+		User:         dockerGuestUser,
+		IdentityFile: keyPath,
+		ProviderRef:  dockerProviderRef(container),
+	}, nil
+}
+
+// DeleteSnapshot removes the snapshot image.
+func (p *dockerSatelliteProvider) DeleteSnapshot(_ context.Context, opts *satelliteSnapshotOptions) error {
+	out, err := exec.Command("docker", "image", "rm", opts.Handle).CombinedOutput() //nolint:gosec // G204: recorded snapshot image
+	if err != nil && !strings.Contains(strings.ToLower(string(out)), "no such image") {
+		return fmt.Errorf("docker image rm %s: %w: %s", opts.Handle, err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
 // --- docker CLI plumbing ---
 
 // dockerProviderDir is the provider's slice of the per-satellite state dir
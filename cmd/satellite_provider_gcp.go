@@ -216,6 +216,148 @@ func (p *gcpSatelliteProvider) Down(_ context.Context, opts *satelliteDownOption
 	return nil
 }
 
+// --- snapshots (boot-disk snapshots via gcloud) ---
+
+// defaultGCPZone mirrors the embedded module's zone default (variables.tf):
+// the zone the VM lives in when the infra block names none.
+const defaultGCPZone = "us-east1-b"
+
+// gcpSnapshotName names a satellite's disk snapshot (GCE resource names are
+// lowercase, start with a letter, and are at most 63 characters).
+func gcpSnapshotName(satName, label string) (string, error) {
+	name := "grove-" + satName + "-" + label
+	if len(name) > 63 {
+		return "", fmt.Errorf("gcp snapshot name %q is longer than 63 characters — use a shorter label", name)
+	}
+	return name, nil
+}
+
+// gcpComputeScope resolves the project and zone the satellite's VM lives in.
+// The VM and its boot disk are both named after the satellite (vm_name).
+func gcpComputeScope(name string, infra satelliteInfraConfig) (project, zone string, err error) {
+	if _, err := exec.LookPath("gcloud"); err != nil {
+		return "", "", fmt.Errorf("gcloud not found on PATH — gcp snapshots and stops drive the Google Cloud CLI: %w", err)
+	}
+	if infra.Project == "" {
+		return "", "", fmt.Errorf("satellite %q has no [satellites.%s.infra] project — gcloud needs the VM's project", name, name)
+	}
+	zone = infra.Zone
+	if zone == "" {
+		zone = defaultGCPZone
+	}
+	return infra.Project, zone, nil
+}
+
+// Snapshot snapshots the VM's boot disk while it runs (crash-consistent; the
+// guest's page cache is flushed over the pinned transport first, best-effort).
+func (p *gcpSatelliteProvider) Snapshot(_ context.Context, opts *satelliteSnapshotOptions) (string, error) {
+	project, zone, err := gcpComputeScope(opts.Name, opts.Infra)
+	if err != nil {
+		return "", err
+	}
+	snapName, err := gcpSnapshotName(opts.Name, opts.Label)
+	if err != nil {
+		return "", err
+	}
+	if tmpDir, err := os.MkdirTemp("", "grove-satellite-gcp-snapshot-"); err == nil {
+		defer func() { _ = os.RemoveAll(tmpDir) }()
+		if ssh, err := newSatelliteSSH(opts.Entry, tmpDir); err == nil {
+			_ = ssh.runCommand("sync")
+		}
+	}
+	if err := runInherited("", "gcloud", "compute", "disks", "snapshot", opts.Name,
+		"--project", project, "--zone", zone, "--snapshot-names", snapName, "--quiet"); err != nil {
+		return "", fmt.Errorf("gcloud compute disks snapshot %s: %w", opts.Name, err)
+	}
+	return snapName, nil
+}
+
+// Restore swaps the VM's boot disk for a new disk created from the snapshot:
+// stop → create disk → detach the old boot disk → attach the new one as boot
+// (auto-deleted with the VM, like the original) → start → delete the old
+// disk. The instance itself is kept, so its sshd host keys and metadata
+// survive; its ephemeral external IP does not, and is read back. Terraform's
+// state still describes the image-initialized disk, so a later `up` plans a
+// replacement of the VM.
+func (p *gcpSatelliteProvider) Restore(_ context.Context, opts *satelliteSnapshotOptions) (satelliteEndpoint, error) {
+	project, zone, err := gcpComputeScope(opts.Name, opts.Infra)
+	if err != nil {
+		return satelliteEndpoint{}, err
+	}
+	scope := []string{"--project", project, "--zone", zone}
+	oldDisk, err := gcloudOutput(append([]string{"compute", "instances", "describe", opts.Name, "--format=value(disks[0].source.basename())"}, scope...)...)
+	if err != nil {
+		return satelliteEndpoint{}, err
+	}
+	newDisk := fmt.Sprintf("%s-restore-%d", opts.Name, time.Now().Unix())
+	steps := [][]string{
+		{"compute", "instances", "stop", opts.Name},
+		{"compute", "disks", "create", newDisk, "--source-snapshot", opts.Handle, "--type", "pd-balanced"},
+		{"compute", "instances", "detach-disk", opts.Name, "--disk", oldDisk},
+		{"compute", "instances", "attach-disk", opts.Name, "--disk", newDisk, "--boot"},
+		{"compute", "instances", "set-disk-auto-delete", opts.Name, "--disk", newDisk, "--auto-delete"},
+		{"compute", "instances", "start", opts.Name},
+		{"compute", "disks", "delete", oldDisk, "--quiet"},
+	}
+	for _, step := range steps {
+		if err := runInherited("", "gcloud", append(step, scope...)...); err != nil {
+			return satelliteEndpoint{}, fmt.Errorf("gcloud %s: %w", strings.Join(step[:3], " "), err)
+		}
+	}
+	ip, err := gcloudOutput(append([]string{"compute", "instances", "describe", opts.Name, "--format=value(networkInterfaces[0].accessConfigs[0].natIP)"}, scope...)...)
+	if err != nil {
+		return satelliteEndpoint{}, err
+	}
+	return satelliteEndpoint{
+		SSHAddr:      ip + ":22",
+		User:         opts.Entry.User,
+		IdentityFile: opts.Entry.IdentityFile,
+	}, nil
+}
+
+// DeleteSnapshot deletes the disk snapshot (billable storage).
+func (p *gcpSatelliteProvider) DeleteSnapshot(_ context.Context, opts *satelliteSnapshotOptions) error {
+	project, _, err := gcpComputeScope(opts.Name, opts.Infra)
+	if err != nil {
+		return err
+	}
+	out, err := exec.Command("gcloud", "compute", "snapshots", "delete", opts.Handle, "--project", project, "--quiet").CombinedOutput() //nolint:gosec // G204: recorded handle
+	if err != nil && !strings.Contains(string(out), "was not found") {
+		return fmt.Errorf("gcloud compute snapshots delete %s: %w: %s", opts.Handle, err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// Stop powers the VM off (`gcloud compute instances stop` — an ACPI
+// shutdown). The boot disk is still billed; compute is not.
+func (p *gcpSatelliteProvider) Stop(_ context.Context, name string, _ satelliteConfigEntry, infra satelliteInfraConfig) error {
+	project, zone, err := gcpComputeScope(name, infra)
+	if err != nil {
+		return err
+	}
+	if err := runInherited("", "gcloud", "compute", "instances", "stop", name, "--project", project, "--zone", zone, "--quiet"); err != nil {
+		return fmt.Errorf("gcloud compute instances stop %s: %w", name, err)
+	}
+	return nil
+}
+
+// gcloudOutput runs a read-only gcloud command and returns its trimmed,
+// non-empty stdout.
+func gcloudOutput(args ...string) (string, error) {
+	out, err := exec.Command("gcloud", args...).Output() //nolint:gosec // G204: internal args
+	if err != nil {
+		if ee, ok := err.(*exec.ExitError); ok && len(ee.Stderr) > 0 {
+			return "", fmt.Errorf("gcloud %s: %w: %s", strings.Join(args[:3], " "), err, strings.TrimSpace(string(ee.Stderr)))
+		}
+		return "", fmt.Errorf("gcloud %s: %w", strings.Join(args[:3], " "), err)
+	}
+	value := strings.TrimSpace(string(out))
+	if value == "" {
+		return "", fmt.Errorf("gcloud %s returned nothing", strings.Join(args[:3], " "))
+	}
+	return value, nil
+}
+
 // --- gcp/terraform-specific helpers (moved from satellite.go with the seam) ---
 
 // satelliteTFVarsName is the variables file `up` persists into the terraform
@@ -0,0 +1,973 @@
+package cmd
+
+// The qemu satellite provider — local full VMs on Linux workstations, the
+// counterpart of tart for hosts without Virtualization.framework. Like tart
+// there is no terraform: the provider downloads (once, into the shared image
+// cache) an Ubuntu cloud image for the host's arch, layers a per-satellite
+// qcow2 overlay on it, and boots it under qemu with a cloud-init NoCloud seed
+// that creates the guest user with the satellite's dedicated ed25519 key and
+// passwordless sudo. Networking is qemu user-mode (slirp): no bridge, no root,
+// just a hostfwd from a loopback port to the guest's :22.
+//
+// qemu facts this file relies on:
+//   - `-daemonize` forks after the VM is initialized and the parent exits 0,
+//     so a plain Run() returns once the VM is up (or failed to start), and
+//     `-pidfile` names the process that IS the VM.
+//   - the hostfwd listener accepts TCP before the guest's sshd is up (slirp
+//     answers for the guest), so a TCP probe proves nothing — readiness is
+//     ssh-keyscan returning the guest's key.
+//   - cloud-init generates the sshd host keys on first boot, so unlike tart
+//     clones every qemu satellite has its own; a re-up after `down` pins a
+//     new one.
+//   - KVM needs a readable+writable /dev/kvm; without it the provider falls
+//     back to TCG emulation (usable, slow) with a warning.
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/grovetools/core/pkg/paths"
+)
+
+// qemuSatelliteTarget is the infra target name the provider registers under.
+const qemuSatelliteTarget = "qemu"
+
+// qemuGuestUser is the login user the cloud-init seed creates.
+const qemuGuestUser = "grove"
+
+// qemuVMNamePrefix namespaces the VMs grove creates with qemu, apart from
+// the tart and docker ones.
+const qemuVMNamePrefix = "grove-qemu-"
+
+// qemuVMName names a satellite's VM (the qemu -name and the cloud-init
+// hostname/instance-id).
+func qemuVMName(satName string) string { return qemuVMNamePrefix + satName }
+
+// qemuProviderRef is the provider_ref state value for a qemu satellite
+// ("qemu:<vm-name>").
+func qemuProviderRef(vmName string) string { return qemuSatelliteTarget + ":" + vmName }
+
+// defaultQemuImageURL is the cloud image booted when neither --image nor
+// [satellites.<name>.infra] image is set: Ubuntu 24.04 for the guest arch
+// (cloud-init and sshd preinstalled, apt available for full-kind prep).
+const defaultQemuImageURL = "https://cloud-images.ubuntu.com/noble/current/noble-server-cloudimg-%s.img"
+
+// Guest sizing. Exec satellites get a fixed disk; full ones get the shared
+// verb's guest capacity budget on top of the OS, so the post-auth guest
+// capacity preflight passes on a freshly created disk.
+const (
+	qemuDefaultDiskBytes  = uint64(20 << 30)
+	qemuGuestOSAllowance  = uint64(4 << 30)
+	qemuDefaultMemoryMiB  = 4096
+	qemuDefaultMaxVCPUs   = 4
+	qemuSSHReadyTimeout   = 5 * time.Minute
+	qemuStopGraceTimeout  = 90 * time.Second
+	qemuImageFetchTimeout = 30 * time.Minute
+)
+
+// qemuUEFIFirmwareCandidates are the distro paths of the aarch64 UEFI build
+// the virt machine boots cloud images with (Debian/Ubuntu qemu-efi-aarch64,
+// Fedora edk2-aarch64, qemu's own bundled copy).
+var qemuUEFIFirmwareCandidates = []string{
+	"/usr/share/qemu-efi-aarch64/QEMU_EFI.fd",
+	"/usr/share/edk2/aarch64/QEMU_EFI.fd",
+	"/usr/share/qemu/edk2-aarch64-code.fd",
+}
+
+// qemuHostAvailableBytes reports the free bytes of the filesystem holding
+// dir (or its nearest existing ancestor). A var so tests can fake the host.
+var qemuHostAvailableBytes = statfsAvailableBytes
+
+// qemuSatelliteProvider runs local qemu/KVM VMs.
+type qemuSatelliteProvider struct {
+	target string
+	// Resolved by PrepareUp for Up.
+	binary   string // qemu-system-<arch>
+	accel    string // "kvm" or "tcg"
+	firmware string // aarch64 UEFI firmware; "" on amd64 (SeaBIOS)
+	seedTool string // cloud-localds, genisoimage, mkisofs or xorriso
+	image    string // base image URL or local path
+	digest   string // configured sha256 of a downloaded image; "" = SHA256SUMS
+}
+
+// newQemuSatelliteProvider is the registry constructor for the "qemu" target.
+func newQemuSatelliteProvider(target string) satelliteProvider {
+	return &qemuSatelliteProvider{target: target}
+}
+
+func (p *qemuSatelliteProvider) Kind() string { return p.target }
+
+// DefaultSatelliteKind: exec, like tart — --kind full asks for the stack.
+func (p *qemuSatelliteProvider) DefaultSatelliteKind() string { return satelliteKindExec }
+
+// UsesBootstrapScript: full runs the shared bootstrap after the prebuilt
+// install (Up wrote its startup-done sentinel); exec is client-side only.
+func (p *qemuSatelliteProvider) UsesBootstrapScript(kind string) bool {
+	return kind == satelliteKindFull
+}
+
+// DefaultPrebuiltTarget: the guest runs the host's arch (KVM does not
+// cross-virtualize), so the stack is built for linux/<host arch>.
+func (p *qemuSatelliteProvider) DefaultPrebuiltTarget() (string, error) {
+	if _, err := qemuSystemBinary(runtime.GOARCH); err != nil {
+		return "", err
+	}
+	return "linux/" + runtime.GOARCH, nil
+}
+
+// PrepareUp validates the host (Linux, qemu-system + qemu-img + a seed-ISO
+// tool on PATH, UEFI firmware on arm64, KVM or a TCG warning), resolves the
+// base image, and for --kind full checks the host filesystem against the
+// capacity plan's host budget. Read-only: nothing is downloaded or created.
+func (p *qemuSatelliteProvider) PrepareUp(opts *satelliteUpOptions) error {
+	if err := p.resolveHost(); err != nil {
+		return err
+	}
+	seedTool, err := qemuSeedTool()
+	if err != nil {
+		return err
+	}
+
+	image := opts.Infra.Image
+	if image == "" {
+		image = fmt.Sprintf(defaultQemuImageURL, runtime.GOARCH)
+	}
+	if !isQemuImageURL(image) {
+		image = expandUserPath(image)
+		if _, err := os.Stat(image); err != nil {
+			return fmt.Errorf("qemu base image %q: %w (pass an http(s) URL or a local qcow2/raw file)", opts.Infra.Image, err)
+		}
+	}
+	digest := strings.ToLower(strings.TrimPrefix(opts.Infra.ImageSHA256, "sha256:"))
+	if digest != "" && !isSHA256Hex(digest) {
+		return fmt.Errorf("[satellites.%s.infra] image_sha256 %q is not a hex sha256 digest", opts.Name, opts.Infra.ImageSHA256)
+	}
+
+	if opts.SatelliteKind == satelliteKindFull {
+		dir, err := qemuProviderDir(opts.Name)
+		if err != nil {
+			return err
+		}
+		required, err := opts.CapacityPlan.Host.RequiredBytes()
+		if err != nil {
+			return fmt.Errorf("full qemu host capacity preflight: %w", err)
+		}
+		available, err := qemuHostAvailableBytes(dir)
+		if err != nil {
+			return fmt.Errorf("full qemu host capacity preflight: %w", err)
+		}
+		if available < required {
+			return fmt.Errorf("full qemu host capacity preflight (before disk creation): insufficient headroom under %s: available=%d required=%d", dir, available, required)
+		}
+	}
+
+	p.seedTool, p.image, p.digest = seedTool, image, digest
+	return nil
+}
+
+// resolveHost checks what booting a VM needs on this host (Linux, qemu-system
+// and qemu-img on PATH, UEFI firmware on arm64) and picks the accelerator:
+// KVM, or TCG with a warning. Shared by PrepareUp and the snapshot paths that
+// restart a VM.
+func (p *qemuSatelliteProvider) resolveHost() error {
+	if runtime.GOOS != "linux" {
+		return fmt.Errorf("the %q satellite target needs a Linux host (qemu with KVM; this host is %s/%s — on Apple Silicon use --target tart)", p.target, runtime.GOOS, runtime.GOARCH)
+	}
+	binary, err := qemuSystemBinary(runtime.GOARCH)
+	if err != nil {
+		return err
+	}
+	for _, tool := range []string{binary, "qemu-img"} {
+		if _, err := exec.LookPath(tool); err != nil {
+			return fmt.Errorf("%s not found on PATH — install qemu (Debian/Ubuntu: `apt install qemu-system qemu-utils`): %w", tool, err)
+		}
+	}
+	firmware := ""
+	if runtime.GOARCH == "arm64" {
+		if firmware = firstExistingFile(qemuUEFIFirmwareCandidates); firmware == "" {
+			return fmt.Errorf("no aarch64 UEFI firmware found (looked in %s) — install qemu-efi-aarch64", strings.Join(qemuUEFIFirmwareCandidates, ", "))
+		}
+	}
+	accel := "kvm"
+	if f, err := os.OpenFile("/dev/kvm", os.O_RDWR, 0); err != nil {
+		accel = "tcg"
+		fmt.Printf("warning: /dev/kvm is not usable (%v) — the VM will run under TCG emulation, which is much slower (add yourself to the kvm group to fix)\n", err)
+	} else {
+		_ = f.Close()
+	}
+	p.binary, p.accel, p.firmware = binary, accel, firmware
+	return nil
+}
+
+// Up creates (or restarts) the satellite's VM and returns its endpoint. No
+// confirm prompt: a local VM costs nothing and `down` removes it. Steps:
+// base image (cached) → overlay disk + cloud-init seed → stamp provider_ref →
+// daemonized qemu with a loopback ssh forward → keyscan pin → auth → guest
+// prep → endpoint.
+func (p *qemuSatelliteProvider) Up(ctx context.Context, opts *satelliteUpOptions) (satelliteEndpoint, error) {
+	if p.image == "" {
+		return satelliteEndpoint{}, fmt.Errorf("qemu satellite provider: Up called without PrepareUp")
+	}
+	full := opts.SatelliteKind == satelliteKindFull
+	if opts.PostConfirm != nil {
+		if err := opts.PostConfirm(); err != nil {
+			return satelliteEndpoint{}, err
+		}
+	}
+
+	vmName := qemuVMName(opts.Name)
+	ref := qemuProviderRef(vmName)
+	dir, err := qemuProviderDir(opts.Name)
+	if err != nil {
+		return satelliteEndpoint{}, err
+	}
+	disk := filepath.Join(dir, "disk.qcow2")
+	keyPath, err := ensureSatelliteProviderKey(dir, vmName)
+	if err != nil {
+		return satelliteEndpoint{}, err
+	}
+
+	if _, statErr := os.Stat(disk); statErr == nil {
+		// A disk already exists: reuse it only when the state file says WE
+		// created it for this satellite (the tart ours-check).
+		entries, serr := loadSatelliteState()
+		if serr != nil || entries[opts.Name].ProviderRef != ref {
+			return satelliteEndpoint{}, fmt.Errorf(
+				"qemu disk %s exists but is not recorded as satellite %q's VM in the grove state file — refusing to adopt it (remove %s or pick another satellite name)",
+				disk, opts.Name, dir)
+		}
+		if entries[opts.Name].effectiveKind() != opts.SatelliteKind {
+			return satelliteEndpoint{}, fmt.Errorf("qemu VM %q is recorded as kind %q, not requested kind %q — refusing an in-place kind conversion; destroy and recreate it", vmName, entries[opts.Name].effectiveKind(), opts.SatelliteKind)
+		}
+	} else {
+		base, err := ensureQemuBaseImage(ctx, p.image, p.digest)
+		if err != nil {
+			return satelliteEndpoint{}, err
+		}
+		size := qemuDiskBytes(full, opts.CapacityPlan)
+		fmt.Printf("Creating local qemu VM %q (%d GiB overlay on %s)...\n", vmName, size>>30, filepath.Base(base))
+		if err := createQemuOverlay(base, disk, size); err != nil {
+			return satelliteEndpoint{}, err
+		}
+		if err := p.writeSeed(dir, vmName, keyPath); err != nil {
+			_ = os.Remove(disk)
+			return satelliteEndpoint{}, err
+		}
+		// Stamp provider_ref the moment the disk exists (the tart/docker
+		// partial-entry pattern): a failed later step leaves an entry the
+		// re-run's ours-check recognizes and `down` can clean up.
+		entries, serr := loadSatelliteState()
+		if serr != nil {
+			_ = os.Remove(disk)
+			return satelliteEndpoint{}, fmt.Errorf("load partial-up state after disk creation (disk removed): %w", serr)
+		}
+		stamp := entries[opts.Name]
+		stamp.ProviderRef = ref
+		stamp.Kind = opts.SatelliteKind
+		if err := upsertSatelliteState(opts.Name, stamp); err != nil {
+			_ = os.Remove(disk)
+			return satelliteEndpoint{}, fmt.Errorf("persist provider_ref immediately after disk creation (disk removed): %w", err)
+		}
+	}
+
+	port, running := qemuRunningPort(dir, vmName)
+	if running {
+		fmt.Printf("Local qemu VM %q is already running — reusing it.\n", vmName)
+	} else {
+		if port, err = p.startVM(dir, vmName); err != nil {
+			return satelliteEndpoint{}, err
+		}
+	}
+	addr := net.JoinHostPort("127.0.0.1", strconv.Itoa(port))
+
+	// The forward answers before sshd does; keyscan returning the guest's
+	// key is the readiness signal (first boot runs cloud-init first).
+	fmt.Printf("Waiting for %q to boot (console log: %s)...\n", vmName, filepath.Join(dir, "console.log"))
+	hostKey, err := sshKeyscanHostKey(addr)
+	if err != nil {
+		return satelliteEndpoint{}, fmt.Errorf("ssh-keyscan host-key pin of qemu VM %q (check the console log %s): %w", vmName, filepath.Join(dir, "console.log"), err)
+	}
+	tmpDir, err := os.MkdirTemp("", "grove-satellite-qemu-")
+	if err != nil {
+		return satelliteEndpoint{}, err
+	}
+	defer func() { _ = os.RemoveAll(tmpDir) }()
+	ssh, err := newSatelliteSSH(satelliteConfigEntry{
+		SSHAddr:      addr,
+		User:         qemuGuestUser,
+		HostKey:      hostKey,
+		IdentityFile: keyPath,
+	}, tmpDir)
+	if err != nil {
+		return satelliteEndpoint{}, err
+	}
+	if err := waitForSatelliteSSHAuth(ssh, qemuSSHReadyTimeout); err != nil {
+		return satelliteEndpoint{}, err
+	}
+	// The key is in before cloud-init finishes; let it settle so the prep
+	// below does not race its package and user modules.
+	_ = ssh.runCommand("cloud-init status --wait >/dev/null 2>&1 || true")
+	if err := ssh.runScript(sshGuestPrepScript(full)); err != nil {
+		return satelliteEndpoint{}, fmt.Errorf("guest prep of qemu VM %q: %w", vmName, err)
+	}
+
+	return satelliteEndpoint{
+		SSHAddr:      addr,
+		User:         qemuGuestUser,
+		IdentityFile: keyPath,
+		ProviderRef:  ref,
+	}, nil
+}
+
+// Down tears the VM down: destroy confirm, PostConfirm, graceful guest
+// poweroff over ssh (SIGTERM to qemu only when the guest is unreachable), and
+// removal of the provider dir (overlay disk, seed, key, logs). The base image
+// cache is shared across satellites and stays. State-entry removal is the
+// shared verb's job.
+func (p *qemuSatelliteProvider) Down(_ context.Context, opts *satelliteDownOptions) error {
+	dir, err := qemuProviderDir(opts.Name)
+	if err != nil {
+		return err
+	}
+	vmName := qemuVMName(opts.Name)
+	entry, hasEntry := loadMergedSatellites()[opts.Name]
+	if !opts.AssumeYes {
+		if err := confirmOrAbort(fmt.Sprintf("Delete local qemu VM %q (its disk under %s) and remove satellite %q's registry entry?", vmName, dir, opts.Name)); err != nil {
+			return err
+		}
+	}
+	if opts.PostConfirm != nil {
+		if err := opts.PostConfirm(); err != nil {
+			return err
+		}
+	}
+
+	if pid, ok := qemuVMPid(dir, vmName); ok {
+		if err := stopQemuVMGracefully(entry, hasEntry, vmName, pid); err != nil {
+			return err
+		}
+	}
+	if _, err := os.Stat(dir); err != nil {
+		fmt.Printf("(no local qemu VM %q — nothing to delete)\n", vmName)
+		return nil
+	}
+	if err := os.RemoveAll(dir); err != nil {
+		return fmt.Errorf("remove qemu VM files %s: %w", dir, err)
+	}
+	fmt.Printf("Local qemu VM %q deleted.\n", vmName)
+	return nil
+}
+
+// stopQemuVMGracefully powers the guest off over the pinned ssh transport
+// (`sync; sudo poweroff`) and waits for qemu to exit; an unreachable guest or
+// a poweroff that never completes gets SIGTERM, then SIGKILL.
+func stopQemuVMGracefully(entry satelliteConfigEntry, hasEntry bool, vmName string, pid int) error {
+	if hasEntry && entry.SSHAddr != "" && entry.HostKey != "" {
+		tmpDir, err := os.MkdirTemp("", "grove-satellite-qemu-down-")
+		if err != nil {
+			return err
+		}
+		defer func() { _ = os.RemoveAll(tmpDir) }()
+		if ssh, err := newSatelliteSSH(entry, tmpDir); err == nil {
+			if err := ssh.runCommand("sync"); err == nil {
+				fmt.Printf("Powering off guest %q gracefully (sync + poweroff over ssh)...\n", vmName)
+				_ = ssh.runCommand("sudo poweroff")
+				if waitForPidExit(pid, qemuStopGraceTimeout) {
+					return nil
+				}
+			}
+		}
+	}
+	fmt.Printf("warning: guest %q did not power off over ssh — stopping qemu (hard poweroff).\n", vmName)
+	_ = syscall.Kill(pid, syscall.SIGTERM)
+	if waitForPidExit(pid, 10*time.Second) {
+		return nil
+	}
+	if err := syscall.Kill(pid, syscall.SIGKILL); err != nil && !errors.Is(err, syscall.ESRCH) {
+		return fmt.Errorf("kill qemu VM %q (pid %d): %w", vmName, pid, err)
+	}
+	waitForPidExit(pid, 10*time.Second)
+	return nil
+}
+
+// --- snapshots (qcow2 internal snapshots of the overlay) ---
+
+// Snapshot takes a qcow2 internal snapshot of the overlay, tagged with the
+// label (the handle). qemu-img needs the disk's write lock, so a running VM
+// is powered off gracefully first and booted again afterwards; the base
+// image is immutable, so the overlay alone is the machine's state.
+func (p *qemuSatelliteProvider) Snapshot(_ context.Context, opts *satelliteSnapshotOptions) (string, error) {
+	err := p.withVMStopped(opts, func(disk string) error {
+		out, err := exec.Command("qemu-img", "snapshot", "-c", opts.Label, disk).CombinedOutput() //nolint:gosec // G204: validated label, state-dir path
+		if err != nil {
+			return fmt.Errorf("qemu-img snapshot -c %s: %w: %s", opts.Label, err, strings.TrimSpace(string(out)))
+		}
+		return nil
+	})
+	return opts.Label, err
+}
+
+// Restore reverts the overlay to the snapshot and boots the VM (stopping it
+// first when it runs). The forwarded port is reused when still free.
+func (p *qemuSatelliteProvider) Restore(_ context.Context, opts *satelliteSnapshotOptions) (satelliteEndpoint, error) {
+	if err := p.resolveHost(); err != nil {
+		return satelliteEndpoint{}, err
+	}
+	dir, disk, vmName, err := qemuSnapshotDisk(opts)
+	if err != nil {
+		return satelliteEndpoint{}, err
+	}
+	if pid, ok := qemuVMPid(dir, vmName); ok {
+		if err := stopQemuVMGracefully(opts.Entry, true, vmName, pid); err != nil {
+			return satelliteEndpoint{}, err
+		}
+	}
+	if out, err := exec.Command("qemu-img", "snapshot", "-a", opts.Handle, disk).CombinedOutput(); err != nil { //nolint:gosec // G204: recorded handle, state-dir path
+		return satelliteEndpoint{}, fmt.Errorf("qemu-img snapshot -a %s: %w: %s", opts.Handle, err, strings.TrimSpace(string(out)))
+	}
+	port, err := p.startVM(dir, vmName)
+	if err != nil {
+		return satelliteEndpoint{}, err
+	}
+	return satelliteEndpoint{
+		SSHAddr:      net.JoinHostPort("127.0.0.1", strconv.Itoa(port)),
+		User:         qemuGuestUser,
+		IdentityFile: filepath.Join(dir, "id_ed25519"),
+		ProviderRef:  qemuProviderRef(vmName),
+	}, nil
+}
+
+// DeleteSnapshot drops the internal snapshot. The snapshots live inside the
+// overlay, so once `down` removed the disk there is nothing left to delete.
+func (p *qemuSatelliteProvider) DeleteSnapshot(_ context.Context, opts *satelliteSnapshotOptions) error {
+	dir, err := qemuProviderDir(opts.Name)
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(filepath.Join(dir, "disk.qcow2")); os.IsNotExist(err) {
+		return nil
+	}
+	return p.withVMStopped(opts, func(disk string) error {
+		out, err := exec.Command("qemu-img", "snapshot", "-d", opts.Handle, disk).CombinedOutput() //nolint:gosec // G204: recorded handle, state-dir path
+		if err != nil && !strings.Contains(string(out), "not found") {
+			return fmt.Errorf("qemu-img snapshot -d %s: %w: %s", opts.Handle, err, strings.TrimSpace(string(out)))
+		}
+		return nil
+	})
+}
+
+// Stop powers a running VM off gracefully; the overlay stays for `up`.
+func (p *qemuSatelliteProvider) Stop(_ context.Context, name string, entry satelliteConfigEntry, _ satelliteInfraConfig) error {
+	dir, _, vmName, err := qemuSnapshotDisk(&satelliteSnapshotOptions{Name: name, Entry: entry})
+	if err != nil {
+		return err
+	}
+	pid, running := qemuVMPid(dir, vmName)
+	if !running {
+		return nil
+	}
+	return stopQemuVMGracefully(entry, true, vmName, pid)
+}
+
+// withVMStopped runs fn against the satellite's overlay with the VM powered
+// off, booting it again afterwards when it was running.
+func (p *qemuSatelliteProvider) withVMStopped(opts *satelliteSnapshotOptions, fn func(disk string) error) error {
+	dir, disk, vmName, err := qemuSnapshotDisk(opts)
+	if err != nil {
+		return err
+	}
+	pid, running := qemuVMPid(dir, vmName)
+	if running {
+		if err := p.resolveHost(); err != nil {
+			return err
+		}
+		if err := stopQemuVMGracefully(opts.Entry, true, vmName, pid); err != nil {
+			return err
+		}
+	}
+	opErr := fn(disk)
+	if running {
+		if _, err := p.startVM(dir, vmName); err != nil {
+			return errors.Join(opErr, err)
+		}
+	}
+	return opErr
+}
+
+// qemuSnapshotDisk resolves a satellite's provider dir, overlay and VM name,
+// requiring the overlay to exist.
+func qemuSnapshotDisk(opts *satelliteSnapshotOptions) (dir, disk, vmName string, err error) {
+	vmName = qemuVMName(opts.Name)
+	if strings.HasPrefix(opts.Entry.ProviderRef, qemuSatelliteTarget+":") {
+		vmName = strings.TrimPrefix(opts.Entry.ProviderRef, qemuSatelliteTarget+":")
+	}
+	if dir, err = qemuProviderDir(opts.Name); err != nil {
+		return "", "", "", err
+	}
+	disk = filepath.Join(dir, "disk.qcow2")
+	if _, err := os.Stat(disk); err != nil {
+		return "", "", "", fmt.Errorf("qemu VM %q has no disk (%s) — re-provision it with `grove satellite up %s --target qemu`", vmName, disk, opts.Name)
+	}
+	return dir, disk, vmName, nil
+}
+
+// --- qemu plumbing ---
+
+// qemuProviderDir is the provider's slice of the per-satellite state dir
+// (<StateDir>/satellites/<name>/qemu): overlay disk, seed ISO, dedicated
+// keypair, pid file, ssh port and console log.
+func qemuProviderDir(satName string) (string, error) {
+	return satelliteProviderStateDir(satName, qemuSatelliteTarget)
+}
+
+// qemuSystemBinary names the system emulator for a Go arch.
+func qemuSystemBinary(goarch string) (string, error) {
+	switch goarch {
+	case "amd64":
+		return "qemu-system-x86_64", nil
+	case "arm64":
+		return "qemu-system-aarch64", nil
+	}
+	return "", fmt.Errorf("the qemu satellite target supports amd64 and arm64 hosts, not %s", goarch)
+}
+
+// qemuSeedTool finds a tool that can write the cloud-init NoCloud seed ISO
+// (volume label "cidata").
+func qemuSeedTool() (string, error) {
+	for _, tool := range []string{"cloud-localds", "genisoimage", "mkisofs", "xorriso"} {
+		if _, err := exec.LookPath(tool); err == nil {
+			return tool, nil
+		}
+	}
+	return "", fmt.Errorf("no cloud-init seed ISO tool found on PATH — install cloud-image-utils (cloud-localds) or genisoimage")
+}
+
+// qemuSeedCommand builds the argv that writes seed from the user-data and
+// meta-data files with the given tool.
+func qemuSeedCommand(tool, seed, userData, metaData string) []string {
+	switch tool {
+	case "cloud-localds":
+		return []string{tool, seed, userData, metaData}
+	case "xorriso":
+		return []string{tool, "-as", "mkisofs", "-output", seed, "-volid", "cidata", "-joliet", "-rock", userData, metaData}
+	default: // genisoimage, mkisofs
+		return []string{tool, "-output", seed, "-volid", "cidata", "-joliet", "-rock", userData, metaData}
+	}
+}
+
+// qemuCloudInitUserData renders the NoCloud user-data: the guest user with
+// the satellite's key and passwordless sudo, and password auth off.
+func qemuCloudInitUserData(pubKey string) string {
+	return fmt.Sprintf(`#cloud-config
+users:
+  - name: %s
+    shell: /bin/bash
+    sudo: ALL=(ALL) NOPASSWD:ALL
+    lock_passwd: true
+    ssh_authorized_keys:
+      - %s
+ssh_pwauth: false
+`, qemuGuestUser, strings.TrimSpace(pubKey))
+}
+
+// qemuCloudInitMetaData renders the NoCloud meta-data.
+func qemuCloudInitMetaData(vmName string) string {
+	return fmt.Sprintf("instance-id: %s\nlocal-hostname: %s\n", vmName, vmName)
+}
+
+// writeSeed writes seed.iso into dir from the rendered cloud-init files.
+func (p *qemuSatelliteProvider) writeSeed(dir, vmName, keyPath string) error {
+	pub, err := os.ReadFile(keyPath + ".pub")
+	if err != nil {
+		return fmt.Errorf("read satellite public key: %w", err)
+	}
+	userData := filepath.Join(dir, "user-data")
+	metaData := filepath.Join(dir, "meta-data")
+	if err := os.WriteFile(userData, []byte(qemuCloudInitUserData(string(pub))), 0o644); err != nil {
+		return err
+	}
+	if err := os.WriteFile(metaData, []byte(qemuCloudInitMetaData(vmName)), 0o644); err != nil {
+		return err
+	}
+	argv := qemuSeedCommand(p.seedTool, filepath.Join(dir, "seed.iso"), userData, metaData)
+	if out, err := exec.Command(argv[0], argv[1:]...).CombinedOutput(); err != nil { //nolint:gosec // G204: state-dir paths
+		return fmt.Errorf("%s: %w: %s", p.seedTool, err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// qemuDiskBytes sizes the overlay's virtual disk: the default for exec, and
+// for full the guest capacity budget plus the OS, never below the default.
+func qemuDiskBytes(full bool, plan satelliteCapacityPlan) uint64 {
+	if !full {
+		return qemuDefaultDiskBytes
+	}
+	required, err := plan.Guest.RequiredBytes()
+	if err != nil {
+		return qemuDefaultDiskBytes
+	}
+	size := required + qemuGuestOSAllowance
+	if size < qemuDefaultDiskBytes {
+		size = qemuDefaultDiskBytes
+	}
+	// Round up to whole GiB.
+	return (size + (1 << 30) - 1) &^ ((1 << 30) - 1)
+}
+
+// createQemuOverlay creates disk as a qcow2 overlay backed by base. Cloud
+// images are small virtual disks, so the overlay is given the full size and
+// cloud-init's growpart expands the root filesystem on first boot.
+func createQemuOverlay(base, disk string, size uint64) error {
+	format, err := qemuImageFormat(base)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(disk), 0o755); err != nil {
+		return err
+	}
+	out, err := exec.Command("qemu-img", "create", "-f", "qcow2", "-F", format, "-b", base, disk, strconv.FormatUint(size, 10)).CombinedOutput() //nolint:gosec // G204: cache/state-dir paths
+	if err != nil {
+		return fmt.Errorf("qemu-img create %s: %w: %s", disk, err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// qemuImageFormat reads a base image's format ("qcow2", "raw", ...) from
+// `qemu-img info`.
+func qemuImageFormat(image string) (string, error) {
+	out, err := exec.Command("qemu-img", "info", "--output=json", image).Output() //nolint:gosec // G204: resolved image path
+	if err != nil {
+		return "", fmt.Errorf("qemu-img info %s: %w", image, err)
+	}
+	var info struct {
+		Format string `json:"format"`
+	}
+	if err := json.Unmarshal(out, &info); err != nil || info.Format == "" {
+		return "", fmt.Errorf("parse qemu-img info for %s: %v", image, err)
+	}
+	return info.Format, nil
+}
+
+// isQemuImageURL reports whether an image reference is downloaded rather than
+// a local file.
+func isQemuImageURL(image string) bool {
+	return strings.HasPrefix(image, "https://") || strings.HasPrefix(image, "http://")
+}
+
+// qemuImageCacheDir is the shared base-image cache. Images are never
+// modified (every satellite boots an overlay), so one download serves all.
+func qemuImageCacheDir() string {
+	return filepath.Join(paths.CacheDir(), "satellite-images")
+}
+
+// qemuCachedImagePath is where a URL's image is cached: a short hash of the
+// URL keeps "current" images from different releases apart.
+func qemuCachedImagePath(url string) string {
+	sum := sha256.Sum256([]byte(url))
+	return filepath.Join(qemuImageCacheDir(), hex.EncodeToString(sum[:6])+"-"+path.Base(url))
+}
+
+// ensureQemuBaseImage returns the local path of the base image, downloading
+// a URL into the cache on first use (temp file + rename, so an interrupted
+// download is never mistaken for a cached image). The download is checked
+// against digest, or when that is empty against the SHA256SUMS file published
+// next to the image (the Ubuntu/Debian cloud-image layout); an image that
+// cannot be verified is never cached.
+func ensureQemuBaseImage(ctx context.Context, image, digest string) (string, error) {
+	if !isQemuImageURL(image) {
+		return image, nil
+	}
+	dest := qemuCachedImagePath(image)
+	if _, err := os.Stat(dest); err == nil {
+		return dest, nil
+	}
+	if err := os.MkdirAll(filepath.Dir(dest), 0o755); err != nil {
+		return "", err
+	}
+	ctx, cancel := context.WithTimeout(ctx, qemuImageFetchTimeout)
+	defer cancel()
+	if digest == "" {
+		var err error
+		if digest, err = qemuPublishedImageDigest(ctx, image); err != nil {
+			return "", err
+		}
+	}
+	fmt.Printf("Downloading qemu base image %s (first use; cached under %s)...\n", image, filepath.Dir(dest))
+	resp, err := qemuHTTPGet(ctx, image)
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	tmp, err := os.CreateTemp(filepath.Dir(dest), "."+filepath.Base(dest)+".tmp-*")
+	if err != nil {
+		return "", err
+	}
+	tmpName := tmp.Name()
+	defer func() { _ = os.Remove(tmpName) }() // no-op after a successful rename
+	h := sha256.New()
+	if _, err := io.Copy(io.MultiWriter(tmp, h), resp.Body); err != nil {
+		_ = tmp.Close()
+		return "", fmt.Errorf("download %s: %w", image, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return "", err
+	}
+	if got := hex.EncodeToString(h.Sum(nil)); got != digest {
+		return "", fmt.Errorf("download %s: sha256 %s does not match the expected %s — refusing to cache it", image, got, digest)
+	}
+	if err := os.Rename(tmpName, dest); err != nil {
+		return "", err
+	}
+	return dest, nil
+}
+
+// qemuPublishedImageDigest looks the image up in the SHA256SUMS file of the
+// directory it is served from. A mirror without one needs
+// [satellites.<name>.infra] image_sha256 instead.
+func qemuPublishedImageDigest(ctx context.Context, image string) (string, error) {
+	sumsURL := image[:strings.LastIndex(image, "/")+1] + "SHA256SUMS"
+	resp, err := qemuHTTPGet(ctx, sumsURL)
+	if err != nil {
+		return "", fmt.Errorf("verify qemu base image: %w (set [satellites.<name>.infra] image_sha256 for images published without SHA256SUMS)", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	sums, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("download %s: %w", sumsURL, err)
+	}
+	digest, ok := sha256SumsLookup(string(sums), path.Base(image))
+	if !ok {
+		return "", fmt.Errorf("verify qemu base image: %s has no entry for %s (set [satellites.<name>.infra] image_sha256 to pin it)", sumsURL, path.Base(image))
+	}
+	return digest, nil
+}
+
+// qemuHTTPGet GETs url, treating any status but 200 as an error.
+func qemuHTTPGet(ctx context.Context, url string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("download %s: %w", url, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		_ = resp.Body.Close()
+		return nil, fmt.Errorf("download %s: %s", url, resp.Status)
+	}
+	return resp, nil
+}
+
+// sha256SumsLookup finds name's digest in sha256sum output ("<hex>  name",
+// or "<hex> *name" for binary mode).
+func sha256SumsLookup(sums, name string) (string, bool) {
+	for _, line := range strings.Split(sums, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 2 || strings.TrimPrefix(fields[1], "*") != name {
+			continue
+		}
+		if digest := strings.ToLower(fields[0]); isSHA256Hex(digest) {
+			return digest, true
+		}
+	}
+	return "", false
+}
+
+// isSHA256Hex reports whether s is a hex-encoded sha256 digest.
+func isSHA256Hex(s string) bool {
+	if len(s) != sha256.Size*2 {
+		return false
+	}
+	_, err := hex.DecodeString(s)
+	return err == nil
+}
+
+// qemuRunArgs builds the qemu-system argv (without the binary) for a VM whose
+// files live in dir, forwarding 127.0.0.1:port to the guest's :22.
+func qemuRunArgs(dir, vmName, accel, firmware string, port, cpus int) []string {
+	args := []string{"-name", vmName}
+	if firmware != "" {
+		args = append(args, "-machine", "virt", "-bios", firmware)
+	} else {
+		args = append(args, "-machine", "q35")
+	}
+	cpu := "max"
+	if accel == "kvm" {
+		cpu = "host"
+	}
+	args = append(args,
+		"-accel", accel,
+		"-cpu", cpu,
+		"-smp", strconv.Itoa(cpus),
+		"-m", strconv.Itoa(qemuDefaultMemoryMiB),
+		"-drive", "if=virtio,format=qcow2,file="+filepath.Join(dir, "disk.qcow2"),
+		"-drive", "if=virtio,format=raw,readonly=on,file="+filepath.Join(dir, "seed.iso"),
+		"-netdev", fmt.Sprintf("user,id=net0,hostfwd=tcp:127.0.0.1:%d-:22", port),
+		"-device", "virtio-net-pci,netdev=net0",
+		"-display", "none",
+		"-serial", "file:"+filepath.Join(dir, "console.log"),
+		"-pidfile", filepath.Join(dir, "qemu.pid"),
+		"-daemonize",
+	)
+	return args
+}
+
+// startVM boots the VM daemonized and returns its forwarded ssh port. The
+// port is persisted in dir and reused across restarts while it is free, so
+// the registry's ssh_addr stays valid; a port taken meanwhile is replaced.
+func (p *qemuSatelliteProvider) startVM(dir, vmName string) (int, error) {
+	portFile := filepath.Join(dir, "ssh-port")
+	port := 0
+	if b, err := os.ReadFile(portFile); err == nil {
+		if n, err := strconv.Atoi(strings.TrimSpace(string(b))); err == nil && localhostPortFree(n) {
+			port = n
+		}
+	}
+	if port == 0 {
+		n, err := pickFreeLocalhostPort()
+		if err != nil {
+			return 0, fmt.Errorf("pick a local ssh port for qemu VM %q: %w", vmName, err)
+		}
+		port = n
+	}
+	if err := os.WriteFile(portFile, []byte(strconv.Itoa(port)+"\n"), 0o644); err != nil {
+		return 0, err
+	}
+	cpus := runtime.NumCPU()
+	if cpus > qemuDefaultMaxVCPUs {
+		cpus = qemuDefaultMaxVCPUs
+	}
+	fmt.Printf("Starting local qemu VM %q (%s, ssh on 127.0.0.1:%d)...\n", vmName, p.accel, port)
+	out, err := exec.Command(p.binary, qemuRunArgs(dir, vmName, p.accel, p.firmware, port, cpus)...).CombinedOutput() //nolint:gosec // G204: internal args
+	if err != nil {
+		return 0, fmt.Errorf("start qemu VM %q: %w: %s", vmName, err, strings.TrimSpace(string(out)))
+	}
+	return port, nil
+}
+
+// qemuRunningPort returns the forwarded ssh port of a running VM.
+func qemuRunningPort(dir, vmName string) (int, bool) {
+	if _, ok := qemuVMPid(dir, vmName); !ok {
+		return 0, false
+	}
+	b, err := os.ReadFile(filepath.Join(dir, "ssh-port"))
+	if err != nil {
+		return 0, false
+	}
+	port, err := strconv.Atoi(strings.TrimSpace(string(b)))
+	if err != nil {
+		return 0, false
+	}
+	return port, true
+}
+
+// qemuVMPid returns the pid of the satellite's running qemu. A stale pid
+// file (the VM powered off, the pid reused) reads as not running: the live
+// process must be a qemu started with this VM's -name.
+func qemuVMPid(dir, vmName string) (int, bool) {
+	b, err := os.ReadFile(filepath.Join(dir, "qemu.pid"))
+	if err != nil {
+		return 0, false
+	}
+	pid, err := strconv.Atoi(strings.TrimSpace(string(b)))
+	if err != nil || pid <= 0 {
+		return 0, false
+	}
+	if err := syscall.Kill(pid, 0); err != nil && !errors.Is(err, syscall.EPERM) {
+		return 0, false
+	}
+	cmdline, err := os.ReadFile(filepath.Join("/proc", strconv.Itoa(pid), "cmdline"))
+	if err != nil {
+		return 0, false
+	}
+	for _, arg := range strings.Split(string(cmdline), "\x00") {
+		if arg == vmName {
+			return pid, true
+		}
+	}
+	return 0, false
+}
+
+// waitForPidExit polls until pid is gone.
+func waitForPidExit(pid int, timeout time.Duration) bool {
+	deadline := time.Now().Add(timeout)
+	for {
+		if err := syscall.Kill(pid, 0); errors.Is(err, syscall.ESRCH) {
+			return true
+		}
+		if time.Now().After(deadline) {
+			return false
+		}
+		time.Sleep(500 * time.Millisecond)
+	}
+}
+
+// localhostPortFree reports whether 127.0.0.1:port can be bound.
+func localhostPortFree(port int) bool {
+	l, err := net.Listen("tcp", net.JoinHostPort("127.0.0.1", strconv.Itoa(port)))
+	if err != nil {
+		return false
+	}
+	_ = l.Close()
+	return true
+}
+
+// firstExistingFile returns the first path that exists, or "".
+func firstExistingFile(candidates []string) string {
+	for _, c := range candidates {
+		if _, err := os.Stat(c); err == nil {
+			return c
+		}
+	}
+	return ""
+}
+
+// statfsAvailableBytes is the free space (for unprivileged users) of the
+// filesystem holding dir, walking up to the nearest existing ancestor.
+func statfsAvailableBytes(dir string) (uint64, error) {
+	for {
+		var st syscall.Statfs_t
+		err := syscall.Statfs(dir, &st)
+		if err == nil {
+			return uint64(st.Bavail) * uint64(st.Bsize), nil //nolint:gosec // G115: statfs block counts are non-negative
+		}
+		parent := filepath.Dir(dir)
+		if !os.IsNotExist(err) || parent == dir {
+			return 0, fmt.Errorf("statfs %s: %w", dir, err)
+		}
+		dir = parent
+	}
+}
+
+// qemuMachineState maps a satellite's provider dir to a machine_state: a live
+// qemu is running, a disk without one is stopped, no disk is absent.
+func qemuMachineState(satName, vmName string) string {
+	dir, err := qemuProviderDir(satName)
+	if err != nil {
+		return ""
+	}
+	if _, ok := qemuVMPid(dir, vmName); ok {
+		return satelliteMachineRunning
+	}
+	if _, err := os.Stat(filepath.Join(dir, "disk.qcow2")); err == nil {
+		return satelliteMachineStopped
+	}
+	return satelliteMachineAbsent
+}
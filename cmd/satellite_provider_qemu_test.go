@@ -0,0 +1,250 @@
+package cmd
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/grovetools/grove/pkg/satellitecontract"
+)
+
+// stubQemuOnPath puts fake qemu-system/qemu-img/cloud-localds binaries on
+// PATH, so PrepareUp tests never need a real qemu.
+func stubQemuOnPath(t *testing.T) {
+	t.Helper()
+	binary, err := qemuSystemBinary(runtime.GOARCH)
+	if err != nil {
+		t.Skip(err)
+	}
+	dir := t.TempDir()
+	for _, name := range []string{binary, "qemu-img", "cloud-localds"} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte("#!/bin/sh\nexit 0\n"), 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	t.Setenv("PATH", dir)
+}
+
+// TestQemuProviderRegistration pins the registry resolution and axis
+// defaults: exec by default, the shared bootstrap only for full, the host's
+// arch as the cross-build target, and the provider_ref scheme.
+func TestQemuProviderRegistration(t *testing.T) {
+	p, err := satelliteProviderFor("qemu")
+	if err != nil {
+		t.Fatalf("satelliteProviderFor(qemu): %v", err)
+	}
+	if p.Kind() != "qemu" {
+		t.Errorf("qemu Kind() = %q, want qemu", p.Kind())
+	}
+	if p.DefaultSatelliteKind() != satelliteKindExec {
+		t.Errorf("qemu DefaultSatelliteKind() = %q, want %q", p.DefaultSatelliteKind(), satelliteKindExec)
+	}
+	if p.UsesBootstrapScript(satelliteKindExec) || !p.UsesBootstrapScript(satelliteKindFull) {
+		t.Error("qemu UsesBootstrapScript should be true for full only")
+	}
+	if runtime.GOARCH == "amd64" || runtime.GOARCH == "arm64" {
+		if got, err := p.DefaultPrebuiltTarget(); err != nil || got != "linux/"+runtime.GOARCH {
+			t.Errorf("qemu DefaultPrebuiltTarget() = %q, %v; want linux/%s", got, err, runtime.GOARCH)
+		}
+	}
+	if got := qemuProviderRef(qemuVMName("mysat")); got != "qemu:grove-qemu-mysat" {
+		t.Errorf("qemuProviderRef = %q, want qemu:grove-qemu-mysat", got)
+	}
+	if !satelliteTargetPlansCapacity(qemuSatelliteTarget) || satelliteTargetPlansCapacity(dockerSatelliteTarget) {
+		t.Error("capacity planning should cover qemu and not docker")
+	}
+}
+
+// TestQemuPrepareUpPreflight pins the read-only host checks: missing qemu
+// tools, a missing local image, and a full-kind host budget the filesystem
+// cannot hold all fail before anything is created.
+func TestQemuPrepareUpPreflight(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("qemu target is Linux-only")
+	}
+	setupGroveHome(t)
+	p := &qemuSatelliteProvider{target: qemuSatelliteTarget}
+
+	t.Setenv("PATH", t.TempDir())
+	if err := p.PrepareUp(&satelliteUpOptions{Name: "vm"}); err == nil || !strings.Contains(err.Error(), "not found on PATH") {
+		t.Fatalf("missing-qemu preflight error = %v", err)
+	}
+
+	stubQemuOnPath(t)
+	local := filepath.Join(t.TempDir(), "base.qcow2")
+	err := p.PrepareUp(&satelliteUpOptions{Name: "vm", Infra: satelliteInfraConfig{Image: local}})
+	if err == nil || !strings.Contains(err.Error(), "local qcow2/raw file") {
+		t.Errorf("missing local image error = %v", err)
+	}
+	if err := os.WriteFile(local, []byte("img"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	plan := satelliteCapacityPlan{
+		Host:  satellitecontract.CapacityBudget{PayloadBytes: 10, GrowthBytes: 10, ReserveBytes: 10},
+		Guest: satellitecontract.CapacityBudget{PayloadBytes: 10},
+	}
+	old := qemuHostAvailableBytes
+	t.Cleanup(func() { qemuHostAvailableBytes = old })
+	qemuHostAvailableBytes = func(string) (uint64, error) { return 29, nil }
+	full := &satelliteUpOptions{Name: "vm", SatelliteKind: satelliteKindFull, CapacityPlan: plan, Infra: satelliteInfraConfig{Image: local}}
+	if err := p.PrepareUp(full); err == nil || !strings.Contains(err.Error(), "insufficient headroom") {
+		t.Errorf("low-space full preflight error = %v", err)
+	}
+	qemuHostAvailableBytes = func(string) (uint64, error) { return 30, nil }
+	if err := p.PrepareUp(full); err != nil {
+		t.Fatalf("PrepareUp: %v", err)
+	}
+	if p.image != local || p.seedTool != "cloud-localds" || (p.accel != "kvm" && p.accel != "tcg") {
+		t.Errorf("resolved provider = %+v", p)
+	}
+
+	if err := (&qemuSatelliteProvider{target: qemuSatelliteTarget}).PrepareUp(&satelliteUpOptions{Name: "vm"}); err != nil {
+		t.Fatalf("PrepareUp with the default image: %v", err)
+	}
+	if _, err := (&qemuSatelliteProvider{}).Up(t.Context(), &satelliteUpOptions{Name: "vm"}); err == nil || !strings.Contains(err.Error(), "without PrepareUp") {
+		t.Errorf("Up without PrepareUp = %v, want the guard error", err)
+	}
+}
+
+// TestQemuBaseImageVerified pins the download check: the image is cached
+// only when it matches the SHA256SUMS entry next to it or the configured
+// digest, and a mismatch or a missing entry leaves the cache empty.
+func TestQemuBaseImageVerified(t *testing.T) {
+	image := []byte("cloud image bytes")
+	sum := sha256.Sum256(image)
+	digest := hex.EncodeToString(sum[:])
+	sums := digest + " *good.img\n" + strings.Repeat("0", 64) + " *bad.img\n"
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/release/SHA256SUMS":
+			_, _ = w.Write([]byte(sums))
+		case "/release/good.img", "/release/bad.img", "/release/unlisted.img", "/nosums/pinned.img":
+			_, _ = w.Write(image)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+	t.Setenv("GROVE_HOME", t.TempDir())
+	ctx := context.Background()
+
+	got, err := ensureQemuBaseImage(ctx, srv.URL+"/release/good.img", "")
+	if err != nil {
+		t.Fatalf("verified download: %v", err)
+	}
+	if data, err := os.ReadFile(got); err != nil || string(data) != string(image) {
+		t.Errorf("cached image = %q, %v", data, err)
+	}
+	if _, err := ensureQemuBaseImage(ctx, srv.URL+"/release/bad.img", ""); err == nil || !strings.Contains(err.Error(), "does not match") {
+		t.Errorf("mismatched download error = %v", err)
+	}
+	if _, err := os.Stat(qemuCachedImagePath(srv.URL + "/release/bad.img")); !os.IsNotExist(err) {
+		t.Errorf("mismatched image was cached: %v", err)
+	}
+	if _, err := ensureQemuBaseImage(ctx, srv.URL+"/release/unlisted.img", ""); err == nil || !strings.Contains(err.Error(), "no entry") {
+		t.Errorf("unlisted download error = %v", err)
+	}
+	if _, err := ensureQemuBaseImage(ctx, srv.URL+"/nosums/pinned.img", ""); err == nil || !strings.Contains(err.Error(), "image_sha256") {
+		t.Errorf("download without SHA256SUMS error = %v", err)
+	}
+	if _, err := ensureQemuBaseImage(ctx, srv.URL+"/nosums/pinned.img", digest); err != nil {
+		t.Errorf("pinned download: %v", err)
+	}
+}
+
+// TestQemuRunArgs pins the VM invocation: loopback-only ssh forward,
+// daemonized with a pid file, the overlay and seed as virtio drives, and the
+// accelerator-matched CPU model.
+func TestQemuRunArgs(t *testing.T) {
+	args := strings.Join(qemuRunArgs("/s", "grove-sat-vm", "kvm", "", 2222, 2), " ")
+	for _, want := range []string{
+		"-name grove-sat-vm",
+		"-machine q35",
+		"-accel kvm -cpu host",
+		"file=/s/disk.qcow2",
+		"readonly=on,file=/s/seed.iso",
+		"hostfwd=tcp:127.0.0.1:2222-:22",
+		"-pidfile /s/qemu.pid",
+		"-daemonize",
+	} {
+		if !strings.Contains(args, want) {
+			t.Errorf("qemu args lack %q: %s", want, args)
+		}
+	}
+	arm := strings.Join(qemuRunArgs("/s", "grove-sat-vm", "tcg", "/fw.fd", 2222, 2), " ")
+	if !strings.Contains(arm, "-machine virt -bios /fw.fd") || !strings.Contains(arm, "-accel tcg -cpu max") {
+		t.Errorf("aarch64/tcg args = %s", arm)
+	}
+}
+
+func TestQemuCloudInit(t *testing.T) {
+	ud := qemuCloudInitUserData("ssh-ed25519 AAAA grove-sat-vm\n")
+	for _, want := range []string{"#cloud-config", "name: grove", "NOPASSWD:ALL", "- ssh-ed25519 AAAA grove-sat-vm\n", "ssh_pwauth: false"} {
+		if !strings.Contains(ud, want) {
+			t.Errorf("user-data lacks %q:\n%s", want, ud)
+		}
+	}
+	if md := qemuCloudInitMetaData("grove-sat-vm"); md != "instance-id: grove-sat-vm\nlocal-hostname: grove-sat-vm\n" {
+		t.Errorf("meta-data = %q", md)
+	}
+	for tool, want := range map[string]string{
+		"cloud-localds": "cloud-localds seed.iso ud md",
+		"genisoimage":   "genisoimage -output seed.iso -volid cidata -joliet -rock ud md",
+		"xorriso":       "xorriso -as mkisofs -output seed.iso -volid cidata -joliet -rock ud md",
+	} {
+		if got := strings.Join(qemuSeedCommand(tool, "seed.iso", "ud", "md"), " "); got != want {
+			t.Errorf("qemuSeedCommand(%s) = %q, want %q", tool, got, want)
+		}
+	}
+}
+
+// TestQemuDiskBytes: exec gets the default disk; full gets the guest budget
+// plus the OS allowance, rounded up to whole GiB and never below the default.
+func TestQemuDiskBytes(t *testing.T) {
+	if got := qemuDiskBytes(false, satelliteCapacityPlan{}); got != qemuDefaultDiskBytes {
+		t.Errorf("exec disk = %d", got)
+	}
+	small := satelliteCapacityPlan{Guest: satellitecontract.CapacityBudget{PayloadBytes: 1 << 30}}
+	if got := qemuDiskBytes(true, small); got != qemuDefaultDiskBytes {
+		t.Errorf("small full disk = %d, want the default", got)
+	}
+	big := satelliteCapacityPlan{Guest: satellitecontract.CapacityBudget{PayloadBytes: 30<<30 + 1}}
+	if got, want := qemuDiskBytes(true, big), uint64(35<<30); got != want {
+		t.Errorf("big full disk = %d, want %d", got, want)
+	}
+}
+
+// TestQemuMachineState: no disk is absent, a disk without a live qemu (or
+// with a stale pid file) is stopped.
+func TestQemuMachineState(t *testing.T) {
+	setupGroveHome(t)
+	if got := qemuMachineState("vm", "grove-sat-vm"); got != satelliteMachineAbsent {
+		t.Errorf("no disk: %q, want absent", got)
+	}
+	dir, err := qemuProviderDir("vm")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "disk.qcow2"), nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	// This test process is alive but is not the VM's qemu.
+	if err := os.WriteFile(filepath.Join(dir, "qemu.pid"), []byte(fmt.Sprintf("%d\n", os.Getpid())), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if got := qemuMachineState("vm", "grove-sat-vm"); got != satelliteMachineStopped {
+		t.Errorf("disk, stale pid: %q, want stopped", got)
+	}
+}
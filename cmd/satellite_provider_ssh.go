@@ -0,0 +1,266 @@
+package cmd
+
+// The ssh satellite provider — ADOPTS a machine that already runs sshd (a
+// spare Linux box, a homelab server, a local sshd container for tests)
+// instead of creating one. There is no terraform, no image and no
+// per-satellite key: the host is reached at the infra block's address as its
+// ssh_user, authenticating with identity_file or the ssh agent exactly like
+// the user's own ssh would.
+//
+// Up keyscans the host key, pins it (a re-up refuses a host key that differs
+// from the registry's — a reinstalled or spoofed host is never silently
+// re-trusted), verifies auth and passwordless sudo over the pinned
+// transport, and applies the minimal guest prep the other providers get from
+// their images or startup scripts: the grove bin dir on the login PATH, and
+// for --kind full the bootstrap's startup-done prerequisites. The shared `up`
+// verb then runs its prebuilt path (implied, like tart — an adopted host has
+// no toolchain the source bootstrap could rely on) and, for full, the shared
+// bootstrap.
+//
+// Down only DEREGISTERS: the host is not the provider's to destroy. Nothing
+// is removed from it; the shared verb drops the registry/state entry.
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// sshSatelliteTarget is the infra target name the provider registers under.
+const sshSatelliteTarget = "ssh"
+
+// sshDefaultPrebuiltTarget is the cross-build target when --prebuilt-target is
+// not given. The host's arch is only known once it is reachable over a pinned
+// transport, so Up checks it against the resolved target and names the flag
+// to pass on a mismatch.
+const sshDefaultPrebuiltTarget = "linux/amd64"
+
+// sshProviderRef is the provider_ref state value for an adopted host
+// ("ssh:<user>@<host>:<port>").
+func sshProviderRef(user, addr string) string {
+	return sshSatelliteTarget + ":" + user + "@" + addr
+}
+
+// sshSatelliteAddr normalizes the infra address to host:port, defaulting the
+// port to 22 (a bare IPv6 literal may be written with or without brackets).
+func sshSatelliteAddr(address string) (string, error) {
+	address = strings.TrimSpace(address)
+	if address == "" {
+		return "", fmt.Errorf("the ssh target needs the host to adopt — pass --address <host[:port]> or set [satellites.<name>.infra] address")
+	}
+	if host, port, err := net.SplitHostPort(address); err == nil {
+		if host == "" || port == "" {
+			return "", fmt.Errorf("ssh target address %q is missing a host or port", address)
+		}
+		return net.JoinHostPort(host, port), nil
+	}
+	return net.JoinHostPort(strings.Trim(address, "[]"), "22"), nil
+}
+
+// sshSatelliteProvider adopts existing sshd hosts.
+type sshSatelliteProvider struct {
+	target string
+	// addr is the normalized host:port, set by PrepareUp for Up.
+	addr string
+}
+
+// newSSHSatelliteProvider is the registry constructor for the "ssh" target.
+func newSSHSatelliteProvider(target string) satelliteProvider {
+	return &sshSatelliteProvider{target: target}
+}
+
+func (p *sshSatelliteProvider) Kind() string { return p.target }
+
+// DefaultSatelliteKind: an adopted host is an exec endpoint unless --kind full
+// asks for the whole stack (groved, syncd, note sync).
+func (p *sshSatelliteProvider) DefaultSatelliteKind() string { return satelliteKindExec }
+
+// UsesBootstrapScript: full runs the shared bootstrap after the prebuilt
+// install (Up wrote its startup-done sentinel); exec is provisioned
+// client-side only.
+func (p *sshSatelliteProvider) UsesBootstrapScript(kind string) bool {
+	return kind == satelliteKindFull
+}
+
+// DefaultPrebuiltTarget: see sshDefaultPrebuiltTarget.
+func (p *sshSatelliteProvider) DefaultPrebuiltTarget() (string, error) {
+	return sshDefaultPrebuiltTarget, nil
+}
+
+// PrepareUp validates the adoption inputs: an address and a login user.
+// Read-only — no connection is made before Up.
+func (p *sshSatelliteProvider) PrepareUp(opts *satelliteUpOptions) error {
+	if _, err := exec.LookPath("ssh"); err != nil {
+		return fmt.Errorf("ssh not found on PATH — the ssh target drives the OpenSSH client: %w", err)
+	}
+	addr, err := sshSatelliteAddr(opts.Infra.Address)
+	if err != nil {
+		return err
+	}
+	if opts.Infra.SSHUser == "" {
+		return fmt.Errorf("the ssh target needs the login user on the host — pass --ssh-user or set [satellites.%s.infra] ssh_user", opts.Name)
+	}
+	p.addr = addr
+	return nil
+}
+
+// Up adopts the host and returns its endpoint. No confirm prompt: nothing is
+// created or billed. Steps: reachability → host-key pin (checked against the
+// registry's on a re-up) → stamp provider_ref → auth + sudo + arch checks and
+// guest prep over the pinned transport → endpoint.
+func (p *sshSatelliteProvider) Up(ctx context.Context, opts *satelliteUpOptions) (satelliteEndpoint, error) {
+	if p.addr == "" {
+		return satelliteEndpoint{}, fmt.Errorf("ssh satellite provider: Up called without PrepareUp")
+	}
+	if opts.PostConfirm != nil {
+		if err := opts.PostConfirm(); err != nil {
+			return satelliteEndpoint{}, err
+		}
+	}
+	ref := sshProviderRef(opts.Infra.SSHUser, p.addr)
+
+	if err := waitForTCPPort(ctx, p.addr, 30*time.Second); err != nil {
+		if ctx.Err() != nil {
+			return satelliteEndpoint{}, err
+		}
+		return satelliteEndpoint{}, fmt.Errorf("ssh host %s is not reachable — is it up and running sshd? %w", p.addr, err)
+	}
+	hostKey, err := sshKeyscanHostKey(p.addr)
+	if err != nil {
+		return satelliteEndpoint{}, fmt.Errorf("ssh-keyscan host-key pin: %w", err)
+	}
+	existing := loadMergedSatellites()[opts.Name]
+	if existing.ProviderRef == ref && existing.HostKey != "" && existing.HostKey != hostKey {
+		return satelliteEndpoint{}, fmt.Errorf(
+			"ssh host %s presents a different host key than the one pinned for satellite %q — refusing to re-trust it (if the host was reinstalled, deregister it with `grove satellite down %s` and adopt it again)",
+			p.addr, opts.Name, opts.Name)
+	}
+
+	// Stamp provider_ref the moment the host is adopted (the tart/docker
+	// partial-entry pattern): a failed later step leaves an entry `down` can
+	// deregister. The shared verb overwrites it with the full entry.
+	if entries, serr := loadSatelliteState(); serr == nil {
+		stamp := entries[opts.Name]
+		stamp.ProviderRef = ref
+		stamp.Kind = opts.SatelliteKind
+		if err := upsertSatelliteState(opts.Name, stamp); err != nil {
+			fmt.Printf("warning: could not stamp the satellite state entry (a failed `up` leaves no entry for `down` to deregister): %v\n", err)
+		}
+	}
+
+	identityFile := ""
+	if opts.Infra.IdentityFile != "" {
+		identityFile = expandUserPath(opts.Infra.IdentityFile)
+	}
+	tmpDir, err := os.MkdirTemp("", "grove-satellite-ssh-")
+	if err != nil {
+		return satelliteEndpoint{}, err
+	}
+	defer func() { _ = os.RemoveAll(tmpDir) }()
+	ssh, err := newSatelliteSSH(satelliteConfigEntry{
+		SSHAddr:      p.addr,
+		User:         opts.Infra.SSHUser,
+		HostKey:      hostKey,
+		IdentityFile: identityFile,
+	}, tmpDir)
+	if err != nil {
+		return satelliteEndpoint{}, err
+	}
+	if err := ssh.runCommand("true"); err != nil {
+		return satelliteEndpoint{}, fmt.Errorf("ssh auth to %s failed — the host must accept your key for %q non-interactively (ssh-copy-id, or pass --identity-file): %w", ssh.dest(), opts.Infra.SSHUser, err)
+	}
+	uname, err := ssh.outputCommand("uname -sm", "")
+	if err != nil {
+		return satelliteEndpoint{}, fmt.Errorf("read the platform of %s: %w", ssh.dest(), err)
+	}
+	if err := checkSSHGuestPlatform(uname, opts.PrebuiltTarget); err != nil {
+		return satelliteEndpoint{}, err
+	}
+	fmt.Printf("Adopting ssh host %s as satellite %q...\n", ssh.dest(), opts.Name)
+	if err := ssh.runScript(sshGuestPrepScript(opts.SatelliteKind == satelliteKindFull)); err != nil {
+		return satelliteEndpoint{}, fmt.Errorf("guest prep on %s: %w", ssh.dest(), err)
+	}
+
+	return satelliteEndpoint{
+		SSHAddr:      p.addr,
+		User:         opts.Infra.SSHUser,
+		IdentityFile: opts.Infra.IdentityFile,
+		ProviderRef:  ref,
+	}, nil
+}
+
+// Down deregisters the satellite: confirm, PostConfirm, and nothing else —
+// the host and everything installed on it stay as they are. State-entry
+// removal is the shared verb's job.
+func (p *sshSatelliteProvider) Down(_ context.Context, opts *satelliteDownOptions) error {
+	host := "the host"
+	if entry, ok := loadMergedSatellites()[opts.Name]; ok && entry.SSHAddr != "" {
+		host = entry.SSHAddr
+	}
+	if !opts.AssumeYes {
+		if err := confirmOrAbort(fmt.Sprintf("Deregister satellite %q? (ssh host %s is left running and untouched)", opts.Name, host)); err != nil {
+			return err
+		}
+	}
+	if opts.PostConfirm != nil {
+		if err := opts.PostConfirm(); err != nil {
+			return err
+		}
+	}
+	fmt.Printf("Satellite %q deregistered; %s was not modified (the grove stack under %s stays installed).\n", opts.Name, host, satelliteUserBinDir)
+	return nil
+}
+
+// sshGuestPrepScript renders the adoption prep: it fails early without
+// passwordless sudo (the prebuilt install and bootstrap need it) and is
+// idempotent. Full mode installs the bootstrap's prerequisites (Debian/Ubuntu
+// hosts) and writes its startup-done sentinel LAST, like tart's full prep.
+func sshGuestPrepScript(full bool) string {
+	var b strings.Builder
+	b.WriteString(`set -eu
+sudo -n true 2>/dev/null || { echo "$(id -un) needs passwordless sudo on this host (the grove-syncd install and bootstrap use it)" >&2; exit 1; }
+# Exec-satellite guest prep (the gcp bootstrap's equivalents): the grove bin
+# dir the prebuilt install targets, and a login-shell PATH that includes it.
+mkdir -p "$HOME/.local/share/grove/bin"
+printf 'export PATH="$HOME/.local/share/grove/bin:$PATH"\n' | sudo tee /etc/profile.d/grove-satellite.sh >/dev/null
+`)
+	if full {
+		b.WriteString(`command -v apt-get >/dev/null || { echo "--kind full on the ssh target needs a Debian/Ubuntu host (apt-get)" >&2; exit 1; }
+sudo mkdir -p /var/lib/grove-satellite
+sudo rm -f /var/lib/grove-satellite/startup-done
+sudo env DEBIAN_FRONTEND=noninteractive apt-get update -qq
+sudo env DEBIAN_FRONTEND=noninteractive apt-get install -y -qq curl jq git ca-certificates
+sudo loginctl enable-linger "$(id -un)"
+printf 'full-ssh-v1\n' | sudo tee /var/lib/grove-satellite/startup-done >/dev/null
+`)
+	}
+	return b.String()
+}
+
+// checkSSHGuestPlatform compares the host's `uname -sm` with the cross-build
+// target. An empty target (no stack ships) always passes.
+func checkSSHGuestPlatform(uname, target string) error {
+	if target == "" {
+		return nil
+	}
+	fields := strings.Fields(uname)
+	if len(fields) != 2 {
+		return fmt.Errorf("could not read the host's platform from `uname -sm` output %q", strings.TrimSpace(uname))
+	}
+	goos := strings.ToLower(fields[0])
+	goarch := fields[1]
+	switch goarch {
+	case "x86_64":
+		goarch = "amd64"
+	case "aarch64":
+		goarch = "arm64"
+	}
+	if host := goos + "/" + goarch; host != target {
+		return fmt.Errorf("the host is %s but the stack would be cross-built for %s — re-run with --prebuilt-target %s", host, target, host)
+	}
+	return nil
+}
@@ -0,0 +1,168 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestSSHProviderRegistration pins the registry resolution and axis defaults:
+// "ssh" resolves to the adopting provider — exec by default, the shared
+// bootstrap only for full — and the provider_ref scheme.
+func TestSSHProviderRegistration(t *testing.T) {
+	p, err := satelliteProviderFor("ssh")
+	if err != nil {
+		t.Fatalf("satelliteProviderFor(ssh): %v", err)
+	}
+	if p.Kind() != "ssh" {
+		t.Errorf("ssh Kind() = %q, want ssh", p.Kind())
+	}
+	if p.DefaultSatelliteKind() != satelliteKindExec {
+		t.Errorf("ssh DefaultSatelliteKind() = %q, want %q", p.DefaultSatelliteKind(), satelliteKindExec)
+	}
+	if p.UsesBootstrapScript(satelliteKindExec) {
+		t.Error("ssh UsesBootstrapScript(exec) = true, want false")
+	}
+	if !p.UsesBootstrapScript(satelliteKindFull) {
+		t.Error("ssh UsesBootstrapScript(full) = false, want true")
+	}
+	if got := sshProviderRef("me", "192.168.1.20:22"); got != "ssh:me@192.168.1.20:22" {
+		t.Errorf("sshProviderRef = %q", got)
+	}
+	if got := satelliteProviderRefTarget(sshProviderRef("me", "[::1]:2222")); got != sshSatelliteTarget {
+		t.Errorf("provider_ref target = %q, want ssh", got)
+	}
+}
+
+func TestSSHSatelliteAddr(t *testing.T) {
+	for in, want := range map[string]string{
+		"192.168.1.20":     "192.168.1.20:22",
+		" homelab.lan ":    "homelab.lan:22",
+		"homelab.lan:2222": "homelab.lan:2222",
+		"::1":              "[::1]:22",
+		"[fd00::20]":       "[fd00::20]:22",
+		"[fd00::20]:2200":  "[fd00::20]:2200",
+		"127.0.0.1:55022":  "127.0.0.1:55022",
+	} {
+		got, err := sshSatelliteAddr(in)
+		if err != nil {
+			t.Errorf("sshSatelliteAddr(%q): %v", in, err)
+			continue
+		}
+		if got != want {
+			t.Errorf("sshSatelliteAddr(%q) = %q, want %q", in, got, want)
+		}
+	}
+	for _, bad := range []string{"", "  ", ":22"} {
+		if _, err := sshSatelliteAddr(bad); err == nil {
+			t.Errorf("sshSatelliteAddr(%q) accepted", bad)
+		}
+	}
+}
+
+// TestSSHPrepareUp pins the read-only input validation: address and ssh_user
+// are both required, and Up refuses to run without PrepareUp.
+func TestSSHPrepareUp(t *testing.T) {
+	t.Setenv("PATH", t.TempDir()) // empty dir: no ssh
+	p := &sshSatelliteProvider{target: sshSatelliteTarget}
+	err := p.PrepareUp(&satelliteUpOptions{Name: "homelab", Infra: satelliteInfraConfig{Address: "homelab.lan", SSHUser: "me"}})
+	if err == nil || !strings.Contains(err.Error(), "ssh not found on PATH") {
+		t.Fatalf("missing-ssh preflight error = %v", err)
+	}
+
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "ssh"), []byte("#!/bin/sh\nexit 0\n"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", dir)
+	err = p.PrepareUp(&satelliteUpOptions{Name: "homelab", Infra: satelliteInfraConfig{SSHUser: "me"}})
+	if err == nil || !strings.Contains(err.Error(), "--address") {
+		t.Errorf("PrepareUp without an address = %v, want the --address remediation", err)
+	}
+	err = p.PrepareUp(&satelliteUpOptions{Name: "homelab", Infra: satelliteInfraConfig{Address: "homelab.lan"}})
+	if err == nil || !strings.Contains(err.Error(), "--ssh-user") {
+		t.Errorf("PrepareUp without a user = %v, want the --ssh-user remediation", err)
+	}
+	if _, err := p.Up(t.Context(), &satelliteUpOptions{Name: "homelab"}); err == nil || !strings.Contains(err.Error(), "without PrepareUp") {
+		t.Errorf("Up without PrepareUp = %v, want the guard error", err)
+	}
+	if err := p.PrepareUp(&satelliteUpOptions{Name: "homelab", Infra: satelliteInfraConfig{Address: "homelab.lan", SSHUser: "me"}}); err != nil {
+		t.Fatalf("PrepareUp: %v", err)
+	}
+	if p.addr != "homelab.lan:22" {
+		t.Errorf("addr = %q, want homelab.lan:22", p.addr)
+	}
+}
+
+func TestCheckSSHGuestPlatform(t *testing.T) {
+	for _, tc := range []struct {
+		uname, target string
+		ok            bool
+	}{
+		{"Linux x86_64\n", "linux/amd64", true},
+		{"Linux aarch64\n", "linux/arm64", true},
+		{"Linux aarch64\n", "linux/amd64", false},
+		{"Darwin arm64\n", "linux/arm64", false},
+		{"garbage", "linux/amd64", false},
+		{"", "", true}, // nothing ships
+	} {
+		err := checkSSHGuestPlatform(tc.uname, tc.target)
+		if (err == nil) != tc.ok {
+			t.Errorf("checkSSHGuestPlatform(%q, %q) = %v, want ok=%v", tc.uname, tc.target, err, tc.ok)
+		}
+	}
+	err := checkSSHGuestPlatform("Linux aarch64", "linux/amd64")
+	if err == nil || !strings.Contains(err.Error(), "--prebuilt-target linux/arm64") {
+		t.Errorf("mismatch error = %v, want the flag remediation", err)
+	}
+}
+
+// TestSSHGuestPrepScript pins what adoption writes: the bin dir and login
+// PATH always, the bootstrap sentinel only for full — and nothing that
+// reconfigures sshd or authorized_keys on a host grove does not own.
+func TestSSHGuestPrepScript(t *testing.T) {
+	exec := sshGuestPrepScript(false)
+	for _, want := range []string{"sudo -n true", ".local/share/grove/bin", "/etc/profile.d/grove-satellite.sh"} {
+		if !strings.Contains(exec, want) {
+			t.Errorf("exec prep lacks %q", want)
+		}
+	}
+	if strings.Contains(exec, "startup-done") {
+		t.Error("exec prep writes the full-kind sentinel")
+	}
+	full := sshGuestPrepScript(true)
+	if !strings.Contains(full, "/var/lib/grove-satellite/startup-done") || !strings.Contains(full, "apt-get") {
+		t.Error("full prep lacks the bootstrap prerequisites")
+	}
+	if strings.Index(full, "tee /var/lib/grove-satellite/startup-done") < strings.Index(full, "apt-get install") {
+		t.Error("full prep writes startup-done before its prerequisites")
+	}
+	for _, script := range []string{exec, full} {
+		for _, forbidden := range []string{"sshd_config", "authorized_keys", "systemctl reload ssh"} {
+			if strings.Contains(script, forbidden) {
+				t.Errorf("guest prep touches %q", forbidden)
+			}
+		}
+	}
+}
+
+// TestSSHDownOnlyDeregisters: Down runs PostConfirm and succeeds without ssh
+// on PATH — it never reaches the host.
+func TestSSHDownOnlyDeregisters(t *testing.T) {
+	setupGroveHome(t)
+	t.Setenv("PATH", t.TempDir())
+	p := &sshSatelliteProvider{target: sshSatelliteTarget}
+	called := false
+	err := p.Down(t.Context(), &satelliteDownOptions{
+		Name:        "homelab",
+		AssumeYes:   true,
+		PostConfirm: func() error { called = true; return nil },
+	})
+	if err != nil {
+		t.Fatalf("Down: %v", err)
+	}
+	if !called {
+		t.Error("Down skipped PostConfirm")
+	}
+}
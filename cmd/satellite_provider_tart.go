@@ -27,6 +27,7 @@ package cmd
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net"
 	"os"
@@ -365,6 +366,150 @@ func stopTartVMGracefully(infra satelliteInfraConfig, entry satelliteConfigEntry
 	return nil
 }
 
+// Stop powers a running VM off gracefully. Full satellites are stopped too:
+// their unreturned records stay on the disk, which a stop keeps.
+func (p *tartSatelliteProvider) Stop(_ context.Context, name string, entry satelliteConfigEntry, infra satelliteInfraConfig) error {
+	vmName := tartVMName(name)
+	if strings.HasPrefix(entry.ProviderRef, tartSatelliteTarget+":") {
+		vmName = strings.TrimPrefix(entry.ProviderRef, tartSatelliteTarget+":")
+	}
+	vms, err := tartList(infra)
+	if err != nil {
+		return err
+	}
+	if vm := findTartVM(vms, vmName); vm == nil || !vm.Running {
+		return nil
+	}
+	return stopTartVMGracefully(infra, entry, true, vmName)
+}
+
+// --- snapshots (tart clone) ---
+
+// tartSnapshotVMName names a snapshot's clone. The prefix differs from
+// tartVMNamePrefix so a snapshot never reads as a satellite VM.
+func tartSnapshotVMName(satName, label string) string {
+	return "grove-snap-" + satName + "-" + label
+}
+
+// tartSnapshotVM resolves the satellite's recorded VM name, refusing full
+// satellites: their guest holds notebook records that only the record-return
+// path may discard, and a restore would roll them back silently.
+func tartSnapshotVM(opts *satelliteSnapshotOptions) (string, error) {
+	if !opts.Entry.isExec() {
+		return "", fmt.Errorf("satellite %q is a full Tart satellite — its guest holds unreturned notebook records, so snapshot/restore is limited to exec satellites", opts.Name)
+	}
+	if _, err := exec.LookPath("tart"); err != nil {
+		return "", fmt.Errorf("tart not found on PATH — install it with `brew install cirruslabs/cli/tart`: %w", err)
+	}
+	if strings.HasPrefix(opts.Entry.ProviderRef, tartSatelliteTarget+":") {
+		return strings.TrimPrefix(opts.Entry.ProviderRef, tartSatelliteTarget+":"), nil
+	}
+	return tartVMName(opts.Name), nil
+}
+
+// Snapshot clones the VM (a CoW copy in the same store). tart clones only a
+// stopped VM consistently, so a running one is powered off gracefully first
+// and started again afterwards.
+func (p *tartSatelliteProvider) Snapshot(ctx context.Context, opts *satelliteSnapshotOptions) (string, error) {
+	vmName, err := tartSnapshotVM(opts)
+	if err != nil {
+		return "", err
+	}
+	vms, err := tartList(opts.Infra)
+	if err != nil {
+		return "", err
+	}
+	vm := findTartVM(vms, vmName)
+	if vm == nil {
+		return "", fmt.Errorf("tart VM %q does not exist — re-provision it with `grove satellite up %s --target tart`", vmName, opts.Name)
+	}
+	snapName := tartSnapshotVMName(opts.Name, opts.Label)
+	if vm.Running {
+		if err := stopTartVMGracefully(opts.Infra, opts.Entry, true, vmName); err != nil {
+			return "", err
+		}
+	}
+	var cloneErr error
+	if out, err := tartCommand(opts.Infra, "clone", vmName, snapName).CombinedOutput(); err != nil {
+		cloneErr = fmt.Errorf("tart clone %s %s: %w: %s", vmName, snapName, err, strings.TrimSpace(string(out)))
+	}
+	if vm.Running {
+		if _, err := p.startSnapshotVM(ctx, opts, vmName); err != nil {
+			return "", errors.Join(cloneErr, err)
+		}
+	}
+	return snapName, cloneErr
+}
+
+// Restore replaces the VM with a clone of the snapshot and boots it. The
+// dedicated key and the image's host key are inside the clone, so the
+// pinned transport carries over; the IP is re-read.
+func (p *tartSatelliteProvider) Restore(ctx context.Context, opts *satelliteSnapshotOptions) (satelliteEndpoint, error) {
+	vmName, err := tartSnapshotVM(opts)
+	if err != nil {
+		return satelliteEndpoint{}, err
+	}
+	vms, err := tartList(opts.Infra)
+	if err != nil {
+		return satelliteEndpoint{}, err
+	}
+	if findTartVM(vms, opts.Handle) == nil {
+		return satelliteEndpoint{}, fmt.Errorf("snapshot VM %q is missing from the tart store (deleted out of band?)", opts.Handle)
+	}
+	if vm := findTartVM(vms, vmName); vm != nil {
+		if vm.Running {
+			if err := stopTartVMGracefully(opts.Infra, opts.Entry, true, vmName); err != nil {
+				return satelliteEndpoint{}, err
+			}
+		}
+		if out, err := tartCommand(opts.Infra, "delete", vmName).CombinedOutput(); err != nil {
+			return satelliteEndpoint{}, fmt.Errorf("tart delete %s: %w: %s", vmName, err, strings.TrimSpace(string(out)))
+		}
+	}
+	if out, err := tartCommand(opts.Infra, "clone", opts.Handle, vmName).CombinedOutput(); err != nil {
+		return satelliteEndpoint{}, fmt.Errorf("tart clone %s %s: %w: %s", opts.Handle, vmName, err, strings.TrimSpace(string(out)))
+	}
+	ip, err := p.startSnapshotVM(ctx, opts, vmName)
+	if err != nil {
+		return satelliteEndpoint{}, err
+	}
+	keyPath, err := ensureTartSatelliteKey(opts.Name)
+	if err != nil {
+		return satelliteEndpoint{}, err
+	}
+	return satelliteEndpoint{
+		SSHAddr:      ip + ":22",
+		User:         tartGuestUser,
+		IdentityFile: keyPath,
+		ProviderRef:  tartProviderRef(vmName),
+	}, nil
+}
+
+// DeleteSnapshot deletes the snapshot's clone.
+func (p *tartSatelliteProvider) DeleteSnapshot(_ context.Context, opts *satelliteSnapshotOptions) error {
+	vms, err := tartList(opts.Infra)
+	if err != nil {
+		return err
+	}
+	if findTartVM(vms, opts.Handle) == nil {
+		return nil
+	}
+	if out, err := tartCommand(opts.Infra, "delete", opts.Handle).CombinedOutput(); err != nil {
+		return fmt.Errorf("tart delete %s: %w: %s", opts.Handle, err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// startSnapshotVM boots the VM detached and returns its IP once sshd answers,
+// so `snapshot`/`restore` hand back a usable satellite.
+func (p *tartSatelliteProvider) startSnapshotVM(ctx context.Context, opts *satelliteSnapshotOptions, vmName string) (string, error) {
+	if err := p.startVM(&satelliteUpOptions{Name: opts.Name, Infra: opts.Infra}, vmName); err != nil {
+		return "", err
+	}
+	logPath, _ := tartRunLogPath(opts.Name)
+	return waitForTartVMSSHPort(ctx, opts.Infra, vmName, logPath, 2*time.Minute)
+}
+
 // --- tart CLI plumbing ---
 
 // tartHomeFlagHelp documents --tart-home, shared by up and down.
@@ -48,7 +48,7 @@ func TestSatelliteProviderFor(t *testing.T) {
 	if err == nil {
 		t.Fatal("unknown target resolved to a provider")
 	}
-	if !strings.Contains(err.Error(), `"vsphere"`) || !strings.Contains(err.Error(), "known providers: docker, gcp, tart") {
+	if !strings.Contains(err.Error(), `"vsphere"`) || !strings.Contains(err.Error(), "known providers: docker, gcp, qemu, ssh, tart") {
 		t.Errorf("unknown-target error does not name the target and list known providers: %v", err)
 	}
 }
@@ -0,0 +1,356 @@
+package cmd
+
+// `grove satellite reap` — the laptop-side reaper for forgotten satellites.
+//
+// Two per-satellite settings in [satellites.<name>] opt a satellite in:
+//
+//	idle_timeout = "2h"  # stop the machine after 2h without guest activity
+//	ttl = "7d"           # destroy the satellite 7 days after `up` created it
+//
+// Idle is judged on the guest over the pinned SSH transport: logged-in or
+// tmux sessions, the 1-minute load, and whether anything grove writes (its
+// state dir — job and session records — and the mirrored ecosystem checkout)
+// changed within the window. A machine booted less than a window ago is not
+// idle yet. An unreachable guest is never acted on: "cannot tell" is not
+// "idle". A stop keeps the disk, so `up` brings the satellite back; the ttl
+// destroy runs the `down` verb itself, record-return refusals included.
+//
+// Every action is recorded in satellites.json ("reaped", see
+// satellite_state.go). The verb is non-interactive and safe to run on a
+// schedule (cron, a launchd/systemd timer, or groved's periodic hook).
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/grovetools/core/cli"
+	"github.com/grovetools/core/config"
+	"github.com/spf13/cobra"
+)
+
+// Reap actions, recorded as satelliteReapRecord.Action.
+const (
+	satelliteReapStop    = "stop"
+	satelliteReapDestroy = "destroy"
+)
+
+// satelliteIdleLoadPerCPU is the 1-minute load per CPU at or above which a
+// guest counts as busy (a build or job running with nobody logged in).
+const satelliteIdleLoadPerCPU = 0.25
+
+// satelliteLifetimeConfig is the raw [satellites.<name>] idle_timeout/ttl
+// pair — decoded separately from satelliteConfigEntry, like the sync and
+// repos subtables; the daemon ignores both keys.
+type satelliteLifetimeConfig struct {
+	IdleTimeout string `yaml:"idle_timeout"`
+	TTL         string `yaml:"ttl"`
+}
+
+// satelliteReapPolicy is a satellite's parsed lifetime settings; a zero
+// duration is unset.
+type satelliteReapPolicy struct {
+	IdleTimeout time.Duration
+	TTL         time.Duration
+}
+
+func (p satelliteReapPolicy) enabled() bool { return p.IdleTimeout > 0 || p.TTL > 0 }
+
+// satelliteReapPoliciesFromConfig decodes every satellite's lifetime settings.
+// A malformed duration is an error naming its key.
+func satelliteReapPoliciesFromConfig(cfg *config.Config) (map[string]satelliteReapPolicy, error) {
+	var raw map[string]satelliteLifetimeConfig
+	if err := cfg.UnmarshalExtension("satellites", &raw); err != nil {
+		return nil, fmt.Errorf("parse [satellites]: %w", err)
+	}
+	policies := make(map[string]satelliteReapPolicy, len(raw))
+	for name, lc := range raw {
+		var policy satelliteReapPolicy
+		var err error
+		if policy.IdleTimeout, err = parseSatelliteLifetime(lc.IdleTimeout); err != nil {
+			return nil, fmt.Errorf("[satellites.%s] idle_timeout: %w", name, err)
+		}
+		if policy.TTL, err = parseSatelliteLifetime(lc.TTL); err != nil {
+			return nil, fmt.Errorf("[satellites.%s] ttl: %w", name, err)
+		}
+		if policy.enabled() {
+			policies[name] = policy
+		}
+	}
+	return policies, nil
+}
+
+// parseSatelliteLifetime parses a Go duration, plus whole days ("7d") since
+// ttls are naturally written in days. Empty is unset; zero or negative is
+// refused rather than read as "reap immediately".
+func parseSatelliteLifetime(value string) (time.Duration, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, nil
+	}
+	var d time.Duration
+	if days, ok := strings.CutSuffix(value, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil {
+			return 0, fmt.Errorf("invalid duration %q", value)
+		}
+		d = time.Duration(n) * 24 * time.Hour
+	} else {
+		var err error
+		if d, err = time.ParseDuration(value); err != nil {
+			return 0, err
+		}
+	}
+	if d <= 0 {
+		return 0, fmt.Errorf("duration %q must be positive", value)
+	}
+	return d, nil
+}
+
+// satelliteGuestActivity is what the guest probe reports.
+type satelliteGuestActivity struct {
+	Uptime   time.Duration
+	Sessions int
+	Load1    float64
+	CPUs     int
+	// Recent is a file changed within the idle window ("" = none).
+	Recent string
+}
+
+// satelliteActivityScript renders the guest probe for an idle window. It
+// prints key=value lines (parseSatelliteGuestActivity) and only reads.
+func satelliteActivityScript(window time.Duration, codeDirExpr string) string {
+	return fmt.Sprintf(`set -u
+echo "uptime=$(cut -d' ' -f1 /proc/uptime)"
+echo "sessions=$(( $(who | wc -l) + $(tmux list-sessions 2>/dev/null | wc -l) ))"
+echo "load=$(cut -d' ' -f1 /proc/loadavg)"
+echo "cpus=$(nproc)"
+since=$(( $(date +%%s) - %d ))
+echo "recent=$(find "$HOME/.local/state/grove" %s -xdev -type f ! -name '*.log' -newermt "@$since" -print -quit 2>/dev/null)"
+`, int64(window/time.Second), codeDirExpr)
+}
+
+func parseSatelliteGuestActivity(out string) (satelliteGuestActivity, error) {
+	var a satelliteGuestActivity
+	seen := map[string]bool{}
+	for _, line := range strings.Split(out, "\n") {
+		key, value, ok := strings.Cut(strings.TrimSpace(line), "=")
+		if !ok {
+			continue
+		}
+		seen[key] = true
+		var err error
+		switch key {
+		case "uptime":
+			var secs float64
+			secs, err = strconv.ParseFloat(value, 64)
+			a.Uptime = time.Duration(secs * float64(time.Second))
+		case "sessions":
+			a.Sessions, err = strconv.Atoi(value)
+		case "load":
+			a.Load1, err = strconv.ParseFloat(value, 64)
+		case "cpus":
+			a.CPUs, err = strconv.Atoi(value)
+		case "recent":
+			a.Recent = value
+		}
+		if err != nil {
+			return a, fmt.Errorf("guest activity probe: bad %s %q", key, value)
+		}
+	}
+	for _, key := range []string{"uptime", "sessions", "load", "cpus"} {
+		if !seen[key] {
+			return a, fmt.Errorf("guest activity probe printed no %s", key)
+		}
+	}
+	return a, nil
+}
+
+// busyReason says why the guest is not idle over window ("" = idle).
+func (a satelliteGuestActivity) busyReason(window time.Duration) string {
+	switch {
+	case a.Uptime < window:
+		return fmt.Sprintf("booted %s ago", a.Uptime.Round(time.Minute))
+	case a.Sessions > 0:
+		return fmt.Sprintf("%d login/tmux session(s)", a.Sessions)
+	case a.CPUs > 0 && a.Load1/float64(a.CPUs) >= satelliteIdleLoadPerCPU:
+		return fmt.Sprintf("load %.2f on %d CPU(s)", a.Load1, a.CPUs)
+	case a.Recent != "":
+		return fmt.Sprintf("recent activity (%s)", a.Recent)
+	}
+	return ""
+}
+
+// satelliteTTLExpired reports the ttl verdict for an entry: expired, or a
+// note when the clock cannot be read (an entry `up` stamped before ttl
+// support carries no created_at).
+func satelliteTTLExpired(policy satelliteReapPolicy, entry satelliteConfigEntry, now time.Time) (expired bool, reason string) {
+	if policy.TTL <= 0 {
+		return false, ""
+	}
+	if entry.CreatedAt.IsZero() {
+		return false, "ttl set but the creation time is unknown — re-run `grove satellite up` to record it"
+	}
+	age := now.Sub(entry.CreatedAt)
+	if age < policy.TTL {
+		return false, ""
+	}
+	return true, fmt.Sprintf("ttl %s expired (created %s)", policy.TTL, entry.CreatedAt.UTC().Format(time.DateTime+" MST"))
+}
+
+func newSatelliteReapCmd() *cobra.Command {
+	var dryRun bool
+	cmd := cli.NewStandardCommand("reap [name...]", "Stop idle satellites and destroy expired ones")
+	cmd.Long = `Apply each satellite's idle_timeout and ttl ([satellites.<name>] in the grove
+config) — by default to every satellite that sets one, or to the named ones.
+
+  idle_timeout  stop the machine once the guest has been idle that long: no
+                login or tmux sessions, low load, and nothing changed under
+                grove's state dir or the ecosystem checkout. The disk is
+                kept; 'grove satellite up' starts it again (gcp: start the
+                instance with gcloud first).
+  ttl           destroy the satellite that long after 'up' created it, via
+                'grove satellite down --yes' (full satellites with
+                unreturned notebook records are still refused).
+
+Durations are Go durations ("90m", "2h") or whole days ("7d"). A guest that
+cannot be reached is left alone. Adopted ssh hosts are never reaped. Each
+action is recorded under "reaped" in the satellite state file.
+
+Safe to run on a schedule; exits non-zero only when an action failed.`
+	cmd.SilenceUsage = true
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Report what would be stopped or destroyed without doing it")
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		cfg, err := config.LoadDefault()
+		if err != nil {
+			return fmt.Errorf("load grove config: %w", err)
+		}
+		policies, err := satelliteReapPoliciesFromConfig(cfg)
+		if err != nil {
+			return err
+		}
+		names := args
+		if len(names) == 0 {
+			for name := range policies {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+		}
+		if len(names) == 0 {
+			fmt.Println("No satellite sets idle_timeout or ttl — nothing to reap.")
+			return nil
+		}
+		var errs []error
+		for _, name := range names {
+			policy, ok := policies[name]
+			if !ok {
+				fmt.Printf("%s: no idle_timeout or ttl set — skipped\n", name)
+				continue
+			}
+			if err := reapSatellite(cmd.Context(), name, policy, dryRun); err != nil {
+				fmt.Printf("%s: %v\n", name, err)
+				errs = append(errs, fmt.Errorf("%s: %w", name, err))
+			}
+		}
+		return errors.Join(errs...)
+	}
+	return cmd
+}
+
+// reapSatellite applies one satellite's policy: the ttl first (a destroy
+// makes the idle check moot), then the idle stop.
+func reapSatellite(ctx context.Context, name string, policy satelliteReapPolicy, dryRun bool) error {
+	provider, entry, infra, err := resolveRecordedSatelliteProvider(name)
+	if err != nil {
+		return err
+	}
+	if provider.Kind() == sshSatelliteTarget {
+		fmt.Printf("%s: adopted ssh host — not reaped\n", name)
+		return nil
+	}
+
+	expired, reason := satelliteTTLExpired(policy, entry, time.Now())
+	if expired {
+		fmt.Printf("%s: %s — destroying\n", name, reason)
+		if dryRun {
+			return nil
+		}
+		down := newSatelliteDownCmd()
+		down.SetArgs([]string{name, "--yes"})
+		if err := down.ExecuteContext(ctx); err != nil {
+			return fmt.Errorf("ttl destroy: %w", err)
+		}
+		return recordSatelliteReapAction(name, satelliteReapDestroy, reason)
+	}
+	if reason != "" {
+		fmt.Printf("%s: %s\n", name, reason)
+	}
+	if policy.IdleTimeout <= 0 {
+		return nil
+	}
+
+	stopper, ok := provider.(satelliteStopper)
+	if !ok {
+		fmt.Printf("%s: the %q target cannot be stopped — idle_timeout ignored\n", name, provider.Kind())
+		return nil
+	}
+	switch probeSatelliteMachineStates(map[string]satelliteConfigEntry{name: entry})[name] {
+	case satelliteMachineStopped, satelliteMachineAbsent:
+		fmt.Printf("%s: not running\n", name)
+		return nil
+	}
+	activity, err := probeSatelliteGuestActivity(name, entry, policy.IdleTimeout)
+	if err != nil {
+		fmt.Printf("%s: guest unreachable, left alone (%v)\n", name, err)
+		return nil
+	}
+	if busy := activity.busyReason(policy.IdleTimeout); busy != "" {
+		fmt.Printf("%s: active — %s\n", name, busy)
+		return nil
+	}
+	reason = fmt.Sprintf("idle for idle_timeout %s (no sessions, load %.2f, no recent activity)", policy.IdleTimeout, activity.Load1)
+	fmt.Printf("%s: %s — stopping\n", name, reason)
+	if dryRun {
+		return nil
+	}
+	if err := stopper.Stop(ctx, name, entry, infra); err != nil {
+		return fmt.Errorf("idle stop: %w", err)
+	}
+	return recordSatelliteReapAction(name, satelliteReapStop, reason)
+}
+
+// probeSatelliteGuestActivity runs the activity probe over the pinned
+// transport.
+func probeSatelliteGuestActivity(name string, entry satelliteConfigEntry, window time.Duration) (satelliteGuestActivity, error) {
+	codeDir, err := resolveSatelliteCodeDir(name, "", false)
+	if err != nil {
+		codeDir = bootstrapRemoteCodeDir
+	}
+	tmpDir, err := os.MkdirTemp("", "grove-satellite-reap-")
+	if err != nil {
+		return satelliteGuestActivity{}, err
+	}
+	defer func() { _ = os.RemoveAll(tmpDir) }()
+	ssh, err := newSatelliteSSH(entry, tmpDir)
+	if err != nil {
+		return satelliteGuestActivity{}, err
+	}
+	out, err := ssh.outputScript(satelliteActivityScript(window, remoteCodeDirExpr(codeDir)))
+	if err != nil {
+		return satelliteGuestActivity{}, err
+	}
+	return parseSatelliteGuestActivity(out)
+}
+
+func recordSatelliteReapAction(name, action, reason string) error {
+	rec := &satelliteReapRecord{Action: action, Reason: reason, At: time.Now().UTC()}
+	if err := recordSatelliteReap(name, rec); err != nil {
+		return fmt.Errorf("record the %s in the satellite state file: %w", action, err)
+	}
+	return nil
+}
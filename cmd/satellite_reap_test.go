@@ -0,0 +1,170 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/grovetools/core/config"
+)
+
+func TestParseSatelliteLifetime(t *testing.T) {
+	for in, want := range map[string]time.Duration{
+		"":     0,
+		"90m":  90 * time.Minute,
+		" 2h ": 2 * time.Hour,
+		"7d":   7 * 24 * time.Hour,
+	} {
+		got, err := parseSatelliteLifetime(in)
+		if err != nil || got != want {
+			t.Errorf("parseSatelliteLifetime(%q) = %v, %v; want %v", in, got, err, want)
+		}
+	}
+	for _, bad := range []string{"0s", "-1h", "xd", "soon", "0d"} {
+		if _, err := parseSatelliteLifetime(bad); err == nil {
+			t.Errorf("parseSatelliteLifetime(%q) accepted", bad)
+		}
+	}
+}
+
+// TestSatelliteReapPoliciesFromConfig: only satellites that set a lifetime
+// get a policy, and a malformed value names its key.
+func TestSatelliteReapPoliciesFromConfig(t *testing.T) {
+	configDir := setupGroveHome(t)
+	write := func(content string) *config.Config {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(configDir, "grove.toml"), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		cfg, err := config.LoadFrom(t.TempDir())
+		if err != nil {
+			t.Fatal(err)
+		}
+		return cfg
+	}
+	policies, err := satelliteReapPoliciesFromConfig(write(`
+[satellites.cloud]
+idle_timeout = "2h"
+ttl = "7d"
+
+[satellites.plain]
+user = "me"
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(policies) != 1 || policies["cloud"] != (satelliteReapPolicy{IdleTimeout: 2 * time.Hour, TTL: 7 * 24 * time.Hour}) {
+		t.Errorf("policies = %+v", policies)
+	}
+	_, err = satelliteReapPoliciesFromConfig(write("[satellites.cloud]\nttl = \"forever\"\n"))
+	if err == nil || !strings.Contains(err.Error(), "[satellites.cloud] ttl") {
+		t.Errorf("malformed ttl error = %v", err)
+	}
+}
+
+func TestParseSatelliteGuestActivity(t *testing.T) {
+	a, err := parseSatelliteGuestActivity("uptime=7260.51\nsessions=0\nload=0.03\ncpus=4\nrecent=\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a.Uptime.Round(time.Second) != 7261*time.Second || a.Sessions != 0 || a.Load1 != 0.03 || a.CPUs != 4 || a.Recent != "" {
+		t.Errorf("activity = %+v", a)
+	}
+	if _, err := parseSatelliteGuestActivity("uptime=1\nload=0\n"); err == nil {
+		t.Error("a probe missing keys parsed")
+	}
+	if _, err := parseSatelliteGuestActivity("uptime=1\nsessions=x\nload=0\ncpus=1\n"); err == nil {
+		t.Error("a non-numeric session count parsed")
+	}
+}
+
+// TestSatelliteBusyReason pins the idle signals: a fresh boot, sessions,
+// load per CPU, and recent file activity each keep a guest alive.
+func TestSatelliteBusyReason(t *testing.T) {
+	window := time.Hour
+	idle := satelliteGuestActivity{Uptime: 3 * time.Hour, Load1: 0.2, CPUs: 4}
+	if got := idle.busyReason(window); got != "" {
+		t.Errorf("idle guest busy: %q", got)
+	}
+	for _, tc := range []struct {
+		mutate func(*satelliteGuestActivity)
+		want   string
+	}{
+		{func(a *satelliteGuestActivity) { a.Uptime = 10 * time.Minute }, "booted"},
+		{func(a *satelliteGuestActivity) { a.Sessions = 1 }, "session"},
+		{func(a *satelliteGuestActivity) { a.Load1 = 1.0 }, "load 1.00"},
+		{func(a *satelliteGuestActivity) { a.Recent = "/home/grove/code/x" }, "recent activity"},
+	} {
+		a := idle
+		tc.mutate(&a)
+		if got := a.busyReason(window); !strings.Contains(got, tc.want) {
+			t.Errorf("busyReason = %q, want %q", got, tc.want)
+		}
+	}
+	script := satelliteActivityScript(90*time.Minute, `"$HOME/code/grovetools"`)
+	if !strings.Contains(script, "- 5400 ))") || !strings.Contains(script, `"$HOME/code/grovetools" -xdev`) {
+		t.Errorf("activity script:\n%s", script)
+	}
+}
+
+func TestSatelliteTTLExpired(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	policy := satelliteReapPolicy{TTL: 24 * time.Hour}
+	if expired, _ := satelliteTTLExpired(policy, satelliteConfigEntry{CreatedAt: now.Add(-23 * time.Hour)}, now); expired {
+		t.Error("young satellite expired")
+	}
+	expired, reason := satelliteTTLExpired(policy, satelliteConfigEntry{CreatedAt: now.Add(-25 * time.Hour)}, now)
+	if !expired || !strings.Contains(reason, "ttl 24h0m0s expired") {
+		t.Errorf("old satellite: %v, %q", expired, reason)
+	}
+	if expired, reason := satelliteTTLExpired(policy, satelliteConfigEntry{}, now); expired || !strings.Contains(reason, "unknown") {
+		t.Errorf("no created_at: %v, %q", expired, reason)
+	}
+	if expired, reason := satelliteTTLExpired(satelliteReapPolicy{}, satelliteConfigEntry{}, now); expired || reason != "" {
+		t.Errorf("no ttl: %v, %q", expired, reason)
+	}
+}
+
+// TestSatelliteReapRecords: reap records survive entry writes (including the
+// entry's removal by `down`), and clearing one leaves the entries alone.
+func TestSatelliteReapRecords(t *testing.T) {
+	setupGroveHome(t)
+	created := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	if err := upsertSatelliteState("sat1", satelliteConfigEntry{SSHAddr: "203.0.113.7:22", HostKey: "k", CreatedAt: created}); err != nil {
+		t.Fatal(err)
+	}
+	rec := &satelliteReapRecord{Action: satelliteReapDestroy, Reason: "ttl 24h0m0s expired", At: created.Add(48 * time.Hour)}
+	if err := recordSatelliteReap("sat1", rec); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := removeSatelliteState("sat1"); err != nil {
+		t.Fatal(err)
+	}
+	sf, err := readSatelliteStateFile()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sf.Satellites) != 0 || sf.Reaped["sat1"] != *rec {
+		t.Errorf("state after down = %+v", sf)
+	}
+
+	if err := upsertSatelliteState("sat1", satelliteConfigEntry{SSHAddr: "203.0.113.8:22", CreatedAt: created}); err != nil {
+		t.Fatal(err)
+	}
+	if err := recordSatelliteReap("sat1", nil); err != nil {
+		t.Fatal(err)
+	}
+	sf, err = readSatelliteStateFile()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sf.Reaped) != 0 || !sf.Satellites["sat1"].CreatedAt.Equal(created) {
+		t.Errorf("state after clear = %+v", sf)
+	}
+	merged, _ := mergeSatelliteEntries(map[string]satelliteConfigEntry{"sat1": {User: "me"}}, sf.Satellites)
+	if !merged["sat1"].CreatedAt.Equal(created) {
+		t.Errorf("merge dropped created_at: %+v", merged["sat1"])
+	}
+}
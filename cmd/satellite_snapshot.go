@@ -0,0 +1,412 @@
+package cmd
+
+// `grove satellite snapshot` / `restore` — point-in-time copies of a
+// satellite's machine, taken and rolled back through the provider's optional
+// satelliteSnapshotter capability (docker: committed image, tart: cloned VM,
+// qemu: qcow2 internal snapshot, gcp: boot-disk snapshot).
+//
+// The provider owns the snapshot itself; grove records what it needs to roll
+// back in <StateDir>/satellites/<name>/snapshots.json: the provider handle and
+// the host key pinned when the snapshot was taken. A restored machine boots
+// with the snapshot's sshd keys, so restore re-scans the (possibly moved)
+// endpoint, checks it against that recorded key, and reconciles the registry
+// state entry before re-running the `repos push` delta — the snapshot's
+// checkouts are as old as the snapshot.
+//
+// `down` deletes a satellite's snapshots with it: they are billable (gcp) or
+// large (docker images, tart clones) and useless without the satellite.
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"time"
+
+	"github.com/grovetools/core/cli"
+	"github.com/spf13/cobra"
+)
+
+// satelliteSnapshotsFileName under the per-satellite state dir.
+const satelliteSnapshotsFileName = "snapshots.json"
+
+// satelliteSnapshotLabelPattern keeps labels valid inside every provider's
+// handle (docker tags, tart VM names, gcp resource names — all lowercase).
+var satelliteSnapshotLabelPattern = regexp.MustCompile(`^[a-z0-9]([a-z0-9-]{0,22}[a-z0-9])?$`)
+
+// satelliteSnapshotRecord is one snapshots.json entry.
+type satelliteSnapshotRecord struct {
+	Label    string `json:"label"`
+	Provider string `json:"provider"`
+	Handle   string `json:"handle"`
+	// HostKey is the satellite's pinned host key when the snapshot was
+	// taken — what the restored machine must present.
+	HostKey   string    `json:"host_key,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// satelliteSnapshotsFile is the on-disk JSON shape.
+type satelliteSnapshotsFile struct {
+	Snapshots []satelliteSnapshotRecord `json:"snapshots"`
+}
+
+func satelliteSnapshotsPath(name string) (string, error) {
+	dir, err := satelliteStateDir(name)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, satelliteSnapshotsFileName), nil
+}
+
+// loadSatelliteSnapshots reads a satellite's snapshot records, oldest first.
+// An absent file means no snapshots.
+func loadSatelliteSnapshots(name string) ([]satelliteSnapshotRecord, error) {
+	path, err := satelliteSnapshotsPath(name)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var sf satelliteSnapshotsFile
+	if err := json.Unmarshal(data, &sf); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+	sort.SliceStable(sf.Snapshots, func(i, j int) bool { return sf.Snapshots[i].CreatedAt.Before(sf.Snapshots[j].CreatedAt) })
+	return sf.Snapshots, nil
+}
+
+// writeSatelliteSnapshots persists the records atomically. No records
+// removes the file, so `down` can reap the empty state dir.
+func writeSatelliteSnapshots(name string, records []satelliteSnapshotRecord) error {
+	path, err := satelliteSnapshotsPath(name)
+	if err != nil {
+		return err
+	}
+	if len(records) == 0 {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return nil
+	}
+	data, err := json.MarshalIndent(satelliteSnapshotsFile{Snapshots: records}, "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(path, append(data, '\n'), 0o600)
+}
+
+// findSatelliteSnapshot returns the index of label in records, or -1.
+func findSatelliteSnapshot(records []satelliteSnapshotRecord, label string) int {
+	for i, r := range records {
+		if r.Label == label {
+			return i
+		}
+	}
+	return -1
+}
+
+// validateSatelliteSnapshotLabel rejects labels a provider handle cannot carry.
+func validateSatelliteSnapshotLabel(label string) error {
+	if !satelliteSnapshotLabelPattern.MatchString(label) {
+		return fmt.Errorf("invalid snapshot label %q: use 1-24 lowercase letters, digits and inner dashes", label)
+	}
+	return nil
+}
+
+// resolveSatelliteSnapshotter resolves the satellite's recorded provider and
+// its snapshot capability.
+func resolveSatelliteSnapshotter(name string) (satelliteSnapshotter, *satelliteSnapshotOptions, error) {
+	provider, entry, infra, err := resolveRecordedSatelliteProvider(name)
+	if err != nil {
+		return nil, nil, err
+	}
+	snapshotter, ok := provider.(satelliteSnapshotter)
+	if !ok {
+		return nil, nil, fmt.Errorf("the %q target does not support snapshots (satellite %q)", provider.Kind(), name)
+	}
+	return snapshotter, &satelliteSnapshotOptions{Name: name, Entry: entry, Infra: infra}, nil
+}
+
+func newSatelliteSnapshotCmd() *cobra.Command {
+	var (
+		list      bool
+		deleteArg string
+		assumeYes bool
+	)
+	cmd := cli.NewStandardCommand("snapshot <name> [label]", "Snapshot a satellite's machine")
+	cmd.Long = `Take a point-in-time snapshot of a satellite's machine, to roll back to
+with 'grove satellite restore'.
+
+  docker  the container is committed to a local image (paused, not stopped)
+  tart    the VM is stopped, cloned, and started again
+  qemu    the VM is stopped for a qcow2 internal snapshot, then started again
+  gcp     the boot disk is snapshotted while the VM runs (crash-consistent)
+
+The ssh target does not support snapshots: an adopted host is not grove's to
+roll back. Full tart satellites are refused too — a rollback would discard
+notebook records not yet returned to the laptop.
+
+The label defaults to the current UTC time (20060102-150405). Snapshots are
+deleted with the satellite by 'grove satellite down'.`
+	cmd.Args = cobra.RangeArgs(1, 2)
+	cmd.SilenceUsage = true
+	cmd.Flags().BoolVar(&list, "list", false, "List the satellite's snapshots")
+	cmd.Flags().StringVar(&deleteArg, "delete", "", "Delete the snapshot with this label")
+	cmd.Flags().BoolVar(&assumeYes, "yes", false, "Skip the delete confirmation prompt")
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		name := args[0]
+		records, err := loadSatelliteSnapshots(name)
+		if err != nil {
+			return err
+		}
+		if list {
+			if len(records) == 0 {
+				fmt.Printf("Satellite %q has no snapshots.\n", name)
+				return nil
+			}
+			for _, r := range records {
+				fmt.Printf("%-24s  %-6s  %s  %s\n", r.Label, r.Provider, r.CreatedAt.Local().Format(time.DateTime), r.Handle)
+			}
+			return nil
+		}
+
+		snapshotter, opts, err := resolveSatelliteSnapshotter(name)
+		if err != nil {
+			return err
+		}
+		if deleteArg != "" {
+			i := findSatelliteSnapshot(records, deleteArg)
+			if i < 0 {
+				return fmt.Errorf("satellite %q has no snapshot %q (see `grove satellite snapshot %s --list`)", name, deleteArg, name)
+			}
+			if !assumeYes {
+				if err := confirmOrAbort(fmt.Sprintf("Delete snapshot %q of satellite %q?", deleteArg, name)); err != nil {
+					return err
+				}
+			}
+			opts.Label, opts.Handle = records[i].Label, records[i].Handle
+			if err := snapshotter.DeleteSnapshot(cmd.Context(), opts); err != nil {
+				return err
+			}
+			if err := writeSatelliteSnapshots(name, append(records[:i:i], records[i+1:]...)); err != nil {
+				return err
+			}
+			fmt.Printf("Deleted snapshot %q of satellite %q.\n", deleteArg, name)
+			return nil
+		}
+
+		label := time.Now().UTC().Format("20060102-150405")
+		if len(args) == 2 {
+			label = args[1]
+		}
+		if err := validateSatelliteSnapshotLabel(label); err != nil {
+			return err
+		}
+		if findSatelliteSnapshot(records, label) >= 0 {
+			return fmt.Errorf("satellite %q already has a snapshot %q — pick another label or delete it with `grove satellite snapshot %s --delete %s`", name, label, name, label)
+		}
+		opts.Label = label
+		fmt.Printf("Snapshotting satellite %q as %q...\n", name, label)
+		handle, err := snapshotter.Snapshot(cmd.Context(), opts)
+		if err != nil {
+			return err
+		}
+		records = append(records, satelliteSnapshotRecord{
+			Label:     label,
+			Provider:  opts.Infra.Target,
+			Handle:    handle,
+			HostKey:   opts.Entry.HostKey,
+			CreatedAt: time.Now().UTC(),
+		})
+		if err := writeSatelliteSnapshots(name, records); err != nil {
+			return fmt.Errorf("snapshot %s was taken but could not be recorded (delete it by hand): %w", handle, err)
+		}
+		fmt.Printf("Snapshot %q of satellite %q taken (%s).\n", label, name, handle)
+		return nil
+	}
+	return cmd
+}
+
+func newSatelliteRestoreCmd() *cobra.Command {
+	var (
+		assumeYes bool
+		noRepos   bool
+		sourceDir string
+	)
+	cmd := cli.NewStandardCommand("restore <name> <label>", "Roll a satellite's machine back to a snapshot")
+	cmd.Long = `Roll a satellite's machine back to a snapshot taken with
+'grove satellite snapshot'. Everything written on the machine since the
+snapshot is lost.
+
+After the provider restores the machine, grove re-scans its SSH endpoint (the
+address or forwarded port may change), checks the host key against the one
+pinned when the snapshot was taken, updates the registry state entry, and
+hot-reloads the daemon's registry. It then re-runs the 'repos push' delta, so
+the mirrored checkouts catch up with the laptop (--no-repos skips it).`
+	cmd.Args = cobra.ExactArgs(2)
+	cmd.SilenceUsage = true
+	cmd.Flags().BoolVar(&assumeYes, "yes", false, "Skip the restore confirmation prompt")
+	cmd.Flags().BoolVar(&noRepos, "no-repos", false, "Skip the repo mirror push after the restore")
+	cmd.Flags().StringVar(&sourceDir, "source-dir", "", "Local ecosystem worktree root for the repo push (default: the go.work root above cwd)")
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		name, label := args[0], args[1]
+		records, err := loadSatelliteSnapshots(name)
+		if err != nil {
+			return err
+		}
+		i := findSatelliteSnapshot(records, label)
+		if i < 0 {
+			return fmt.Errorf("satellite %q has no snapshot %q (see `grove satellite snapshot %s --list`)", name, label, name)
+		}
+		record := records[i]
+		snapshotter, opts, err := resolveSatelliteSnapshotter(name)
+		if err != nil {
+			return err
+		}
+		if record.Provider != "" && record.Provider != opts.Infra.Target {
+			return fmt.Errorf("snapshot %q was taken by the %q target but satellite %q is now provisioned by %q — it cannot be restored", label, record.Provider, name, opts.Infra.Target)
+		}
+		if !assumeYes {
+			if err := confirmOrAbort(fmt.Sprintf("Restore satellite %q to snapshot %q? (changes made on it since %s are lost)", name, label, record.CreatedAt.Local().Format(time.DateTime))); err != nil {
+				return err
+			}
+		}
+		opts.Label, opts.Handle = record.Label, record.Handle
+		fmt.Printf("Restoring satellite %q to snapshot %q...\n", name, label)
+		endpoint, err := snapshotter.Restore(cmd.Context(), opts)
+		if err != nil {
+			return err
+		}
+
+		entry, err := reconcileRestoredSatellite(name, opts.Entry, record, endpoint)
+		if err != nil {
+			return err
+		}
+		tmpDir, err := os.MkdirTemp("", "grove-satellite-restore-")
+		if err != nil {
+			return err
+		}
+		defer func() { _ = os.RemoveAll(tmpDir) }()
+		ssh, err := newSatelliteSSH(entry, tmpDir)
+		if err != nil {
+			return err
+		}
+		if err := waitForSatelliteSSHAuth(ssh, 3*time.Minute); err != nil {
+			return err
+		}
+		if summary, ok := reloadDaemonSatelliteRegistry(); ok {
+			fmt.Printf("Daemon satellite registry hot-reloaded (%s).\n", formatReloadSummary(summary))
+		} else {
+			fmt.Println("Restart groved to pick up the restored endpoint.")
+		}
+
+		if !noRepos && !entry.Bare {
+			if err := pushRestoredSatelliteRepos(name, entry, sourceDir); err != nil {
+				fmt.Printf("warning: repo mirror failed: %v\n", err)
+				fmt.Printf("  retry with: grove satellite repos push %s\n", name)
+			}
+		}
+		fmt.Printf("Satellite %q restored to snapshot %q.\n", name, label)
+		return nil
+	}
+	return cmd
+}
+
+// reconcileRestoredSatellite pins the restored machine's endpoint in the
+// state entry. The restored disk carries the snapshot's sshd keys, so the
+// scanned key must be the one recorded with the snapshot; anything else is
+// refused rather than re-trusted.
+func reconcileRestoredSatellite(name string, entry satelliteConfigEntry, record satelliteSnapshotRecord, endpoint satelliteEndpoint) (satelliteConfigEntry, error) {
+	hostKey, err := sshKeyscanHostKey(endpoint.SSHAddr)
+	if err != nil {
+		return entry, fmt.Errorf("ssh-keyscan the restored satellite: %w", err)
+	}
+	if record.HostKey != "" && hostKey != record.HostKey {
+		return entry, fmt.Errorf("restored satellite %q at %s presents a different host key than the one pinned when snapshot %q was taken — refusing to re-trust it", name, endpoint.SSHAddr, record.Label)
+	}
+	if entries, err := loadSatelliteState(); err == nil {
+		if stored, ok := entries[name]; ok {
+			entry = stored
+		}
+	}
+	entry.SSHAddr = endpoint.SSHAddr
+	entry.HostKey = hostKey
+	if endpoint.User != "" {
+		entry.User = endpoint.User
+	}
+	if endpoint.IdentityFile != "" {
+		entry.IdentityFile = endpoint.IdentityFile
+	}
+	if endpoint.ProviderRef != "" {
+		entry.ProviderRef = endpoint.ProviderRef
+	}
+	if err := upsertSatelliteState(name, entry); err != nil {
+		return entry, fmt.Errorf("record the restored endpoint: %w", err)
+	}
+	return loadMergedSatellites()[name], nil
+}
+
+// pushRestoredSatelliteRepos re-runs the mirror `up` ships, non-interactively:
+// the same repo set and code dir `grove satellite repos push` resolves.
+func pushRestoredSatelliteRepos(name string, entry satelliteConfigEntry, sourceDir string) error {
+	reposCfg, err := loadSatelliteReposOptions(name)
+	if err != nil {
+		return err
+	}
+	syncCfg, err := loadSatelliteSyncOptions(name)
+	if err != nil {
+		return err
+	}
+	repos := resolveSatelliteMirrorRepos(nil, false, reposCfg, resolveSatelliteSyncWorkspaces(syncCfg, "", false))
+	if len(repos) == 0 {
+		return nil
+	}
+	codeDir, err := resolveRemoteCodeDir("", false, reposCfg.CodeDir)
+	if err != nil {
+		return err
+	}
+	if sourceDir == "" {
+		if sourceDir, err = defaultUpgradeSourceDir(); err != nil {
+			return err
+		}
+	}
+	sourceAbs, err := filepath.Abs(sourceDir)
+	if err != nil {
+		return fmt.Errorf("resolve --source-dir: %w", err)
+	}
+	fmt.Printf("\nMirroring %d repo(s) to %q...\n", len(repos), name)
+	return pushSatelliteReposOverSSH(name, entry, sourceAbs, codeDir, repos, false, false, true, false)
+}
+
+// deleteSatelliteSnapshots deletes every recorded snapshot of a satellite
+// being destroyed, best-effort: a failure is warned about with its handle and
+// its record kept, so a later `down` of the same name does not lose track of
+// billable storage.
+func deleteSatelliteSnapshots(ctx context.Context, name string, snapshotter satelliteSnapshotter, opts *satelliteSnapshotOptions) {
+	records, err := loadSatelliteSnapshots(name)
+	if err != nil {
+		fmt.Printf("warning: could not read satellite %q snapshots: %v\n", name, err)
+		return
+	}
+	var kept []satelliteSnapshotRecord
+	for _, r := range records {
+		opts.Label, opts.Handle = r.Label, r.Handle
+		if err := snapshotter.DeleteSnapshot(ctx, opts); err != nil {
+			fmt.Printf("warning: could not delete snapshot %q (%s): %v\n", r.Label, r.Handle, err)
+			kept = append(kept, r)
+			continue
+		}
+		fmt.Printf("Deleted snapshot %q.\n", r.Label)
+	}
+	if err := writeSatelliteSnapshots(name, kept); err != nil {
+		fmt.Printf("warning: could not update satellite %q snapshot records: %v\n", name, err)
+	}
+}
@@ -0,0 +1,92 @@
+package cmd
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestSatelliteSnapshotRecords: records round-trip oldest-first, and writing
+// none removes the file so `down` can reap the state dir.
+func TestSatelliteSnapshotRecords(t *testing.T) {
+	setupGroveHome(t)
+	if got, err := loadSatelliteSnapshots("vm"); err != nil || got != nil {
+		t.Fatalf("no file: %v, %v", got, err)
+	}
+	now := time.Now().UTC()
+	records := []satelliteSnapshotRecord{
+		{Label: "late", Provider: "docker", Handle: "h2", CreatedAt: now},
+		{Label: "early", Provider: "docker", Handle: "h1", HostKey: "ssh-ed25519 AAAA", CreatedAt: now.Add(-time.Hour)},
+	}
+	if err := writeSatelliteSnapshots("vm", records); err != nil {
+		t.Fatal(err)
+	}
+	got, err := loadSatelliteSnapshots("vm")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 2 || got[0].Label != "early" || got[0].HostKey != "ssh-ed25519 AAAA" || got[1].Handle != "h2" {
+		t.Errorf("round-trip = %+v", got)
+	}
+	if findSatelliteSnapshot(got, "late") != 1 || findSatelliteSnapshot(got, "missing") != -1 {
+		t.Error("findSatelliteSnapshot mismatch")
+	}
+
+	if err := writeSatelliteSnapshots("vm", nil); err != nil {
+		t.Fatal(err)
+	}
+	path, _ := satelliteSnapshotsPath("vm")
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("empty write left %s: %v", path, err)
+	}
+}
+
+func TestValidateSatelliteSnapshotLabel(t *testing.T) {
+	for _, ok := range []string{"a", "pre-upgrade", "20260101-120000", "x1"} {
+		if err := validateSatelliteSnapshotLabel(ok); err != nil {
+			t.Errorf("label %q rejected: %v", ok, err)
+		}
+	}
+	for _, bad := range []string{"", "-x", "x-", "Upper", "has_underscore", "has.dot", strings.Repeat("a", 25)} {
+		if err := validateSatelliteSnapshotLabel(bad); err == nil {
+			t.Errorf("label %q accepted", bad)
+		}
+	}
+}
+
+// TestSatelliteSnapshotCapability pins which targets snapshot: every
+// provider grove creates machines with, and not the adopting ssh target.
+func TestSatelliteSnapshotCapability(t *testing.T) {
+	for _, target := range []string{"gcp", tartSatelliteTarget, dockerSatelliteTarget, qemuSatelliteTarget} {
+		p, err := satelliteProviderFor(target)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, ok := p.(satelliteSnapshotter); !ok {
+			t.Errorf("%s provider does not implement satelliteSnapshotter", target)
+		}
+	}
+	p, err := satelliteProviderFor(sshSatelliteTarget)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := p.(satelliteSnapshotter); ok {
+		t.Error("ssh provider implements satelliteSnapshotter")
+	}
+}
+
+func TestSatelliteSnapshotHandles(t *testing.T) {
+	if got := dockerSnapshotImage("mysat", "pre"); got != "grove-satellite-snapshot:mysat-pre" {
+		t.Errorf("dockerSnapshotImage = %q", got)
+	}
+	if got := tartSnapshotVMName("mysat", "pre"); got != "grove-snap-mysat-pre" {
+		t.Errorf("tartSnapshotVMName = %q", got)
+	}
+	if got, err := gcpSnapshotName("mysat", "pre"); err != nil || got != "grove-mysat-pre" {
+		t.Errorf("gcpSnapshotName = %q, %v", got, err)
+	}
+	if _, err := gcpSnapshotName(strings.Repeat("s", 40), strings.Repeat("l", 24)); err == nil {
+		t.Error("gcpSnapshotName accepted a name longer than 63 characters")
+	}
+}
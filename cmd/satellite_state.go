@@ -19,6 +19,7 @@ import (
 	"os"
 	"path/filepath"
 	"sort"
+	"time"
 
 	"github.com/grovetools/core/pkg/paths"
 )
@@ -44,27 +45,49 @@ func satelliteStatePath() (string, error) {
 // yaml/toml tags the config path uses, so the merge code stays obvious.
 type satelliteStateFile struct {
 	Satellites map[string]satelliteConfigEntry `json:"satellites"`
+	// Reaped records why `grove satellite reap` last stopped or destroyed a
+	// satellite, keyed by name. It outlives a destroyed satellite's entry
+	// (the answer to "where did my VM go"); a successful `up` clears it. The
+	// daemon does not read the key.
+	Reaped map[string]satelliteReapRecord `json:"reaped,omitempty"`
 }
 
-// loadSatelliteState reads the state file. An absent file is the normal
-// fresh-machine case and yields an empty map; a read/parse failure is an
-// error (callers decide whether it is fatal — the merged read path degrades
-// with a warning, the write path self-heals).
-func loadSatelliteState() (map[string]satelliteConfigEntry, error) {
+// satelliteReapRecord is one satellites.json "reaped" entry.
+type satelliteReapRecord struct {
+	// Action is satelliteReapStop or satelliteReapDestroy.
+	Action string    `json:"action"`
+	Reason string    `json:"reason"`
+	At     time.Time `json:"at"`
+}
+
+// readSatelliteStateFile reads the whole state file; an absent file is empty.
+func readSatelliteStateFile() (satelliteStateFile, error) {
 	path, err := satelliteStatePath()
 	if err != nil {
-		return nil, err
+		return satelliteStateFile{}, err
 	}
 	data, err := os.ReadFile(path)
 	if err != nil {
 		if os.IsNotExist(err) {
-			return map[string]satelliteConfigEntry{}, nil
+			return satelliteStateFile{}, nil
 		}
-		return nil, err
+		return satelliteStateFile{}, err
 	}
 	var sf satelliteStateFile
 	if err := json.Unmarshal(data, &sf); err != nil {
-		return nil, fmt.Errorf("parse %s: %w", path, err)
+		return satelliteStateFile{}, fmt.Errorf("parse %s: %w", path, err)
+	}
+	return sf, nil
+}
+
+// loadSatelliteState reads the state file. An absent file is the normal
+// fresh-machine case and yields an empty map; a read/parse failure is an
+// error (callers decide whether it is fatal — the merged read path degrades
+// with a warning, the write path self-heals).
+func loadSatelliteState() (map[string]satelliteConfigEntry, error) {
+	sf, err := readSatelliteStateFile()
+	if err != nil {
+		return nil, err
 	}
 	if sf.Satellites == nil {
 		sf.Satellites = map[string]satelliteConfigEntry{}
@@ -74,8 +97,14 @@ func loadSatelliteState() (map[string]satelliteConfigEntry, error) {
 
 // writeSatelliteState atomically persists the full satellite map
 // (temp file + rename in the state dir, so a crash never leaves a
-// half-written satellites.json).
+// half-written satellites.json). The reap records ride along unchanged; an
+// unreadable file has none to keep.
 func writeSatelliteState(entries map[string]satelliteConfigEntry) error {
+	existing, _ := readSatelliteStateFile()
+	return writeSatelliteStateFile(satelliteStateFile{Satellites: entries, Reaped: existing.Reaped})
+}
+
+func writeSatelliteStateFile(sf satelliteStateFile) error {
 	path, err := satelliteStatePath()
 	if err != nil {
 		return err
@@ -83,10 +112,10 @@ func writeSatelliteState(entries map[string]satelliteConfigEntry) error {
 	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
 		return err
 	}
-	if entries == nil {
-		entries = map[string]satelliteConfigEntry{}
+	if sf.Satellites == nil {
+		sf.Satellites = map[string]satelliteConfigEntry{}
 	}
-	data, err := json.MarshalIndent(satelliteStateFile{Satellites: entries}, "", "  ")
+	data, err := json.MarshalIndent(sf, "", "  ")
 	if err != nil {
 		return err
 	}
@@ -141,6 +170,27 @@ func removeSatelliteState(name string) (bool, error) {
 	return true, writeSatelliteState(entries)
 }
 
+// recordSatelliteReap stores (rec != nil) or clears (rec == nil) a
+// satellite's reap record.
+func recordSatelliteReap(name string, rec *satelliteReapRecord) error {
+	sf, err := readSatelliteStateFile()
+	if err != nil {
+		return err
+	}
+	if rec == nil {
+		if _, ok := sf.Reaped[name]; !ok {
+			return nil
+		}
+		delete(sf.Reaped, name)
+	} else {
+		if sf.Reaped == nil {
+			sf.Reaped = map[string]satelliteReapRecord{}
+		}
+		sf.Reaped[name] = *rec
+	}
+	return writeSatelliteStateFile(sf)
+}
+
 // mergeSatelliteEntries merges the config view ([satellites.*] tables from the
 // layered grove config, dotfiles fragments included) with the state view
 // (satellites.json), per name per field — the same rule as the daemon's
@@ -209,6 +259,7 @@ func mergeSatelliteEntries(fromConfig, fromState map[string]satelliteConfigEntry
 		// merge silently strips the marker whenever a config-side table
 		// exists (e.g. the marker-tagged infra block `up` itself writes).
 		out.Bare = st.Bare
+		out.CreatedAt = st.CreatedAt
 		if out.User == "" {
 			out.User = st.User
 		}
@@ -18,6 +18,13 @@ const (
 	tartGuestReserve        = uint64(5 << 30)
 )
 
+// satelliteTargetPlansCapacity reports whether full satellites of the target
+// get a capacity plan: the local-VM targets whose disk lives on this host
+// (tart, qemu). Both budgets come from calculateFullTartCapacityPlan.
+func satelliteTargetPlansCapacity(target string) bool {
+	return target == tartSatelliteTarget || target == qemuSatelliteTarget
+}
+
 type satelliteCapacityPlan struct {
 	Host  satellitecontract.CapacityBudget
 	Guest satellitecontract.CapacityBudget
@@ -29,7 +36,7 @@ type satelliteCapacityPlan struct {
 func calculateFullTartCapacityPlan(sourceRoot string) (satelliteCapacityPlan, error) {
 	payload, err := regularFileBytes(sourceRoot)
 	if err != nil {
-		return satelliteCapacityPlan{}, fmt.Errorf("calculate full Tart payload size: %w", err)
+		return satelliteCapacityPlan{}, fmt.Errorf("calculate full satellite payload size: %w", err)
 	}
 	return satelliteCapacityPlan{
 		Host: satellitecontract.CapacityBudget{
@@ -78,17 +85,17 @@ func regularFileBytes(root string) (uint64, error) {
 func validateFullTartGuestCapacity(ssh *satelliteSSH, budget satellitecontract.CapacityBudget) error {
 	out, err := ssh.outputCommand("df -Pk / | awk 'NR==2 {print $4}'", "")
 	if err != nil {
-		return fmt.Errorf("full Tart guest capacity preflight: %w", err)
+		return fmt.Errorf("full guest capacity preflight: %w", err)
 	}
 	blocks, err := strconv.ParseUint(strings.TrimSpace(out), 10, 64)
 	if err != nil {
-		return fmt.Errorf("full Tart guest capacity preflight returned %q: %w", strings.TrimSpace(out), err)
+		return fmt.Errorf("full guest capacity preflight returned %q: %w", strings.TrimSpace(out), err)
 	}
 	if blocks > ^uint64(0)/1024 {
-		return fmt.Errorf("full Tart guest capacity preflight overflow")
+		return fmt.Errorf("full guest capacity preflight overflow")
 	}
 	if err := satellitecontract.ValidateGuestCapacity(blocks*1024, budget); err != nil {
-		return fmt.Errorf("full Tart guest capacity preflight: %w", err)
+		return fmt.Errorf("full guest capacity preflight: %w", err)
 	}
 	return nil
 }
@@ -1195,6 +1195,14 @@ func (s *satelliteSSH) execCommand(command string, tty bool) error {
 	return cmd.Run()
 }
 
+// remoteCommand builds, without starting, a non-interactive remote command
+// whose stdio the caller wires — the fleet exec streams or captures each
+// host's output. Cancelling ctx kills the local ssh.
+func (s *satelliteSSH) remoteCommand(ctx context.Context, command string) *exec.Cmd {
+	args := append(s.baseOptions(), "-p", s.port, s.dest(), command)
+	return exec.CommandContext(ctx, "ssh", args...) //nolint:gosec // G204: registry/flag-derived
+}
+
 // runCommand runs a single remote command, capturing output into the error.
 func (s *satelliteSSH) runCommand(command string) error {
 	args := append(s.baseOptions(), "-p", s.port, s.dest(), command)
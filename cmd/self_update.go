@@ -50,6 +50,7 @@ This command will download and replace the current grove binary with the latest
 	}
 
 	cmd.Flags().BoolVar(&useGH, "use-gh", false, "Use gh CLI for downloading (supports private repos)")
+	addInstallVerifyFlags(cmd)
 
 	return cmd
 }
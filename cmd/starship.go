@@ -8,6 +8,8 @@ import (
 	"github.com/grovetools/core/starship"
 	"github.com/grovetools/core/util/delegation"
 	"github.com/spf13/cobra"
+
+	"github.com/grovetools/grove/pkg/devlinks"
 )
 
 func newStarshipCmd() *cobra.Command {
@@ -28,6 +30,17 @@ func runAggregatedStarshipStatus(cmd *cobra.Command, args []string) error {
 	tools := []string{"flow", "notebook", "hooks"}
 
 	var outputs []string
+
+	// The active dev-link profile leads, so a shell running a swapped set of
+	// binaries is obvious
+	if config, err := devlinks.LoadConfig(); err == nil && config.ActiveProfile != "" {
+		segment := "dev:" + config.ActiveProfile
+		if config.ActiveProfileDrifted() {
+			segment += "*"
+		}
+		outputs = append(outputs, segment)
+	}
+
 	for _, tool := range tools {
 		// Check if tool exists
 		if _, err := exec.LookPath(tool); err != nil {
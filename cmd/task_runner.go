@@ -14,6 +14,7 @@ import (
 	"github.com/grovetools/core/config"
 	"github.com/grovetools/core/logging"
 	"github.com/grovetools/core/pkg/daemon"
+	"github.com/grovetools/core/pkg/paths"
 	"github.com/spf13/cobra"
 	"golang.org/x/term"
 
@@ -25,12 +26,17 @@ import (
 func addTaskFlags(cmd *cobra.Command) {
 	cmd.Flags().Bool("affected", false, "Only run on workspaces that are dirty or diverge from main, plus their dependents")
 	cmd.Flags().Bool("no-cache", false, "Ignore cached task results")
+	cmd.Flags().String("cache-mode", string(orch.CacheModeCommit), "Task cache key: 'commit' (HEAD + sibling commits, dirty trees never hit) or 'content' (hash of input files, dirty trees can hit)")
 	cmd.Flags().IntP("jobs", "j", runtime.NumCPU(), "Number of parallel workers")
+	cmd.Flags().String("schedule", "waves", "Ordering of dependent jobs: 'waves' (each wave waits for the previous one) or 'critical-path' (start jobs as soon as their own deps finish, longest path first)")
 	cmd.Flags().String("filter", "", "Glob pattern to include only matching projects")
 	cmd.Flags().String("exclude", "", "Comma-separated glob patterns to exclude projects")
 	cmd.Flags().Bool("fail-fast", false, "Stop immediately when one task fails")
 	cmd.Flags().Bool("dry-run", false, "Show what would run without executing")
 	cmd.Flags().BoolP("interactive", "i", false, "Keep TUI open after completion for inspection")
+	cmd.Flags().String("report", "", "Write result reports: junit=<path>,sarif=<path> (SARIF covers vet/lint findings)")
+	cmd.Flags().String("trace", "", "Write the run's timeline to this file as Chrome trace-event JSON and print a timing summary")
+	cmd.Flags().Bool("watch", false, "Keep running: rerun the changed workspaces and their dependents whenever files change (ignores bin/ and .git)")
 }
 
 // executeTaskWithCommand runs a raw command across workspaces using the orchestrator.
@@ -46,6 +52,10 @@ func executeTaskWithCommand(cmd *cobra.Command, verb string, rawCommand []string
 	failFast, _ := cmd.Flags().GetBool("fail-fast")
 	dryRun, _ := cmd.Flags().GetBool("dry-run")
 	interactive, _ := cmd.Flags().GetBool("interactive")
+	cacheMode, err := taskCacheMode(cmd)
+	if err != nil {
+		return err
+	}
 
 	projects, _, err := DiscoverTargetProjects()
 	if err != nil {
@@ -101,6 +111,7 @@ func executeTaskWithCommand(cmd *cobra.Command, verb string, rawCommand []string
 		Jobs:         jobs,
 		FailFast:     failFast,
 		RemoteExec:   true,
+		CacheMode:    cacheMode,
 	}, workspaces, taskJobs, configMap)
 
 	buildFailFast = failFast
@@ -115,14 +126,19 @@ func executeTaskWithCommand(cmd *cobra.Command, verb string, rawCommand []string
 			waves = orch.SortIntoWaves(taskJobs, configMap)
 			hasWaves = len(waves) > 1
 		}
-		return runTaskDryRun(opts, verb, taskJobs, waves, configMap, hasWaves)
+		return runTaskDryRun(opts, verb, taskJobs, waves, configMap, hasWaves, nil)
 	}
 
+	finishReport, err := reportRun(cmd, o, verb, []string{verb})
+	if err != nil {
+		return err
+	}
+	finishTrace := traceRun(cmd, o)
 	if opts.JSONOutput || !isTTY {
-		return runJSONTaskWaves(o, verb, waves)
+		return finishReport(finishTrace(runJSONTaskWaves(o, verb, waves)))
 	}
 
-	return runTuiTask(o, verb, taskJobs)
+	return finishReport(finishTrace(runTuiTask(o, verb, taskJobs)))
 }
 
 func executeTask(cmd *cobra.Command, verb string, strategy orch.ConcurrencyStrategy) error {
@@ -136,6 +152,14 @@ func executeTask(cmd *cobra.Command, verb string, strategy orch.ConcurrencyStrat
 	failFast, _ := cmd.Flags().GetBool("fail-fast")
 	dryRun, _ := cmd.Flags().GetBool("dry-run")
 	interactive, _ := cmd.Flags().GetBool("interactive")
+	cacheMode, err := taskCacheMode(cmd)
+	if err != nil {
+		return err
+	}
+	strategy, err = taskStrategy(cmd, strategy)
+	if err != nil {
+		return err
+	}
 
 	// --target is registered on the build command only; other verbs have no
 	// such flag and stay native.
@@ -191,6 +215,7 @@ func executeTask(cmd *cobra.Command, verb string, strategy orch.ConcurrencyStrat
 		FailFast:     failFast,
 		RemoteExec:   true,
 		Target:       target,
+		CacheMode:    cacheMode,
 	}, workspaces, taskJobs, configMap)
 
 	// Store flags in package vars for TUI/verbose callbacks
@@ -206,14 +231,37 @@ func executeTask(cmd *cobra.Command, verb string, strategy orch.ConcurrencyStrat
 			waves = orch.SortIntoWaves(taskJobs, configMap)
 			hasWaves = len(waves) > 1
 		}
-		return runTaskDryRun(opts, verb, taskJobs, waves, configMap, hasWaves)
+		return runTaskDryRun(opts, verb, taskJobs, waves, configMap, hasWaves, schedulePlan(o, taskJobs))
 	}
 
+	watch, err := watchEnabled(cmd)
+	if err != nil {
+		return err
+	}
+	finishTrace := traceRun(cmd, o)
+	if watch {
+		// No report here: watchEnabled rejects --report, as a watch session
+		// never finishes a run to report on.
+		return finishTrace(runTaskWatch(o, verb, taskJobs, opts.JSONOutput || !isTTY, func(jobs []orch.TaskJob) error {
+			if strategy == orch.StrategyCriticalPath {
+				return runJSONTaskWaves(o, verb, [][]orch.TaskJob{jobs})
+			}
+			return runJSONTaskWaves(o, verb, orch.SortIntoWaves(jobs, configMap))
+		}))
+	}
+	finishReport, err := reportRun(cmd, o, verb, []string{verb})
+	if err != nil {
+		return err
+	}
 	if opts.JSONOutput || !isTTY {
-		return runJSONTaskWaves(o, verb, waves)
+		if strategy == orch.StrategyCriticalPath {
+			// No wave barriers: everything goes to the scheduler at once.
+			waves = [][]orch.TaskJob{taskJobs}
+		}
+		return finishReport(finishTrace(runJSONTaskWaves(o, verb, waves)))
 	}
 
-	return runTuiTask(o, verb, taskJobs)
+	return finishReport(finishTrace(runTuiTask(o, verb, taskJobs)))
 }
 
 // makeVerbTaskJobs resolves the verb's command per workspace into TaskJobs and
@@ -240,7 +288,8 @@ func makeVerbTaskJobs(verb string, workspaces []string) ([]orch.TaskJob, map[str
 // newTaskOrchestrator wires the standard task orchestrator around the given
 // jobs: per-workspace bin/ dirs on PATH, the global daemon as state provider
 // and build queue (degrading to local state + local pool when unreachable),
-// and the derived import dep graph.
+// the derived import dep graph, and the [cache.remote] shared cache when
+// configured.
 func newTaskOrchestrator(options orch.OrchestratorOptions, workspaces []string, taskJobs []orch.TaskJob, configMap map[string]*config.Config) *orch.Orchestrator {
 	var binDirs []string
 	for _, wsPath := range workspaces {
@@ -258,15 +307,20 @@ func newTaskOrchestrator(options orch.OrchestratorOptions, workspaces []string,
 		stateProvider = &orch.LocalStateProvider{}
 	}
 
-	return &orch.Orchestrator{
+	if options.TaskSettings == nil {
+		options.TaskSettings = loadEcosystemTaskSettings()
+	}
+
+	return withRemoteCache(&orch.Orchestrator{
 		Options:       options,
 		RunOpts:       &orch.RunOptions{ExtraPathDirs: binDirs},
-		StateProvider: stateProvider,
+		StateProvider: withTaskCacheMode(options.CacheMode, stateProvider),
 		DaemonClient:  client,
 		BuildClient:   client,
 		Configs:       configMap,
 		DepGraph:      orch.DeriveWorkspaceBuildAfter(taskJobs, configMap),
-	}
+		Artifacts:     buildArtifactStore(),
+	})
 }
 
 // newLocalTaskOrchestrator is the daemon-optional sibling of
@@ -284,10 +338,85 @@ func newLocalTaskOrchestrator(options orch.OrchestratorOptions, workspaces []str
 	return &orch.Orchestrator{
 		Options:       options,
 		RunOpts:       &orch.RunOptions{ExtraPathDirs: binDirs},
-		StateProvider: &orch.LocalStateProvider{},
+		StateProvider: withTaskCacheMode(options.CacheMode, &orch.LocalStateProvider{}),
 		Configs:       configMap,
 		DepGraph:      orch.DeriveWorkspaceBuildAfter(taskJobs, configMap),
+		Artifacts:     buildArtifactStore(),
+	}
+}
+
+// loadEcosystemTaskSettings reads the ecosystem-level [tasks.<verb>] tables
+// (timeouts, retries, quarantine) from the layered grove config. A malformed
+// table is a warning: tasks then run with no policy, exactly as before.
+func loadEcosystemTaskSettings() map[string]orch.TaskVerbConfig {
+	cfg, err := config.LoadDefault()
+	if err != nil {
+		return nil
+	}
+	settings, err := orch.LoadTaskSettings(cfg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "warning: ignoring task settings: %v\n", err)
+		return nil
+	}
+	return settings
+}
+
+// taskStrategy applies --schedule to a command's default strategy. Only
+// dependency-ordered (wave-sorted) commands can switch to the critical-path
+// scheduler; flat verbs have no ordering to relax and stay flat.
+func taskStrategy(cmd *cobra.Command, strategy orch.ConcurrencyStrategy) (orch.ConcurrencyStrategy, error) {
+	f := cmd.Flags().Lookup("schedule")
+	if f == nil {
+		return strategy, nil
+	}
+	switch f.Value.String() {
+	case "", "waves":
+		return strategy, nil
+	case "critical-path":
+		if strategy == orch.StrategyWaveSorted {
+			return orch.StrategyCriticalPath, nil
+		}
+		return strategy, nil
+	default:
+		return "", fmt.Errorf("invalid --schedule %q (want waves or critical-path)", f.Value.String())
+	}
+}
+
+// schedulePlan predicts the critical-path schedule for a dry run, or returns
+// nil when the orchestrator is not using that strategy.
+func schedulePlan(o *orch.Orchestrator, jobs []orch.TaskJob) *orch.SchedulePlan {
+	if o.Options.Strategy != orch.StrategyCriticalPath {
+		return nil
+	}
+	plan := o.PlanSchedule(context.Background(), jobs)
+	return &plan
+}
+
+// taskCacheMode reads and validates the --cache-mode flag. Commands without
+// the flag get the default (commit) mode.
+func taskCacheMode(cmd *cobra.Command) (orch.CacheMode, error) {
+	f := cmd.Flags().Lookup("cache-mode")
+	if f == nil {
+		return orch.CacheModeCommit, nil
 	}
+	return orch.ParseCacheMode(f.Value.String())
+}
+
+// withTaskCacheMode wraps the state provider with the machine-wide
+// content-addressed task cache under --cache-mode content. The store lives in
+// the grove cache dir rather than with groved, so daemon-less runs
+// (LocalStateProvider) cache too.
+func withTaskCacheMode(mode orch.CacheMode, sp orch.StateProvider) orch.StateProvider {
+	if mode != orch.CacheModeContent {
+		return sp
+	}
+	return orch.NewContentStateProvider(sp, filepath.Join(paths.CacheDir(), "task-cache"))
+}
+
+// buildArtifactStore is the machine-wide snapshot store of build outputs that
+// a cached `grove build` restores from (bin/ wiped, worktree switched).
+func buildArtifactStore() *orch.ArtifactStore {
+	return &orch.ArtifactStore{Dir: filepath.Join(paths.CacheDir(), "artifacts")}
 }
 
 // BuildReposForTarget builds the named repos under sourceDir for target via
@@ -337,7 +466,7 @@ func buildReposForTarget(ctx context.Context, sourceDir string, repos []string,
 
 var taskUlog = logging.NewUnifiedLogger("grove-meta.task")
 
-func runTaskDryRun(opts cli.CommandOptions, verb string, jobs []orch.TaskJob, waves [][]orch.TaskJob, configMap map[string]*config.Config, hasWaves bool) error {
+func runTaskDryRun(opts cli.CommandOptions, verb string, jobs []orch.TaskJob, waves [][]orch.TaskJob, configMap map[string]*config.Config, hasWaves bool, plan *orch.SchedulePlan) error {
 	if opts.JSONOutput {
 		result := map[string]any{
 			"mode":  "dry-run",
@@ -345,6 +474,16 @@ func runTaskDryRun(opts cli.CommandOptions, verb string, jobs []orch.TaskJob, wa
 			"waves": len(waves),
 			"total": len(jobs),
 		}
+		if plan != nil {
+			result["schedule"] = map[string]any{
+				"strategy":             string(orch.StrategyCriticalPath),
+				"workers":              plan.Workers,
+				"critical_path":        plan.CriticalPath,
+				"critical_path_ms":     plan.CriticalPathTime.Milliseconds(),
+				"estimated_wall_clock": plan.WallClock.Milliseconds(),
+				"unknown_durations":    plan.Unknown,
+			}
+		}
 		if hasWaves {
 			waveData := make([][]string, len(waves))
 			for i, wave := range waves {
@@ -407,9 +546,37 @@ func runTaskDryRun(opts cli.CommandOptions, verb string, jobs []orch.TaskJob, wa
 		Field("waves", len(waves)).
 		Pretty(fmt.Sprintf("\nTotal: %d projects in %d wave(s)", len(jobs), len(waves))).
 		Emit()
+	if plan != nil {
+		printSchedulePlan(plan)
+	}
 	return nil
 }
 
+// printSchedulePlan reports the critical-path scheduler's prediction for a
+// dry run: the longest chain by estimated duration and the simulated
+// wall-clock time on the worker pool.
+func printSchedulePlan(plan *orch.SchedulePlan) {
+	var steps []string
+	for _, name := range plan.CriticalPath {
+		steps = append(steps, fmt.Sprintf("%s (%s)", name, plan.Estimates[name].Round(time.Second)))
+	}
+	taskUlog.Info("Critical path").
+		Field("path", plan.CriticalPath).
+		Field("critical_path_ms", plan.CriticalPathTime.Milliseconds()).
+		Pretty(fmt.Sprintf("\nCritical path: %s\n  = %s", strings.Join(steps, " → "), plan.CriticalPathTime.Round(time.Second))).
+		Emit()
+	note := ""
+	if plan.Unknown > 0 {
+		note = fmt.Sprintf(" (%d project(s) without history assumed %s each)", plan.Unknown, plan.Assumed.Round(time.Second))
+	}
+	taskUlog.Info("Schedule estimate").
+		Field("workers", plan.Workers).
+		Field("wall_clock_ms", plan.WallClock.Milliseconds()).
+		Field("unknown_durations", plan.Unknown).
+		Pretty(fmt.Sprintf("Estimated wall-clock with %d workers: %s%s", plan.Workers, plan.WallClock.Round(time.Second), note)).
+		Emit()
+}
+
 func runJSONTaskWaves(o *orch.Orchestrator, verb string, waves [][]orch.TaskJob) error {
 	type JSONResult struct {
 		Name     string `json:"name"`
@@ -421,6 +588,10 @@ func runJSONTaskWaves(o *orch.Orchestrator, verb string, waves [][]orch.TaskJob)
 		Error    string `json:"error,omitempty"`
 		Output   string `json:"output,omitempty"`
 		Cached   bool   `json:"cached,omitempty"`
+		Attempts int    `json:"attempts,omitempty"`
+		TimedOut bool   `json:"timed_out,omitempty"`
+		// Quarantined failures are reported but do not count as failed.
+		Quarantined bool `json:"quarantined,omitempty"`
 	}
 
 	var results []JSONResult
@@ -444,12 +615,18 @@ func runJSONTaskWaves(o *orch.Orchestrator, verb string, waves [][]orch.TaskJob)
 				Wave:     waveIdx + 1,
 				Duration: r.Duration.Round(time.Millisecond).String(),
 				Cached:   r.Cached,
+				Attempts: r.Attempts,
+				TimedOut: r.TimedOut,
 			}
 
 			if r.Skipped {
 				skipCount++
 				jr.Skipped = true
 				jr.Success = true
+			} else if r.Quarantined {
+				jr.Quarantined = true
+				jr.Error = r.Err.Error()
+				jr.Output = string(r.Output)
 			} else if r.Err != nil {
 				failCount++
 				jr.Success = false
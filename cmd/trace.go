@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	orch "github.com/grovetools/grove/pkg/orchestrator"
+)
+
+// traceSlowest is how many jobs the --trace summary lists.
+const traceSlowest = 10
+
+// traceRun attaches a recorder to o when --trace is set. The returned func
+// wraps the run's error: it writes the Chrome trace file and prints the
+// timing summary to stderr (keeping --json stdout clean), then hands the
+// run's error back. A trace that cannot be written never masks a run failure.
+func traceRun(cmd *cobra.Command, o *orch.Orchestrator) func(error) error {
+	path, _ := cmd.Flags().GetString("trace")
+	if path == "" {
+		return func(err error) error { return err }
+	}
+	rec := orch.NewTraceRecorder()
+	o.Trace = rec
+	return func(runErr error) error {
+		if err := writeOutputFile(path, rec.WriteChromeTrace); err != nil {
+			if runErr != nil {
+				fmt.Fprintf(os.Stderr, "warning: write trace: %v\n", err)
+				return runErr
+			}
+			return fmt.Errorf("write trace: %w", err)
+		}
+		printTraceSummary(os.Stderr, path, rec.Summary(traceSlowest))
+		return runErr
+	}
+}
+
+func printTraceSummary(out io.Writer, path string, sum orch.TraceSummary) {
+	fmt.Fprintf(out, "\nTrace written to %s (open in chrome://tracing or ui.perfetto.dev)\n", path)
+	if len(sum.Slowest) == 0 {
+		return
+	}
+	fmt.Fprintf(out, "\nSlowest jobs:\n")
+	w := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "  JOB\tWAVE\tTIME")
+	for _, jt := range sum.Slowest {
+		fmt.Fprintf(w, "  %s\t%d\t%s\n", jt.Job, jt.Wave, roundTraceDuration(jt.Duration))
+	}
+	w.Flush()
+
+	fmt.Fprintf(out, "\nWorker utilization (%d workers):\n", sum.Workers)
+	w = tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "  WAVE\tJOBS\tSPAN\tBUSY\tIDLE")
+	for _, wv := range sum.Waves {
+		fmt.Fprintf(w, "  %d\t%d\t%s\t%s\t%s\n", wv.Wave, wv.Jobs,
+			roundTraceDuration(wv.Span), roundTraceDuration(wv.Busy), roundTraceDuration(wv.Idle))
+	}
+	fmt.Fprintf(w, "  total\t\t%s\t\t%s\n", roundTraceDuration(sum.Total), roundTraceDuration(sum.Idle))
+	w.Flush()
+}
+
+func roundTraceDuration(d time.Duration) time.Duration {
+	if d >= time.Second {
+		return d.Round(100 * time.Millisecond)
+	}
+	return d.Round(time.Millisecond)
+}
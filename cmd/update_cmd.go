@@ -32,6 +32,7 @@ If no tools are specified, updates grove itself.`,
 	}
 
 	cmd.Flags().BoolVar(&useGH, "use-gh", false, "Use gh CLI for downloading (supports private repos)")
+	addInstallVerifyFlags(cmd)
 
 	return cmd
 }
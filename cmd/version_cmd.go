@@ -105,7 +105,7 @@ func runVersionList(cmd *cobra.Command, args []string) error {
 	opts := cli.GetOptions(cmd)
 
 	// Create SDK manager
-	manager, err := sdk.NewManager()
+	manager, err := newSDKManager()
 	if err != nil {
 		return fmt.Errorf("failed to create SDK manager: %w", err)
 	}
@@ -237,7 +237,7 @@ func runVersionUse(cmd *cobra.Command, args []string) error {
 	}
 
 	// Create SDK manager
-	manager, err := sdk.NewManager()
+	manager, err := newSDKManager()
 	if err != nil {
 		return fmt.Errorf("failed to create SDK manager: %w", err)
 	}
@@ -292,7 +292,7 @@ func runVersionUninstall(cmd *cobra.Command, args []string) error {
 	}
 
 	// Create SDK manager
-	manager, err := sdk.NewManager()
+	manager, err := newSDKManager()
 	if err != nil {
 		return fmt.Errorf("failed to create SDK manager: %w", err)
 	}
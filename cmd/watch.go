@@ -0,0 +1,141 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/spf13/cobra"
+
+	orch "github.com/grovetools/grove/pkg/orchestrator"
+)
+
+// taskWatch is a --watch session: one long-lived orchestrator rerunning the
+// jobs impacted by each debounced batch of file changes.
+type taskWatch struct {
+	o       *orch.Orchestrator
+	jobs    []orch.TaskJob
+	watcher *orch.Watcher
+}
+
+// watchTriggerMsg starts a rerun in the TUI. changed is empty for a forced
+// full run.
+type watchTriggerMsg struct {
+	changed []string
+	jobs    []orch.TaskJob
+}
+
+// watchErrMsg surfaces a watcher error in the TUI log.
+type watchErrMsg struct{ err error }
+
+// watchEnabled reads --watch. Reports describe a single run, so --watch
+// cannot be combined with --report.
+func watchEnabled(cmd *cobra.Command) (bool, error) {
+	watch, _ := cmd.Flags().GetBool("watch")
+	if !watch {
+		return false, nil
+	}
+	if report, _ := cmd.Flags().GetString("report"); report != "" {
+		return false, errors.New("--watch cannot be combined with --report")
+	}
+	return true, nil
+}
+
+// runTaskWatch runs jobs once, then reruns the impacted ones on every change
+// until interrupted. In the TUI the previous results stay on screen and 'r'
+// forces a full run; in JSON/non-TTY mode each run prints its own document
+// and a line on stdin forces a full run.
+func runTaskWatch(o *orch.Orchestrator, verb string, jobs []orch.TaskJob, useJSON bool, runJSON func([]orch.TaskJob) error) error {
+	watcher, err := orch.NewWatcher(jobs, 0)
+	if err != nil {
+		return fmt.Errorf("start watcher: %w", err)
+	}
+	defer watcher.Close()
+
+	w := &taskWatch{o: o, jobs: jobs, watcher: watcher}
+	if useJSON {
+		return w.runJSON(runJSON)
+	}
+	return runTuiWatch(o, verb, jobs, w)
+}
+
+// affected maps a batch of changed workspaces onto the jobs to rerun.
+func (w *taskWatch) affected(changed []string) []orch.TaskJob {
+	return orch.WatchAffected(w.jobs, changed, w.o.Configs, w.o.DepGraph, w.o.Options.Strategy)
+}
+
+// prepareRerun readies the orchestrator for another run. --affected only
+// shapes the first run; afterwards the watcher decides what runs.
+func (w *taskWatch) prepareRerun() {
+	w.o.Options.AffectedOnly = false
+	w.o.ResetRunState()
+}
+
+// waitCmd blocks until the next change batch that impacts at least one job.
+func (w *taskWatch) waitCmd() tea.Cmd {
+	return func() tea.Msg {
+		for {
+			select {
+			case changed, ok := <-w.watcher.Changes():
+				if !ok {
+					return nil
+				}
+				if jobs := w.affected(changed); len(jobs) > 0 {
+					return watchTriggerMsg{changed: changed, jobs: jobs}
+				}
+			case err := <-w.watcher.Errors():
+				return watchErrMsg{err: err}
+			}
+		}
+	}
+}
+
+func (w *taskWatch) runJSON(run func([]orch.TaskJob) error) error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	full := make(chan struct{}, 1)
+	go func() {
+		sc := bufio.NewScanner(os.Stdin)
+		for sc.Scan() {
+			select {
+			case full <- struct{}{}:
+			default:
+			}
+		}
+	}()
+
+	runOnce := func(jobs []orch.TaskJob) {
+		if err := run(jobs); err != nil {
+			fmt.Fprintf(os.Stderr, "%v\n", err)
+		}
+		fmt.Fprintln(os.Stderr, "Watching for changes (press Enter for a full run, Ctrl+C to stop)...")
+	}
+
+	runOnce(w.jobs)
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case changed := <-w.watcher.Changes():
+			jobs := w.affected(changed)
+			if len(jobs) == 0 {
+				continue
+			}
+			fmt.Fprintf(os.Stderr, "Changes in %s: rerunning %d project(s)\n", strings.Join(changed, ", "), len(jobs))
+			w.prepareRerun()
+			runOnce(jobs)
+		case <-full:
+			w.prepareRerun()
+			runOnce(w.jobs)
+		case err := <-w.watcher.Errors():
+			fmt.Fprintf(os.Stderr, "warning: watch: %v\n", err)
+		}
+	}
+}
@@ -0,0 +1,29 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func TestWatchEnabledRejectsReport(t *testing.T) {
+	newCmd := func(args ...string) *cobra.Command {
+		cmd := &cobra.Command{}
+		cmd.Flags().String("report", "", "")
+		cmd.Flags().Bool("watch", false, "")
+		if err := cmd.Flags().Parse(args); err != nil {
+			t.Fatal(err)
+		}
+		return cmd
+	}
+
+	if watch, err := watchEnabled(newCmd("--watch")); err != nil || !watch {
+		t.Errorf("--watch = %v, %v", watch, err)
+	}
+	if watch, err := watchEnabled(newCmd()); err != nil || watch {
+		t.Errorf("no --watch = %v, %v", watch, err)
+	}
+	if _, err := watchEnabled(newCmd("--watch", "--report", "junit=out.xml")); err == nil {
+		t.Error("--watch with --report should be rejected")
+	}
+}
@@ -0,0 +1,118 @@
+// Package cacheserver is the reference backend for the orchestrator's HTTP
+// remote cache: a flat, file-backed blob store speaking the GET/PUT protocol
+// documented on orchestrator.HTTPRemoteCache. It is what `grove cache serve`
+// runs on a LAN box or satellite, and what tests stand up locally.
+package cacheserver
+
+import (
+	"crypto/subtle"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// maxBodyBytes caps a single uploaded blob (artifact snapshots are gzipped
+// binaries; a few hundred MB is already unusual).
+const maxBodyBytes = 2 << 30
+
+// Handler serves <prefix>/v1/{results,artifacts}/<workspace>/<verbKey>/<token>
+// out of Dir. An empty Token disables authentication.
+type Handler struct {
+	Dir   string
+	Token string
+}
+
+// New returns a Handler rooted at dir.
+func New(dir, token string) *Handler {
+	return &Handler{Dir: dir, Token: token}
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if h.Token != "" {
+		got := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if subtle.ConstantTimeCompare([]byte(got), []byte(h.Token)) != 1 {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+	}
+
+	path, ok := h.blobPath(r.URL.Path)
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet, http.MethodHead:
+		f, err := os.Open(path)
+		if os.IsNotExist(err) {
+			http.NotFound(w, r)
+			return
+		}
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		defer f.Close()
+		info, err := f.Stat()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		http.ServeContent(w, r, "", info.ModTime(), f)
+	case http.MethodPut:
+		if err := writeBlob(path, http.MaxBytesReader(w, r.Body, maxBodyBytes)); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+	default:
+		w.Header().Set("Allow", "GET, HEAD, PUT")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// blobPath maps a request path onto the store. Every segment must be a single
+// non-hidden path element, so no request can address anything outside Dir.
+func (h *Handler) blobPath(urlPath string) (string, bool) {
+	parts := strings.Split(strings.Trim(urlPath, "/"), "/")
+	if len(parts) != 5 || parts[0] != "v1" || (parts[1] != "results" && parts[1] != "artifacts") {
+		return "", false
+	}
+	for _, p := range parts[2:] {
+		if p == "" || strings.HasPrefix(p, ".") || strings.ContainsAny(p, `/\`) {
+			return "", false
+		}
+	}
+	return filepath.Join(h.Dir, parts[1], parts[2], parts[3], parts[4]), true
+}
+
+// writeBlob stores body at path atomically (tmp file + rename), so readers
+// never see a partial upload and a failed upload leaves the old blob intact.
+func writeBlob(path string, body io.Reader) error {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(dir, ".upload-*.tmp")
+	if err != nil {
+		return err
+	}
+	tmpName := tmp.Name()
+	if _, err := io.Copy(tmp, body); err != nil {
+		tmp.Close()
+		os.Remove(tmpName)
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmpName)
+		return err
+	}
+	if err := os.Rename(tmpName, path); err != nil {
+		os.Remove(tmpName)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+package cacheserver
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestHandler_RoundTripAndAuth(t *testing.T) {
+	srv := httptest.NewServer(New(t.TempDir(), "tok"))
+	defer srv.Close()
+
+	do := func(method, path, token, body string) *http.Response {
+		t.Helper()
+		req, err := http.NewRequest(method, srv.URL+path, strings.NewReader(body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return resp
+	}
+
+	const blob = "/v1/results/flow/build@linux_amd64/abc123"
+	if resp := do(http.MethodGet, blob, "tok", ""); resp.StatusCode != http.StatusNotFound {
+		t.Errorf("missing blob: got %s, want 404", resp.Status)
+	}
+	if resp := do(http.MethodPut, blob, "wrong", "{}"); resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("bad token: got %s, want 401", resp.Status)
+	}
+	if resp := do(http.MethodPut, blob, "tok", `{"exit_code":0}`); resp.StatusCode != http.StatusCreated {
+		t.Fatalf("put: got %s", resp.Status)
+	}
+	resp := do(http.MethodGet, blob, "tok", "")
+	data, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || string(data) != `{"exit_code":0}` {
+		t.Errorf("get: %s %q", resp.Status, data)
+	}
+}
+
+// TestHandler_RejectsEscapes pins that no request path can address a file
+// outside the store or a hidden in-progress upload.
+func TestHandler_RejectsEscapes(t *testing.T) {
+	h := New(t.TempDir(), "")
+	for _, p := range []string{
+		"/v1/results/flow/build",
+		"/v1/other/flow/build/tok",
+		"/v1/results/../build/tok",
+		"/v1/results/flow/build/.upload-1.tmp",
+		"/v1/artifacts/flow/build/tok/extra",
+	} {
+		if _, ok := h.blobPath(p); ok {
+			t.Errorf("blobPath(%q) accepted", p)
+		}
+	}
+	if _, ok := h.blobPath("/v1/artifacts/flow/build/tok"); !ok {
+		t.Error("a well-formed artifact path must be accepted")
+	}
+}
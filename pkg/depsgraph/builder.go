@@ -15,6 +15,7 @@ import (
 type Builder struct {
 	workspaces      []string
 	projectRegistry *project.Registry
+	prefixes        project.ModulePrefixes
 	logger          *logrus.Logger
 }
 
@@ -23,13 +24,21 @@ func NewBuilder(workspaces []string, logger *logrus.Logger) *Builder {
 	return &Builder{
 		workspaces:      workspaces,
 		projectRegistry: project.NewRegistry(),
+		prefixes:        project.DefaultModulePrefixes(),
 		logger:          logger,
 	}
 }
 
+// SetModulePrefixes sets which Go modules count as ecosystem workspaces.
+func (b *Builder) SetModulePrefixes(prefixes project.ModulePrefixes) {
+	b.prefixes = prefixes
+	b.projectRegistry = project.NewRegistryFor(prefixes)
+}
+
 // Build constructs the dependency graph
 func (b *Builder) Build() (*Graph, error) {
 	graph := NewGraph()
+	graph.prefixes = b.prefixes
 	modulePathToName := make(map[string]string)
 
 	// First pass: collect all modules and determine their types
@@ -71,11 +80,16 @@ func (b *Builder) Build() (*Graph, error) {
 			// This is a bit hacky, but we need the module path for Go projects
 			// In a real implementation, we might extend the handler interface
 			modulePath = b.getGoModulePath(ws)
+		} else if namer, ok := handler.(project.PackageNamer); ok {
+			// Packages depended on by manifest name (e.g. @acme/kit)
+			if name, err := namer.PackageName(ws); err == nil {
+				modulePath = name
+			}
 		}
 
 		node := &Node{
 			Name: wsName,
-			Path: modulePath, // For Go projects, this is the module path; empty for others
+			Path: modulePath, // Go module path or package name; empty for others
 			Dir:  ws,
 			Deps: []string{},
 		}
@@ -129,6 +143,10 @@ func (b *Builder) Build() (*Graph, error) {
 					node.Deps = append(node.Deps, dep.Name)
 					graph.AddEdge(wsName, depName)
 				}
+			} else if depName, ok := modulePathToName[dep.Name]; ok && depName != wsName {
+				// Package name of another workspace
+				node.Deps = append(node.Deps, dep.Name)
+				graph.AddEdge(wsName, depName)
 			} else {
 				// For other project types, use name directly
 				// This assumes the dependency name matches the workspace name
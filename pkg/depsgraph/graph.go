@@ -5,12 +5,14 @@ import (
 	"sort"
 
 	"github.com/sirupsen/logrus"
+
+	"github.com/grovetools/grove/pkg/project"
 )
 
 // Node represents a module in the dependency graph
 type Node struct {
 	Name    string   // Module name (e.g., "grove-core")
-	Path    string   // Full module path (e.g., "github.com/grovetools/core")
+	Path    string   // Full module path (e.g., "github.com/grovetools/core") or package name
 	Dir     string   // Directory path
 	Deps    []string // Direct dependencies (module paths)
 	Version string   // Current version (if known)
@@ -21,6 +23,7 @@ type Graph struct {
 	nodes    map[string]*Node    // Key is module name
 	edges    map[string][]string // Adjacency list: module -> dependencies
 	revEdges map[string][]string // Reverse edges: module -> dependents
+	prefixes project.ModulePrefixes
 }
 
 // NewGraph creates a new dependency graph
@@ -32,6 +35,15 @@ func NewGraph() *Graph {
 	}
 }
 
+// ModulePrefixes returns the ecosystem module prefixes the graph was built
+// with (the upstream grove namespace for graphs built by hand).
+func (g *Graph) ModulePrefixes() project.ModulePrefixes {
+	if len(g.prefixes) == 0 {
+		return project.DefaultModulePrefixes()
+	}
+	return g.prefixes
+}
+
 // AddNode adds a node to the graph
 func (g *Graph) AddNode(node *Node) {
 	g.nodes[node.Name] = node
@@ -49,13 +61,15 @@ func (g *Graph) GetNode(name string) (*Node, bool) {
 	return node, exists
 }
 
-// BuildGraph builds a dependency graph from the workspace
+// BuildGraph builds a dependency graph from the workspace, using the module
+// prefixes of the ecosystem at rootDir (see project.LoadModulePrefixes).
 func BuildGraph(rootDir string, workspaces []string) (*Graph, error) {
 	// Use the new builder with a default logger
 	logger := logrus.New()
 	logger.SetLevel(logrus.WarnLevel) // Only show warnings and errors
 
 	builder := NewBuilder(workspaces, logger)
+	builder.SetModulePrefixes(project.LoadModulePrefixes(rootDir))
 	return builder.Build()
 }
 
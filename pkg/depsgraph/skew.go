@@ -0,0 +1,166 @@
+package depsgraph
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"golang.org/x/mod/modfile"
+	"golang.org/x/mod/semver"
+)
+
+// Masking reasons: how go.work hides a skew from local builds. Builds with
+// GOWORK=off (releases, CI of a single repo) still see every pinned version.
+const (
+	// MaskedByUse: the module is itself a go.work member, so every version
+	// pin resolves to the local checkout.
+	MaskedByUse = "use"
+	// MaskedByReplace: go.work replaces the module for all versions.
+	MaskedByReplace = "replace"
+	// MaskedByBuildList: every workspace pinning the module is a go.work
+	// member; they share one build list, which selects the highest pin.
+	MaskedByBuildList = "build-list"
+)
+
+// Pin is the version of a module one workspace requires.
+type Pin struct {
+	Workspace string `json:"workspace"`
+	Version   string `json:"version"`
+	Indirect  bool   `json:"indirect,omitempty"`
+}
+
+// Skew is a module required at more than one version across the graph's Go
+// workspaces.
+type Skew struct {
+	Module   string   `json:"module"`
+	Versions []string `json:"versions"` // distinct, ascending
+	Pins     []Pin    `json:"pins"`
+	// Internal is set when the module is one of the graph's workspaces.
+	Internal bool `json:"internal"`
+	// MaskedBy is one of the MaskedBy* reasons, or "" when local go.work
+	// builds see the skew too.
+	MaskedBy string `json:"masked_by,omitempty"`
+}
+
+// Latest returns the highest pinned version.
+func (s Skew) Latest() string {
+	return s.Versions[len(s.Versions)-1]
+}
+
+// LoadGoWork parses rootDir/go.work. It returns nil and no error when the
+// ecosystem has no go.work.
+func LoadGoWork(rootDir string) (*modfile.WorkFile, error) {
+	path := filepath.Join(rootDir, "go.work")
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	wf, err := modfile.ParseWork(path, data, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return wf, nil
+}
+
+// Skew reports every module that the go.mod files of the graph's workspaces
+// require at more than one version, sorted by module path. Indirect
+// requirements count only when indirect is set. goWork (rooted at rootDir;
+// may be nil) decides whether each skew is masked in local builds.
+func (g *Graph) Skew(rootDir string, goWork *modfile.WorkFile, indirect bool) ([]Skew, error) {
+	pins := make(map[string][]Pin)
+	for _, node := range g.nodes {
+		if node.Dir == "" {
+			continue
+		}
+		goModPath := filepath.Join(node.Dir, "go.mod")
+		data, err := os.ReadFile(goModPath)
+		if err != nil {
+			continue // not a Go workspace
+		}
+		mf, err := modfile.ParseLax(goModPath, data, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", goModPath, err)
+		}
+		for _, r := range mf.Require {
+			if r.Indirect && !indirect {
+				continue
+			}
+			pins[r.Mod.Path] = append(pins[r.Mod.Path], Pin{Workspace: node.Name, Version: r.Mod.Version, Indirect: r.Indirect})
+		}
+	}
+
+	// Workspaces that are go.work members, and the modules go.work replaces
+	used := make(map[string]bool)
+	replaced := make(map[string]bool)
+	if goWork != nil {
+		useDirs := make(map[string]bool)
+		for _, u := range goWork.Use {
+			dir := u.Path
+			if !filepath.IsAbs(dir) {
+				dir = filepath.Join(rootDir, dir)
+			}
+			useDirs[filepath.Clean(dir)] = true
+		}
+		for name, node := range g.nodes {
+			if node.Dir != "" && useDirs[filepath.Clean(node.Dir)] {
+				used[name] = true
+			}
+		}
+		for _, r := range goWork.Replace {
+			if r.Old.Version == "" {
+				replaced[r.Old.Path] = true
+			}
+		}
+	}
+	workspaceModules := make(map[string]string)
+	for name, node := range g.nodes {
+		if node.Path != "" {
+			workspaceModules[node.Path] = name
+		}
+	}
+
+	var skews []Skew
+	for module, modPins := range pins {
+		distinct := make(map[string]bool)
+		for _, p := range modPins {
+			distinct[p.Version] = true
+		}
+		if len(distinct) < 2 {
+			continue
+		}
+
+		s := Skew{Module: module, Pins: modPins}
+		for v := range distinct {
+			s.Versions = append(s.Versions, v)
+		}
+		sort.Slice(s.Versions, func(i, j int) bool { return semver.Compare(s.Versions[i], s.Versions[j]) < 0 })
+		sort.Slice(s.Pins, func(i, j int) bool { return s.Pins[i].Workspace < s.Pins[j].Workspace })
+
+		wsName, internal := workspaceModules[module]
+		s.Internal = internal
+		switch {
+		case internal && used[wsName]:
+			s.MaskedBy = MaskedByUse
+		case replaced[module]:
+			s.MaskedBy = MaskedByReplace
+		case goWork != nil && allUsed(modPins, used):
+			s.MaskedBy = MaskedByBuildList
+		}
+		skews = append(skews, s)
+	}
+	sort.Slice(skews, func(i, j int) bool { return skews[i].Module < skews[j].Module })
+	return skews, nil
+}
+
+func allUsed(pins []Pin, used map[string]bool) bool {
+	for _, p := range pins {
+		if !used[p.Workspace] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,96 @@
+package depsgraph
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeSkewWorkspace(t *testing.T, root, name, goMod string) *Node {
+	t.Helper()
+	dir := filepath.Join(root, name)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "go.mod"), []byte(goMod), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return &Node{Name: name, Path: "github.com/grovetools/" + name, Dir: dir}
+}
+
+func TestSkew(t *testing.T) {
+	root := t.TempDir()
+	g := NewGraph()
+	g.AddNode(writeSkewWorkspace(t, root, "core", `module github.com/grovetools/core
+
+require (
+	github.com/spf13/cobra v1.8.0
+	golang.org/x/sys v0.10.0 // indirect
+)
+`))
+	g.AddNode(writeSkewWorkspace(t, root, "flow", `module github.com/grovetools/flow
+
+require (
+	github.com/grovetools/core v0.6.1
+	github.com/spf13/cobra v1.7.0
+	github.com/pkg/errors v0.9.1
+	golang.org/x/sys v0.12.0 // indirect
+)
+`))
+	g.AddNode(writeSkewWorkspace(t, root, "nb", `module github.com/grovetools/nb
+
+require (
+	github.com/grovetools/core v0.6.3
+	github.com/pkg/errors v0.8.0
+)
+`))
+	g.AddNode(&Node{Name: "docs", Dir: filepath.Join(root, "docs")}) // no go.mod
+
+	goWork, err := LoadGoWork(root)
+	if err != nil || goWork != nil {
+		t.Fatalf("LoadGoWork without go.work = %v, %v", goWork, err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "go.work"), []byte("go 1.22\n\nuse (\n\t./core\n\t./flow\n)\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	goWork, err = LoadGoWork(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	skews, err := g.Skew(root, goWork, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(skews) != 3 {
+		t.Fatalf("got %d skews: %+v", len(skews), skews)
+	}
+	core, cobra, pkgErrors := skews[0], skews[2], skews[1]
+
+	if core.Module != "github.com/grovetools/core" || !core.Internal || core.MaskedBy != MaskedByUse || core.Latest() != "v0.6.3" {
+		t.Errorf("core skew = %+v", core)
+	}
+	if len(core.Pins) != 2 || core.Pins[0].Workspace != "flow" || core.Pins[1].Version != "v0.6.3" {
+		t.Errorf("core pins = %+v", core.Pins)
+	}
+	// nb is not a go.work member, so its v0.8.0 is built as pinned
+	if pkgErrors.Module != "github.com/pkg/errors" || pkgErrors.Internal || pkgErrors.MaskedBy != "" {
+		t.Errorf("pkg/errors skew = %+v", pkgErrors)
+	}
+	if cobra.MaskedBy != MaskedByBuildList || cobra.Versions[0] != "v1.7.0" {
+		t.Errorf("cobra skew = %+v", cobra)
+	}
+
+	skews, err = g.Skew(root, nil, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(skews) != 4 || skews[3].Module != "golang.org/x/sys" || !skews[3].Pins[0].Indirect {
+		t.Errorf("skews with indirect = %+v", skews)
+	}
+	for _, s := range skews {
+		if s.MaskedBy != "" {
+			t.Errorf("%s masked without a go.work: %s", s.Module, s.MaskedBy)
+		}
+	}
+}
@@ -0,0 +1,308 @@
+// Package depsupgrade inventories the third-party Go modules required across
+// an ecosystem's workspaces and plans upgrades that leave every module at a
+// single version ecosystem-wide.
+package depsupgrade
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"golang.org/x/mod/modfile"
+	"golang.org/x/mod/module"
+	"golang.org/x/mod/semver"
+)
+
+// Workspace is a Go workspace of the ecosystem.
+type Workspace struct {
+	Name string
+	Path string
+}
+
+// Requirement is one require directive of a workspace's go.mod.
+type Requirement struct {
+	Workspace string `json:"workspace"`
+	Module    string `json:"module"`
+	Version   string `json:"version"`
+	Indirect  bool   `json:"indirect,omitempty"`
+}
+
+// Collect reads the go.mod of each workspace and returns its requirements on
+// modules for which internal returns false. Indirect requirements are only
+// included when indirect is set. Workspaces without a go.mod are skipped.
+func Collect(workspaces []Workspace, internal func(modulePath string) bool, indirect bool) ([]Requirement, error) {
+	var reqs []Requirement
+	for _, ws := range workspaces {
+		goModPath := filepath.Join(ws.Path, "go.mod")
+		data, err := os.ReadFile(goModPath)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, err
+		}
+		mf, err := modfile.ParseLax(goModPath, data, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", goModPath, err)
+		}
+		for _, r := range mf.Require {
+			if internal(r.Mod.Path) || (r.Indirect && !indirect) {
+				continue
+			}
+			reqs = append(reqs, Requirement{
+				Workspace: ws.Name,
+				Module:    r.Mod.Path,
+				Version:   r.Mod.Version,
+				Indirect:  r.Indirect,
+			})
+		}
+	}
+	return reqs, nil
+}
+
+// VersionSource lists the published versions of a module.
+type VersionSource interface {
+	// Versions returns the known versions of modulePath. A module that does
+	// not exist yields no versions and no error.
+	Versions(ctx context.Context, modulePath string) ([]string, error)
+}
+
+// Usage is the version of a module one workspace requires.
+type Usage struct {
+	Workspace string `json:"workspace"`
+	Version   string `json:"version"`
+}
+
+// Module summarizes one third-party module across all workspaces.
+type Module struct {
+	Path string `json:"module"`
+	// Current is the highest version any workspace requires, MinUsed the
+	// lowest.
+	Current string `json:"current"`
+	MinUsed string `json:"min_used"`
+	// LatestMinor is the newest release within Current's major version.
+	LatestMinor string `json:"latest_minor,omitempty"`
+	// LatestMajor is the newest release of a later major version, which
+	// lives at a different module path (LatestMajorPath).
+	LatestMajor     string  `json:"latest_major,omitempty"`
+	LatestMajorPath string  `json:"latest_major_path,omitempty"`
+	UsedBy          []Usage `json:"used_by"`
+	Error           string  `json:"error,omitempty"`
+}
+
+// Outdated reports whether some workspace requires an older version than the
+// newest release of the module's major version, or the workspaces disagree
+// on the version.
+func (m Module) Outdated() bool {
+	return m.MinUsed != m.Target() || m.Current != m.Target()
+}
+
+// Split reports whether the workspaces require the module at more than one
+// version.
+func (m Module) Split() bool {
+	return m.MinUsed != m.Current
+}
+
+// HasNewMajor reports whether a later major version has been released.
+func (m Module) HasNewMajor() bool {
+	return m.LatestMajor != ""
+}
+
+// Target is the version every workspace should require after an upgrade: the
+// newest release of the current major version, never lower than Current.
+func (m Module) Target() string {
+	if m.LatestMinor != "" && semver.Compare(m.LatestMinor, m.Current) > 0 {
+		return m.LatestMinor
+	}
+	return m.Current
+}
+
+// maxMajorProbes bounds the search for later major versions.
+const maxMajorProbes = 10
+
+// Analyze groups reqs by module and looks up the newest releases of each.
+// Lookup failures are recorded on the module rather than returned.
+func Analyze(ctx context.Context, reqs []Requirement, src VersionSource) []Module {
+	modules := Group(reqs)
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, 8)
+	for i := range modules {
+		wg.Add(1)
+		go func(m *Module) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			if err := resolveLatest(ctx, m, src); err != nil {
+				m.Error = err.Error()
+			}
+		}(&modules[i])
+	}
+	wg.Wait()
+	return modules
+}
+
+// Group summarizes reqs by module, sorted by path, without looking up any
+// releases: LatestMinor is unset, so each module's Target is its Current.
+func Group(reqs []Requirement) []Module {
+	byPath := make(map[string]*Module)
+	for _, r := range reqs {
+		m, ok := byPath[r.Module]
+		if !ok {
+			m = &Module{Path: r.Module, Current: r.Version, MinUsed: r.Version}
+			byPath[r.Module] = m
+		}
+		if semver.Compare(r.Version, m.Current) > 0 {
+			m.Current = r.Version
+		}
+		if semver.Compare(r.Version, m.MinUsed) < 0 {
+			m.MinUsed = r.Version
+		}
+		m.UsedBy = append(m.UsedBy, Usage{Workspace: r.Workspace, Version: r.Version})
+	}
+
+	modules := make([]Module, 0, len(byPath))
+	for _, m := range byPath {
+		sort.Slice(m.UsedBy, func(i, j int) bool { return m.UsedBy[i].Workspace < m.UsedBy[j].Workspace })
+		modules = append(modules, *m)
+	}
+	sort.Slice(modules, func(i, j int) bool { return modules[i].Path < modules[j].Path })
+	return modules
+}
+
+func resolveLatest(ctx context.Context, m *Module, src VersionSource) error {
+	versions, err := src.Versions(ctx, m.Path)
+	if err != nil {
+		return err
+	}
+	m.LatestMinor = latestRelease(versions, semver.Major(m.Current))
+
+	// A new major version is a new module path: path/vN+1, path/vN+2, ...
+	prefix, pathMajor, ok := module.SplitPathVersion(m.Path)
+	if !ok || strings.HasPrefix(pathMajor, ".") {
+		// gopkg.in paths version differently; not probed
+		return nil
+	}
+	major := 1
+	if pathMajor != "" {
+		major, _ = strconv.Atoi(strings.TrimPrefix(pathMajor, "/v"))
+	}
+	// Majors published as +incompatible tags on the unsuffixed path predate
+	// the first /vN path, so probing starts past them
+	if pathMajor == "" {
+		for _, v := range versions {
+			if semver.Build(v) == "+incompatible" {
+				if n, err := strconv.Atoi(strings.TrimPrefix(semver.Major(v), "v")); err == nil && n > major {
+					major = n
+				}
+			}
+		}
+	}
+	for n := major + 1; n <= major+maxMajorProbes; n++ {
+		path := fmt.Sprintf("%s/v%d", prefix, n)
+		versions, err := src.Versions(ctx, path)
+		if err != nil {
+			return err
+		}
+		latest := latestRelease(versions, fmt.Sprintf("v%d", n))
+		if latest == "" {
+			break
+		}
+		m.LatestMajor, m.LatestMajorPath = latest, path
+	}
+	return nil
+}
+
+// latestRelease returns the highest non-prerelease version with the given
+// major ("v0", "v1", ...), or "" if there is none. +incompatible versions are
+// ignored.
+func latestRelease(versions []string, major string) string {
+	var latest string
+	for _, v := range versions {
+		if !semver.IsValid(v) || semver.Prerelease(v) != "" || semver.Build(v) != "" {
+			continue
+		}
+		if semver.Major(v) != major {
+			continue
+		}
+		if latest == "" || semver.Compare(v, latest) > 0 {
+			latest = v
+		}
+	}
+	return latest
+}
+
+// Upgrade moves one module of a workspace to a new version.
+type Upgrade struct {
+	Module string `json:"module"`
+	From   string `json:"from"`
+	To     string `json:"to"`
+}
+
+// Step upgrades the modules of one workspace; it is built, tested and kept or
+// reverted as a unit.
+type Step struct {
+	Workspace string    `json:"workspace"`
+	Upgrades  []Upgrade `json:"upgrades"`
+}
+
+// Plan is an ordered upgrade set. Majors lists the modules with a newer major
+// version, which needs import path changes and is never planned.
+type Plan struct {
+	Steps  []Step   `json:"steps"`
+	Majors []Module `json:"majors,omitempty"`
+}
+
+// BuildPlan moves every workspace requiring a module to the module's Target,
+// so each module ends up at one version across the ecosystem. Steps follow
+// order (workspace names, dependencies first); workspaces missing from order
+// come last, by name. Modules whose lookup failed are left alone.
+func BuildPlan(modules []Module, order []string) Plan {
+	var plan Plan
+	upgrades := make(map[string][]Upgrade)
+	for _, m := range modules {
+		if m.Error != "" {
+			continue
+		}
+		if m.HasNewMajor() {
+			plan.Majors = append(plan.Majors, m)
+		}
+		target := m.Target()
+		for _, u := range m.UsedBy {
+			if semver.Compare(u.Version, target) < 0 {
+				upgrades[u.Workspace] = append(upgrades[u.Workspace], Upgrade{Module: m.Path, From: u.Version, To: target})
+			}
+		}
+	}
+
+	rank := make(map[string]int, len(order))
+	for i, name := range order {
+		rank[name] = i
+	}
+	names := make([]string, 0, len(upgrades))
+	for name := range upgrades {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		ri, iok := rank[names[i]]
+		rj, jok := rank[names[j]]
+		switch {
+		case iok && jok:
+			return ri < rj
+		case iok != jok:
+			return iok
+		}
+		return names[i] < names[j]
+	})
+
+	for _, name := range names {
+		ups := upgrades[name]
+		sort.Slice(ups, func(i, j int) bool { return ups[i].Module < ups[j].Module })
+		plan.Steps = append(plan.Steps, Step{Workspace: name, Upgrades: ups})
+	}
+	return plan
+}
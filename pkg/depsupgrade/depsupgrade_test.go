@@ -0,0 +1,170 @@
+package depsupgrade
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type fakeVersions map[string][]string
+
+func (f fakeVersions) Versions(_ context.Context, modulePath string) ([]string, error) {
+	return f[modulePath], nil
+}
+
+func writeGoMod(t *testing.T, dir, content string) Workspace {
+	t.Helper()
+	path := filepath.Join(dir, filepath.Base(dir))
+	if err := os.MkdirAll(path, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(path, "go.mod"), []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return Workspace{Name: filepath.Base(dir), Path: path}
+}
+
+func TestCollect(t *testing.T) {
+	root := t.TempDir()
+	core := writeGoMod(t, filepath.Join(root, "core"), `module github.com/grovetools/core
+
+go 1.22
+
+require (
+	github.com/spf13/cobra v1.7.0
+	golang.org/x/sys v0.10.0 // indirect
+)
+`)
+	flow := writeGoMod(t, filepath.Join(root, "flow"), `module github.com/grovetools/flow
+
+go 1.22
+
+require (
+	github.com/grovetools/core v0.5.0
+	github.com/spf13/cobra v1.8.0
+)
+`)
+	empty := Workspace{Name: "docs", Path: filepath.Join(root, "docs")}
+	internal := func(p string) bool { return strings.HasPrefix(p, "github.com/grovetools/") }
+
+	reqs, err := Collect([]Workspace{core, flow, empty}, internal, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Requirement{
+		{Workspace: "core", Module: "github.com/spf13/cobra", Version: "v1.7.0"},
+		{Workspace: "flow", Module: "github.com/spf13/cobra", Version: "v1.8.0"},
+	}
+	if !reflect.DeepEqual(reqs, want) {
+		t.Errorf("Collect = %+v, want %+v", reqs, want)
+	}
+
+	reqs, err = Collect([]Workspace{core}, internal, true)
+	if err != nil || len(reqs) != 2 || !reqs[1].Indirect {
+		t.Errorf("Collect with indirect = %+v, %v", reqs, err)
+	}
+}
+
+func TestAnalyze(t *testing.T) {
+	src := fakeVersions{
+		"github.com/spf13/cobra":       {"v1.7.0", "v1.8.0", "v1.9.0-rc.1", "v1.8.1"},
+		"github.com/go-chi/chi":        {"v1.5.4", "v4.1.2+incompatible"},
+		"github.com/go-chi/chi/v5":     {"v5.0.0", "v5.1.0"},
+		"github.com/go-chi/chi/v6":     {},
+		"github.com/redis/go-redis/v9": {"v9.0.0", "v9.5.1"},
+	}
+	reqs := []Requirement{
+		{Workspace: "flow", Module: "github.com/spf13/cobra", Version: "v1.8.0"},
+		{Workspace: "core", Module: "github.com/spf13/cobra", Version: "v1.7.0"},
+		{Workspace: "core", Module: "github.com/go-chi/chi", Version: "v1.5.4"},
+		{Workspace: "hooks", Module: "github.com/redis/go-redis/v9", Version: "v9.5.1"},
+	}
+
+	modules := Analyze(context.Background(), reqs, src)
+	if len(modules) != 3 {
+		t.Fatalf("got %d modules", len(modules))
+	}
+	chi, redis, cobra := modules[0], modules[1], modules[2]
+
+	if cobra.Current != "v1.8.0" || cobra.MinUsed != "v1.7.0" || cobra.LatestMinor != "v1.8.1" || cobra.HasNewMajor() {
+		t.Errorf("cobra = %+v", cobra)
+	}
+	if !cobra.Outdated() || cobra.Target() != "v1.8.1" {
+		t.Errorf("cobra should be outdated with target v1.8.1, got %s", cobra.Target())
+	}
+	if cobra.UsedBy[0].Workspace != "core" {
+		t.Errorf("UsedBy not sorted: %+v", cobra.UsedBy)
+	}
+
+	if chi.LatestMinor != "v1.5.4" || chi.LatestMajor != "v5.1.0" || chi.LatestMajorPath != "github.com/go-chi/chi/v5" {
+		t.Errorf("chi = %+v", chi)
+	}
+	if chi.Outdated() {
+		t.Error("chi is at the latest v1 and should not count as outdated")
+	}
+
+	if redis.Outdated() || redis.HasNewMajor() {
+		t.Errorf("redis = %+v", redis)
+	}
+}
+
+func TestBuildPlan(t *testing.T) {
+	modules := []Module{
+		{
+			Path: "github.com/spf13/cobra", Current: "v1.8.0", MinUsed: "v1.7.0", LatestMinor: "v1.8.1",
+			UsedBy: []Usage{{"core", "v1.7.0"}, {"flow", "v1.8.0"}, {"hooks", "v1.8.1"}},
+		},
+		{
+			Path: "github.com/go-chi/chi", Current: "v1.5.4", MinUsed: "v1.5.0", LatestMinor: "v1.5.4",
+			LatestMajor: "v5.1.0", LatestMajorPath: "github.com/go-chi/chi/v5",
+			UsedBy: []Usage{{"core", "v1.5.4"}, {"zeta", "v1.5.0"}},
+		},
+		{
+			Path: "example.com/broken", Current: "v0.1.0", MinUsed: "v0.1.0", Error: "lookup failed",
+			UsedBy: []Usage{{"core", "v0.1.0"}},
+		},
+	}
+
+	plan := BuildPlan(modules, []string{"core", "hooks", "flow"})
+	want := []Step{
+		{Workspace: "core", Upgrades: []Upgrade{{"github.com/spf13/cobra", "v1.7.0", "v1.8.1"}}},
+		{Workspace: "flow", Upgrades: []Upgrade{{"github.com/spf13/cobra", "v1.8.0", "v1.8.1"}}},
+		{Workspace: "zeta", Upgrades: []Upgrade{{"github.com/go-chi/chi", "v1.5.0", "v1.5.4"}}},
+	}
+	if !reflect.DeepEqual(plan.Steps, want) {
+		t.Errorf("steps = %+v, want %+v", plan.Steps, want)
+	}
+	if len(plan.Majors) != 1 || plan.Majors[0].Path != "github.com/go-chi/chi" {
+		t.Errorf("majors = %+v", plan.Majors)
+	}
+}
+
+// TestGroupSplitFollowUp covers the post-upgrade recheck: go get raised
+// x/sys in flow only (MVS), so it is split, and a plan built from Group
+// alone moves the laggard to the version already in use.
+func TestGroupSplitFollowUp(t *testing.T) {
+	reqs := []Requirement{
+		{Workspace: "core", Module: "golang.org/x/sys", Version: "v0.20.0"},
+		{Workspace: "flow", Module: "golang.org/x/sys", Version: "v0.22.0"},
+		{Workspace: "core", Module: "github.com/spf13/cobra", Version: "v1.8.1"},
+		{Workspace: "flow", Module: "github.com/spf13/cobra", Version: "v1.8.1"},
+	}
+	var split []Module
+	for _, m := range Group(reqs) {
+		if m.Split() {
+			split = append(split, m)
+		}
+	}
+	if len(split) != 1 || split[0].Path != "golang.org/x/sys" {
+		t.Fatalf("split = %+v", split)
+	}
+
+	plan := BuildPlan(split, []string{"core", "flow"})
+	want := []Step{{Workspace: "core", Upgrades: []Upgrade{{"golang.org/x/sys", "v0.20.0", "v0.22.0"}}}}
+	if !reflect.DeepEqual(plan.Steps, want) {
+		t.Errorf("steps = %+v, want %+v", plan.Steps, want)
+	}
+}
@@ -0,0 +1,54 @@
+package depsupgrade
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// GoList is the VersionSource backed by `go list -m -versions`, which
+// consults GOPROXY like any other module download.
+type GoList struct {
+	// Env is the environment of the go command (os.Environ() when nil).
+	Env []string
+}
+
+// Versions implements VersionSource.
+func (g GoList) Versions(ctx context.Context, modulePath string) ([]string, error) {
+	cmd := exec.CommandContext(ctx, "go", "list", "-m", "-versions", "-json", modulePath)
+	// Outside any module, so the query is not resolved against a go.mod
+	cmd.Dir = os.TempDir()
+	cmd.Env = g.Env
+	if cmd.Env == nil {
+		cmd.Env = os.Environ()
+	}
+	cmd.Env = append(cmd.Env, "GOWORK=off", "GOFLAGS=-mod=mod")
+
+	output, err := cmd.Output()
+	if err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok && isMissingModule(string(exitErr.Stderr)) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var info struct {
+		Versions []string `json:"Versions"`
+	}
+	if err := json.Unmarshal(output, &info); err != nil {
+		return nil, err
+	}
+	return info.Versions, nil
+}
+
+// isMissingModule recognizes the go command's complaints about a module path
+// nobody serves, such as a major version that was never published.
+func isMissingModule(stderr string) bool {
+	for _, s := range []string{"not found", "404", "410", "no matching versions", "unrecognized import path"} {
+		if strings.Contains(stderr, s) {
+			return true
+		}
+	}
+	return false
+}
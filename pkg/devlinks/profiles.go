@@ -0,0 +1,179 @@
+package devlinks
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// Profile is a named set of dev links that are activated together, e.g.
+// "feature-x" = {flow: wt-a, cx: wt-b, core-tools: wt-a}
+type Profile struct {
+	// Links maps a binary name to the alias of the link to activate
+	Links   map[string]string `json:"links"`
+	SavedAt string            `json:"saved_at"`
+}
+
+// ProfileChange is how applying a profile changes one binary's active link.
+// An empty alias means the released version.
+type ProfileChange struct {
+	Binary  string
+	Current string
+	Profile string
+}
+
+// CurrentLinks returns the active alias of every binary that has one.
+func (c *Config) CurrentLinks() map[string]string {
+	links := make(map[string]string)
+	for name, bin := range c.Binaries {
+		if bin.Current != "" {
+			links[name] = bin.Current
+		}
+	}
+	return links
+}
+
+// SaveProfile stores links as profile name, replacing any profile of that
+// name. Every binary and alias must be registered.
+func (c *Config) SaveProfile(name string, links map[string]string) error {
+	if name == "" {
+		return fmt.Errorf("profile name is required")
+	}
+	if len(links) == 0 {
+		return fmt.Errorf("profile '%s' would be empty: no dev links given or active", name)
+	}
+	if err := c.checkLinks(links); err != nil {
+		return err
+	}
+	if c.Profiles == nil {
+		c.Profiles = make(map[string]*Profile)
+	}
+	saved := make(map[string]string, len(links))
+	for bin, alias := range links {
+		saved[bin] = alias
+	}
+	c.Profiles[name] = &Profile{Links: saved, SavedAt: time.Now().Format(time.RFC3339)}
+	return nil
+}
+
+// DeleteProfile removes profile name. The active links are not changed.
+func (c *Config) DeleteProfile(name string) error {
+	if _, ok := c.Profiles[name]; !ok {
+		return fmt.Errorf("profile '%s' not found", name)
+	}
+	delete(c.Profiles, name)
+	if c.ActiveProfile == name {
+		c.ActiveProfile = ""
+		c.ProfileRestore = nil
+	}
+	return nil
+}
+
+// DiffProfile returns the binaries whose active link differs from profile
+// name, sorted by binary.
+func (c *Config) DiffProfile(name string) ([]ProfileChange, error) {
+	profile, ok := c.Profiles[name]
+	if !ok {
+		return nil, fmt.Errorf("profile '%s' not found", name)
+	}
+	var changes []ProfileChange
+	for bin, alias := range profile.Links {
+		current := ""
+		if b, ok := c.Binaries[bin]; ok {
+			current = b.Current
+		}
+		if current != alias {
+			changes = append(changes, ProfileChange{Binary: bin, Current: current, Profile: alias})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Binary < changes[j].Binary })
+	return changes, nil
+}
+
+// ApplyProfile activates every link of profile name and marks it active. The
+// links active before the first profile was applied are remembered for
+// ClearProfile. It returns the binaries whose link changed; nothing changes
+// if any link of the profile is no longer registered.
+func (c *Config) ApplyProfile(name string) ([]string, error) {
+	profile, ok := c.Profiles[name]
+	if !ok {
+		return nil, fmt.Errorf("profile '%s' not found", name)
+	}
+	if err := c.checkLinks(profile.Links); err != nil {
+		return nil, fmt.Errorf("profile '%s' is stale: %w", name, err)
+	}
+
+	if c.ActiveProfile == "" {
+		// Switching between profiles keeps the original pre-profile state
+		c.ProfileRestore = make(map[string]string)
+		for bin := range profile.Links {
+			c.ProfileRestore[bin] = c.Binaries[bin].Current
+		}
+	} else {
+		for bin := range profile.Links {
+			if _, ok := c.ProfileRestore[bin]; !ok {
+				c.ProfileRestore[bin] = c.Binaries[bin].Current
+			}
+		}
+	}
+
+	var changed []string
+	for bin, alias := range profile.Links {
+		if c.Binaries[bin].Current != alias {
+			c.Binaries[bin].Current = alias
+			changed = append(changed, bin)
+		}
+	}
+	sort.Strings(changed)
+	c.ActiveProfile = name
+	return changed, nil
+}
+
+// ClearProfile deactivates the active profile, restoring the links that were
+// active before it was applied. It returns the binaries whose link changed.
+func (c *Config) ClearProfile() ([]string, error) {
+	if c.ActiveProfile == "" {
+		return nil, fmt.Errorf("no profile is active")
+	}
+	var changed []string
+	for bin, alias := range c.ProfileRestore {
+		b, ok := c.Binaries[bin]
+		if !ok {
+			continue
+		}
+		if _, registered := b.Links[alias]; alias != "" && !registered {
+			alias = "" // the old link was removed meanwhile; fall back to release
+		}
+		if b.Current != alias {
+			b.Current = alias
+			changed = append(changed, bin)
+		}
+	}
+	sort.Strings(changed)
+	c.ActiveProfile = ""
+	c.ProfileRestore = nil
+	return changed, nil
+}
+
+// ActiveProfileDrifted reports whether links were switched by hand since the
+// active profile was applied.
+func (c *Config) ActiveProfileDrifted() bool {
+	if c.ActiveProfile == "" {
+		return false
+	}
+	changes, err := c.DiffProfile(c.ActiveProfile)
+	return err != nil || len(changes) > 0
+}
+
+func (c *Config) checkLinks(links map[string]string) error {
+	for bin, alias := range links {
+		b, ok := c.Binaries[bin]
+		if !ok {
+			return fmt.Errorf("binary '%s' is not registered", bin)
+		}
+		if _, ok := b.Links[alias]; !ok {
+			return fmt.Errorf("alias '%s' not found for binary '%s'", alias, bin)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,115 @@
+package devlinks
+
+import (
+	"reflect"
+	"testing"
+)
+
+func testConfig() *Config {
+	links := func(aliases ...string) *BinaryLinks {
+		b := &BinaryLinks{Links: map[string]LinkInfo{}}
+		for _, a := range aliases {
+			b.Links[a] = LinkInfo{Path: "/wt/" + a + "/bin"}
+		}
+		return b
+	}
+	c := &Config{Binaries: map[string]*BinaryLinks{
+		"flow":       links("main", "wt-a"),
+		"cx":         links("main", "wt-b"),
+		"core-tools": links("wt-a"),
+	}}
+	c.Binaries["flow"].Current = "main"
+	return c
+}
+
+func TestProfileApplyAndClear(t *testing.T) {
+	c := testConfig()
+	if err := c.SaveProfile("feature-x", map[string]string{"flow": "wt-a", "cx": "wt-b", "core-tools": "wt-a"}); err != nil {
+		t.Fatal(err)
+	}
+
+	diff, err := c.DiffProfile("feature-x")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []ProfileChange{
+		{Binary: "core-tools", Current: "", Profile: "wt-a"},
+		{Binary: "cx", Current: "", Profile: "wt-b"},
+		{Binary: "flow", Current: "main", Profile: "wt-a"},
+	}
+	if !reflect.DeepEqual(diff, want) {
+		t.Errorf("DiffProfile = %+v, want %+v", diff, want)
+	}
+
+	changed, err := c.ApplyProfile("feature-x")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(changed, []string{"core-tools", "cx", "flow"}) {
+		t.Errorf("ApplyProfile changed %v", changed)
+	}
+	if c.ActiveProfile != "feature-x" || c.Binaries["cx"].Current != "wt-b" || c.ActiveProfileDrifted() {
+		t.Errorf("after apply: active=%q cx=%q", c.ActiveProfile, c.Binaries["cx"].Current)
+	}
+
+	// A manual switch shows as drift
+	c.Binaries["cx"].Current = "main"
+	if !c.ActiveProfileDrifted() {
+		t.Error("manual switch should drift from the profile")
+	}
+
+	changed, err = c.ClearProfile()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(changed, []string{"core-tools", "cx", "flow"}) {
+		t.Errorf("ClearProfile changed %v", changed)
+	}
+	if c.Binaries["flow"].Current != "main" || c.Binaries["cx"].Current != "" || c.ActiveProfile != "" {
+		t.Errorf("clear did not restore the pre-profile links: %+v", c.CurrentLinks())
+	}
+	if _, err := c.ClearProfile(); err == nil {
+		t.Error("clearing without an active profile should fail")
+	}
+}
+
+func TestProfileSwitchKeepsOriginalRestore(t *testing.T) {
+	c := testConfig()
+	_ = c.SaveProfile("a", map[string]string{"flow": "wt-a"})
+	_ = c.SaveProfile("b", map[string]string{"flow": "main", "cx": "wt-b"})
+
+	if _, err := c.ApplyProfile("a"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.ApplyProfile("b"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.ClearProfile(); err != nil {
+		t.Fatal(err)
+	}
+	if got := c.CurrentLinks(); !reflect.DeepEqual(got, map[string]string{"flow": "main"}) {
+		t.Errorf("links after clear = %v", got)
+	}
+}
+
+func TestProfileValidation(t *testing.T) {
+	c := testConfig()
+	if err := c.SaveProfile("bad", map[string]string{"flow": "nope"}); err == nil {
+		t.Error("unknown alias should be rejected")
+	}
+	if err := c.SaveProfile("bad", map[string]string{"nb": "main"}); err == nil {
+		t.Error("unregistered binary should be rejected")
+	}
+	if err := c.SaveProfile("empty", nil); err == nil {
+		t.Error("empty profile should be rejected")
+	}
+
+	_ = c.SaveProfile("x", map[string]string{"flow": "wt-a", "cx": "wt-b"})
+	delete(c.Binaries["cx"].Links, "wt-b")
+	if _, err := c.ApplyProfile("x"); err == nil {
+		t.Error("applying a profile with a removed link should fail")
+	}
+	if c.Binaries["flow"].Current != "main" || c.ActiveProfile != "" {
+		t.Error("a failed apply must not change any link")
+	}
+}
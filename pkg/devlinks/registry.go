@@ -13,6 +13,13 @@ import (
 type Config struct {
 	// Binaries maps a binary name (e.g., "grove", "flow") to its link information
 	Binaries map[string]*BinaryLinks `json:"binaries"`
+	// Profiles are named sets of links applied together (see profiles.go)
+	Profiles map[string]*Profile `json:"profiles,omitempty"`
+	// ActiveProfile is the name of the applied profile, if any
+	ActiveProfile string `json:"active_profile,omitempty"`
+	// ProfileRestore holds the aliases that were current before the active
+	// profile was applied ("" for the released version)
+	ProfileRestore map[string]string `json:"profile_restore,omitempty"`
 }
 
 // BinaryLinks holds all local development links and the current active link for a single binary
@@ -65,7 +72,12 @@ func SaveConfig(config *Config) error {
 		return err
 	}
 
-	return os.WriteFile(configPath, data, 0o600) //nolint:gosec // G306: internal tool, non-sensitive config file
+	// Write-then-rename so a profile switch never leaves a torn registry
+	tmpPath := configPath + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0o600); err != nil { //nolint:gosec // G306: internal tool, non-sensitive config file
+		return err
+	}
+	return os.Rename(tmpPath, configPath)
 }
 
 // ClearAllCurrentLinks resets all active development links
@@ -79,6 +91,8 @@ func ClearAllCurrentLinks() error {
 	for _, binary := range config.Binaries {
 		binary.Current = ""
 	}
+	config.ActiveProfile = ""
+	config.ProfileRestore = nil
 
 	return SaveConfig(config)
 }
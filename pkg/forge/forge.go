@@ -0,0 +1,274 @@
+// Package forge abstracts the git hosting service ("forge") a repository lives
+// on: GitHub, GitLab or Gitea. Release orchestration, repository creation and
+// secret management go through the Forge interface, and the implementation is
+// chosen per repository from its origin remote.
+package forge
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/grovetools/core/config"
+)
+
+// Kind identifies a forge implementation.
+type Kind string
+
+const (
+	KindGitHub Kind = "github"
+	KindGitLab Kind = "gitlab"
+	KindGitea  Kind = "gitea"
+)
+
+// Repo identifies a repository on a forge. Owner may contain slashes for
+// GitLab subgroups.
+type Repo struct {
+	Host  string
+	Owner string
+	Name  string
+}
+
+// Slug returns OWNER/NAME.
+func (r Repo) Slug() string {
+	return r.Owner + "/" + r.Name
+}
+
+// WebURL returns the repository's home page.
+func (r Repo) WebURL() string {
+	return "https://" + r.Host + "/" + r.Slug()
+}
+
+func (r Repo) String() string {
+	return r.Host + "/" + r.Slug()
+}
+
+// RunState is the normalized state of a CI run across forges.
+type RunState string
+
+const (
+	RunNone      RunState = "none" // no run for the commit
+	RunPending   RunState = "pending"
+	RunSuccess   RunState = "success"
+	RunFailure   RunState = "failure"
+	RunCancelled RunState = "cancelled"
+	RunSkipped   RunState = "skipped"
+	RunUnknown   RunState = "unknown"
+)
+
+// Done reports whether the run has finished.
+func (s RunState) Done() bool {
+	return s != RunPending && s != RunNone && s != RunUnknown
+}
+
+// Run is a CI run (GitHub workflow run, GitLab pipeline, Gitea commit status).
+type Run struct {
+	ID    string
+	State RunState
+	URL   string
+}
+
+// ReleaseOptions describe a release to create for an existing tag.
+type ReleaseOptions struct {
+	Tag        string
+	Title      string
+	Notes      string
+	Prerelease bool
+}
+
+// CreateRepoOptions describe a repository to create.
+type CreateRepoOptions struct {
+	Description string
+	Public      bool
+}
+
+// Forge is a git hosting service. Every method addresses the repository
+// explicitly so one Forge can serve all repositories on its host.
+type Forge interface {
+	Kind() Kind
+
+	// CIStatus returns the latest CI run for commit sha.
+	CIStatus(ctx context.Context, repo Repo, sha string) (Run, error)
+	// WaitForCI waits for CI on commit sha to finish and fails if it did not
+	// pass. It returns nil when the repository has no CI or no run starts
+	// within a few minutes.
+	WaitForCI(ctx context.Context, repo Repo, sha string) error
+	// WaitForRelease waits for the release pipeline triggered by pushing tag
+	// and fails if it did not pass.
+	WaitForRelease(ctx context.Context, repo Repo, tag string) error
+	// CIConfigured reports whether the checkout at dir defines CI this forge
+	// runs.
+	CIConfigured(dir string) bool
+
+	CreateRelease(ctx context.Context, repo Repo, opts ReleaseOptions) error
+
+	// RepoExists reports whether repo exists and is visible to the caller.
+	RepoExists(ctx context.Context, repo Repo) (bool, error)
+	// CreateRepo creates repo and returns its HTTPS clone URL.
+	CreateRepo(ctx context.Context, repo Repo, opts CreateRepoOptions) (string, error)
+
+	// SetSecret creates or updates a CI secret (a CI/CD variable on GitLab).
+	SetSecret(ctx context.Context, repo Repo, name, value string) error
+	DeleteSecret(ctx context.Context, repo Repo, name string) error
+	ListSecrets(ctx context.Context, repo Repo) ([]string, error)
+}
+
+// HostConfig configures one forge host under [forge.hosts."<host>"].
+type HostConfig struct {
+	Type     string `yaml:"type"`      // github, gitlab or gitea
+	APIURL   string `yaml:"api_url"`   // default https://<host>/api/v4 (GitLab) or /api/v1 (Gitea)
+	TokenEnv string `yaml:"token_env"` // default GITLAB_TOKEN or GITEA_TOKEN
+}
+
+// Config is the [forge] table of the grove config.
+type Config struct {
+	Hosts map[string]HostConfig `yaml:"hosts"`
+}
+
+// LoadConfig reads the [forge] table from the grove config in dir. A missing
+// config or table yields the zero value.
+func LoadConfig(dir string) Config {
+	var fc Config
+	cfg, err := config.LoadFrom(dir)
+	if err != nil {
+		return fc
+	}
+	_ = cfg.UnmarshalExtension("forge", &fc)
+	return fc
+}
+
+// well-known public forges; anything else needs [forge.hosts] or a host name
+// that says what it is
+var knownHosts = map[string]Kind{
+	"github.com":   KindGitHub,
+	"gitlab.com":   KindGitLab,
+	"gitea.com":    KindGitea,
+	"codeberg.org": KindGitea,
+}
+
+// DetectKind returns the forge kind serving host.
+func DetectKind(host string, cfg Config) (Kind, error) {
+	if hc, ok := cfg.Hosts[host]; ok && hc.Type != "" {
+		switch kind := Kind(strings.ToLower(hc.Type)); kind {
+		case KindGitHub, KindGitLab, KindGitea:
+			return kind, nil
+		default:
+			return "", fmt.Errorf("forge.hosts.%q: unknown type %q (want github, gitlab or gitea)", host, hc.Type)
+		}
+	}
+	if kind, ok := knownHosts[host]; ok {
+		return kind, nil
+	}
+	name := strings.ToLower(host)
+	switch {
+	case strings.Contains(name, "github"):
+		return KindGitHub, nil
+	case strings.Contains(name, "gitlab"):
+		return KindGitLab, nil
+	case strings.Contains(name, "gitea"), strings.Contains(name, "forgejo"):
+		return KindGitea, nil
+	}
+	return "", fmt.Errorf("cannot tell which forge serves %s; set [forge.hosts.%q] type = \"github\", \"gitlab\" or \"gitea\" in grove.toml", host, host)
+}
+
+// New returns the forge serving host.
+func New(host string, cfg Config) (Forge, error) {
+	kind, err := DetectKind(host, cfg)
+	if err != nil {
+		return nil, err
+	}
+	hc := cfg.Hosts[host]
+	switch kind {
+	case KindGitLab:
+		return NewGitLab(apiURL(hc, "https://"+host+"/api/v4"), os.Getenv(tokenEnv(hc, "GITLAB_TOKEN"))), nil
+	case KindGitea:
+		return NewGitea(apiURL(hc, "https://"+host+"/api/v1"), os.Getenv(tokenEnv(hc, "GITEA_TOKEN"))), nil
+	default:
+		return NewGitHub(), nil
+	}
+}
+
+func apiURL(hc HostConfig, def string) string {
+	if hc.APIURL != "" {
+		return strings.TrimSuffix(hc.APIURL, "/")
+	}
+	return def
+}
+
+func tokenEnv(hc HostConfig, def string) string {
+	if hc.TokenEnv != "" {
+		return hc.TokenEnv
+	}
+	return def
+}
+
+// HasCIConfig reports whether the checkout at dir defines CI for any forge,
+// for when the forge itself is unknown.
+func HasCIConfig(dir string) bool {
+	for _, f := range []Forge{NewGitHub(), &GitLab{}, &Gitea{}} {
+		if f.CIConfigured(dir) {
+			return true
+		}
+	}
+	return false
+}
+
+// ForRepo returns the forge and repository behind the origin remote of the
+// checkout at dir, honoring the [forge] table of dir's grove config.
+func ForRepo(dir string) (Forge, Repo, error) {
+	cmd := exec.Command("git", "-C", dir, "config", "--get", "remote.origin.url")
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, Repo{}, fmt.Errorf("failed to get remote URL of %s: %w", dir, err)
+	}
+	repo, err := ParseRemote(strings.TrimSpace(string(out)))
+	if err != nil {
+		return nil, Repo{}, err
+	}
+	f, err := New(repo.Host, LoadConfig(dir))
+	if err != nil {
+		return nil, Repo{}, err
+	}
+	return f, repo, nil
+}
+
+// ErrNotForgeRemote is returned by ParseRemote for remotes that are not on a
+// forge, such as local paths and file:// URLs.
+var ErrNotForgeRemote = errors.New("not a forge remote")
+
+// ParseRemote parses a git remote URL: scp-like (git@host:owner/name.git),
+// ssh://, git:// or http(s)://.
+func ParseRemote(remote string) (Repo, error) {
+	var host, path string
+	if strings.Contains(remote, "://") {
+		u, err := url.Parse(remote)
+		if err != nil {
+			return Repo{}, fmt.Errorf("unable to parse repository URL %s: %w", remote, err)
+		}
+		switch u.Scheme {
+		case "http", "https":
+			host = u.Host
+		case "ssh", "git", "git+ssh":
+			host = u.Hostname()
+		default:
+			return Repo{}, fmt.Errorf("%s: %w", remote, ErrNotForgeRemote)
+		}
+		path = u.Path
+	} else if at, colon := strings.Index(remote, "@"), strings.Index(remote, ":"); colon > 0 && at < colon && !strings.Contains(remote[:colon], "/") {
+		host = remote[at+1 : colon]
+		path = remote[colon+1:]
+	} else {
+		return Repo{}, fmt.Errorf("%s: %w", remote, ErrNotForgeRemote)
+	}
+
+	path = strings.TrimSuffix(strings.Trim(path, "/"), ".git")
+	i := strings.LastIndex(path, "/")
+	if host == "" || i <= 0 || i == len(path)-1 {
+		return Repo{}, fmt.Errorf("unable to parse repository URL: %s", remote)
+	}
+	return Repo{Host: host, Owner: path[:i], Name: path[i+1:]}, nil
+}
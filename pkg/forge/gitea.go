@@ -0,0 +1,193 @@
+package forge
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Gitea is a Gitea (or Forgejo) instance, driven through its v1 REST API with
+// an access token. CI state comes from commit statuses, which Gitea Actions
+// and external CI both report.
+type Gitea struct {
+	api  restClient
+	poll poller
+}
+
+// NewGitea returns a Gitea forge for the API at apiURL
+// (https://codeberg.org/api/v1). token may be empty for public read access.
+func NewGitea(apiURL, token string) *Gitea {
+	return &Gitea{
+		api: restClient{
+			baseURL: apiURL,
+			http:    &http.Client{Timeout: 30 * time.Second},
+			auth: func(req *http.Request) {
+				if token != "" {
+					req.Header.Set("Authorization", "token "+token)
+				}
+			},
+		},
+		poll: defaultPoller(),
+	}
+}
+
+func (g *Gitea) Kind() Kind { return KindGitea }
+
+func (g *Gitea) repoPath(repo Repo) string {
+	return "/repos/" + url.PathEscape(repo.Owner) + "/" + url.PathEscape(repo.Name)
+}
+
+type giteaCommitStatus struct {
+	Status    string `json:"status"`
+	Context   string `json:"context"`
+	TargetURL string `json:"target_url"`
+}
+
+// combinedRun folds the latest status of each context at ref, keeping those
+// whose context matches, into one run: failed if any failed, pending if any
+// is pending, successful once all passed.
+func (g *Gitea) combinedRun(ctx context.Context, repo Repo, ref string, match func(context string) bool) (Run, error) {
+	var combined struct {
+		SHA      string              `json:"sha"`
+		Statuses []giteaCommitStatus `json:"statuses"`
+	}
+	if err := g.api.do(ctx, http.MethodGet, g.repoPath(repo)+"/commits/"+url.PathEscape(ref)+"/status", nil, &combined); err != nil {
+		if isStatus(err, http.StatusNotFound) {
+			return Run{State: RunNone}, nil
+		}
+		return Run{State: RunUnknown}, err
+	}
+
+	run := Run{ID: combined.SHA, State: RunNone}
+	for _, status := range combined.Statuses {
+		if match != nil && !match(status.Context) {
+			continue
+		}
+		var state RunState
+		switch status.Status {
+		case "success", "warning":
+			state = RunSuccess
+		case "failure", "error":
+			state = RunFailure
+		default:
+			state = RunPending
+		}
+		if statusSeverity[state] > statusSeverity[run.State] {
+			run.State = state
+			run.URL = status.TargetURL
+		}
+	}
+	return run, nil
+}
+
+var statusSeverity = map[RunState]int{RunNone: 0, RunSuccess: 1, RunPending: 2, RunFailure: 3}
+
+func (g *Gitea) CIStatus(ctx context.Context, repo Repo, sha string) (Run, error) {
+	return g.combinedRun(ctx, repo, sha, nil)
+}
+
+func (g *Gitea) WaitForCI(ctx context.Context, repo Repo, sha string) error {
+	run, err := g.poll.waitRun(ctx, g.poll.ciAppearTimeout, func(ctx context.Context) (Run, error) {
+		return g.CIStatus(ctx, repo, sha)
+	})
+	if errors.Is(err, errNoRun) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("CI for %s failed: %w (%s)", repo.Name, err, run.URL)
+	}
+	return nil
+}
+
+// WaitForRelease waits for the statuses of the tag's release workflow, i.e.
+// contexts naming a "release" job, like the GitHub forge's Release workflow.
+func (g *Gitea) WaitForRelease(ctx context.Context, repo Repo, tag string) error {
+	isRelease := func(context string) bool {
+		return strings.Contains(strings.ToLower(context), "release")
+	}
+	run, err := g.poll.waitRun(ctx, g.poll.releaseTimeout, func(ctx context.Context) (Run, error) {
+		return g.combinedRun(ctx, repo, tag, isRelease)
+	})
+	if errors.Is(err, errNoRun) {
+		return fmt.Errorf("no release workflow started for tag %s of %s within %s", tag, repo.Name, g.poll.releaseTimeout)
+	}
+	if err != nil {
+		return fmt.Errorf("release workflow for %s@%s failed: %w (%s)", repo.Name, tag, err, run.URL)
+	}
+	return nil
+}
+
+// CIConfigured reports whether dir has Gitea Actions workflows; Gitea also
+// runs workflows kept under .github/.
+func (g *Gitea) CIConfigured(dir string) bool {
+	for _, workflows := range []string{".gitea/workflows", ".forgejo/workflows", ".github/workflows"} {
+		if _, err := os.Stat(filepath.Join(dir, workflows)); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+func (g *Gitea) CreateRelease(ctx context.Context, repo Repo, opts ReleaseOptions) error {
+	body := map[string]interface{}{"tag_name": opts.Tag, "name": opts.Title, "body": opts.Notes, "prerelease": opts.Prerelease}
+	return g.api.do(ctx, http.MethodPost, g.repoPath(repo)+"/releases", body, nil)
+}
+
+func (g *Gitea) RepoExists(ctx context.Context, repo Repo) (bool, error) {
+	err := g.api.do(ctx, http.MethodGet, g.repoPath(repo), nil, nil)
+	if isStatus(err, http.StatusNotFound) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// CreateRepo creates the repository under the token's user when Owner is that
+// user, otherwise under the organization Owner.
+func (g *Gitea) CreateRepo(ctx context.Context, repo Repo, opts CreateRepoOptions) (string, error) {
+	var user struct {
+		Login string `json:"login"`
+	}
+	if err := g.api.do(ctx, http.MethodGet, "/user", nil, &user); err != nil {
+		return "", fmt.Errorf("failed to look up the token's user: %w", err)
+	}
+	path := "/orgs/" + url.PathEscape(repo.Owner) + "/repos"
+	if strings.EqualFold(user.Login, repo.Owner) {
+		path = "/user/repos"
+	}
+	body := map[string]interface{}{"name": repo.Name, "private": !opts.Public, "description": opts.Description}
+	var created struct {
+		CloneURL string `json:"clone_url"`
+	}
+	if err := g.api.do(ctx, http.MethodPost, path, body, &created); err != nil {
+		return "", err
+	}
+	return created.CloneURL, nil
+}
+
+func (g *Gitea) SetSecret(ctx context.Context, repo Repo, name, value string) error {
+	return g.api.do(ctx, http.MethodPut, g.repoPath(repo)+"/actions/secrets/"+url.PathEscape(name), map[string]string{"data": value}, nil)
+}
+
+func (g *Gitea) DeleteSecret(ctx context.Context, repo Repo, name string) error {
+	return g.api.do(ctx, http.MethodDelete, g.repoPath(repo)+"/actions/secrets/"+url.PathEscape(name), nil, nil)
+}
+
+func (g *Gitea) ListSecrets(ctx context.Context, repo Repo) ([]string, error) {
+	var secrets []struct {
+		Name string `json:"name"`
+	}
+	if err := g.api.do(ctx, http.MethodGet, g.repoPath(repo)+"/actions/secrets", nil, &secrets); err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(secrets))
+	for _, s := range secrets {
+		names = append(names, s.Name)
+	}
+	return names, nil
+}
@@ -0,0 +1,97 @@
+package forge
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+var giteaRepo = Repo{Host: "codeberg.org", Owner: "acme", Name: "core"}
+
+type giteaCombined struct {
+	SHA      string              `json:"sha"`
+	Statuses []giteaCommitStatus `json:"statuses"`
+}
+
+func TestGiteaWaitForRelease(t *testing.T) {
+	srv := newFakeForgeServer(t, map[string]http.HandlerFunc{
+		"GET /repos/acme/core/commits/v1.2.0/status": replySequence(
+			// CI on the tagged commit has already passed; the release
+			// workflow has not reported yet
+			giteaCombined{SHA: "abc", Statuses: []giteaCommitStatus{{Status: "success", Context: "CI / test (push)"}}},
+			giteaCombined{SHA: "abc", Statuses: []giteaCommitStatus{
+				{Status: "success", Context: "CI / test (push)"},
+				{Status: "pending", Context: "Release / build (push)"},
+			}},
+			giteaCombined{SHA: "abc", Statuses: []giteaCommitStatus{
+				{Status: "success", Context: "CI / test (push)"},
+				{Status: "success", Context: "Release / build (push)"},
+			}},
+		),
+	})
+	g := NewGitea(srv.URL, "token")
+	g.poll = fastPoller()
+
+	if err := g.WaitForRelease(context.Background(), giteaRepo, "v1.2.0"); err != nil {
+		t.Fatal(err)
+	}
+	if n := len(srv.requests); n != 3 {
+		t.Errorf("polled %d times, want 3 (until the release status passed)", n)
+	}
+}
+
+func TestGiteaCIStatusFolding(t *testing.T) {
+	srv := newFakeForgeServer(t, map[string]http.HandlerFunc{
+		"GET /repos/acme/core/commits/abc/status": replyJSON(giteaCombined{SHA: "abc", Statuses: []giteaCommitStatus{
+			{Status: "success", Context: "lint"},
+			{Status: "pending", Context: "test"},
+			{Status: "failure", Context: "build", TargetURL: "https://codeberg.org/acme/core/actions/runs/3"},
+		}}),
+	})
+	g := NewGitea(srv.URL, "")
+	g.poll = fastPoller()
+
+	run, err := g.CIStatus(context.Background(), giteaRepo, "abc")
+	if err != nil || run.State != RunFailure || run.URL != "https://codeberg.org/acme/core/actions/runs/3" {
+		t.Errorf("CIStatus = %+v, %v", run, err)
+	}
+	if err := g.WaitForCI(context.Background(), giteaRepo, "abc"); err == nil {
+		t.Error("WaitForCI should fail on a failed status")
+	}
+}
+
+func TestGiteaSecretsAndRepos(t *testing.T) {
+	var auth string
+	srv := newFakeForgeServer(t, map[string]http.HandlerFunc{
+		"PUT /repos/acme/core/actions/secrets/GROVE_PAT": func(w http.ResponseWriter, r *http.Request) {
+			auth = r.Header.Get("Authorization")
+			w.WriteHeader(http.StatusCreated)
+		},
+		"GET /repos/acme/core/actions/secrets": replyJSON([]map[string]string{{"name": "GROVE_PAT"}}),
+		"GET /user":                            replyJSON(map[string]string{"login": "someone"}),
+		"POST /orgs/acme/repos":                replyJSON(map[string]string{"clone_url": "https://codeberg.org/acme/core.git"}),
+	})
+	g := NewGitea(srv.URL, "gitea-token")
+	ctx := context.Background()
+
+	if err := g.SetSecret(ctx, giteaRepo, "GROVE_PAT", "value"); err != nil {
+		t.Fatal(err)
+	}
+	if auth != "token gitea-token" {
+		t.Errorf("Authorization = %q", auth)
+	}
+	if body := srv.body("PUT /repos/acme/core/actions/secrets/GROVE_PAT"); body["data"] != "value" {
+		t.Errorf("secret body = %v", body)
+	}
+	if names, err := g.ListSecrets(ctx, giteaRepo); err != nil || len(names) != 1 {
+		t.Errorf("ListSecrets = %v, %v", names, err)
+	}
+
+	cloneURL, err := g.CreateRepo(ctx, giteaRepo, CreateRepoOptions{Public: true})
+	if err != nil || cloneURL != "https://codeberg.org/acme/core.git" {
+		t.Fatalf("CreateRepo = %q, %v", cloneURL, err)
+	}
+	if body := srv.body("POST /orgs/acme/repos"); body["private"] != false {
+		t.Errorf("repo body = %v", body)
+	}
+}
@@ -0,0 +1,154 @@
+package forge
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/grovetools/grove/pkg/gh"
+)
+
+// GitHub is github.com or a GitHub Enterprise host, driven through the gh CLI
+// and its authentication.
+type GitHub struct{}
+
+// NewGitHub returns the GitHub forge.
+func NewGitHub() *GitHub {
+	return &GitHub{}
+}
+
+func (g *GitHub) Kind() Kind { return KindGitHub }
+
+// repoArg is repo as gh's --repo value, [HOST/]OWNER/REPO.
+func (g *GitHub) repoArg(repo Repo) string {
+	if repo.Host == "" || repo.Host == "github.com" {
+		return repo.Slug()
+	}
+	return repo.String()
+}
+
+func (g *GitHub) run(ctx context.Context, args ...string) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, "gh", args...)
+	out, err := cmd.Output()
+	if err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok && len(exitErr.Stderr) > 0 {
+			return nil, fmt.Errorf("gh %s: %s", args[0], strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		return nil, fmt.Errorf("gh %s: %w", args[0], err)
+	}
+	return out, nil
+}
+
+func (g *GitHub) CIStatus(ctx context.Context, repo Repo, sha string) (Run, error) {
+	out, err := g.run(ctx, "run", "list", "--commit", sha, "--limit", "1", "--json", "databaseId,status,conclusion,url", "--repo", g.repoArg(repo))
+	if err != nil {
+		return Run{State: RunUnknown}, err
+	}
+	var runs []struct {
+		DatabaseID int64  `json:"databaseId"`
+		Status     string `json:"status"`
+		Conclusion string `json:"conclusion"`
+		URL        string `json:"url"`
+	}
+	if err := json.Unmarshal(out, &runs); err != nil {
+		return Run{State: RunUnknown}, fmt.Errorf("failed to parse CI status: %w", err)
+	}
+	if len(runs) == 0 {
+		return Run{State: RunNone}, nil
+	}
+
+	run := Run{ID: strconv.FormatInt(runs[0].DatabaseID, 10), State: RunUnknown, URL: runs[0].URL}
+	switch runs[0].Status {
+	case "completed":
+		switch runs[0].Conclusion {
+		case "success", "neutral":
+			run.State = RunSuccess
+		case "failure", "timed_out", "startup_failure", "action_required":
+			run.State = RunFailure
+		case "cancelled":
+			run.State = RunCancelled
+		case "skipped":
+			run.State = RunSkipped
+		}
+	case "in_progress", "queued", "requested", "waiting", "pending":
+		run.State = RunPending
+	}
+	return run, nil
+}
+
+func (g *GitHub) WaitForCI(ctx context.Context, repo Repo, sha string) error {
+	return gh.WaitForCIRun(ctx, g.repoArg(repo), sha)
+}
+
+func (g *GitHub) WaitForRelease(ctx context.Context, repo Repo, tag string) error {
+	return gh.WaitForReleaseRun(ctx, g.repoArg(repo), tag)
+}
+
+func (g *GitHub) CIConfigured(dir string) bool {
+	_, err := os.Stat(filepath.Join(dir, ".github"))
+	return err == nil
+}
+
+func (g *GitHub) CreateRelease(ctx context.Context, repo Repo, opts ReleaseOptions) error {
+	args := []string{"release", "create", opts.Tag, "--repo", g.repoArg(repo), "--title", opts.Title, "--notes", opts.Notes}
+	if opts.Prerelease {
+		args = append(args, "--prerelease")
+	}
+	_, err := g.run(ctx, args...)
+	return err
+}
+
+func (g *GitHub) RepoExists(ctx context.Context, repo Repo) (bool, error) {
+	// gh does not tell "not found" from other failures by exit code
+	_, err := g.run(ctx, "repo", "view", g.repoArg(repo), "--json", "name")
+	return err == nil, nil
+}
+
+func (g *GitHub) CreateRepo(ctx context.Context, repo Repo, opts CreateRepoOptions) (string, error) {
+	args := []string{"repo", "create", g.repoArg(repo)}
+	if opts.Public {
+		args = append(args, "--public")
+	} else {
+		args = append(args, "--private")
+	}
+	if opts.Description != "" {
+		args = append(args, "--description", opts.Description)
+	}
+	if _, err := g.run(ctx, args...); err != nil {
+		return "", err
+	}
+	return repo.WebURL() + ".git", nil
+}
+
+func (g *GitHub) SetSecret(ctx context.Context, repo Repo, name, value string) error {
+	_, err := g.run(ctx, "secret", "set", name, "--body", value, "--repo", g.repoArg(repo))
+	return err
+}
+
+func (g *GitHub) DeleteSecret(ctx context.Context, repo Repo, name string) error {
+	_, err := g.run(ctx, "secret", "delete", name, "--repo", g.repoArg(repo))
+	return err
+}
+
+func (g *GitHub) ListSecrets(ctx context.Context, repo Repo) ([]string, error) {
+	out, err := g.run(ctx, "secret", "list", "--json", "name", "--repo", g.repoArg(repo))
+	if err != nil {
+		return nil, err
+	}
+	var secrets []struct {
+		Name string `json:"name"`
+	}
+	if err := json.Unmarshal(out, &secrets); err != nil {
+		return nil, fmt.Errorf("failed to parse secrets: %w", err)
+	}
+	names := make([]string, 0, len(secrets))
+	for _, s := range secrets {
+		names = append(names, s.Name)
+	}
+	return names, nil
+}
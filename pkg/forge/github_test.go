@@ -0,0 +1,80 @@
+package forge
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+// installGHMock puts a gh stand-in on PATH, in the style of the e2e mocks: it
+// logs its arguments and answers the subcommands the GitHub forge uses.
+func installGHMock(t *testing.T) (logPath string) {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("gh mock is a shell script")
+	}
+	dir := t.TempDir()
+	logPath = filepath.Join(dir, "gh.log")
+	script := `#!/bin/sh
+echo "gh $*" >> "` + logPath + `"
+case "$1 $2" in
+  "run list")    echo '[{"databaseId": 12345, "status": "completed", "conclusion": "failure", "url": "https://github.com/acme/core/actions/runs/12345"}]' ;;
+  "secret list") echo '[{"name": "GROVE_PAT"}, {"name": "NPM_TOKEN"}]' ;;
+  "repo view")   echo "repository not found" >&2; exit 1 ;;
+esac
+exit 0
+`
+	if err := os.WriteFile(filepath.Join(dir, "gh"), []byte(script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+	return logPath
+}
+
+func TestGitHubForge(t *testing.T) {
+	logPath := installGHMock(t)
+	g := NewGitHub()
+	ctx := context.Background()
+	repo := Repo{Host: "github.com", Owner: "acme", Name: "core"}
+
+	run, err := g.CIStatus(ctx, repo, "abc")
+	if err != nil || run.State != RunFailure || run.ID != "12345" {
+		t.Errorf("CIStatus = %+v, %v", run, err)
+	}
+	if exists, err := g.RepoExists(ctx, repo); err != nil || exists {
+		t.Errorf("RepoExists = %v, %v", exists, err)
+	}
+	cloneURL, err := g.CreateRepo(ctx, repo, CreateRepoOptions{Public: true})
+	if err != nil || cloneURL != "https://github.com/acme/core.git" {
+		t.Errorf("CreateRepo = %q, %v", cloneURL, err)
+	}
+	if err := g.SetSecret(ctx, repo, "GROVE_PAT", "value"); err != nil {
+		t.Error(err)
+	}
+	names, err := g.ListSecrets(ctx, repo)
+	if err != nil || strings.Join(names, ",") != "GROVE_PAT,NPM_TOKEN" {
+		t.Errorf("ListSecrets = %v, %v", names, err)
+	}
+	if err := g.CreateRelease(ctx, Repo{Host: "github.acme.internal", Owner: "acme", Name: "core"}, ReleaseOptions{Tag: "v1.0.0", Title: "v1.0.0", Prerelease: true}); err != nil {
+		t.Error(err)
+	}
+
+	data, err := os.ReadFile(logPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	log := string(data)
+	for _, want := range []string{
+		"gh run list --commit abc --limit 1 --json databaseId,status,conclusion,url --repo acme/core",
+		"gh repo create acme/core --public",
+		"gh secret set GROVE_PAT --body value --repo acme/core",
+		"gh release create v1.0.0 --repo github.acme.internal/acme/core --title v1.0.0 --notes  --prerelease",
+	} {
+		if !strings.Contains(log, want+"\n") {
+			t.Errorf("gh was not called as %q; log:\n%s", want, log)
+		}
+	}
+}
@@ -0,0 +1,199 @@
+package forge
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"time"
+)
+
+// GitLab is a GitLab instance, driven through its v4 REST API with a personal,
+// project or group access token.
+type GitLab struct {
+	api  restClient
+	poll poller
+}
+
+// NewGitLab returns a GitLab forge for the API at apiURL
+// (https://gitlab.com/api/v4). token may be empty for public read access.
+func NewGitLab(apiURL, token string) *GitLab {
+	return &GitLab{
+		api: restClient{
+			baseURL: apiURL,
+			http:    &http.Client{Timeout: 30 * time.Second},
+			auth: func(req *http.Request) {
+				if token != "" {
+					req.Header.Set("PRIVATE-TOKEN", token)
+				}
+			},
+		},
+		poll: defaultPoller(),
+	}
+}
+
+func (g *GitLab) Kind() Kind { return KindGitLab }
+
+// project returns the URL-encoded project path GitLab accepts as an :id.
+func (g *GitLab) project(repo Repo) string {
+	return "/projects/" + url.PathEscape(repo.Slug())
+}
+
+type gitlabPipeline struct {
+	ID     int64  `json:"id"`
+	Status string `json:"status"`
+	WebURL string `json:"web_url"`
+}
+
+func (p gitlabPipeline) run() Run {
+	state := RunUnknown
+	switch p.Status {
+	case "created", "waiting_for_resource", "preparing", "pending", "running", "scheduled":
+		state = RunPending
+	case "success":
+		state = RunSuccess
+	case "failed":
+		state = RunFailure
+	case "canceled":
+		state = RunCancelled
+	case "skipped", "manual":
+		state = RunSkipped
+	}
+	return Run{ID: strconv.FormatInt(p.ID, 10), State: state, URL: p.WebURL}
+}
+
+// latestPipeline returns the newest pipeline matching query (sha=... or
+// ref=...).
+func (g *GitLab) latestPipeline(ctx context.Context, repo Repo, query url.Values) (Run, error) {
+	query.Set("per_page", "1")
+	query.Set("order_by", "id")
+	query.Set("sort", "desc")
+	var pipelines []gitlabPipeline
+	if err := g.api.do(ctx, http.MethodGet, g.project(repo)+"/pipelines?"+query.Encode(), nil, &pipelines); err != nil {
+		return Run{State: RunUnknown}, err
+	}
+	if len(pipelines) == 0 {
+		return Run{State: RunNone}, nil
+	}
+	return pipelines[0].run(), nil
+}
+
+func (g *GitLab) CIStatus(ctx context.Context, repo Repo, sha string) (Run, error) {
+	return g.latestPipeline(ctx, repo, url.Values{"sha": {sha}})
+}
+
+func (g *GitLab) WaitForCI(ctx context.Context, repo Repo, sha string) error {
+	run, err := g.poll.waitRun(ctx, g.poll.ciAppearTimeout, func(ctx context.Context) (Run, error) {
+		return g.CIStatus(ctx, repo, sha)
+	})
+	if errors.Is(err, errNoRun) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("CI pipeline for %s failed: %w (%s)", repo.Name, err, run.URL)
+	}
+	return nil
+}
+
+func (g *GitLab) WaitForRelease(ctx context.Context, repo Repo, tag string) error {
+	run, err := g.poll.waitRun(ctx, g.poll.releaseTimeout, func(ctx context.Context) (Run, error) {
+		return g.latestPipeline(ctx, repo, url.Values{"ref": {tag}})
+	})
+	if errors.Is(err, errNoRun) {
+		return fmt.Errorf("no pipeline started for tag %s of %s within %s", tag, repo.Name, g.poll.releaseTimeout)
+	}
+	if err != nil {
+		return fmt.Errorf("release pipeline for %s@%s failed: %w (%s)", repo.Name, tag, err, run.URL)
+	}
+	return nil
+}
+
+func (g *GitLab) CIConfigured(dir string) bool {
+	_, err := os.Stat(filepath.Join(dir, ".gitlab-ci.yml"))
+	return err == nil
+}
+
+func (g *GitLab) CreateRelease(ctx context.Context, repo Repo, opts ReleaseOptions) error {
+	// GitLab has no prerelease flag; upcoming releases are date-driven
+	body := map[string]string{"tag_name": opts.Tag, "name": opts.Title, "description": opts.Notes}
+	return g.api.do(ctx, http.MethodPost, g.project(repo)+"/releases", body, nil)
+}
+
+func (g *GitLab) RepoExists(ctx context.Context, repo Repo) (bool, error) {
+	err := g.api.do(ctx, http.MethodGet, g.project(repo), nil, nil)
+	if isStatus(err, http.StatusNotFound) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+func (g *GitLab) CreateRepo(ctx context.Context, repo Repo, opts CreateRepoOptions) (string, error) {
+	var namespace struct {
+		ID int64 `json:"id"`
+	}
+	if err := g.api.do(ctx, http.MethodGet, "/namespaces/"+url.PathEscape(repo.Owner), nil, &namespace); err != nil {
+		return "", fmt.Errorf("failed to look up namespace %s: %w", repo.Owner, err)
+	}
+	visibility := "private"
+	if opts.Public {
+		visibility = "public"
+	}
+	body := map[string]interface{}{
+		"name":         repo.Name,
+		"path":         repo.Name,
+		"namespace_id": namespace.ID,
+		"visibility":   visibility,
+		"description":  opts.Description,
+	}
+	var created struct {
+		HTTPURL string `json:"http_url_to_repo"`
+	}
+	if err := g.api.do(ctx, http.MethodPost, "/projects", body, &created); err != nil {
+		return "", err
+	}
+	return created.HTTPURL, nil
+}
+
+// maskableValue matches values GitLab accepts for masked variables.
+var maskableValue = regexp.MustCompile(`^[A-Za-z0-9+/=@:.~_-]{8,}$`)
+
+func (g *GitLab) SetSecret(ctx context.Context, repo Repo, name, value string) error {
+	path := g.project(repo) + "/variables"
+	body := map[string]interface{}{"value": value, "masked": maskableValue.MatchString(value)}
+	err := g.api.do(ctx, http.MethodPut, path+"/"+url.PathEscape(name), body, nil)
+	if !isStatus(err, http.StatusNotFound) {
+		return err
+	}
+	body["key"] = name
+	return g.api.do(ctx, http.MethodPost, path, body, nil)
+}
+
+func (g *GitLab) DeleteSecret(ctx context.Context, repo Repo, name string) error {
+	return g.api.do(ctx, http.MethodDelete, g.project(repo)+"/variables/"+url.PathEscape(name), nil, nil)
+}
+
+// ListSecrets follows GitLab's X-Next-Page header, which is empty on the
+// last page.
+func (g *GitLab) ListSecrets(ctx context.Context, repo Repo) ([]string, error) {
+	var names []string
+	for page := "1"; page != ""; {
+		var variables []struct {
+			Key string `json:"key"`
+		}
+		query := url.Values{"per_page": {"100"}, "page": {page}}
+		header, err := g.api.doHeader(ctx, http.MethodGet, g.project(repo)+"/variables?"+query.Encode(), nil, &variables)
+		if err != nil {
+			return nil, err
+		}
+		for _, v := range variables {
+			names = append(names, v.Key)
+		}
+		page = header.Get("X-Next-Page")
+	}
+	return names, nil
+}
@@ -0,0 +1,226 @@
+package forge
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeForgeServer is a local stand-in for a forge's REST API. Handlers are
+// keyed by "METHOD escaped-path"; requests are recorded in order.
+type fakeForgeServer struct {
+	*httptest.Server
+	mu       sync.Mutex
+	handlers map[string]http.HandlerFunc
+	requests []string
+	bodies   map[string]map[string]interface{}
+}
+
+func newFakeForgeServer(t *testing.T, handlers map[string]http.HandlerFunc) *fakeForgeServer {
+	t.Helper()
+	s := &fakeForgeServer{handlers: handlers, bodies: map[string]map[string]interface{}{}}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := r.Method + " " + r.URL.EscapedPath()
+		s.mu.Lock()
+		s.requests = append(s.requests, key)
+		if r.Body != nil {
+			var body map[string]interface{}
+			if json.NewDecoder(r.Body).Decode(&body) == nil {
+				s.bodies[key] = body
+			}
+		}
+		handler, ok := s.handlers[key]
+		s.mu.Unlock()
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		handler(w, r)
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *fakeForgeServer) body(key string) map[string]interface{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.bodies[key]
+}
+
+func replyJSON(v interface{}) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(v)
+	}
+}
+
+// replySequence answers with each reply in turn, repeating the last.
+func replySequence(replies ...interface{}) http.HandlerFunc {
+	var mu sync.Mutex
+	n := 0
+	return func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		reply := replies[n]
+		if n < len(replies)-1 {
+			n++
+		}
+		mu.Unlock()
+		replyJSON(reply)(w, r)
+	}
+}
+
+func fastPoller() poller {
+	return poller{interval: time.Millisecond, ciAppearTimeout: 20 * time.Millisecond, releaseTimeout: 20 * time.Millisecond, watchTimeout: time.Second}
+}
+
+var gitlabRepo = Repo{Host: "gitlab.example.com", Owner: "platform/tools", Name: "core"}
+
+const gitlabProject = "/projects/platform%2Ftools%2Fcore"
+
+func TestGitLabWaitForCI(t *testing.T) {
+	srv := newFakeForgeServer(t, map[string]http.HandlerFunc{
+		"GET " + gitlabProject + "/pipelines": replySequence(
+			[]gitlabPipeline{},
+			[]gitlabPipeline{{ID: 7, Status: "running"}},
+			[]gitlabPipeline{{ID: 7, Status: "success"}},
+		),
+	})
+	g := NewGitLab(srv.URL, "glpat-test")
+	g.poll = fastPoller()
+
+	if err := g.WaitForCI(context.Background(), gitlabRepo, "abc123"); err != nil {
+		t.Fatal(err)
+	}
+	run, err := g.CIStatus(context.Background(), gitlabRepo, "abc123")
+	if err != nil || run.State != RunSuccess || run.ID != "7" {
+		t.Errorf("CIStatus = %+v, %v", run, err)
+	}
+}
+
+func TestGitLabWaitForReleaseFailure(t *testing.T) {
+	srv := newFakeForgeServer(t, map[string]http.HandlerFunc{
+		"GET " + gitlabProject + "/pipelines": replyJSON([]gitlabPipeline{{ID: 9, Status: "failed", WebURL: "https://gitlab.example.com/p/9"}}),
+	})
+	g := NewGitLab(srv.URL, "")
+	g.poll = fastPoller()
+
+	err := g.WaitForRelease(context.Background(), gitlabRepo, "v1.2.0")
+	if err == nil || !strings.Contains(err.Error(), "failure") {
+		t.Fatalf("WaitForRelease = %v, want a failed pipeline", err)
+	}
+}
+
+func TestGitLabNoPipeline(t *testing.T) {
+	srv := newFakeForgeServer(t, map[string]http.HandlerFunc{
+		"GET " + gitlabProject + "/pipelines": replyJSON([]gitlabPipeline{}),
+	})
+	g := NewGitLab(srv.URL, "")
+	g.poll = fastPoller()
+
+	if err := g.WaitForCI(context.Background(), gitlabRepo, "abc123"); err != nil {
+		t.Errorf("a missing CI pipeline should not fail: %v", err)
+	}
+	if err := g.WaitForRelease(context.Background(), gitlabRepo, "v1.2.0"); err == nil {
+		t.Error("a missing release pipeline should fail")
+	}
+}
+
+func TestGitLabSecrets(t *testing.T) {
+	var token string
+	srv := newFakeForgeServer(t, map[string]http.HandlerFunc{
+		"POST " + gitlabProject + "/variables": func(w http.ResponseWriter, r *http.Request) {
+			token = r.Header.Get("PRIVATE-TOKEN")
+			w.WriteHeader(http.StatusCreated)
+		},
+		"GET " + gitlabProject + "/variables":              replyJSON([]map[string]string{{"key": "GROVE_PAT"}}),
+		"DELETE " + gitlabProject + "/variables/GROVE_PAT": func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusNoContent) },
+	})
+	g := NewGitLab(srv.URL, "glpat-test")
+	ctx := context.Background()
+
+	// The PUT 404s (no such variable yet), so SetSecret creates it
+	if err := g.SetSecret(ctx, gitlabRepo, "GROVE_PAT", "ghp_0123456789abcdef"); err != nil {
+		t.Fatal(err)
+	}
+	if token != "glpat-test" {
+		t.Errorf("PRIVATE-TOKEN = %q", token)
+	}
+	body := srv.body("POST " + gitlabProject + "/variables")
+	if body["key"] != "GROVE_PAT" || body["masked"] != true {
+		t.Errorf("variable body = %v", body)
+	}
+
+	names, err := g.ListSecrets(ctx, gitlabRepo)
+	if err != nil || len(names) != 1 || names[0] != "GROVE_PAT" {
+		t.Errorf("ListSecrets = %v, %v", names, err)
+	}
+	if err := g.DeleteSecret(ctx, gitlabRepo, "GROVE_PAT"); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestGitLabListSecretsPaginates(t *testing.T) {
+	srv := newFakeForgeServer(t, map[string]http.HandlerFunc{
+		"GET " + gitlabProject + "/variables": func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Query().Get("page") == "1" {
+				w.Header().Set("X-Next-Page", "2")
+				replyJSON([]map[string]string{{"key": "GROVE_PAT"}})(w, r)
+				return
+			}
+			replyJSON([]map[string]string{{"key": "NPM_TOKEN"}})(w, r)
+		},
+	})
+	g := NewGitLab(srv.URL, "glpat-test")
+
+	names, err := g.ListSecrets(context.Background(), gitlabRepo)
+	if err != nil || len(names) != 2 || names[0] != "GROVE_PAT" || names[1] != "NPM_TOKEN" {
+		t.Errorf("ListSecrets = %v, %v", names, err)
+	}
+}
+
+func TestGitLabWaitStopsOnAuthError(t *testing.T) {
+	srv := newFakeForgeServer(t, map[string]http.HandlerFunc{
+		"GET " + gitlabProject + "/pipelines": func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, `{"message":"401 Unauthorized"}`, http.StatusUnauthorized)
+		},
+	})
+	g := NewGitLab(srv.URL, "glpat-bad")
+	g.poll = fastPoller()
+
+	if err := g.WaitForCI(context.Background(), gitlabRepo, "abc123"); err == nil || !isStatus(errors.Unwrap(err), http.StatusUnauthorized) {
+		t.Errorf("WaitForCI = %v, want the 401", err)
+	}
+	if n := len(srv.requests); n != 1 {
+		t.Errorf("polled %d times, want 1 (a 401 is not retried)", n)
+	}
+}
+
+func TestGitLabCreateRepo(t *testing.T) {
+	srv := newFakeForgeServer(t, map[string]http.HandlerFunc{
+		"GET /namespaces/platform%2Ftools": replyJSON(map[string]int{"id": 42}),
+		"POST /projects":                   replyJSON(map[string]string{"http_url_to_repo": "https://gitlab.example.com/platform/tools/core.git"}),
+	})
+	g := NewGitLab(srv.URL, "glpat-test")
+
+	exists, err := g.RepoExists(context.Background(), gitlabRepo)
+	if err != nil || exists {
+		t.Fatalf("RepoExists = %v, %v", exists, err)
+	}
+	cloneURL, err := g.CreateRepo(context.Background(), gitlabRepo, CreateRepoOptions{Description: "core"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cloneURL != "https://gitlab.example.com/platform/tools/core.git" {
+		t.Errorf("clone URL = %q", cloneURL)
+	}
+	body := srv.body("POST /projects")
+	if body["namespace_id"] != float64(42) || body["visibility"] != "private" {
+		t.Errorf("project body = %v", body)
+	}
+}
@@ -0,0 +1,147 @@
+package forge
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// restClient is the JSON-over-HTTP plumbing shared by the GitLab and Gitea
+// forges.
+type restClient struct {
+	baseURL string
+	http    *http.Client
+	auth    func(*http.Request)
+}
+
+// apiError is a non-2xx response.
+type apiError struct {
+	Method, URL string
+	Status      int
+	Body        string
+}
+
+func (e *apiError) Error() string {
+	return fmt.Sprintf("%s %s: %d %s", e.Method, e.URL, e.Status, e.Body)
+}
+
+func isStatus(err error, status int) bool {
+	apiErr, ok := err.(*apiError)
+	return ok && apiErr.Status == status
+}
+
+// isTransient reports whether a failed request is worth retrying: network
+// errors and 5xx responses are; any other status (bad token, missing repo)
+// will fail the same way on every poll.
+func isTransient(err error) bool {
+	apiErr, ok := err.(*apiError)
+	return !ok || apiErr.Status >= 500
+}
+
+// do sends body (if non-nil) as JSON and decodes a JSON response into out (if
+// non-nil).
+func (c *restClient) do(ctx context.Context, method, path string, body, out interface{}) error {
+	_, err := c.doHeader(ctx, method, path, body, out)
+	return err
+}
+
+// doHeader is do that also returns the response headers, for the pagination
+// links some endpoints return there.
+func (c *restClient) doHeader(ctx context.Context, method, path string, body, out interface{}) (http.Header, error) {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		reader = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, reader)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.auth != nil {
+		c.auth(req)
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, &apiError{Method: method, URL: req.URL.String(), Status: resp.StatusCode, Body: string(bytes.TrimSpace(data))}
+	}
+	if out == nil || len(data) == 0 {
+		return resp.Header, nil
+	}
+	if err := json.Unmarshal(data, out); err != nil {
+		return nil, fmt.Errorf("%s %s: %w", method, req.URL, err)
+	}
+	return resp.Header, nil
+}
+
+// poller controls how the REST forges poll for CI runs.
+type poller struct {
+	interval        time.Duration
+	ciAppearTimeout time.Duration // a CI run that never starts is not an error
+	releaseTimeout  time.Duration // a release run that never starts is
+	watchTimeout    time.Duration
+}
+
+func defaultPoller() poller {
+	return poller{
+		interval:        5 * time.Second,
+		ciAppearTimeout: 5 * time.Minute,
+		releaseTimeout:  30 * time.Minute,
+		watchTimeout:    30 * time.Minute,
+	}
+}
+
+// errNoRun is returned by waitRun when no run appeared in time.
+var errNoRun = fmt.Errorf("no run appeared")
+
+// waitRun polls latest until it reports a run, then until that run is done.
+// It fails with errNoRun when no run appears within appear, and with an error
+// when the run does not succeed. Transient errors are polled through; any
+// other API error is returned at once.
+func (p poller) waitRun(ctx context.Context, appear time.Duration, latest func(context.Context) (Run, error)) (Run, error) {
+	appearBy := time.Now().Add(appear)
+	deadline := appearBy.Add(p.watchTimeout)
+	for {
+		run, err := latest(ctx)
+		if err != nil && !isTransient(err) {
+			return run, err
+		}
+		if err == nil {
+			switch {
+			case run.State == RunNone && time.Now().After(appearBy):
+				return run, errNoRun
+			case run.State == RunSuccess || run.State == RunSkipped:
+				return run, nil
+			case run.State.Done():
+				return run, fmt.Errorf("run %s finished with %s", run.ID, run.State)
+			}
+		}
+		if time.Now().After(deadline) {
+			return run, fmt.Errorf("timeout waiting for run %s to finish", run.ID)
+		}
+		select {
+		case <-ctx.Done():
+			return run, ctx.Err()
+		case <-time.After(p.interval):
+		}
+	}
+}
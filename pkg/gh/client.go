@@ -221,7 +221,12 @@ func WaitForReleaseWorkflow(ctx context.Context, repoPath, versionTag string) er
 	if err != nil {
 		return fmt.Errorf("failed to get repository slug: %w", err)
 	}
+	return WaitForReleaseRun(ctx, slug, versionTag)
+}
 
+// WaitForReleaseRun is WaitForReleaseWorkflow for a repository given as a gh
+// --repo argument ([HOST/]OWNER/REPO) rather than a checkout.
+func WaitForReleaseRun(ctx context.Context, slug, versionTag string) error {
 	// Create a context with 60-minute timeout for the entire operation
 	// (30 minutes to find workflows + 30 minutes to watch them complete)
 	watchCtx, cancel := context.WithTimeout(ctx, 60*time.Minute)
@@ -234,11 +239,7 @@ func WaitForReleaseWorkflow(ctx context.Context, repoPath, versionTag string) er
 // waitForBothWorkflows waits for both CI and release workflows to complete
 // It first waits for any existing CI workflow to complete, then waits for the release workflow
 func waitForBothWorkflows(ctx context.Context, slug, versionTag string) error {
-	// Extract repo name from slug (owner/repo -> repo)
-	repoName := slug
-	if parts := strings.Split(slug, "/"); len(parts) == 2 {
-		repoName = parts[1]
-	}
+	repoName := slugRepoName(slug)
 
 	fmt.Printf("%s\n", waitingStyle().Render("Waiting for workflows to complete for "+repoName+"@"+versionTag+"..."))
 
@@ -420,10 +421,22 @@ func WaitForCIWorkflow(ctx context.Context, repoPath string) error {
 	if err != nil {
 		return err
 	}
-	repoName := slug
-	if parts := strings.Split(slug, "/"); len(parts) == 2 {
-		repoName = parts[1]
+
+	// Get latest commit on main
+	cmd := exec.CommandContext(ctx, "git", "rev-parse", "origin/main")
+	cmd.Dir = repoPath
+	output, err := cmd.Output()
+	if err != nil {
+		return fmt.Errorf("failed to get latest commit on main for %s: %w", slugRepoName(slug), err)
 	}
+	return WaitForCIRun(ctx, slug, strings.TrimSpace(string(output)))
+}
+
+// WaitForCIRun waits for the CI workflow run of commitSHA in the repository
+// given as a gh --repo argument ([HOST/]OWNER/REPO). Like WaitForCIWorkflow it
+// returns immediately when the repository has no CI workflow.
+func WaitForCIRun(ctx context.Context, slug, commitSHA string) error {
+	repoName := slugRepoName(slug)
 
 	// First, check if CI workflow exists at all
 	checkCmd := exec.CommandContext(ctx, "gh", "workflow", "list",
@@ -457,17 +470,9 @@ func WaitForCIWorkflow(ctx context.Context, repoPath string) error {
 		return nil
 	}
 
-	// Get latest commit on main
-	cmd := exec.CommandContext(ctx, "git", "rev-parse", "origin/main")
-	cmd.Dir = repoPath
-	output, err := cmd.Output()
-	if err != nil {
-		return fmt.Errorf("failed to get latest commit on main for %s: %w", repoName, err)
-	}
-	commitSHA := strings.TrimSpace(string(output))
-
 	// Poll for the CI run for this commit to appear
 	var runID string
+	var cmd *exec.Cmd
 	findTimeout := time.After(5 * time.Minute) // Reduced from 15 to 5 minutes
 	ticker := time.NewTicker(5 * time.Second)
 	defer ticker.Stop()
@@ -519,3 +524,8 @@ foundCI:
 	fmt.Printf("%s\n", successStyle().Render("CI workflow for "+repoName+" completed successfully"))
 	return nil
 }
+
+// slugRepoName returns the repository name of a [HOST/]OWNER/REPO slug.
+func slugRepoName(slug string) string {
+	return slug[strings.LastIndex(slug, "/")+1:]
+}
@@ -0,0 +1,469 @@
+package orchestrator
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// maxArtifactSnapshots bounds how many snapshots the store keeps per
+// workspace; the least recently used are pruned after each new snapshot.
+const maxArtifactSnapshots = 5
+
+// ArtifactStore is the local store behind build-output restore: a snapshot of
+// a job's declared outputs (the top-level files of bin/, or of
+// Target.OutDir() under a cross target) per cache token, at
+// <Dir>/<workspace>/<key>/ alongside a manifest of each file's hash and mode.
+//
+// Deciding a build cache hit verifies, read-only, that the snapshot can bring
+// the workspace's outputs up to date; a hit with no usable snapshot is
+// demoted to a miss. Once the hit is taken, the orchestrator restores
+// whatever is missing or different. That is what makes "cached" mean "your
+// binaries are there and current" rather than just "skipped".
+type ArtifactStore struct {
+	Dir string
+}
+
+// artifactManifest is the on-disk shape of a snapshot's manifest.json.
+type artifactManifest struct {
+	OutDir string          `json:"out_dir"`
+	Files  []artifactEntry `json:"files"`
+}
+
+type artifactEntry struct {
+	Name   string      `json:"name"`
+	SHA256 string      `json:"sha256"`
+	Mode   os.FileMode `json:"mode"`
+}
+
+// artifactKey derives the snapshot directory name from the verb key and the
+// cache token the result was recorded under.
+func artifactKey(verbKey, token string) string {
+	h := sha256.Sum256([]byte(verbKey + "\x00" + token))
+	return hex.EncodeToString(h[:])[:32]
+}
+
+func (s *ArtifactStore) snapshotDir(workspace, verbKey, token string) string {
+	return filepath.Join(s.Dir, workspace, artifactKey(verbKey, token))
+}
+
+// Snapshot copies the regular files at the top level of <wsPath>/<outDir>
+// into the store under (verbKey, token). Subdirectories are skipped: native
+// bin/ holds cross-target bin/<goos>_<goarch> dirs that belong to other keys.
+// A workspace with no outputs records an empty manifest, so library repos
+// still hit.
+func (s *ArtifactStore) Snapshot(workspace, wsPath, outDir, verbKey, token string) error {
+	dst := s.snapshotDir(workspace, verbKey, token)
+	tmp := dst + ".tmp"
+	if err := os.RemoveAll(tmp); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(tmp, 0o755); err != nil {
+		return err
+	}
+
+	manifest := artifactManifest{OutDir: outDir}
+	src := filepath.Join(wsPath, outDir)
+	entries, err := os.ReadDir(src)
+	if err != nil && !os.IsNotExist(err) {
+		os.RemoveAll(tmp)
+		return err
+	}
+	for _, e := range entries {
+		if !e.Type().IsRegular() {
+			continue
+		}
+		info, err := e.Info()
+		if err != nil {
+			os.RemoveAll(tmp)
+			return err
+		}
+		sum, err := copyFileHashed(filepath.Join(src, e.Name()), filepath.Join(tmp, e.Name()), info.Mode().Perm())
+		if err != nil {
+			os.RemoveAll(tmp)
+			return fmt.Errorf("snapshot %s/%s: %w", outDir, e.Name(), err)
+		}
+		manifest.Files = append(manifest.Files, artifactEntry{Name: e.Name(), SHA256: sum, Mode: info.Mode().Perm()})
+	}
+
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		os.RemoveAll(tmp)
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(tmp, "manifest.json"), data, 0o644); err != nil {
+		os.RemoveAll(tmp)
+		return err
+	}
+	if err := os.RemoveAll(dst); err != nil {
+		os.RemoveAll(tmp)
+		return err
+	}
+	if err := os.Rename(tmp, dst); err != nil {
+		os.RemoveAll(tmp)
+		return err
+	}
+	s.prune(workspace)
+	return nil
+}
+
+// load reads the manifest of the snapshot stored under (verbKey, token).
+// Snapshots may come from a remote cache, so the manifest must name outDir
+// and only flat file names are honored.
+func (s *ArtifactStore) load(workspace, outDir, verbKey, token string) (string, artifactManifest, error) {
+	dir := s.snapshotDir(workspace, verbKey, token)
+	var manifest artifactManifest
+	data, err := os.ReadFile(filepath.Join(dir, "manifest.json"))
+	if err != nil {
+		return dir, manifest, fmt.Errorf("no artifact snapshot for %s: %w", workspace, err)
+	}
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return dir, manifest, fmt.Errorf("corrupt artifact manifest for %s: %w", workspace, err)
+	}
+	if manifest.OutDir != outDir {
+		return dir, manifest, fmt.Errorf("artifact snapshot for %s is for %s, not %s", workspace, manifest.OutDir, outDir)
+	}
+	for _, f := range manifest.Files {
+		if f.Name != filepath.Base(f.Name) || f.Name == "." || f.Name == ".." || f.Name == "manifest.json" {
+			return dir, manifest, fmt.Errorf("artifact snapshot for %s names an invalid file %q", workspace, f.Name)
+		}
+	}
+	return dir, manifest, nil
+}
+
+// staleOutputs returns the manifest entries whose copy under dstDir is
+// missing or differs.
+func staleOutputs(dstDir string, manifest artifactManifest) []artifactEntry {
+	var stale []artifactEntry
+	for _, f := range manifest.Files {
+		if sum, err := hashFile(filepath.Join(dstDir, f.Name)); err != nil || sum != f.SHA256 {
+			stale = append(stale, f)
+		}
+	}
+	return stale
+}
+
+// Verify checks, without writing anything, that the snapshot stored under
+// (verbKey, token) can bring the workspace's <outDir> outputs up to date:
+// every output that is missing or differs must be in the snapshot with its
+// recorded hash. It returns how many files a Restore would copy back, and an
+// error when the snapshot is missing or unusable (a cache miss).
+func (s *ArtifactStore) Verify(workspace, wsPath, outDir, verbKey, token string) (int, error) {
+	dir, manifest, err := s.load(workspace, outDir, verbKey, token)
+	if err != nil {
+		return 0, err
+	}
+	stale := staleOutputs(filepath.Join(wsPath, outDir), manifest)
+	for _, f := range stale {
+		if sum, err := hashFile(filepath.Join(dir, f.Name)); err != nil || sum != f.SHA256 {
+			return 0, fmt.Errorf("artifact snapshot for %s is corrupt: %s hash mismatch", workspace, f.Name)
+		}
+	}
+	return len(stale), nil
+}
+
+// Restore copies back every <outDir> output of the workspace that is missing
+// or differs from the snapshot stored under (verbKey, token), and marks the
+// snapshot as used so pruning evicts it last. It returns the number of files
+// restored, and an error when no usable snapshot exists.
+func (s *ArtifactStore) Restore(workspace, wsPath, outDir, verbKey, token string) (int, error) {
+	dir, manifest, err := s.load(workspace, outDir, verbKey, token)
+	if err != nil {
+		return 0, err
+	}
+	now := time.Now()
+	_ = os.Chtimes(dir, now, now)
+
+	dstDir := filepath.Join(wsPath, outDir)
+	restored := 0
+	for _, f := range staleOutputs(dstDir, manifest) {
+		dst := filepath.Join(dstDir, f.Name)
+		if err := os.MkdirAll(dstDir, 0o755); err != nil {
+			return restored, err
+		}
+		// Copy beside the destination and rename over it: a running binary
+		// (or a symlink pointing at it) never sees a partial file.
+		tmp := dst + ".grove-restore"
+		sum, err := copyFileHashed(filepath.Join(dir, f.Name), tmp, f.Mode)
+		if err != nil {
+			os.Remove(tmp)
+			return restored, err
+		}
+		if sum != f.SHA256 {
+			os.Remove(tmp)
+			return restored, fmt.Errorf("artifact snapshot for %s is corrupt: %s hash mismatch", workspace, f.Name)
+		}
+		if err := os.Rename(tmp, dst); err != nil {
+			os.Remove(tmp)
+			return restored, err
+		}
+		restored++
+	}
+	return restored, nil
+}
+
+// Export writes the snapshot stored under (verbKey, token) as a gzipped tar of
+// its flat directory (manifest.json plus the files) — the artifact body of
+// the remote cache protocol.
+func (s *ArtifactStore) Export(workspace, verbKey, token string, w io.Writer) error {
+	dir := s.snapshotDir(workspace, verbKey, token)
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return fmt.Errorf("no artifact snapshot for %s: %w", workspace, err)
+	}
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+	for _, e := range entries {
+		if !e.Type().IsRegular() {
+			continue
+		}
+		info, err := e.Info()
+		if err != nil {
+			return err
+		}
+		hdr, err := tar.FileInfoHeader(info, "")
+		if err != nil {
+			return err
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+		f, err := os.Open(filepath.Join(dir, e.Name()))
+		if err != nil {
+			return err
+		}
+		_, err = io.Copy(tw, f)
+		f.Close()
+		if err != nil {
+			return err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return gz.Close()
+}
+
+// Import unpacks an Export stream into the store under (verbKey, token). Only
+// flat regular-file entries are accepted, and the result must carry a
+// manifest; Restore re-verifies every file hash before it is used.
+func (s *ArtifactStore) Import(workspace, verbKey, token string, r io.Reader) error {
+	dst := s.snapshotDir(workspace, verbKey, token)
+	tmp := dst + ".tmp"
+	if err := os.RemoveAll(tmp); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(tmp, 0o755); err != nil {
+		return err
+	}
+	fail := func(err error) error {
+		os.RemoveAll(tmp)
+		return err
+	}
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return fail(err)
+	}
+	tr := tar.NewReader(gz)
+	hasManifest := false
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return fail(err)
+		}
+		if hdr.Typeflag != tar.TypeReg || hdr.Name != filepath.Base(hdr.Name) || strings.HasPrefix(hdr.Name, ".") {
+			return fail(fmt.Errorf("unexpected entry %q in artifact snapshot for %s", hdr.Name, workspace))
+		}
+		f, err := os.OpenFile(filepath.Join(tmp, hdr.Name), os.O_CREATE|os.O_WRONLY|os.O_TRUNC, os.FileMode(hdr.Mode).Perm())
+		if err != nil {
+			return fail(err)
+		}
+		_, err = io.Copy(f, tr)
+		f.Close()
+		if err != nil {
+			return fail(err)
+		}
+		if hdr.Name == "manifest.json" {
+			hasManifest = true
+		}
+	}
+	if !hasManifest {
+		return fail(fmt.Errorf("artifact snapshot for %s has no manifest", workspace))
+	}
+	if err := os.RemoveAll(dst); err != nil {
+		return fail(err)
+	}
+	if err := os.Rename(tmp, dst); err != nil {
+		return fail(err)
+	}
+	s.prune(workspace)
+	return nil
+}
+
+// prune keeps only the maxArtifactSnapshots most recently used snapshots of
+// workspace, by directory mtime: set when a snapshot is written and bumped
+// by every Restore. Failures are ignored: pruning is housekeeping.
+func (s *ArtifactStore) prune(workspace string) {
+	root := filepath.Join(s.Dir, workspace)
+	entries, err := os.ReadDir(root)
+	if err != nil {
+		return
+	}
+	type snap struct {
+		path string
+		mod  int64
+	}
+	var snaps []snap
+	for _, e := range entries {
+		if !e.IsDir() || filepath.Ext(e.Name()) == ".tmp" {
+			continue
+		}
+		info, err := e.Info()
+		if err != nil {
+			continue
+		}
+		snaps = append(snaps, snap{path: filepath.Join(root, e.Name()), mod: info.ModTime().UnixNano()})
+	}
+	if len(snaps) <= maxArtifactSnapshots {
+		return
+	}
+	sort.Slice(snaps, func(i, j int) bool { return snaps[i].mod > snaps[j].mod })
+	for _, sn := range snaps[maxArtifactSnapshots:] {
+		os.RemoveAll(sn.path)
+	}
+}
+
+// copyFileHashed copies src to dst with the given permissions and returns the
+// SHA-256 of the bytes written.
+func copyFileHashed(src, dst string, mode os.FileMode) (string, error) {
+	in, err := os.Open(src)
+	if err != nil {
+		return "", err
+	}
+	defer in.Close()
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, mode)
+	if err != nil {
+		return "", err
+	}
+	h := sha256.New()
+	if _, err := io.Copy(io.MultiWriter(out, h), in); err != nil {
+		out.Close()
+		return "", err
+	}
+	if err := out.Close(); err != nil {
+		return "", err
+	}
+	// OpenFile's mode is subject to umask and ignored for existing files.
+	if err := os.Chmod(dst, mode); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+func hashFile(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// outputDir is the repo-relative directory holding a build's declared
+// outputs: bin/ natively, Target.OutDir() under a cross target.
+func (o *Orchestrator) outputDir() string {
+	if t, ok := o.crossTarget(); ok {
+		return t.OutDir()
+	}
+	return "bin"
+}
+
+// resultToken is the token the job's result is keyed under in the active
+// cache mode (cacheToken or contentToken); "" when it cannot be derived.
+func (o *Orchestrator) resultToken(job TaskJob, states map[string]WorkspaceState) string {
+	if o.Options.CacheMode == CacheModeContent {
+		return o.contentToken(job, states)
+	}
+	s, ok := states[job.Name]
+	if !ok {
+		return ""
+	}
+	return o.cacheToken(s.CommitHash, job, states)
+}
+
+// hasArtifacts is the output half of a build cache hit decision: with an
+// artifact store configured, a snapshot matching the cached result must be
+// able to bring the job's outputs up to date, or the hit is demoted to a
+// miss and the job rebuilds. It writes nothing to the workspace; the caller
+// runs restoreArtifacts once it takes the hit. Non-build verbs have no
+// declared outputs.
+func (o *Orchestrator) hasArtifacts(ctx context.Context, job TaskJob, verb string, states map[string]WorkspaceState) bool {
+	if o.Artifacts == nil || verb != "build" {
+		return true
+	}
+	token := o.resultToken(job, states)
+	if token == "" {
+		return false
+	}
+	if _, err := o.Artifacts.Verify(job.Name, job.Path, o.outputDir(), o.verbKey(verb), token); err == nil {
+		return true
+	}
+	// No usable local snapshot: the remote cache may have one from the
+	// machine that built these inputs.
+	if err := o.fetchRemoteArtifacts(ctx, job, verb, token); err != nil {
+		return false
+	}
+	_, err := o.Artifacts.Verify(job.Name, job.Path, o.outputDir(), o.verbKey(verb), token)
+	return err == nil
+}
+
+// restoreArtifacts puts a cache hit's outputs back from the snapshot
+// hasArtifacts verified. It returns false when the restore fails, in which
+// case the caller runs the job after all.
+func (o *Orchestrator) restoreArtifacts(job TaskJob, verb string, states map[string]WorkspaceState) bool {
+	if o.Artifacts == nil || verb != "build" {
+		return true
+	}
+	token := o.resultToken(job, states)
+	if token == "" {
+		return false
+	}
+	_, err := o.Artifacts.Restore(job.Name, job.Path, o.outputDir(), o.verbKey(verb), token)
+	return err == nil
+}
+
+// snapshotArtifacts records a successful build's outputs under the token its
+// result is cached by. A commit token cannot describe a dirty tree (the job's
+// own or a go.work sibling's), so such a build is not snapshotted: it would
+// overwrite the clean commit's snapshot with binaries built from uncommitted
+// edits. A failed snapshot only costs the next hit (it becomes a miss), so the
+// error is surfaced as a warning line, never a build failure.
+func (o *Orchestrator) snapshotArtifacts(job TaskJob, verb string, states map[string]WorkspaceState, eventsChan chan<- TaskEvent) {
+	if o.Artifacts == nil || verb != "build" || !o.remoteTokenTrusted(job, states) {
+		return
+	}
+	token := o.resultToken(job, states)
+	if token == "" {
+		return
+	}
+	if err := o.Artifacts.Snapshot(job.Name, job.Path, o.outputDir(), o.verbKey(verb), token); err != nil {
+		eventsChan <- TaskEvent{Job: job, Verb: verb, Type: "output", OutputLine: fmt.Sprintf("warning: could not snapshot build outputs: %v", err)}
+	}
+}
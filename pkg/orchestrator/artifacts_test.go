@@ -0,0 +1,230 @@
+package orchestrator
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/grovetools/core/pkg/models"
+)
+
+func writeOutput(t *testing.T, wsPath, rel, content string) {
+	t.Helper()
+	path := filepath.Join(wsPath, rel)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o755); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestArtifacts_HitRestoresWipedBin is the ticket's scenario: bin/ is wiped
+// after a cached build, and the next cache hit must put the binary back
+// instead of merely skipping.
+func TestArtifacts_HitRestoresWipedBin(t *testing.T) {
+	ws := filepath.Join(t.TempDir(), "flow")
+	o := &Orchestrator{
+		Options:   OrchestratorOptions{Verb: "build"},
+		DepGraph:  depGraphFixture(),
+		Artifacts: &ArtifactStore{Dir: t.TempDir()},
+	}
+	job := TaskJob{Name: "flow", Path: ws}
+	states := cacheStates("core1")
+	writeOutput(t, ws, "bin/flow", "flow-v1")
+	writeOutput(t, ws, "bin/linux_arm64/flow", "cross")
+
+	recordRun(o, job, states)
+	o.snapshotArtifacts(job, "build", states, make(chan TaskEvent, 1))
+
+	if err := os.RemoveAll(filepath.Join(ws, "bin")); err != nil {
+		t.Fatal(err)
+	}
+	if !o.isCacheHit(context.Background(), job, states) {
+		t.Fatal("a cached build with a snapshot should hit")
+	}
+	if _, err := os.Stat(filepath.Join(ws, "bin")); !os.IsNotExist(err) {
+		t.Fatal("deciding the hit must not write to the workspace")
+	}
+	if !o.restoreArtifacts(job, "build", states) {
+		t.Fatal("restoring the hit's outputs failed")
+	}
+	data, err := os.ReadFile(filepath.Join(ws, "bin", "flow"))
+	if err != nil || string(data) != "flow-v1" {
+		t.Fatalf("bin/flow not restored: %q, %v", data, err)
+	}
+	if info, err := os.Stat(filepath.Join(ws, "bin", "flow")); err != nil || info.Mode().Perm()&0o100 == 0 {
+		t.Errorf("restored binary must stay executable: %v", info)
+	}
+	if _, err := os.Stat(filepath.Join(ws, "bin", "linux_arm64")); !os.IsNotExist(err) {
+		t.Error("cross-target subdirs belong to other keys and must not be snapshotted")
+	}
+
+	// A stale binary (e.g. built in another worktree) is replaced too.
+	writeOutput(t, ws, "bin/flow", "something-else")
+	if !o.isCacheHit(context.Background(), job, states) || !o.restoreArtifacts(job, "build", states) {
+		t.Fatal("hit expected")
+	}
+	if data, _ := os.ReadFile(filepath.Join(ws, "bin", "flow")); string(data) != "flow-v1" {
+		t.Errorf("differing output must be restored, got %q", data)
+	}
+}
+
+// TestArtifacts_DirtyBuildKeepsCleanSnapshot pins that a build of a dirty
+// tree — the job's own or a go.work sibling's, as in `grove deps upgrade`'s
+// verification build — never replaces the clean commit's snapshot: its commit
+// token would restore binaries built from uncommitted edits.
+func TestArtifacts_DirtyBuildKeepsCleanSnapshot(t *testing.T) {
+	ws := filepath.Join(t.TempDir(), "flow")
+	o := &Orchestrator{
+		Options:   OrchestratorOptions{Verb: "build"},
+		DepGraph:  depGraphFixture(),
+		Artifacts: &ArtifactStore{Dir: t.TempDir()},
+	}
+	job := TaskJob{Name: "flow", Path: ws}
+	states := cacheStates("core1")
+	writeOutput(t, ws, "bin/flow", "clean")
+	recordRun(o, job, states)
+	o.snapshotArtifacts(job, "build", states, make(chan TaskEvent, 1))
+
+	for _, dirty := range []string{"flow", "core"} {
+		dirtyStates := cacheStates("core1")
+		s := dirtyStates[dirty]
+		s.IsDirty = true
+		dirtyStates[dirty] = s
+		writeOutput(t, ws, "bin/flow", "built-from-dirty-"+dirty)
+		o.snapshotArtifacts(job, "build", dirtyStates, make(chan TaskEvent, 1))
+
+		if err := os.RemoveAll(filepath.Join(ws, "bin")); err != nil {
+			t.Fatal(err)
+		}
+		if !o.isCacheHit(context.Background(), job, states) || !o.restoreArtifacts(job, "build", states) {
+			t.Fatalf("dirty %s: the clean commit's snapshot should still hit", dirty)
+		}
+		if data, _ := os.ReadFile(filepath.Join(ws, "bin", "flow")); string(data) != "clean" {
+			t.Errorf("dirty %s: clean checkout restored %q, want the clean build", dirty, data)
+		}
+	}
+}
+
+// TestArtifacts_HitWithoutSnapshotRebuilds pins the demotion: a recorded
+// result with no snapshot (cached before snapshots existed, or pruned) cannot
+// prove the binaries are there, so it is a miss.
+func TestArtifacts_HitWithoutSnapshotRebuilds(t *testing.T) {
+	o := &Orchestrator{
+		Options:   OrchestratorOptions{Verb: "build"},
+		DepGraph:  depGraphFixture(),
+		Artifacts: &ArtifactStore{Dir: t.TempDir()},
+	}
+	job := TaskJob{Name: "nav", Path: t.TempDir()}
+	states := cacheStates("core1")
+	recordRun(o, job, states)
+	if o.isCacheHit(context.Background(), job, states) {
+		t.Error("a build hit without an artifact snapshot must rebuild")
+	}
+
+	// Non-build verbs declare no outputs and are unaffected.
+	s := states["nav"]
+	s.TaskResults["test"] = &models.TaskResult{ExitCode: 0, CommitHash: o.cacheToken(s.CommitHash, job, states)}
+	if !o.isCacheHitForVerb(context.Background(), job, "test", states) {
+		t.Error("test results must not depend on the artifact store")
+	}
+}
+
+// TestArtifacts_CrossTargetUsesOutDir checks that cross builds snapshot and
+// restore Target.OutDir(), keyed apart from the native build.
+func TestArtifacts_CrossTargetUsesOutDir(t *testing.T) {
+	ws := filepath.Join(t.TempDir(), "nav")
+	target := Target{GOOS: "plan9", GOARCH: "arm"}
+	o := &Orchestrator{
+		Options:   OrchestratorOptions{Verb: "build", Target: target},
+		Artifacts: &ArtifactStore{Dir: t.TempDir()},
+	}
+	job := TaskJob{Name: "nav", Path: ws}
+	states := map[string]WorkspaceState{"nav": {CommitHash: "nav1"}}
+	writeOutput(t, ws, "bin/nav", "native")
+	writeOutput(t, ws, target.OutDir()+"/nav", "cross")
+
+	s := states["nav"]
+	s.TaskResults = map[string]*models.TaskResult{
+		o.verbKey("build"): {ExitCode: 0, CommitHash: "nav1"},
+	}
+	states["nav"] = s
+	o.snapshotArtifacts(job, "build", states, make(chan TaskEvent, 1))
+
+	os.RemoveAll(filepath.Join(ws, target.OutDir()))
+	if !o.isCacheHit(context.Background(), job, states) || !o.restoreArtifacts(job, "build", states) {
+		t.Fatal("cross build hit expected")
+	}
+	if data, _ := os.ReadFile(filepath.Join(ws, target.OutDir(), "nav")); string(data) != "cross" {
+		t.Errorf("cross output not restored into %s: %q", target.OutDir(), data)
+	}
+}
+
+// TestArtifacts_CorruptSnapshotIsMiss checks the hit decision verifies the
+// snapshot files it would restore: a tampered snapshot is a miss, decided
+// before anything in bin/ is touched.
+func TestArtifacts_CorruptSnapshotIsMiss(t *testing.T) {
+	ws := filepath.Join(t.TempDir(), "nav")
+	o := &Orchestrator{
+		Options:   OrchestratorOptions{Verb: "build"},
+		Artifacts: &ArtifactStore{Dir: t.TempDir()},
+	}
+	job := TaskJob{Name: "nav", Path: ws}
+	states := map[string]WorkspaceState{"nav": {CommitHash: "nav1", TaskResults: map[string]*models.TaskResult{
+		"build": {ExitCode: 0, CommitHash: "nav1"},
+	}}}
+	writeOutput(t, ws, "bin/nav", "nav-v1")
+	o.snapshotArtifacts(job, "build", states, make(chan TaskEvent, 1))
+
+	snap := o.Artifacts.snapshotDir("nav", "build", "nav1")
+	if err := os.WriteFile(filepath.Join(snap, "nav"), []byte("tampered"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if !o.isCacheHit(context.Background(), job, states) {
+		t.Error("outputs that already match need nothing from the snapshot")
+	}
+	writeOutput(t, ws, "bin/nav", "local-edit")
+	if o.isCacheHit(context.Background(), job, states) {
+		t.Error("a snapshot that cannot restore the outputs must be a miss")
+	}
+	if data, _ := os.ReadFile(filepath.Join(ws, "bin", "nav")); string(data) != "local-edit" {
+		t.Errorf("a miss must leave bin/ alone, got %q", data)
+	}
+}
+
+// TestArtifacts_PruneEvictsLeastRecentlyUsed pins LRU eviction: a restored
+// snapshot outlives newer snapshots nobody has used.
+func TestArtifacts_PruneEvictsLeastRecentlyUsed(t *testing.T) {
+	store := &ArtifactStore{Dir: t.TempDir()}
+	ws := t.TempDir()
+	writeOutput(t, ws, "bin/nav", "nav")
+	base := time.Now().Add(-time.Hour)
+	for i := 0; i < maxArtifactSnapshots; i++ {
+		token := fmt.Sprintf("tok%d", i)
+		if err := store.Snapshot("nav", ws, "bin", "build", token); err != nil {
+			t.Fatal(err)
+		}
+		mod := base.Add(time.Duration(i) * time.Minute)
+		if err := os.Chtimes(store.snapshotDir("nav", "build", token), mod, mod); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// tok0 is the oldest snapshot but the most recently used.
+	if _, err := store.Restore("nav", ws, "bin", "build", "tok0"); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Snapshot("nav", ws, "bin", "build", "tok5"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(store.snapshotDir("nav", "build", "tok0")); err != nil {
+		t.Error("a recently restored snapshot must survive pruning")
+	}
+	if _, err := os.Stat(store.snapshotDir("nav", "build", "tok1")); !os.IsNotExist(err) {
+		t.Error("the least recently used snapshot should have been pruned")
+	}
+}
@@ -1,6 +1,7 @@
 package orchestrator
 
 import (
+	"context"
 	"testing"
 
 	"github.com/grovetools/core/pkg/models"
@@ -50,22 +51,22 @@ func TestCacheHit_SiblingCommitInvalidates(t *testing.T) {
 	recordRun(o, flow, states)
 	recordRun(o, nav, states)
 
-	if !o.isCacheHit(flow, states) {
+	if !o.isCacheHit(context.Background(), flow, states) {
 		t.Fatal("flow should be cached when nothing has changed")
 	}
 
 	// core commits. flow depends on it, nav does not.
 	states["core"] = WorkspaceState{CommitHash: "core2"}
-	if o.isCacheHit(flow, states) {
+	if o.isCacheHit(context.Background(), flow, states) {
 		t.Error("flow must rebuild after a commit in its go.work sibling core")
 	}
-	if !o.isCacheHit(nav, states) {
+	if !o.isCacheHit(context.Background(), nav, states) {
 		t.Error("nav does not depend on core and must stay cached")
 	}
 
 	// Rebuilding flow against the new core re-validates its cache.
 	recordRun(o, flow, states)
-	if !o.isCacheHit(flow, states) {
+	if !o.isCacheHit(context.Background(), flow, states) {
 		t.Error("flow should be cached again once rebuilt against the new core")
 	}
 }
@@ -84,20 +85,20 @@ func TestCacheHit_DirtySiblingNeverHits(t *testing.T) {
 	states["core"] = WorkspaceState{CommitHash: "core1", IsDirty: true}
 	recordRun(o, flow, states)
 
-	if o.isCacheHit(flow, states) {
+	if o.isCacheHit(context.Background(), flow, states) {
 		t.Error("flow must not hit the cache while core is dirty")
 	}
 
 	// core's edits are staged into a commit; the dirty-run result is stale.
 	states["core"] = WorkspaceState{CommitHash: "core2"}
-	if o.isCacheHit(flow, states) {
+	if o.isCacheHit(context.Background(), flow, states) {
 		t.Error("a result recorded against dirty core must not survive its commit")
 	}
 
 	// core's edits are reverted instead: still a different token than the
 	// dirty run recorded, so flow rebuilds rather than trusting it.
 	states["core"] = WorkspaceState{CommitHash: "core1"}
-	if o.isCacheHit(flow, states) {
+	if o.isCacheHit(context.Background(), flow, states) {
 		t.Error("a result recorded against dirty core must not survive its revert")
 	}
 }
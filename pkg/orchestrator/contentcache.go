@@ -0,0 +1,280 @@
+package orchestrator
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/grovetools/core/pkg/models"
+)
+
+// ParseCacheMode validates a --cache-mode value. "" selects the default
+// (commit) mode.
+func ParseCacheMode(s string) (CacheMode, error) {
+	switch CacheMode(s) {
+	case "", CacheModeCommit:
+		return CacheModeCommit, nil
+	case CacheModeContent:
+		return CacheModeContent, nil
+	}
+	return "", fmt.Errorf("invalid cache mode %q (want %q or %q)", s, CacheModeCommit, CacheModeContent)
+}
+
+// TaskResultStore is an optional StateProvider capability: a content-addressed
+// task result store. Under CacheModeContent the orchestrator looks results up
+// by (verb key, contentToken) and records every finished verb into it.
+type TaskResultStore interface {
+	LookupTask(verbKey, token string) (*models.TaskResult, bool)
+	RecordTask(verbKey, token string, result *models.TaskResult) error
+}
+
+// contentToken is the content-mode counterpart of cacheToken: a hash of the
+// job's own input-file hash plus the input-file hash of every dependency in
+// its go.work closure. Unlike cacheToken it needs no dirty special-casing —
+// uncommitted edits are part of the content — and a rebase that leaves the
+// tree byte-identical keeps the same token. Returns "" (a miss) when the
+// content hash of the job or of any dependency is unknown: skipping the dep
+// would let a result built against different sibling sources hit.
+func (o *Orchestrator) contentToken(job TaskJob, states map[string]WorkspaceState) string {
+	s, ok := states[job.Name]
+	if !ok || s.ContentHash == "" {
+		return ""
+	}
+	h := sha256.New()
+	fmt.Fprintf(h, "%s=%s", job.Name, s.ContentHash)
+	h.Write([]byte{0})
+	for _, dep := range o.DepGraph.Closure(job.Name) {
+		ds, ok := states[dep]
+		if !ok || ds.ContentHash == "" {
+			return ""
+		}
+		fmt.Fprintf(h, "%s=%s", dep, ds.ContentHash)
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// taskStore returns the state provider's content-addressed store, or nil when
+// content-mode caching is off or the provider has none.
+func (o *Orchestrator) taskStore() TaskResultStore {
+	if o.Options.CacheMode != CacheModeContent {
+		return nil
+	}
+	store, _ := o.StateProvider.(TaskResultStore)
+	return store
+}
+
+// HashWorkspaceInputs hashes every input file of the git workspace at dir:
+// tracked files plus untracked files not excluded by .gitignore (git ls-files
+// --cached --others --exclude-standard). Each entry contributes its path, its
+// executable bit and its content (a symlink contributes its target; a tracked
+// file deleted from the worktree contributes a deletion marker), so the hash
+// changes exactly when a build could observe a difference.
+func HashWorkspaceInputs(dir string) (string, error) {
+	cmd := exec.Command("git", "ls-files", "-z", "--cached", "--others", "--exclude-standard")
+	cmd.Dir = dir
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("git ls-files in %s: %w: %s", dir, err, strings.TrimSpace(stderr.String()))
+	}
+
+	// --cached and --others can both list a path (e.g. during a merge);
+	// dedupe so the hash depends on content, not on index state.
+	seen := make(map[string]bool)
+	var files []string
+	for _, f := range strings.Split(string(out), "\x00") {
+		if f != "" && !seen[f] {
+			seen[f] = true
+			files = append(files, f)
+		}
+	}
+	sort.Strings(files)
+
+	h := sha256.New()
+	for _, f := range files {
+		h.Write([]byte(f))
+		h.Write([]byte{0})
+		if err := hashInputFile(h, filepath.Join(dir, f)); err != nil {
+			return "", err
+		}
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+func hashInputFile(w io.Writer, path string) error {
+	info, err := os.Lstat(path)
+	if os.IsNotExist(err) {
+		_, err = w.Write([]byte("deleted"))
+		return err
+	}
+	if err != nil {
+		return err
+	}
+	switch {
+	case info.Mode()&os.ModeSymlink != 0:
+		target, err := os.Readlink(path)
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintf(w, "link:%s", target)
+		return err
+	case info.IsDir():
+		// A submodule or nested repo gitlink: its own commit is not visible
+		// here, so only its presence is recorded.
+		_, err = w.Write([]byte("dir"))
+		return err
+	}
+	mode := "file"
+	if info.Mode()&0o111 != 0 {
+		mode = "exec"
+	}
+	if _, err := fmt.Fprintf(w, "%s:", mode); err != nil {
+		return err
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	_, err = io.Copy(w, f)
+	return err
+}
+
+// LocalTaskCache is the on-disk TaskResultStore behind content-mode caching:
+// one JSON-encoded models.TaskResult per (verb key, content token) at
+// <Dir>/<verbKey>/<token>.json. It needs no daemon, and because keys are
+// content hashes it is safely shared by every worktree on the machine.
+type LocalTaskCache struct {
+	Dir string
+}
+
+func (c *LocalTaskCache) path(verbKey, token string) string {
+	return filepath.Join(c.Dir, verbKey, token+".json")
+}
+
+// LookupTask returns the stored result for the key, false when missing or
+// unreadable.
+func (c *LocalTaskCache) LookupTask(verbKey, token string) (*models.TaskResult, bool) {
+	if c == nil || c.Dir == "" || token == "" {
+		return nil, false
+	}
+	data, err := os.ReadFile(c.path(verbKey, token))
+	if err != nil {
+		return nil, false
+	}
+	var tr models.TaskResult
+	if err := json.Unmarshal(data, &tr); err != nil {
+		return nil, false
+	}
+	return &tr, true
+}
+
+// RecordTask stores result under the key atomically (tmp file + rename), so
+// concurrent grove invocations never observe a partial entry.
+func (c *LocalTaskCache) RecordTask(verbKey, token string, result *models.TaskResult) error {
+	if c == nil || c.Dir == "" || token == "" || result == nil {
+		return nil
+	}
+	path := c.path(verbKey, token)
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	data, err := json.Marshal(result)
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(dir, ".task-*.tmp")
+	if err != nil {
+		return err
+	}
+	tmpName := tmp.Name()
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmpName)
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmpName)
+		return err
+	}
+	if err := os.Rename(tmpName, path); err != nil {
+		os.Remove(tmpName)
+		return err
+	}
+	return nil
+}
+
+// ContentStateProvider decorates another StateProvider (daemon or local) with
+// per-workspace ContentHash and a LocalTaskCache, enabling CacheModeContent
+// for every caller regardless of whether groved is running.
+type ContentStateProvider struct {
+	Inner StateProvider
+	Cache *LocalTaskCache
+}
+
+// NewContentStateProvider wraps inner (LocalStateProvider when nil) with a
+// content-addressed task cache rooted at cacheDir.
+func NewContentStateProvider(inner StateProvider, cacheDir string) *ContentStateProvider {
+	if inner == nil {
+		inner = &LocalStateProvider{}
+	}
+	return &ContentStateProvider{Inner: inner, Cache: &LocalTaskCache{Dir: cacheDir}}
+}
+
+func (c *ContentStateProvider) GetState(ctx context.Context, workspaces []string) (map[string]WorkspaceState, error) {
+	states, err := c.Inner.GetState(ctx, workspaces)
+	if err != nil || states == nil {
+		states = make(map[string]WorkspaceState, len(workspaces))
+	}
+	// Workspaces the inner provider could not report (groved down, or not
+	// tracked by it) take their git state from the local provider; a state
+	// carrying only a ContentHash would look clean and on main, and would
+	// hide them from filterAffected's own fallback.
+	var missing []string
+	for _, wsPath := range workspaces {
+		if _, ok := states[filepath.Base(wsPath)]; !ok {
+			missing = append(missing, wsPath)
+		}
+	}
+	if len(missing) > 0 {
+		local, lerr := (&LocalStateProvider{}).GetState(ctx, missing)
+		if lerr != nil {
+			return nil, lerr
+		}
+		for name, s := range local {
+			states[name] = s
+		}
+	}
+	for _, wsPath := range workspaces {
+		name := filepath.Base(wsPath)
+		s := states[name]
+		// A workspace we cannot hash (not a git checkout, git missing) keeps
+		// ContentHash "" and simply never hits the content cache.
+		if hash, err := HashWorkspaceInputs(wsPath); err == nil {
+			s.ContentHash = hash
+		}
+		states[name] = s
+	}
+	return states, nil
+}
+
+func (c *ContentStateProvider) LookupTask(verbKey, token string) (*models.TaskResult, bool) {
+	return c.Cache.LookupTask(verbKey, token)
+}
+
+func (c *ContentStateProvider) RecordTask(verbKey, token string, result *models.TaskResult) error {
+	return c.Cache.RecordTask(verbKey, token, result)
+}
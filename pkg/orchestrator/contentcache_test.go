@@ -0,0 +1,218 @@
+package orchestrator
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+func contentStates(coreHash string) map[string]WorkspaceState {
+	return map[string]WorkspaceState{
+		"core": {CommitHash: "core1", ContentHash: coreHash},
+		"flow": {CommitHash: "flow1", ContentHash: "flow-content", IsDirty: true},
+		"nav":  {CommitHash: "nav1", ContentHash: "nav-content"},
+	}
+}
+
+// recordContentRun stores a successful "build" result exactly as
+// executeJobVerbs does under CacheModeContent.
+func recordContentRun(o *Orchestrator, job TaskJob, states map[string]WorkspaceState) {
+	o.recordContentResult(job, "build", 0, 0, states)
+}
+
+// TestContentCache_DirtyTreeHits is the point of the feature: a dirty
+// workspace whose inputs match a previous run hits, and a change to a go.work
+// sibling's content invalidates only its dependents.
+func TestContentCache_DirtyTreeHits(t *testing.T) {
+	o := &Orchestrator{
+		Options:       OrchestratorOptions{Verb: "build", CacheMode: CacheModeContent},
+		DepGraph:      depGraphFixture(),
+		StateProvider: NewContentStateProvider(nil, t.TempDir()),
+	}
+	flow := TaskJob{Name: "flow"}
+	nav := TaskJob{Name: "nav"}
+
+	states := contentStates("core-a")
+	if o.isCacheHit(context.Background(), flow, states) {
+		t.Fatal("nothing recorded yet, flow must miss")
+	}
+	recordContentRun(o, flow, states)
+	recordContentRun(o, nav, states)
+	if !o.isCacheHit(context.Background(), flow, states) {
+		t.Fatal("dirty flow with unchanged content should hit")
+	}
+
+	states = contentStates("core-b")
+	if o.isCacheHit(context.Background(), flow, states) {
+		t.Error("flow must rebuild after its sibling core's content changed")
+	}
+	if !o.isCacheHit(context.Background(), nav, states) {
+		t.Error("nav does not depend on core and must stay cached")
+	}
+
+	// Reverting core's edit restores the original content token.
+	states = contentStates("core-a")
+	if !o.isCacheHit(context.Background(), flow, states) {
+		t.Error("flow should hit again once core's content is back")
+	}
+}
+
+// TestContentCache_RequiresStoreAndHash pins the safe fallbacks: no store on
+// the provider, an unhashable workspace or dependency, a failed run and
+// --no-cache all miss.
+func TestContentCache_RequiresStoreAndHash(t *testing.T) {
+	flow := TaskJob{Name: "flow"}
+	states := contentStates("core-a")
+
+	noStore := &Orchestrator{
+		Options:       OrchestratorOptions{Verb: "build", CacheMode: CacheModeContent},
+		DepGraph:      depGraphFixture(),
+		StateProvider: &LocalStateProvider{},
+	}
+	recordContentRun(noStore, flow, states)
+	if noStore.isCacheHit(context.Background(), flow, states) {
+		t.Error("a provider without a TaskResultStore must never hit")
+	}
+
+	o := &Orchestrator{
+		Options:       OrchestratorOptions{Verb: "build", CacheMode: CacheModeContent},
+		DepGraph:      depGraphFixture(),
+		StateProvider: NewContentStateProvider(nil, t.TempDir()),
+	}
+	unhashed := map[string]WorkspaceState{"flow": {CommitHash: "flow1"}}
+	recordContentRun(o, flow, unhashed)
+	if o.isCacheHit(context.Background(), flow, unhashed) {
+		t.Error("a workspace without a content hash must never hit")
+	}
+
+	noDepHash := contentStates("")
+	recordContentRun(o, flow, noDepHash)
+	if o.isCacheHit(context.Background(), flow, noDepHash) {
+		t.Error("a dependency without a content hash must never hit")
+	}
+
+	o.recordContentResult(flow, "build", 1, 0, states)
+	if o.isCacheHit(context.Background(), flow, states) {
+		t.Error("a failed run must not be a cache hit")
+	}
+
+	recordContentRun(o, flow, states)
+	o.Options.NoCache = true
+	if o.isCacheHit(context.Background(), flow, states) {
+		t.Error("--no-cache must bypass the content cache")
+	}
+}
+
+// TestHashWorkspaceInputs covers what counts as an input: tracked and
+// untracked files change the hash, .gitignored files do not.
+func TestHashWorkspaceInputs(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not on PATH")
+	}
+	dir := t.TempDir()
+	run := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	write := func(name, content string) {
+		t.Helper()
+		if err := os.MkdirAll(filepath.Dir(filepath.Join(dir, name)), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	run("init", "-q")
+	write(".gitignore", "bin/\n")
+	write("main.go", "package main\n")
+	run("add", ".")
+
+	hash := func() string {
+		t.Helper()
+		h, err := HashWorkspaceInputs(dir)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return h
+	}
+	base := hash()
+
+	write("bin/tool", "binary")
+	if got := hash(); got != base {
+		t.Error("ignored bin/ output must not change the input hash")
+	}
+
+	write("extra.go", "package main\n")
+	withUntracked := hash()
+	if withUntracked == base {
+		t.Error("an untracked, non-ignored file must change the input hash")
+	}
+
+	write("main.go", "package main\n\nfunc main() {}\n")
+	if got := hash(); got == withUntracked {
+		t.Error("editing a tracked file must change the input hash")
+	}
+
+	write("main.go", "package main\n")
+	if got := hash(); got != withUntracked {
+		t.Error("reverting an edit must restore the previous input hash")
+	}
+}
+
+type failingStateProvider struct{}
+
+func (failingStateProvider) GetState(context.Context, []string) (map[string]WorkspaceState, error) {
+	return nil, errors.New("daemon unavailable")
+}
+
+// TestContentStateProvider_InnerFailureKeepsGitState pins that a failed inner
+// provider does not yield hash-only states: those would read as clean, hide
+// the workspace from --affected and skip filterAffected's local fallback.
+func TestContentStateProvider_InnerFailureKeepsGitState(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not on PATH")
+	}
+	dir := filepath.Join(t.TempDir(), "flow")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	run := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", append([]string{"-c", "user.name=t", "-c", "user.email=t@example.com"}, args...)...)
+		cmd.Dir = dir
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	main := filepath.Join(dir, "main.go")
+	if err := os.WriteFile(main, []byte("package main\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	run("init", "-q")
+	run("add", ".")
+	run("commit", "-q", "-m", "init")
+	if err := os.WriteFile(main, []byte("package main\n\nfunc main() {}\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	p := &ContentStateProvider{Inner: failingStateProvider{}, Cache: &LocalTaskCache{Dir: t.TempDir()}}
+	states, err := p.GetState(context.Background(), []string{dir})
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := states["flow"]
+	if s.ContentHash == "" {
+		t.Error("ContentHash should still be set")
+	}
+	if s.CommitHash == "" || !s.IsDirty {
+		t.Errorf("git state should come from the local provider, got %+v", s)
+	}
+}
@@ -0,0 +1,182 @@
+package orchestrator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/grovetools/core/config"
+	"github.com/grovetools/core/pkg/taskexec"
+)
+
+// TaskVerbConfig is one [tasks.<verb>] table of grove.toml:
+//
+//	[tasks.test]
+//	timeout = "10m"     # per attempt; a hung verb is killed and reported
+//	retries = 2         # extra attempts after a failure or timeout
+//	quarantine = ["flow", "tend"]
+//
+// Timeout and Retries in a workspace's own grove.toml override the
+// ecosystem-level table; Retries is a pointer so an explicit 0 there turns
+// inherited retries off. Quarantine lists workspaces whose failures of this
+// verb are reported but never fail the run (known-flaky jobs still run and
+// stay visible); a workspace is quarantined when the ecosystem table or its
+// own table lists it.
+type TaskVerbConfig struct {
+	Timeout    string   `yaml:"timeout"`
+	Retries    *int     `yaml:"retries"`
+	Quarantine []string `yaml:"quarantine"`
+}
+
+// LoadTaskSettings decodes the [tasks] extension of cfg. A nil cfg or missing
+// table yields nil.
+func LoadTaskSettings(cfg *config.Config) (map[string]TaskVerbConfig, error) {
+	if cfg == nil {
+		return nil, nil
+	}
+	var tasks map[string]TaskVerbConfig
+	if err := cfg.UnmarshalExtension("tasks", &tasks); err != nil {
+		return nil, fmt.Errorf("parse [tasks]: %w", err)
+	}
+	for verb, tc := range tasks {
+		if tc.Timeout != "" {
+			if _, err := time.ParseDuration(tc.Timeout); err != nil {
+				return nil, fmt.Errorf("parse [tasks.%s] timeout %q: %w", verb, tc.Timeout, err)
+			}
+		}
+		if tc.Retries != nil && *tc.Retries < 0 {
+			return nil, fmt.Errorf("[tasks.%s] retries must not be negative", verb)
+		}
+	}
+	return tasks, nil
+}
+
+// verbPolicy is the resolved execution policy of one job verb.
+type verbPolicy struct {
+	Timeout     time.Duration
+	Retries     int
+	Quarantined bool
+}
+
+// verbPolicy resolves the timeout/retries/quarantine for job's verb: the
+// ecosystem defaults (Options.TaskSettings) overlaid with the workspace's own
+// [tasks.<verb>]. A workspace table that fails to parse is ignored, keeping
+// the ecosystem defaults.
+func (o *Orchestrator) verbPolicy(job TaskJob, verb string) verbPolicy {
+	var p verbPolicy
+	if tc, ok := o.Options.TaskSettings[verb]; ok {
+		p.Timeout, _ = time.ParseDuration(tc.Timeout)
+		if tc.Retries != nil {
+			p.Retries = *tc.Retries
+		}
+		p.Quarantined = tc.quarantines(job.Name)
+	}
+	if own, err := LoadTaskSettings(o.Configs[job.Name]); err == nil {
+		if tc, ok := own[verb]; ok {
+			if tc.Timeout != "" {
+				p.Timeout, _ = time.ParseDuration(tc.Timeout)
+			}
+			if tc.Retries != nil {
+				p.Retries = *tc.Retries
+			}
+			p.Quarantined = p.Quarantined || tc.quarantines(job.Name)
+		}
+	}
+	return p
+}
+
+func (tc TaskVerbConfig) quarantines(name string) bool {
+	for _, q := range tc.Quarantine {
+		if q == name {
+			return true
+		}
+	}
+	return false
+}
+
+// errTaskTimeout marks a verb attempt killed by its [tasks.<verb>] timeout.
+var errTaskTimeout = errors.New("task timed out")
+
+// IsTimeout reports whether a TaskResult error came from a verb timeout.
+func IsTimeout(err error) bool {
+	return errors.Is(err, errTaskTimeout)
+}
+
+// attemptStats summarizes the attempts runWithPolicy made.
+type attemptStats struct {
+	attempts int
+	timedOut bool
+}
+
+// runWithPolicy runs one verb through runProcess under its policy: each
+// attempt gets its own deadline, and a failed or timed-out attempt is retried
+// up to policy.Retries times, announced with "timeout" and "retry" events.
+// Attempts that never started, a missing make target and a cancelled run are
+// not retried. The returned duration covers all attempts.
+func (o *Orchestrator) runWithPolicy(ctx context.Context, job TaskJob, verb string, command, env []string, policy verbPolicy, eventsChan chan<- TaskEvent) ([]byte, time.Duration, error, bool, attemptStats) {
+	var (
+		stats attemptStats
+		total time.Duration
+	)
+	for {
+		stats.attempts++
+		attemptCtx, cancel := ctx, context.CancelFunc(func() {})
+		if policy.Timeout > 0 {
+			attemptCtx, cancel = context.WithTimeout(ctx, policy.Timeout)
+			attemptCtx = withAttemptGroup(attemptCtx, fmt.Sprintf("%s/%s/%s/%d", o.buildGroupID(), job.Name, verb, stats.attempts))
+		}
+		output, duration, err, started := o.runProcess(attemptCtx, job, verb, command, env, eventsChan)
+		timedOut := err != nil && ctx.Err() == nil && errors.Is(attemptCtx.Err(), context.DeadlineExceeded)
+		cancel()
+		total += duration
+
+		if timedOut {
+			stats.timedOut = true
+			o.cancelAttemptGroup(attemptCtx)
+			err = fmt.Errorf("%w after %s", errTaskTimeout, policy.Timeout)
+			eventsChan <- TaskEvent{Job: job, Verb: verb, Type: "timeout", Attempt: stats.attempts,
+				OutputLine: fmt.Sprintf("%s %s timed out after %s (attempt %d)", job.Name, verb, policy.Timeout, stats.attempts)}
+			output = append(output, []byte(fmt.Sprintf("\n%s timed out after %s\n", verb, policy.Timeout))...)
+		}
+
+		retryable := err != nil && started && ctx.Err() == nil && !taskexec.IsMakeTargetMissing(command, string(output))
+		if !retryable || stats.attempts > policy.Retries {
+			return output, total, err, started, stats
+		}
+		eventsChan <- TaskEvent{Job: job, Verb: verb, Type: "retry", Attempt: stats.attempts + 1,
+			OutputLine: fmt.Sprintf("retrying %s %s (attempt %d of %d): %v", job.Name, verb, stats.attempts+1, policy.Retries+1, err)}
+	}
+}
+
+// attemptGroupKey carries a per-attempt remote submission group on the
+// context, so a timed-out remote attempt can be cancelled on the daemon
+// without touching the rest of the run's group.
+type attemptGroupKey struct{}
+
+func withAttemptGroup(ctx context.Context, group string) context.Context {
+	return context.WithValue(ctx, attemptGroupKey{}, group)
+}
+
+func attemptGroup(ctx context.Context) string {
+	g, _ := ctx.Value(attemptGroupKey{}).(string)
+	return g
+}
+
+// cancelAttemptGroup asks the daemon to kill a timed-out remote attempt. A
+// no-op for local attempts (the taskexec context already killed the process).
+func (o *Orchestrator) cancelAttemptGroup(ctx context.Context) {
+	group := attemptGroup(ctx)
+	if group == "" || o.BuildClient == nil {
+		return
+	}
+	o.remoteMu.Lock()
+	submitted := o.attemptGroups[group]
+	o.remoteMu.Unlock()
+	if !submitted {
+		return
+	}
+	cctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	_ = o.BuildClient.CancelBuild(cctx, group)
+}
@@ -0,0 +1,160 @@
+package orchestrator
+
+import (
+	"context"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/grovetools/core/config"
+	"github.com/grovetools/core/pkg/models"
+)
+
+func TestPolicy_RetriesUntilSuccess(t *testing.T) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("sh not on PATH")
+	}
+	dir := t.TempDir()
+	marker := filepath.Join(dir, "ran-once")
+	// Fails on the first attempt, passes on the second: the flaky test shape.
+	script := "if [ -e " + marker + " ]; then echo pass; else touch " + marker + "; echo flake; exit 1; fi"
+
+	o := &Orchestrator{Options: OrchestratorOptions{
+		Verb:         "test",
+		Jobs:         1,
+		TaskSettings: map[string]TaskVerbConfig{"test": {Retries: intPtr(2)}},
+	}}
+	jobs := []TaskJob{{Name: "flow", Path: dir, Command: []string{"sh", "-c", script}}}
+
+	var retries []TaskEvent
+	var result *TaskResult
+	for ev := range o.RunWithEvents(context.Background(), jobs) {
+		switch ev.Type {
+		case "retry":
+			retries = append(retries, ev)
+		case "finish":
+			result = ev.Result
+		}
+	}
+	if len(retries) != 1 || retries[0].Attempt != 2 {
+		t.Fatalf("expected one retry event announcing attempt 2, got %+v", retries)
+	}
+	if result == nil || result.Err != nil || result.Attempts != 2 {
+		t.Fatalf("expected success on attempt 2, got %+v", result)
+	}
+}
+
+func TestPolicy_TimeoutKillsHungVerb(t *testing.T) {
+	if _, err := exec.LookPath("sleep"); err != nil {
+		t.Skip("sleep not on PATH")
+	}
+	o := &Orchestrator{Options: OrchestratorOptions{
+		Verb:         "test",
+		Jobs:         1,
+		TaskSettings: map[string]TaskVerbConfig{"test": {Timeout: "200ms"}},
+	}}
+	jobs := []TaskJob{{Name: "flow", Path: t.TempDir(), Command: []string{"sleep", "30"}}}
+
+	start := time.Now()
+	var timeouts int
+	var result *TaskResult
+	for ev := range o.RunWithEvents(context.Background(), jobs) {
+		switch ev.Type {
+		case "timeout":
+			timeouts++
+		case "finish":
+			result = ev.Result
+		}
+	}
+	if elapsed := time.Since(start); elapsed > 10*time.Second {
+		t.Fatalf("hung verb was not killed at its deadline (took %s)", elapsed)
+	}
+	if timeouts != 1 {
+		t.Errorf("expected one timeout event, got %d", timeouts)
+	}
+	if result == nil || !result.TimedOut || !IsTimeout(result.Err) {
+		t.Fatalf("expected a timed-out result, got %+v", result)
+	}
+}
+
+// TestPolicy_WorkspaceOverridesEcosystem checks resolution order: ecosystem
+// defaults, overridden by the workspace's own [tasks.<verb>].
+func TestPolicy_WorkspaceOverridesEcosystem(t *testing.T) {
+	o := &Orchestrator{Options: OrchestratorOptions{
+		TaskSettings: map[string]TaskVerbConfig{
+			"test": {Timeout: "5m", Retries: intPtr(1), Quarantine: []string{"flow"}},
+		},
+	}}
+	p := o.verbPolicy(TaskJob{Name: "flow"}, "test")
+	if p.Timeout != 5*time.Minute || p.Retries != 1 || !p.Quarantined {
+		t.Errorf("ecosystem policy not applied: %+v", p)
+	}
+	if p := o.verbPolicy(TaskJob{Name: "nav"}, "test"); p.Quarantined {
+		t.Error("quarantine must only apply to listed workspaces")
+	}
+	if p := o.verbPolicy(TaskJob{Name: "flow"}, "build"); p != (verbPolicy{}) {
+		t.Errorf("other verbs must have no policy, got %+v", p)
+	}
+
+	// nav's own table turns the inherited retries off with an explicit 0
+	// and quarantines itself.
+	o.Configs = map[string]*config.Config{"nav": {Extensions: map[string]interface{}{
+		"tasks": map[string]interface{}{
+			"test": map[string]interface{}{"timeout": "1m", "retries": 0, "quarantine": []interface{}{"nav"}},
+		},
+	}}}
+	if p := o.verbPolicy(TaskJob{Name: "nav"}, "test"); p.Timeout != time.Minute || p.Retries != 0 || !p.Quarantined {
+		t.Errorf("workspace policy not applied: %+v", p)
+	}
+	if p := o.verbPolicy(TaskJob{Name: "flow"}, "test"); p.Retries != 1 {
+		t.Errorf("flow has no table of its own and keeps the ecosystem retries, got %+v", p)
+	}
+}
+
+func intPtr(n int) *int {
+	return &n
+}
+
+// TestPolicy_QuarantinedFailureDoesNotFailFast runs a quarantined failing job
+// under --fail-fast: it is reported as a quarantined failure and the run
+// carries on.
+func TestPolicy_QuarantinedFailureDoesNotFailFast(t *testing.T) {
+	fake := newFakeBuildClient()
+	fake.jobEvents["flaky"] = []models.BuildJobEvent{
+		{Event: models.BuildEventStarted},
+		{Event: models.BuildEventFinished, ExitCode: 1, Error: "exit status 1"},
+	}
+	fake.jobEvents["ws2"] = []models.BuildJobEvent{
+		{Event: models.BuildEventStarted},
+		{Event: models.BuildEventFinished, ExitCode: 0},
+	}
+	o := remoteOrchestrator(fake)
+	o.Options.FailFast = true
+	o.Options.Jobs = 1
+	o.Options.TaskSettings = map[string]TaskVerbConfig{"build": {Quarantine: []string{"flaky"}}}
+
+	jobs := []TaskJob{
+		{Name: "flaky", Path: t.TempDir(), Command: []string{"make", "build"}},
+		{Name: "ws2", Path: t.TempDir(), Command: []string{"make", "build"}},
+	}
+	_, _, finishes := collectTaskEvents(o.RunWithEvents(context.Background(), jobs))
+	if len(finishes) != 2 {
+		t.Fatalf("expected both jobs to finish, got %d", len(finishes))
+	}
+	for _, f := range finishes {
+		switch f.Job.Name {
+		case "flaky":
+			if !f.Result.Quarantined || f.Result.Failed() {
+				t.Errorf("flaky should be a quarantined, non-failing result: %+v", f.Result)
+			}
+		case "ws2":
+			if f.Result.Err != nil {
+				t.Errorf("ws2 must still run after a quarantined failure: %v", f.Result.Err)
+			}
+		}
+	}
+	if len(fake.cancelled) != 0 {
+		t.Error("a quarantined failure must not trigger fail-fast")
+	}
+}
@@ -0,0 +1,270 @@
+package orchestrator
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/grovetools/core/pkg/models"
+)
+
+// RemoteCache is a shared task-result store that lives beyond this machine:
+// a teammate's laptop, a satellite or a LAN box. Results and artifact
+// snapshots are addressed by workspace, verb key and the same token the local
+// cache uses (cacheToken or contentToken), so a hit there means the identical
+// inputs were already built somewhere.
+//
+// Implementations report a missing entry as (nil, nil) rather than an error.
+type RemoteCache interface {
+	GetResult(ctx context.Context, workspace, verbKey, token string) (*models.TaskResult, error)
+	PutResult(ctx context.Context, workspace, verbKey, token string, result *models.TaskResult) error
+	GetArtifacts(ctx context.Context, workspace, verbKey, token string) (io.ReadCloser, error)
+	PutArtifacts(ctx context.Context, workspace, verbKey, token string, body io.Reader) error
+}
+
+// RemoteCachePolicy is the opt-in read/write policy for a RemoteCache. Both
+// default off: reading trusts results built elsewhere, writing publishes this
+// machine's outputs.
+type RemoteCachePolicy struct {
+	Read  bool
+	Write bool
+}
+
+// HTTPRemoteCache speaks the plain GET/PUT protocol served by
+// `grove cache serve`:
+//
+//	GET|PUT <base>/v1/results/<workspace>/<verbKey>/<token>    JSON models.TaskResult
+//	GET|PUT <base>/v1/artifacts/<workspace>/<verbKey>/<token>  tar.gz snapshot
+//
+// A 404 is a miss. An optional bearer token is sent on every request.
+type HTTPRemoteCache struct {
+	BaseURL string
+	Token   string
+	Client  *http.Client
+}
+
+// NewHTTPRemoteCache returns a client for baseURL with a conservative
+// timeout: a slow cache must never be slower than rebuilding.
+func NewHTTPRemoteCache(baseURL, token string, timeout time.Duration) *HTTPRemoteCache {
+	if timeout <= 0 {
+		timeout = 30 * time.Second
+	}
+	return &HTTPRemoteCache{
+		BaseURL: strings.TrimRight(baseURL, "/"),
+		Token:   token,
+		Client:  &http.Client{Timeout: timeout},
+	}
+}
+
+func (c *HTTPRemoteCache) url(kind, workspace, verbKey, token string) string {
+	return fmt.Sprintf("%s/v1/%s/%s/%s/%s", c.BaseURL, kind,
+		url.PathEscape(workspace), url.PathEscape(verbKey), url.PathEscape(token))
+}
+
+func (c *HTTPRemoteCache) do(ctx context.Context, method, u string, body io.Reader) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, u, body)
+	if err != nil {
+		return nil, err
+	}
+	if c.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.Token)
+	}
+	client := c.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return client.Do(req)
+}
+
+// get returns the response body on 200, (nil, nil) on 404.
+func (c *HTTPRemoteCache) get(ctx context.Context, u string) (io.ReadCloser, error) {
+	resp, err := c.do(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return resp.Body, nil
+	case http.StatusNotFound:
+		resp.Body.Close()
+		return nil, nil
+	}
+	resp.Body.Close()
+	return nil, fmt.Errorf("remote cache GET %s: %s", u, resp.Status)
+}
+
+func (c *HTTPRemoteCache) put(ctx context.Context, u string, body io.Reader) error {
+	resp, err := c.do(ctx, http.MethodPut, u, body)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("remote cache PUT %s: %s", u, resp.Status)
+	}
+	return nil
+}
+
+func (c *HTTPRemoteCache) GetResult(ctx context.Context, workspace, verbKey, token string) (*models.TaskResult, error) {
+	body, err := c.get(ctx, c.url("results", workspace, verbKey, token))
+	if err != nil || body == nil {
+		return nil, err
+	}
+	defer body.Close()
+	var tr models.TaskResult
+	if err := json.NewDecoder(body).Decode(&tr); err != nil {
+		return nil, fmt.Errorf("decode remote result for %s: %w", workspace, err)
+	}
+	return &tr, nil
+}
+
+func (c *HTTPRemoteCache) PutResult(ctx context.Context, workspace, verbKey, token string, result *models.TaskResult) error {
+	data, err := json.Marshal(result)
+	if err != nil {
+		return err
+	}
+	return c.put(ctx, c.url("results", workspace, verbKey, token), bytes.NewReader(data))
+}
+
+func (c *HTTPRemoteCache) GetArtifacts(ctx context.Context, workspace, verbKey, token string) (io.ReadCloser, error) {
+	return c.get(ctx, c.url("artifacts", workspace, verbKey, token))
+}
+
+func (c *HTTPRemoteCache) PutArtifacts(ctx context.Context, workspace, verbKey, token string, body io.Reader) error {
+	return c.put(ctx, c.url("artifacts", workspace, verbKey, token), body)
+}
+
+// remoteTimeout bounds each remote cache round trip made from the scheduling
+// path (cache-hit checks run before any worker starts).
+const remoteTimeout = 30 * time.Second
+
+// remoteReadable reports whether a remote cache is configured for reads.
+func (o *Orchestrator) remoteReadable() bool {
+	return o.Remote != nil && o.RemotePolicy.Read && !o.Options.NoCache
+}
+
+// remoteWritable reports whether results should be published to the remote.
+func (o *Orchestrator) remoteWritable() bool {
+	return o.Remote != nil && o.RemotePolicy.Write
+}
+
+// remoteTokenTrusted reports whether the job's result token describes what
+// was actually built, so it may be shared with other machines or keyed to an
+// artifact snapshot. Content mode
+// hashes uncommitted edits into the token; a commit token cannot describe a
+// dirty tree, neither the job's own nor any go.work sibling it compiles.
+func (o *Orchestrator) remoteTokenTrusted(job TaskJob, states map[string]WorkspaceState) bool {
+	if o.Options.CacheMode == CacheModeContent {
+		return true
+	}
+	if s, ok := states[job.Name]; !ok || s.IsDirty {
+		return false
+	}
+	for _, dep := range o.DepGraph.Closure(job.Name) {
+		if ds, ok := states[dep]; ok && ds.IsDirty {
+			return false
+		}
+	}
+	return true
+}
+
+// hasRemoteResult is the remote fallback for a local cache miss: a successful
+// result recorded under the job's current token anywhere the remote has seen
+// it. A hit is copied into the local cache (the content store, or the daemon
+// in commit mode) so the next run does not need the network. Remote errors
+// are misses.
+func (o *Orchestrator) hasRemoteResult(ctx context.Context, job TaskJob, verb string, states map[string]WorkspaceState) bool {
+	if !o.remoteReadable() || !o.remoteTokenTrusted(job, states) {
+		return false
+	}
+	token := o.resultToken(job, states)
+	if token == "" {
+		return false
+	}
+	ctx, cancel := context.WithTimeout(ctx, remoteTimeout)
+	defer cancel()
+	verbKey := o.verbKey(verb)
+	tr, err := o.Remote.GetResult(ctx, job.Name, verbKey, token)
+	if err != nil || tr == nil || tr.ExitCode != 0 {
+		return false
+	}
+	if o.Options.CacheMode == CacheModeContent {
+		if store := o.taskStore(); store != nil {
+			_ = store.RecordTask(verbKey, token, tr)
+		}
+	} else {
+		o.reportTaskVerb(ctx, job, verb, 0, token, tr.DurationMs, "")
+	}
+	return true
+}
+
+// fetchRemoteArtifacts imports the remote's snapshot for the job into the
+// local artifact store so Restore can use it.
+func (o *Orchestrator) fetchRemoteArtifacts(ctx context.Context, job TaskJob, verb, token string) error {
+	if !o.remoteReadable() {
+		return errors.New("remote cache not readable")
+	}
+	ctx, cancel := context.WithTimeout(ctx, remoteTimeout)
+	defer cancel()
+	body, err := o.Remote.GetArtifacts(ctx, job.Name, o.verbKey(verb), token)
+	if err != nil {
+		return err
+	}
+	if body == nil {
+		return fmt.Errorf("no remote artifact snapshot for %s", job.Name)
+	}
+	defer body.Close()
+	return o.Artifacts.Import(job.Name, o.verbKey(verb), token, body)
+}
+
+// publishRemote uploads a successful verb's result (and, for builds, its
+// artifact snapshot) under the token it was recorded with. Failures are
+// reported as warning lines; publishing never fails a build.
+func (o *Orchestrator) publishRemote(ctx context.Context, job TaskJob, verb string, duration time.Duration, states map[string]WorkspaceState, eventsChan chan<- TaskEvent) {
+	if !o.remoteWritable() {
+		return
+	}
+	// Never publish results from a tree whose state a commit token cannot
+	// describe: another machine at the same commits would trust them.
+	if !o.remoteTokenTrusted(job, states) {
+		return
+	}
+	token := o.resultToken(job, states)
+	if token == "" {
+		return
+	}
+	warn := func(err error) {
+		eventsChan <- TaskEvent{Job: job, Verb: verb, Type: "output", OutputLine: fmt.Sprintf("warning: remote cache publish failed: %v", err)}
+	}
+	ctx, cancel := context.WithTimeout(ctx, remoteTimeout)
+	defer cancel()
+	verbKey := o.verbKey(verb)
+	if o.Artifacts != nil && verb == "build" {
+		var buf bytes.Buffer
+		if err := o.Artifacts.Export(job.Name, verbKey, token, &buf); err != nil {
+			warn(err)
+			return
+		}
+		// Artifacts first: a result visible without its snapshot would be a
+		// hit other machines cannot restore.
+		if err := o.Remote.PutArtifacts(ctx, job.Name, verbKey, token, &buf); err != nil {
+			warn(err)
+			return
+		}
+	}
+	if err := o.Remote.PutResult(ctx, job.Name, verbKey, token, &models.TaskResult{
+		ExitCode:   0,
+		CommitHash: token,
+		DurationMs: duration.Milliseconds(),
+		Timestamp:  time.Now(),
+	}); err != nil {
+		warn(err)
+	}
+}
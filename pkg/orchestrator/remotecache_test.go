@@ -0,0 +1,151 @@
+package orchestrator
+
+import (
+	"context"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/grovetools/grove/pkg/cacheserver"
+)
+
+// TestRemoteCache_SecondMachineRestores walks the ticket's scenario against a
+// local stand-in server: machine A builds and publishes; machine B, with
+// empty local stores and no bin/, hits through the remote and gets the
+// binary back.
+func TestRemoteCache_SecondMachineRestores(t *testing.T) {
+	srv := httptest.NewServer(cacheserver.New(t.TempDir(), "s3cret"))
+	defer srv.Close()
+
+	job := func(root string) TaskJob {
+		return TaskJob{Name: "nav", Path: filepath.Join(root, "nav")}
+	}
+	states := func() map[string]WorkspaceState {
+		return map[string]WorkspaceState{"nav": {CommitHash: "nav1"}}
+	}
+
+	a := &Orchestrator{
+		Options:      OrchestratorOptions{Verb: "build"},
+		Artifacts:    &ArtifactStore{Dir: t.TempDir()},
+		Remote:       NewHTTPRemoteCache(srv.URL, "s3cret", 0),
+		RemotePolicy: RemoteCachePolicy{Write: true},
+	}
+	jobA := job(t.TempDir())
+	writeOutput(t, jobA.Path, "bin/nav", "nav-binary")
+	events := make(chan TaskEvent, 4)
+	a.snapshotArtifacts(jobA, "build", states(), events)
+	a.publishRemote(context.Background(), jobA, "build", 0, states(), events)
+	close(events)
+	for ev := range events {
+		t.Errorf("unexpected warning: %s", ev.OutputLine)
+	}
+
+	b := &Orchestrator{
+		Options:      OrchestratorOptions{Verb: "build"},
+		Artifacts:    &ArtifactStore{Dir: t.TempDir()},
+		Remote:       NewHTTPRemoteCache(srv.URL, "s3cret", 0),
+		RemotePolicy: RemoteCachePolicy{Read: true},
+	}
+	jobB := job(t.TempDir())
+	if !b.isCacheHit(context.Background(), jobB, states()) || !b.restoreArtifacts(jobB, "build", states()) {
+		t.Fatal("machine B should hit through the remote cache")
+	}
+	if data, err := os.ReadFile(filepath.Join(jobB.Path, "bin", "nav")); err != nil || string(data) != "nav-binary" {
+		t.Fatalf("remote artifacts not restored: %q, %v", data, err)
+	}
+
+	// Read policy off: the same remote entry is ignored.
+	b.RemotePolicy.Read = false
+	b.Artifacts = &ArtifactStore{Dir: t.TempDir()}
+	if b.isCacheHit(context.Background(), jobB, states()) {
+		t.Error("remote reads must be opt-in")
+	}
+}
+
+// TestRemoteCache_DirtyNeverPublishedOrTrusted pins commit mode's safety: a
+// dirty tree has no commit identity, so its results are not published and a
+// dirty tree never trusts a remote result.
+func TestRemoteCache_DirtyNeverPublishedOrTrusted(t *testing.T) {
+	srv := httptest.NewServer(cacheserver.New(t.TempDir(), ""))
+	defer srv.Close()
+	o := &Orchestrator{
+		Options:      OrchestratorOptions{Verb: "test"},
+		Remote:       NewHTTPRemoteCache(srv.URL, "", 0),
+		RemotePolicy: RemoteCachePolicy{Read: true, Write: true},
+	}
+	job := TaskJob{Name: "nav", Path: t.TempDir()}
+	dirty := map[string]WorkspaceState{"nav": {CommitHash: "nav1", IsDirty: true}}
+	clean := map[string]WorkspaceState{"nav": {CommitHash: "nav1"}}
+
+	o.publishRemote(context.Background(), job, "test", 0, dirty, make(chan TaskEvent, 1))
+	if o.isCacheHitForVerb(context.Background(), job, "test", clean) {
+		t.Fatal("a dirty run must not be published")
+	}
+
+	o.publishRemote(context.Background(), job, "test", 0, clean, make(chan TaskEvent, 1))
+	if !o.isCacheHitForVerb(context.Background(), job, "test", clean) {
+		t.Fatal("a clean run should be published and hit")
+	}
+	if o.isCacheHitForVerb(context.Background(), job, "test", dirty) {
+		t.Error("a dirty tree must not trust a remote commit-keyed result")
+	}
+}
+
+// TestRemoteCache_DirtyDepNeverPublished extends the dirty rule to go.work
+// siblings: flow built against a dirty core has no commit identity either,
+// so publishing it would hand another machine a result for sources it lacks.
+func TestRemoteCache_DirtyDepNeverPublished(t *testing.T) {
+	srv := httptest.NewServer(cacheserver.New(t.TempDir(), ""))
+	defer srv.Close()
+	o := &Orchestrator{
+		Options:      OrchestratorOptions{Verb: "test"},
+		DepGraph:     depGraphFixture(),
+		Remote:       NewHTTPRemoteCache(srv.URL, "", 0),
+		RemotePolicy: RemoteCachePolicy{Read: true, Write: true},
+	}
+	flow := TaskJob{Name: "flow", Path: t.TempDir()}
+	dirtyCore := map[string]WorkspaceState{
+		"core": {CommitHash: "core1", IsDirty: true},
+		"flow": {CommitHash: "flow1"},
+	}
+
+	o.publishRemote(context.Background(), flow, "test", 0, dirtyCore, make(chan TaskEvent, 1))
+	tr, err := o.Remote.GetResult(context.Background(), "flow", o.verbKey("test"), o.resultToken(flow, dirtyCore))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tr != nil {
+		t.Error("a run against a dirty dependency must not be published")
+	}
+}
+
+// TestRemoteCache_HitRecordedLocally pins that a remote hit is copied into
+// the local content store, so the next run hits without the network.
+func TestRemoteCache_HitRecordedLocally(t *testing.T) {
+	srv := httptest.NewServer(cacheserver.New(t.TempDir(), ""))
+	defer srv.Close()
+	states := map[string]WorkspaceState{"nav": {CommitHash: "nav1", ContentHash: "nav-content"}}
+	job := TaskJob{Name: "nav", Path: t.TempDir()}
+
+	a := &Orchestrator{
+		Options:      OrchestratorOptions{Verb: "test", CacheMode: CacheModeContent},
+		Remote:       NewHTTPRemoteCache(srv.URL, "", 0),
+		RemotePolicy: RemoteCachePolicy{Write: true},
+	}
+	a.publishRemote(context.Background(), job, "test", 0, states, make(chan TaskEvent, 1))
+
+	b := &Orchestrator{
+		Options:       OrchestratorOptions{Verb: "test", CacheMode: CacheModeContent},
+		StateProvider: NewContentStateProvider(nil, t.TempDir()),
+		Remote:        NewHTTPRemoteCache(srv.URL, "", 0),
+		RemotePolicy:  RemoteCachePolicy{Read: true},
+	}
+	if !b.isCacheHitForVerb(context.Background(), job, "test", states) {
+		t.Fatal("b should hit through the remote cache")
+	}
+	b.Remote = nil
+	if !b.isCacheHitForVerb(context.Background(), job, "test", states) {
+		t.Error("the remote hit should have been recorded in the local store")
+	}
+}
@@ -0,0 +1,327 @@
+package orchestrator
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/url"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// ResultCollector accumulates the final results of every run it is attached
+// to (Orchestrator.Results), exactly as RunWithResults would return them. The
+// report writers below consume its Results.
+type ResultCollector struct {
+	mu      sync.Mutex
+	results []TaskResult
+}
+
+// Record keeps ev's result if it carries a final one.
+func (c *ResultCollector) Record(ev TaskEvent) {
+	if r, ok := eventResult(ev); ok {
+		c.mu.Lock()
+		c.results = append(c.results, r)
+		c.mu.Unlock()
+	}
+}
+
+// Results returns a copy of the collected results.
+func (c *ResultCollector) Results() []TaskResult {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]TaskResult(nil), c.results...)
+}
+
+// junitTestSuites is the JUnit XML document: one suite per workspace, one
+// case per verb.
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Skipped  int              `xml:"skipped,attr"`
+	Time     string           `xml:"time,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name     string          `xml:"name,attr"`
+	Tests    int             `xml:"tests,attr"`
+	Failures int             `xml:"failures,attr"`
+	Skipped  int             `xml:"skipped,attr"`
+	Time     string          `xml:"time,attr"`
+	Cases    []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	Classname string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitMessage `xml:"failure,omitempty"`
+	Skipped   *junitMessage `xml:"skipped,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+type junitMessage struct {
+	Message string `xml:"message,attr,omitempty"`
+	Body    string `xml:",chardata"`
+}
+
+// WriteJUnit writes results as JUnit XML named name. verbs is the run's verb
+// list: a whole-job cache hit (a result with no verb) becomes a passing case
+// per verb. Failures carry the error summary as their message and the full
+// captured output as their body; skipped verbs and quarantined failures are
+// reported as skipped so they stay visible without failing the suite.
+func WriteJUnit(w io.Writer, name string, verbs []string, results []TaskResult) error {
+	doc := junitTestSuites{Name: name}
+	suites := make(map[string]*junitTestSuite)
+	secs := make(map[string]float64)
+	var order []string
+	var total float64
+
+	for _, r := range results {
+		suite := suites[r.Job.Name]
+		if suite == nil {
+			suite = &junitTestSuite{Name: r.Job.Name}
+			suites[r.Job.Name] = suite
+			order = append(order, r.Job.Name)
+		}
+		caseVerbs := []string{r.Verb}
+		if r.Verb == "" {
+			caseVerbs = verbs
+		}
+		for _, verb := range caseVerbs {
+			tc := junitTestCase{
+				Name:      verb,
+				Classname: r.Job.Name,
+				Time:      junitSeconds(r.Duration.Seconds()),
+			}
+			switch {
+			case r.Cached:
+				tc.SystemOut = "cached"
+			case r.Skipped:
+				tc.Skipped = &junitMessage{Message: errString(r.Err)}
+				suite.Skipped++
+			case r.Quarantined:
+				tc.Skipped = &junitMessage{Message: "quarantined failure: " + errString(r.Err), Body: string(r.Output)}
+				suite.Skipped++
+			case r.Err != nil:
+				msg := extractErrorSummary(string(r.Output))
+				if msg == "" {
+					msg = r.Err.Error()
+				}
+				tc.Failure = &junitMessage{Message: msg, Body: string(r.Output)}
+				suite.Failures++
+			}
+			suite.Cases = append(suite.Cases, tc)
+			suite.Tests++
+		}
+		secs[r.Job.Name] += r.Duration.Seconds()
+		total += r.Duration.Seconds()
+	}
+
+	for _, name := range order {
+		suite := suites[name]
+		suite.Time = junitSeconds(secs[name])
+		doc.Suites = append(doc.Suites, *suite)
+		doc.Tests += suite.Tests
+		doc.Failures += suite.Failures
+		doc.Skipped += suite.Skipped
+	}
+	doc.Time = junitSeconds(total)
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+func junitSeconds(s float64) string {
+	return strconv.FormatFloat(s, 'f', 3, 64)
+}
+
+func errString(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
+
+// Diagnostic is one file:line[:col]: message finding in verb output.
+type Diagnostic struct {
+	File    string
+	Line    int
+	Column  int
+	Message string
+	// Linter is the golangci-lint style "(name)" suffix of the message, if
+	// any; the suffix is stripped from Message.
+	Linter string
+}
+
+var (
+	diagnosticRe = regexp.MustCompile(`^(\S+?\.[A-Za-z0-9]+):(\d+)(?::(\d+))?:\s*(.+)$`)
+	linterRe     = regexp.MustCompile(`\s+\(([A-Za-z0-9_-]+)\)$`)
+)
+
+// ParseDiagnostics extracts compiler/vet/linter style findings from output,
+// working line by line over the same trimmed, non-empty lines the error
+// summary uses. Lines that are not findings (package headers, summaries) are
+// skipped.
+func ParseDiagnostics(output string) []Diagnostic {
+	var diags []Diagnostic
+	for _, line := range outputLines(output) {
+		m := diagnosticRe.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		d := Diagnostic{File: strings.TrimPrefix(m[1], "./"), Message: m[4]}
+		d.Line, _ = strconv.Atoi(m[2])
+		if m[3] != "" {
+			d.Column, _ = strconv.Atoi(m[3])
+		}
+		if lm := linterRe.FindStringSubmatch(d.Message); lm != nil {
+			d.Linter = lm[1]
+			d.Message = strings.TrimSuffix(d.Message, lm[0])
+		}
+		diags = append(diags, d)
+	}
+	return diags
+}
+
+// sarifVerbs are the verbs whose output WriteSARIF reads for findings.
+var sarifVerbs = map[string]bool{"vet": true, "lint": true}
+
+// WriteSARIF writes the vet and lint findings in results as a SARIF 2.1.0
+// log. Each workspace is a uriBaseId pointing at its directory, so locations
+// stay workspace-relative; rules are the verb, or the reporting linter when
+// the finding names one.
+func WriteSARIF(w io.Writer, results []TaskResult) error {
+	type artifactLocation struct {
+		URI       string `json:"uri"`
+		URIBaseID string `json:"uriBaseId,omitempty"`
+	}
+	type region struct {
+		StartLine   int `json:"startLine"`
+		StartColumn int `json:"startColumn,omitempty"`
+	}
+	type physicalLocation struct {
+		ArtifactLocation artifactLocation `json:"artifactLocation"`
+		Region           region           `json:"region"`
+	}
+	type location struct {
+		PhysicalLocation physicalLocation `json:"physicalLocation"`
+	}
+	type message struct {
+		Text string `json:"text"`
+	}
+	type result struct {
+		RuleID    string     `json:"ruleId"`
+		Level     string     `json:"level"`
+		Message   message    `json:"message"`
+		Locations []location `json:"locations"`
+	}
+	type rule struct {
+		ID string `json:"id"`
+	}
+
+	var out []result
+	rules := make(map[string]bool)
+	bases := make(map[string]artifactLocation)
+	for _, r := range results {
+		if !sarifVerbs[r.Verb] || r.Cached {
+			continue
+		}
+		level := "warning"
+		if r.Failed() {
+			level = "error"
+		}
+		for _, d := range ParseDiagnostics(string(r.Output)) {
+			loc := artifactLocation{URI: filepath.ToSlash(d.File)}
+			if rel, ok := workspaceRelative(r.Job.Path, d.File); ok {
+				loc = artifactLocation{URI: filepath.ToSlash(rel), URIBaseID: r.Job.Name}
+				bases[r.Job.Name] = artifactLocation{URI: dirURI(r.Job.Path)}
+			} else if filepath.IsAbs(d.File) {
+				loc.URI = (&url.URL{Scheme: "file", Path: filepath.ToSlash(d.File)}).String()
+			}
+			ruleID := r.Verb
+			if d.Linter != "" {
+				ruleID = d.Linter
+			}
+			rules[ruleID] = true
+			out = append(out, result{
+				RuleID:  ruleID,
+				Level:   level,
+				Message: message{Text: d.Message},
+				Locations: []location{{PhysicalLocation: physicalLocation{
+					ArtifactLocation: loc,
+					Region:           region{StartLine: d.Line, StartColumn: d.Column},
+				}}},
+			})
+		}
+	}
+
+	var ruleList []rule
+	for id := range rules {
+		ruleList = append(ruleList, rule{ID: id})
+	}
+	sort.Slice(ruleList, func(i, j int) bool { return ruleList[i].ID < ruleList[j].ID })
+	if out == nil {
+		out = []result{}
+	}
+
+	run := map[string]any{
+		"tool": map[string]any{
+			"driver": map[string]any{"name": "grove", "rules": ruleList},
+		},
+		"results": out,
+	}
+	if len(bases) > 0 {
+		run["originalUriBaseIds"] = bases
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(map[string]any{
+		"$schema": "https://json.schemastore.org/sarif-2.1.0.json",
+		"version": "2.1.0",
+		"runs":    []any{run},
+	})
+}
+
+// workspaceRelative resolves a diagnostic's file against the workspace it was
+// reported in. Relative paths are already workspace-relative; absolute ones
+// are made relative when they fall inside the workspace.
+func workspaceRelative(wsPath, file string) (string, bool) {
+	if wsPath == "" {
+		return "", false
+	}
+	if !filepath.IsAbs(file) {
+		return filepath.Clean(file), true
+	}
+	rel, err := filepath.Rel(wsPath, file)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", false
+	}
+	return rel, true
+}
+
+// dirURI is wsPath as a file:// URI with the trailing slash SARIF requires of
+// base URIs.
+func dirURI(wsPath string) string {
+	abs, err := filepath.Abs(wsPath)
+	if err != nil {
+		abs = wsPath
+	}
+	return fmt.Sprintf("%s/", strings.TrimSuffix((&url.URL{Scheme: "file", Path: filepath.ToSlash(abs)}).String(), "/"))
+}
@@ -0,0 +1,127 @@
+package orchestrator
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/grovetools/core/pkg/models"
+)
+
+func TestParseDiagnostics(t *testing.T) {
+	out := `# github.com/grovetools/flow/pkg/run
+./pkg/run/run.go:42:7: printf: fmt.Sprintf format %d has arg name of wrong type string
+pkg/cli/root.go:10:2: Error return value of ` + "`f.Close`" + ` is not checked (errcheck)
+main.go:3: missing doc comment
+2 issues.`
+	diags := ParseDiagnostics(out)
+	if len(diags) != 3 {
+		t.Fatalf("got %d diagnostics, want 3: %+v", len(diags), diags)
+	}
+	if d := diags[0]; d.File != "pkg/run/run.go" || d.Line != 42 || d.Column != 7 || d.Linter != "" {
+		t.Errorf("vet finding = %+v", d)
+	}
+	if d := diags[1]; d.Linter != "errcheck" || strings.HasSuffix(d.Message, "(errcheck)") {
+		t.Errorf("lint finding = %+v", d)
+	}
+	if d := diags[2]; d.Line != 3 || d.Column != 0 {
+		t.Errorf("line-only finding = %+v", d)
+	}
+}
+
+func TestWriteJUnit_SuitePerWorkspace(t *testing.T) {
+	results := []TaskResult{
+		{Job: TaskJob{Name: "flow"}, Verb: "vet", Duration: time.Second},
+		{Job: TaskJob{Name: "flow"}, Verb: "test", Err: errors.New("exit status 1"),
+			Output: []byte("--- FAIL: TestRun\nrun_test.go:9: boom\nFAIL")},
+		{Job: TaskJob{Name: "flow"}, Verb: "lint", Skipped: true, Err: errors.New("skipped: test failed")},
+		// A whole-job cache hit expands into a case per verb.
+		{Job: TaskJob{Name: "nav"}, Cached: true},
+		{Job: TaskJob{Name: "tend"}, Verb: "test", Quarantined: true, Err: errors.New("exit status 1")},
+	}
+	var buf bytes.Buffer
+	if err := WriteJUnit(&buf, "check", []string{"vet", "test"}, results); err != nil {
+		t.Fatal(err)
+	}
+	var doc junitTestSuites
+	if err := xml.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatalf("invalid JUnit XML: %v\n%s", err, buf.String())
+	}
+	if doc.Tests != 6 || doc.Failures != 1 || doc.Skipped != 2 || len(doc.Suites) != 3 {
+		t.Fatalf("totals tests=%d failures=%d skipped=%d suites=%d", doc.Tests, doc.Failures, doc.Skipped, len(doc.Suites))
+	}
+	fail := doc.Suites[0].Cases[1].Failure
+	if fail == nil || !strings.Contains(fail.Body, "run_test.go:9: boom") || !strings.Contains(fail.Message, "FAIL") {
+		t.Errorf("failure should carry the summary and captured output: %+v", fail)
+	}
+	if nav := doc.Suites[1]; nav.Name != "nav" || len(nav.Cases) != 2 || nav.Cases[0].Failure != nil {
+		t.Errorf("cached job should pass once per verb: %+v", nav)
+	}
+}
+
+// TestWriteSARIF_FromRemoteExec runs vet through the remote build queue and
+// checks the collected results produce workspace-relative SARIF findings.
+func TestWriteSARIF_FromRemoteExec(t *testing.T) {
+	fake := newFakeBuildClient()
+	fake.jobEvents["flow"] = []models.BuildJobEvent{
+		{Event: models.BuildEventStarted},
+		{Event: models.BuildEventOutput, Line: "# github.com/grovetools/flow"},
+		{Event: models.BuildEventOutput, Line: "./main.go:12:2: unreachable code"},
+		{Event: models.BuildEventFinished, ExitCode: 1},
+	}
+	o := remoteOrchestrator(fake)
+	o.Options.Verb = "vet"
+	o.Results = &ResultCollector{}
+	wsPath := t.TempDir()
+	jobs := []TaskJob{{Name: "flow", Path: wsPath, Command: []string{"go", "vet", "./..."}}}
+	for range o.RunWithEvents(context.Background(), jobs) {
+	}
+
+	var buf bytes.Buffer
+	if err := WriteSARIF(&buf, o.Results.Results()); err != nil {
+		t.Fatal(err)
+	}
+	var log struct {
+		Version string `json:"version"`
+		Runs    []struct {
+			OriginalURIBaseIDs map[string]struct {
+				URI string `json:"uri"`
+			} `json:"originalUriBaseIds"`
+			Results []struct {
+				RuleID    string `json:"ruleId"`
+				Level     string `json:"level"`
+				Locations []struct {
+					PhysicalLocation struct {
+						ArtifactLocation struct {
+							URI       string `json:"uri"`
+							URIBaseID string `json:"uriBaseId"`
+						} `json:"artifactLocation"`
+						Region struct {
+							StartLine int `json:"startLine"`
+						} `json:"region"`
+					} `json:"physicalLocation"`
+				} `json:"locations"`
+			} `json:"results"`
+		} `json:"runs"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &log); err != nil {
+		t.Fatalf("invalid SARIF: %v", err)
+	}
+	if log.Version != "2.1.0" || len(log.Runs) != 1 || len(log.Runs[0].Results) != 1 {
+		t.Fatalf("unexpected SARIF: %s", buf.String())
+	}
+	res := log.Runs[0].Results[0]
+	loc := res.Locations[0].PhysicalLocation
+	if res.RuleID != "vet" || res.Level != "error" || loc.ArtifactLocation.URI != "main.go" ||
+		loc.ArtifactLocation.URIBaseID != "flow" || loc.Region.StartLine != 12 {
+		t.Errorf("finding = %+v", res)
+	}
+	if base := log.Runs[0].OriginalURIBaseIDs["flow"].URI; !strings.HasPrefix(base, "file://") || !strings.HasSuffix(base, "/") {
+		t.Errorf("workspace base URI = %q", base)
+	}
+}
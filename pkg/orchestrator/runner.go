@@ -38,6 +38,19 @@ type Orchestrator struct {
 	// --affected expansion uses it alongside the declared/scheduling edges in
 	// Configs so import-cycle partners of a changed member are still selected.
 	DepGraph *DepGraph
+	// Artifacts, when set, snapshots every successful build's outputs and
+	// restores them on a build cache hit (see ArtifactStore). nil keeps the
+	// old behavior: a cached build is just skipped.
+	Artifacts *ArtifactStore
+	// Remote is an optional shared cache consulted on local misses and
+	// published to after successful verbs, as RemotePolicy allows.
+	Remote       RemoteCache
+	RemotePolicy RemoteCachePolicy
+	// Trace, when set, records the timeline of every run (--trace).
+	Trace *TraceRecorder
+	// Results, when set, collects every run's final results for report
+	// writers (--report) whatever consumes the event stream.
+	Results *ResultCollector
 
 	// Remote-exec state: one submission group per orchestrator run, plus
 	// a latch that disables remote exec after the first failed submit.
@@ -46,6 +59,11 @@ type Orchestrator struct {
 	remoteSubmitted bool
 	groupID         string
 
+	// attemptGroups records the per-attempt submission groups used by
+	// remote attempts that carry a timeout (see runWithPolicy), so they can
+	// be cancelled individually and on abort.
+	attemptGroups map[string]bool
+
 	// Cross-cgo state: GROVE_TARGET_CGO_CFLAGS is resolved at most once per
 	// run (go list + shim write are I/O); "" means unavailable/omitted.
 	crossCgoOnce  sync.Once
@@ -73,14 +91,28 @@ func (o *Orchestrator) verbKey(verb string) string {
 	return verb
 }
 
-func (o *Orchestrator) isCacheHit(job TaskJob, states map[string]WorkspaceState) bool {
-	return o.isCacheHitForVerb(job, o.Options.Verb, states)
+func (o *Orchestrator) isCacheHit(ctx context.Context, job TaskJob, states map[string]WorkspaceState) bool {
+	return o.isCacheHitForVerb(ctx, job, o.Options.Verb, states)
+}
+
+// isCacheHitForVerb reports whether the job's verb can be skipped: a
+// successful result is recorded for the current token and, for builds with an
+// artifact store, a snapshot can restore the outputs it produced. Taking the
+// hit is the caller's restoreArtifacts step.
+func (o *Orchestrator) isCacheHitForVerb(ctx context.Context, job TaskJob, verb string, states map[string]WorkspaceState) bool {
+	if !o.hasCachedResult(job, verb, states) && !o.hasRemoteResult(ctx, job, verb, states) {
+		return false
+	}
+	return o.hasArtifacts(ctx, job, verb, states)
 }
 
-func (o *Orchestrator) isCacheHitForVerb(job TaskJob, verb string, states map[string]WorkspaceState) bool {
+func (o *Orchestrator) hasCachedResult(job TaskJob, verb string, states map[string]WorkspaceState) bool {
 	if o.Options.NoCache {
 		return false
 	}
+	if o.Options.CacheMode == CacheModeContent {
+		return o.isContentCacheHit(job, verb, states)
+	}
 	s, ok := states[job.Name]
 	if !ok || s.IsDirty || s.TaskResults == nil {
 		return false
@@ -99,6 +131,22 @@ func (o *Orchestrator) isCacheHitForVerb(job TaskJob, verb string, states map[st
 	return tr.ExitCode == 0 && tr.CommitHash == o.cacheToken(s.CommitHash, job, states)
 }
 
+// isContentCacheHit is the CacheModeContent lookup: dirty trees are allowed —
+// their edits are part of the token — and results come from the provider's
+// TaskResultStore rather than the daemon's per-workspace TaskResults.
+func (o *Orchestrator) isContentCacheHit(job TaskJob, verb string, states map[string]WorkspaceState) bool {
+	store := o.taskStore()
+	if store == nil {
+		return false
+	}
+	token := o.contentToken(job, states)
+	if token == "" {
+		return false
+	}
+	tr, ok := store.LookupTask(o.verbKey(verb), token)
+	return ok && tr != nil && tr.ExitCode == 0
+}
+
 // cacheToken is the cache-validity token stored in (and compared against) a
 // task result's CommitHash: the job's own HEAD commit, plus a fingerprint of
 // the state of its go.work-linked dependency closure.
@@ -149,15 +197,22 @@ func (o *Orchestrator) RunWithResults(ctx context.Context, jobs []TaskJob) ([]Ta
 	events := o.RunWithEvents(ctx, jobs)
 	var results []TaskResult
 	for event := range events {
-		if event.Type == "finish" || event.Type == "cached" {
-			if event.Result != nil {
-				results = append(results, *event.Result)
-			}
+		if r, ok := eventResult(event); ok {
+			results = append(results, r)
 		}
 	}
 	return results, nil
 }
 
+// eventResult returns the final result an event carries: finish and cached
+// events, as collected by RunWithResults and ResultCollector.
+func eventResult(event TaskEvent) (TaskResult, bool) {
+	if (event.Type == "finish" || event.Type == "cached") && event.Result != nil {
+		return *event.Result, true
+	}
+	return TaskResult{}, false
+}
+
 // RunWithEvents runs tasks and returns a channel of events for TUI consumption.
 func (o *Orchestrator) RunWithEvents(ctx context.Context, jobs []TaskJob) <-chan TaskEvent {
 	var states map[string]WorkspaceState
@@ -169,10 +224,38 @@ func (o *Orchestrator) RunWithEvents(ctx context.Context, jobs []TaskJob) <-chan
 		jobs, states = o.filterAffected(ctx, jobs, states)
 	}
 
-	if o.Options.Strategy == StrategyWaveSorted {
-		return o.runWaves(ctx, jobs, states)
+	switch o.Options.Strategy {
+	case StrategyWaveSorted:
+		return o.observeEvents(o.runWaves(ctx, jobs, states))
+	case StrategyCriticalPath:
+		return o.observeEvents(o.runCriticalPath(ctx, jobs, states))
 	}
-	return o.runFlat(ctx, jobs, states)
+	return o.observeEvents(o.runFlat(ctx, jobs, states))
+}
+
+// observeEvents tees events into o.Trace and o.Results on their way to the
+// caller.
+func (o *Orchestrator) observeEvents(events <-chan TaskEvent) <-chan TaskEvent {
+	if o.Trace == nil && o.Results == nil {
+		return events
+	}
+	if o.Trace != nil {
+		o.Trace.beginRun(o.workerCount())
+	}
+	out := make(chan TaskEvent, cap(events))
+	go func() {
+		defer close(out)
+		for ev := range events {
+			if o.Trace != nil {
+				o.Trace.Record(ev)
+			}
+			if o.Results != nil {
+				o.Results.Record(ev)
+			}
+			out <- ev
+		}
+	}()
+	return out
 }
 
 // filterAffected reduces jobs to the --affected selection (dirty or divergent
@@ -213,7 +296,7 @@ func (o *Orchestrator) runFlat(ctx context.Context, jobs []TaskJob, states map[s
 
 	var execJobs []TaskJob
 	for _, job := range jobs {
-		if o.isCacheHit(job, states) {
+		if o.isCacheHit(ctx, job, states) && o.restoreArtifacts(job, o.Options.Verb, states) {
 			eventsChan <- TaskEvent{
 				Job:  job,
 				Type: "cached",
@@ -221,6 +304,8 @@ func (o *Orchestrator) runFlat(ctx context.Context, jobs []TaskJob, states map[s
 					Job:    job,
 					Cached: true,
 				},
+				Time: time.Now(),
+				Wave: 1,
 			}
 		} else {
 			execJobs = append(execJobs, job)
@@ -234,7 +319,7 @@ func (o *Orchestrator) runFlat(ctx context.Context, jobs []TaskJob, states map[s
 
 	go func() {
 		defer close(eventsChan)
-		o.executeJobs(ctx, execJobs, numWorkers, env, states, eventsChan)
+		o.executeJobs(ctx, execJobs, 1, numWorkers, env, states, eventsChan)
 	}()
 	return eventsChan
 }
@@ -251,10 +336,10 @@ func (o *Orchestrator) runWaves(ctx context.Context, jobs []TaskJob, states map[
 
 	go func() {
 		defer close(eventsChan)
-		for _, wave := range waves {
+		for i, wave := range waves {
 			var execJobs []TaskJob
 			for _, job := range wave {
-				if o.isCacheHit(job, states) {
+				if o.isCacheHit(ctx, job, states) && o.restoreArtifacts(job, o.Options.Verb, states) {
 					eventsChan <- TaskEvent{
 						Job:  job,
 						Type: "cached",
@@ -262,13 +347,15 @@ func (o *Orchestrator) runWaves(ctx context.Context, jobs []TaskJob, states map[
 							Job:    job,
 							Cached: true,
 						},
+						Time: time.Now(),
+						Wave: i + 1,
 					}
 				} else {
 					execJobs = append(execJobs, job)
 				}
 			}
 			if len(execJobs) > 0 {
-				o.executeJobs(ctx, execJobs, numWorkers, env, states, eventsChan)
+				o.executeJobs(ctx, execJobs, i+1, numWorkers, env, states, eventsChan)
 			}
 		}
 	}()
@@ -284,7 +371,7 @@ func (o *Orchestrator) pipelineVerbs() []string {
 	return []string{o.Options.Verb}
 }
 
-func (o *Orchestrator) executeJobs(ctx context.Context, jobs []TaskJob, numWorkers int, env []string, states map[string]WorkspaceState, eventsChan chan<- TaskEvent) {
+func (o *Orchestrator) executeJobs(ctx context.Context, jobs []TaskJob, wave, numWorkers int, env []string, states map[string]WorkspaceState, eventsChan chan<- TaskEvent) {
 	jobsChan := make(chan TaskJob, len(jobs))
 	runCtx, cancel := context.WithCancel(ctx)
 	defer cancel()
@@ -310,12 +397,17 @@ func (o *Orchestrator) executeJobs(ctx context.Context, jobs []TaskJob, numWorke
 							Verb: verbs[0],
 							Err:  runCtx.Err(),
 						},
+						Time:   time.Now(),
+						Wave:   wave,
+						Worker: i + 1,
 					}
 					continue
 				default:
 				}
 
-				o.executeJobVerbs(runCtx, job, verbs, isPipeline, env, states, eventsChan, &once, cancel)
+				stamped, done := stampEvents(eventsChan, wave, i+1)
+				o.executeJobVerbs(runCtx, job, verbs, isPipeline, env, states, stamped, &once, cancel)
+				done()
 			}
 		}()
 	}
@@ -334,6 +426,29 @@ func (o *Orchestrator) executeJobs(ctx context.Context, jobs []TaskJob, numWorke
 	}
 }
 
+// stampEvents returns a channel forwarding to eventsChan that stamps each
+// event with the wave and worker slot its job runs in and the time it was
+// emitted. The returned func closes it and waits until everything has been
+// forwarded.
+func stampEvents(eventsChan chan<- TaskEvent, wave, worker int) (chan<- TaskEvent, func()) {
+	ch := make(chan TaskEvent)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for ev := range ch {
+			ev.Wave, ev.Worker = wave, worker
+			if ev.Time.IsZero() {
+				ev.Time = time.Now()
+			}
+			eventsChan <- ev
+		}
+	}()
+	return ch, func() {
+		close(ch)
+		<-done
+	}
+}
+
 func (o *Orchestrator) executeJobVerbs(ctx context.Context, job TaskJob, verbs []string, isPipeline bool, env []string, states map[string]WorkspaceState, eventsChan chan<- TaskEvent, once *sync.Once, cancel context.CancelFunc) {
 	for vi, verb := range verbs {
 		select {
@@ -349,7 +464,7 @@ func (o *Orchestrator) executeJobVerbs(ctx context.Context, job TaskJob, verbs [
 		}
 
 		// Per-verb cache check in pipeline mode
-		if isPipeline && o.isCacheHitForVerb(job, verb, states) {
+		if isPipeline && o.isCacheHitForVerb(ctx, job, verb, states) && o.restoreArtifacts(job, verb, states) {
 			eventsChan <- TaskEvent{
 				Job: job, Verb: verb, Type: "cached",
 				Result: &TaskResult{Job: job, Verb: verb, Cached: true},
@@ -366,7 +481,8 @@ func (o *Orchestrator) executeJobVerbs(ctx context.Context, job TaskJob, verbs [
 			command = job.Command
 		}
 
-		output, duration, err, started := o.runProcess(ctx, job, verb, command, env, eventsChan)
+		policy := o.verbPolicy(job, verb)
+		output, duration, err, started, stats := o.runWithPolicy(ctx, job, verb, command, env, policy, eventsChan)
 		if !started {
 			// The process (or remote submission) never ran — report the
 			// failure without a task-result report, mirroring the local
@@ -406,12 +522,15 @@ func (o *Orchestrator) executeJobVerbs(ctx context.Context, job TaskJob, verbs [
 		}
 
 		result := TaskResult{
-			Job:      job,
-			Verb:     verb,
-			Output:   output,
-			Err:      err,
-			Duration: duration,
-			Skipped:  skipped,
+			Job:         job,
+			Verb:        verb,
+			Output:      output,
+			Err:         err,
+			Duration:    duration,
+			Skipped:     skipped,
+			Attempts:    stats.attempts,
+			TimedOut:    stats.timedOut,
+			Quarantined: err != nil && policy.Quarantined,
 		}
 		eventsChan <- TaskEvent{Job: job, Verb: verb, Type: "finish", Result: &result}
 
@@ -420,7 +539,9 @@ func (o *Orchestrator) executeJobVerbs(ctx context.Context, job TaskJob, verbs [
 		if err != nil {
 			exitCode = 1
 			errSummary = extractErrorSummary(string(output))
-			if o.Options.FailFast {
+			// A quarantined failure is recorded (never cached as a pass) but
+			// neither aborts the run nor skips the rest of the pipeline.
+			if o.Options.FailFast && !result.Quarantined {
 				once.Do(cancel)
 			}
 		}
@@ -432,8 +553,13 @@ func (o *Orchestrator) executeJobVerbs(ctx context.Context, job TaskJob, verbs [
 			token = o.cacheToken(s.CommitHash, job, states)
 		}
 		o.reportTaskVerb(ctx, job, verb, exitCode, token, duration.Milliseconds(), errSummary)
+		o.recordContentResult(job, verb, exitCode, duration, states)
+		if err == nil && !skipped {
+			o.snapshotArtifacts(job, verb, states, eventsChan)
+			o.publishRemote(ctx, job, verb, duration, states, eventsChan)
+		}
 
-		if err != nil && isPipeline {
+		if err != nil && isPipeline && !result.Quarantined {
 			o.emitSkippedVerbs(job, verbs[vi+1:], verb, eventsChan)
 			return
 		}
@@ -467,6 +593,27 @@ func (o *Orchestrator) reportTaskVerb(ctx context.Context, job TaskJob, verb str
 	_ = o.DaemonClient.ReportTask(ctx, job.Path, o.verbKey(verb), exitCode, cacheToken, durationMs, errorSummary)
 }
 
+// recordContentResult stores one verb's outcome in the content-addressed store
+// under CacheModeContent. The token is computed from the states hashed before
+// the run, so it names the inputs the verb actually ran against even if the
+// verb itself rewrote tracked files.
+func (o *Orchestrator) recordContentResult(job TaskJob, verb string, exitCode int, duration time.Duration, states map[string]WorkspaceState) {
+	store := o.taskStore()
+	if store == nil {
+		return
+	}
+	token := o.contentToken(job, states)
+	if token == "" {
+		return
+	}
+	_ = store.RecordTask(o.verbKey(verb), token, &models.TaskResult{
+		ExitCode:   exitCode,
+		CommitHash: token,
+		DurationMs: duration.Milliseconds(),
+		Timestamp:  time.Now(),
+	})
+}
+
 // runProcess executes a single verb for a job, preferring the daemon's
 // machine-wide build queue when remote exec is enabled and falling back to
 // the local process path when it is not available. The returned bool is
@@ -581,18 +728,14 @@ func prependJobBinDir(env []string, jobPath string) []string {
 
 func extractErrorSummary(output string) string {
 	const maxLen = 200
-	output = strings.TrimSpace(output)
-	if output == "" {
+	lines := outputLines(output)
+	if len(lines) == 0 {
 		return ""
 	}
-	lines := strings.Split(output, "\n")
 	// Take last few non-empty lines
-	var tail []string
-	for i := len(lines) - 1; i >= 0 && len(tail) < 5; i-- {
-		line := strings.TrimSpace(lines[i])
-		if line != "" {
-			tail = append([]string{line}, tail...)
-		}
+	tail := lines
+	if len(tail) > 5 {
+		tail = tail[len(tail)-5:]
 	}
 	summary := strings.Join(tail, "\n")
 	if len(summary) > maxLen {
@@ -601,6 +744,18 @@ func extractErrorSummary(output string) string {
 	return summary
 }
 
+// outputLines splits verb output into its trimmed, non-empty lines — the unit
+// both the error summary and diagnostic parsing (ParseDiagnostics) work on.
+func outputLines(output string) []string {
+	var lines []string
+	for _, line := range strings.Split(output, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
 func jobPaths(jobs []TaskJob) []string {
 	paths := make([]string, len(jobs))
 	for i, j := range jobs {
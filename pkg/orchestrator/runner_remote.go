@@ -61,6 +61,18 @@ func (o *Orchestrator) buildGroupID() string {
 	return o.groupID
 }
 
+// ResetRunState starts a fresh remote submission group and re-enables remote
+// exec, so a long-lived orchestrator (--watch) gives each rerun its own unit
+// of cancellation instead of reusing one a previous run already cancelled.
+func (o *Orchestrator) ResetRunState() {
+	o.remoteMu.Lock()
+	defer o.remoteMu.Unlock()
+	o.groupID = ""
+	o.remoteSubmitted = false
+	o.remoteDisabled = false
+	o.attemptGroups = nil
+}
+
 func (o *Orchestrator) markRemoteSubmitted() {
 	o.remoteMu.Lock()
 	o.remoteSubmitted = true
@@ -74,6 +86,10 @@ func (o *Orchestrator) cancelRemoteGroup() {
 	o.remoteMu.Lock()
 	groupID := o.groupID
 	submitted := o.remoteSubmitted
+	attempts := make([]string, 0, len(o.attemptGroups))
+	for g := range o.attemptGroups {
+		attempts = append(attempts, g)
+	}
 	o.remoteMu.Unlock()
 	if !submitted || o.BuildClient == nil {
 		return
@@ -81,6 +97,9 @@ func (o *Orchestrator) cancelRemoteGroup() {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 	_ = o.BuildClient.CancelBuild(ctx, groupID)
+	for _, g := range attempts {
+		_ = o.BuildClient.CancelBuild(ctx, g)
+	}
 }
 
 // runRemoteProcess executes one job verb through the daemon's machine-wide
@@ -90,12 +109,18 @@ func (o *Orchestrator) cancelRemoteGroup() {
 // errRemoteUnavailable when the submission itself failed so the caller can
 // fall back to the local pool.
 func (o *Orchestrator) runRemoteProcess(ctx context.Context, job TaskJob, verb string, command, env []string, eventsChan chan<- TaskEvent) ([]byte, time.Duration, error, bool) {
+	// An attempt with its own deadline gets its own submission group so the
+	// daemon can kill just that attempt when it times out.
+	groupID := attemptGroup(ctx)
+	if groupID == "" {
+		groupID = o.buildGroupID()
+	}
 	jobID, err := o.BuildClient.SubmitBuild(ctx, models.BuildJobRequest{
 		Workspace: job.Name,
 		Dir:       job.Path,
 		Command:   command,
 		Env:       prependJobBinDir(env, job.Path),
-		GroupID:   o.buildGroupID(),
+		GroupID:   groupID,
 		Verb:      verb,
 	})
 	if err != nil {
@@ -103,6 +128,14 @@ func (o *Orchestrator) runRemoteProcess(ctx context.Context, job TaskJob, verb s
 		return nil, 0, errRemoteUnavailable, false
 	}
 	o.markRemoteSubmitted()
+	if groupID != o.buildGroupID() {
+		o.remoteMu.Lock()
+		if o.attemptGroups == nil {
+			o.attemptGroups = make(map[string]bool)
+		}
+		o.attemptGroups[groupID] = true
+		o.remoteMu.Unlock()
+	}
 
 	events, err := o.BuildClient.StreamBuildEvents(ctx, jobID)
 	if err != nil {
@@ -0,0 +1,410 @@
+package orchestrator
+
+import (
+	"context"
+	"runtime"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/grovetools/core/config"
+)
+
+// defaultJobEstimate is the duration assumed for a job with no recorded
+// history when no other job has history either.
+const defaultJobEstimate = 30 * time.Second
+
+// SchedulePlan is the critical-path scheduler's prediction for a run: the
+// estimated duration of every job, the longest dependency chain by estimated
+// time, and the wall-clock time a list schedule over the worker pool takes.
+type SchedulePlan struct {
+	Estimates    map[string]time.Duration
+	CriticalPath []string
+	// CriticalPathTime is the sum of the critical path's estimates — a lower
+	// bound on wall-clock time however many workers there are.
+	CriticalPathTime time.Duration
+	// WallClock is the simulated duration with Workers workers.
+	WallClock time.Duration
+	Workers   int
+	// Unknown counts jobs without recorded history; they were assumed to
+	// take Assumed.
+	Unknown int
+	Assumed time.Duration
+}
+
+// scheduleGraph is the dependency structure the critical-path strategy
+// schedules over: the build_after edges in configs restricted to the job set
+// (the same scheduling edges SortIntoWaves uses, import cycles already
+// condensed away by DeriveWorkspaceBuildAfter).
+type scheduleGraph struct {
+	jobs       []TaskJob
+	deps       map[string][]string
+	dependents map[string][]string
+}
+
+func newScheduleGraph(jobs []TaskJob, configs map[string]*config.Config) *scheduleGraph {
+	g := &scheduleGraph{
+		jobs:       jobs,
+		deps:       make(map[string][]string),
+		dependents: make(map[string][]string),
+	}
+	inSet := make(map[string]bool, len(jobs))
+	for _, job := range jobs {
+		inSet[job.Name] = true
+	}
+	for _, job := range jobs {
+		cfg, ok := configs[job.Name]
+		if !ok || cfg == nil {
+			continue
+		}
+		for _, dep := range cfg.BuildAfter {
+			if inSet[dep] && dep != job.Name {
+				g.deps[job.Name] = append(g.deps[job.Name], dep)
+				g.dependents[dep] = append(g.dependents[dep], job.Name)
+			}
+		}
+	}
+	return g
+}
+
+// priorities returns each job's longest downstream path: its own estimate plus
+// the most expensive chain of jobs that (transitively) wait on it. Scheduling
+// the highest priority first keeps the critical path moving. Declared cycles
+// (which SortIntoWaves breaks with its fallback wave) are cut where the walk
+// re-enters a node.
+func (g *scheduleGraph) priorities(est map[string]time.Duration) (map[string]time.Duration, map[string]string) {
+	prio := make(map[string]time.Duration, len(g.jobs))
+	next := make(map[string]string, len(g.jobs))
+	visiting := make(map[string]bool)
+	var walk func(name string) time.Duration
+	walk = func(name string) time.Duration {
+		if p, ok := prio[name]; ok {
+			return p
+		}
+		if visiting[name] {
+			return 0
+		}
+		visiting[name] = true
+		var best time.Duration
+		bestNext := ""
+		// Sorted for a deterministic critical path on ties.
+		dependents := append([]string(nil), g.dependents[name]...)
+		sort.Strings(dependents)
+		for _, d := range dependents {
+			if p := walk(d); p > best {
+				best, bestNext = p, d
+			}
+		}
+		visiting[name] = false
+		prio[name] = est[name] + best
+		next[name] = bestNext
+		return prio[name]
+	}
+	for _, job := range g.jobs {
+		walk(job.Name)
+	}
+	return prio, next
+}
+
+// estimateDurations returns each job's expected duration from the recorded
+// task results of the verbs it will run. Jobs with no history get the median
+// of the known estimates (defaultJobEstimate when nothing is known); the
+// second return is how many jobs were guessed and the third the guess.
+func (o *Orchestrator) estimateDurations(jobs []TaskJob, states map[string]WorkspaceState) (map[string]time.Duration, int, time.Duration) {
+	est := make(map[string]time.Duration, len(jobs))
+	var known []time.Duration
+	var unknown []string
+	for _, job := range jobs {
+		var total time.Duration
+		complete := true
+		s := states[job.Name]
+		for _, verb := range o.pipelineVerbs() {
+			tr, ok := s.TaskResults[o.verbKey(verb)]
+			if !ok || tr == nil || tr.DurationMs <= 0 {
+				complete = false
+				break
+			}
+			total += time.Duration(tr.DurationMs) * time.Millisecond
+		}
+		if !complete {
+			unknown = append(unknown, job.Name)
+			continue
+		}
+		est[job.Name] = total
+		known = append(known, total)
+	}
+	assumed := defaultJobEstimate
+	if len(known) > 0 {
+		sort.Slice(known, func(i, j int) bool { return known[i] < known[j] })
+		assumed = known[len(known)/2]
+	}
+	for _, name := range unknown {
+		est[name] = assumed
+	}
+	return est, len(unknown), assumed
+}
+
+func (o *Orchestrator) workerCount() int {
+	if o.Options.Jobs > 0 {
+		return o.Options.Jobs
+	}
+	return runtime.NumCPU()
+}
+
+// PlanSchedule predicts the critical-path schedule for jobs without running
+// anything (for --dry-run). Jobs whose every verb has a cached result are
+// estimated at zero, as they complete immediately in a real run. (Only the
+// recorded result is consulted — no artifact restore happens on a dry run.)
+func (o *Orchestrator) PlanSchedule(ctx context.Context, jobs []TaskJob) SchedulePlan {
+	var states map[string]WorkspaceState
+	if o.StateProvider != nil {
+		states, _ = o.StateProvider.GetState(ctx, jobPaths(jobs))
+	}
+	est, unknown, assumed := o.estimateDurations(jobs, states)
+	for _, job := range jobs {
+		cached := true
+		for _, verb := range o.pipelineVerbs() {
+			if !o.hasCachedResult(job, verb, states) {
+				cached = false
+				break
+			}
+		}
+		if cached {
+			est[job.Name] = 0
+		}
+	}
+	g := newScheduleGraph(jobs, o.Configs)
+	prio, next := g.priorities(est)
+
+	plan := SchedulePlan{
+		Estimates: est,
+		Workers:   o.workerCount(),
+		Unknown:   unknown,
+		Assumed:   assumed,
+	}
+	// The critical path starts at the highest-priority root and follows the
+	// most expensive dependent at each step.
+	head := ""
+	for _, job := range jobs {
+		if len(g.deps[job.Name]) == 0 && (head == "" || prio[job.Name] > prio[head]) {
+			head = job.Name
+		}
+	}
+	seen := make(map[string]bool)
+	for n := head; n != "" && !seen[n]; n = next[n] {
+		seen[n] = true
+		plan.CriticalPath = append(plan.CriticalPath, n)
+		plan.CriticalPathTime += est[n]
+	}
+	plan.WallClock = simulateSchedule(g, est, prio, plan.Workers)
+	return plan
+}
+
+// simulateSchedule replays the critical-path list schedule with estimated
+// durations and returns the predicted makespan.
+func simulateSchedule(g *scheduleGraph, est, prio map[string]time.Duration, workers int) time.Duration {
+	remaining := make(map[string]int, len(g.jobs))
+	for _, job := range g.jobs {
+		remaining[job.Name] = len(g.deps[job.Name])
+	}
+	q := newReadyQueue(g.jobs)
+	for _, job := range g.jobs {
+		if remaining[job.Name] == 0 {
+			q.push(job.Name)
+		}
+	}
+	type running struct {
+		name string
+		end  time.Duration
+	}
+	var (
+		now    time.Duration
+		active []running
+		done   int
+	)
+	for done < len(g.jobs) {
+		sortByPriority(q.ready, prio)
+		for len(active) < workers && len(q.ready) > 0 {
+			n := q.pop()
+			active = append(active, running{name: n, end: now + est[n]})
+		}
+		if len(active) == 0 {
+			// Only a declared cycle can strand jobs: release the rest, as
+			// SortIntoWaves' fallback wave does.
+			q.releaseAll()
+			continue
+		}
+		sort.Slice(active, func(i, j int) bool { return active[i].end < active[j].end })
+		fin := active[0]
+		active = active[1:]
+		now = fin.end
+		done++
+		for _, d := range g.dependents[fin.name] {
+			remaining[d]--
+			if remaining[d] == 0 {
+				q.push(d)
+			}
+		}
+	}
+	return now
+}
+
+// readyQueue is the list scheduler's ready list. Each job enters it at most
+// once: a job released early by a cycle break is not queued again when its
+// remaining dependencies later finish.
+type readyQueue struct {
+	jobs   []TaskJob
+	ready  []string
+	queued map[string]bool
+}
+
+func newReadyQueue(jobs []TaskJob) *readyQueue {
+	return &readyQueue{jobs: jobs, queued: make(map[string]bool, len(jobs))}
+}
+
+func (q *readyQueue) push(name string) {
+	if !q.queued[name] {
+		q.queued[name] = true
+		q.ready = append(q.ready, name)
+	}
+}
+
+func (q *readyQueue) pop() string {
+	name := q.ready[0]
+	q.ready = q.ready[1:]
+	return name
+}
+
+// releaseAll queues every job not yet queued.
+func (q *readyQueue) releaseAll() {
+	for _, job := range q.jobs {
+		q.push(job.Name)
+	}
+}
+
+func sortByPriority(names []string, prio map[string]time.Duration) {
+	sort.SliceStable(names, func(i, j int) bool {
+		if prio[names[i]] != prio[names[j]] {
+			return prio[names[i]] > prio[names[j]]
+		}
+		return names[i] < names[j]
+	})
+}
+
+// runCriticalPath is StrategyCriticalPath: unlike runWaves, a job starts the
+// moment its own dependencies have finished (successfully or not, as with
+// waves) rather than when the whole previous wave has. Among ready jobs the
+// one with the longest estimated downstream path goes first.
+func (o *Orchestrator) runCriticalPath(ctx context.Context, jobs []TaskJob, states map[string]WorkspaceState) <-chan TaskEvent {
+	numWorkers := o.workerCount()
+	env := o.buildJobEnv(jobs)
+	eventsChan := make(chan TaskEvent, len(jobs)*3)
+
+	est, _, _ := o.estimateDurations(jobs, states)
+	g := newScheduleGraph(jobs, o.Configs)
+	prio, _ := g.priorities(est)
+	byName := make(map[string]TaskJob, len(jobs))
+	for _, job := range jobs {
+		byName[job.Name] = job
+	}
+	// There are no wave barriers here; events still carry the job's wave
+	// from SortIntoWaves as its dependency level.
+	level := make(map[string]int, len(jobs))
+	for i, wave := range SortIntoWaves(jobs, o.Configs) {
+		for _, job := range wave {
+			level[job.Name] = i + 1
+		}
+	}
+
+	go func() {
+		defer close(eventsChan)
+		runCtx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		var once sync.Once
+		verbs := o.pipelineVerbs()
+		isPipeline := len(o.Options.Pipeline) > 0
+
+		remaining := make(map[string]int, len(jobs))
+		for _, job := range jobs {
+			remaining[job.Name] = len(g.deps[job.Name])
+		}
+		q := newReadyQueue(jobs)
+		for _, job := range jobs {
+			if remaining[job.Name] == 0 {
+				q.push(job.Name)
+			}
+		}
+
+		type slotDone struct {
+			name string
+			slot int
+		}
+		finished := make(chan slotDone, len(jobs))
+		var wg sync.WaitGroup
+		active, done := 0, 0
+		// Free worker slots, lowest first, so event Worker numbers stay
+		// within 1..numWorkers as with the wave pool.
+		var free []int
+		for i := numWorkers; i >= 1; i-- {
+			free = append(free, i)
+		}
+		complete := func(name string) {
+			done++
+			for _, d := range g.dependents[name] {
+				remaining[d]--
+				if remaining[d] == 0 {
+					q.push(d)
+				}
+			}
+		}
+
+		for done < len(jobs) {
+			sortByPriority(q.ready, prio)
+			for len(q.ready) > 0 {
+				name := q.ready[0]
+				job := byName[name]
+				if o.isCacheHit(ctx, job, states) && o.restoreArtifacts(job, o.Options.Verb, states) {
+					q.pop()
+					eventsChan <- TaskEvent{Job: job, Type: "cached", Result: &TaskResult{Job: job, Cached: true}, Time: time.Now(), Wave: level[name]}
+					complete(name)
+					sortByPriority(q.ready, prio)
+					continue
+				}
+				if active >= numWorkers {
+					break
+				}
+				q.pop()
+				active++
+				slot := free[len(free)-1]
+				free = free[:len(free)-1]
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					stamped, flush := stampEvents(eventsChan, level[job.Name], slot)
+					o.executeJobVerbs(runCtx, job, verbs, isPipeline, env, states, stamped, &once, cancel)
+					flush()
+					finished <- slotDone{job.Name, slot}
+				}()
+			}
+			if done >= len(jobs) {
+				break
+			}
+			if active == 0 {
+				// Nothing running and nothing ready: a declared build_after
+				// cycle. Release everything left, like SortIntoWaves.
+				q.releaseAll()
+				continue
+			}
+			fin := <-finished
+			active--
+			free = append(free, fin.slot)
+			complete(fin.name)
+		}
+		wg.Wait()
+
+		if runCtx.Err() != nil {
+			o.cancelRemoteGroup()
+		}
+	}()
+	return eventsChan
+}
@@ -0,0 +1,148 @@
+package orchestrator
+
+import (
+	"context"
+	"os/exec"
+	"testing"
+	"time"
+
+	"github.com/grovetools/core/config"
+	"github.com/grovetools/core/pkg/models"
+)
+
+// staticStates is a StateProvider returning fixed states.
+type staticStates map[string]WorkspaceState
+
+func (s staticStates) GetState(ctx context.Context, workspaces []string) (map[string]WorkspaceState, error) {
+	return s, nil
+}
+
+func withHistory(verb string, d time.Duration) WorkspaceState {
+	return WorkspaceState{TaskResults: map[string]*models.TaskResult{
+		verb: {DurationMs: d.Milliseconds()},
+	}}
+}
+
+func TestPlanSchedule_CriticalPathAndWallClock(t *testing.T) {
+	o := &Orchestrator{
+		Options: OrchestratorOptions{Verb: "test", Strategy: StrategyCriticalPath, Jobs: 2, NoCache: true},
+		Configs: map[string]*config.Config{
+			"c": {BuildAfter: []string{"a"}},
+		},
+		StateProvider: staticStates{
+			"a": withHistory("test", 10*time.Second),
+			"b": withHistory("test", 5*time.Second),
+			"c": withHistory("test", 20*time.Second),
+			// "d" has no history: assumed the median of the others (10s).
+		},
+	}
+	jobs := []TaskJob{{Name: "a"}, {Name: "b"}, {Name: "c"}, {Name: "d"}}
+
+	plan := o.PlanSchedule(context.Background(), jobs)
+	if len(plan.CriticalPath) != 2 || plan.CriticalPath[0] != "a" || plan.CriticalPath[1] != "c" {
+		t.Fatalf("critical path = %v, want [a c]", plan.CriticalPath)
+	}
+	if plan.CriticalPathTime != 30*time.Second {
+		t.Errorf("critical path time = %s, want 30s", plan.CriticalPathTime)
+	}
+	if plan.Unknown != 1 || plan.Assumed != 10*time.Second {
+		t.Errorf("unknown = %d assumed %s, want 1 assumed 10s", plan.Unknown, plan.Assumed)
+	}
+	// a and d start together; c starts the moment a finishes (10s) and b
+	// fills d's slot, so nothing waits on a wave barrier: 10s + 20s.
+	if plan.WallClock != 30*time.Second {
+		t.Errorf("wall clock = %s, want 30s", plan.WallClock)
+	}
+}
+
+// TestCriticalPath_StartsWhenOwnDepsFinish runs a slow independent job next
+// to a short chain. Under waves the chain's second job would wait for the
+// slow job's wave; here it must finish first.
+func TestCriticalPath_StartsWhenOwnDepsFinish(t *testing.T) {
+	for _, tool := range []string{"sh", "sleep"} {
+		if _, err := exec.LookPath(tool); err != nil {
+			t.Skipf("%s not on PATH", tool)
+		}
+	}
+	o := &Orchestrator{
+		Options: OrchestratorOptions{Verb: "test", Strategy: StrategyCriticalPath, Jobs: 3},
+		Configs: map[string]*config.Config{
+			"after-fast": {BuildAfter: []string{"fast"}},
+		},
+	}
+	jobs := []TaskJob{
+		{Name: "slow", Path: t.TempDir(), Command: []string{"sleep", "2"}},
+		{Name: "fast", Path: t.TempDir(), Command: []string{"sh", "-c", "true"}},
+		{Name: "after-fast", Path: t.TempDir(), Command: []string{"sh", "-c", "true"}},
+	}
+
+	var order []string
+	started := make(map[string]bool)
+	for ev := range o.RunWithEvents(context.Background(), jobs) {
+		switch ev.Type {
+		case "start":
+			if ev.Job.Name == "after-fast" && !started["fast"] {
+				t.Error("after-fast started before its dependency")
+			}
+			started[ev.Job.Name] = true
+		case "finish":
+			if ev.Result != nil && ev.Result.Err != nil {
+				t.Fatalf("%s failed: %v", ev.Job.Name, ev.Result.Err)
+			}
+			order = append(order, ev.Job.Name)
+		}
+	}
+	if len(order) != 3 {
+		t.Fatalf("expected 3 finished jobs, got %v", order)
+	}
+	if order[2] != "slow" {
+		t.Errorf("after-fast should not wait for the slow job: finish order %v", order)
+	}
+}
+
+// cycleConfigs declares a build_after cycle between a and b with c waiting
+// on both, so the cycle break releases c before its dependencies finish.
+func cycleConfigs() map[string]*config.Config {
+	return map[string]*config.Config{
+		"a": {BuildAfter: []string{"b"}},
+		"b": {BuildAfter: []string{"a"}},
+		"c": {BuildAfter: []string{"a", "b"}},
+	}
+}
+
+func TestSimulateSchedule_CycleRunsEachJobOnce(t *testing.T) {
+	jobs := []TaskJob{{Name: "a"}, {Name: "b"}, {Name: "c"}}
+	g := newScheduleGraph(jobs, cycleConfigs())
+	est := map[string]time.Duration{"a": time.Second, "b": 2 * time.Second, "c": 4 * time.Second}
+	prio, _ := g.priorities(est)
+	// One worker runs everything back to back, each job exactly once.
+	if got := simulateSchedule(g, est, prio, 1); got != 7*time.Second {
+		t.Errorf("makespan = %s, want 7s", got)
+	}
+}
+
+func TestCriticalPath_CycleRunsEachJobOnce(t *testing.T) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("sh not on PATH")
+	}
+	o := &Orchestrator{
+		Options: OrchestratorOptions{Verb: "test", Strategy: StrategyCriticalPath, Jobs: 1},
+		Configs: cycleConfigs(),
+	}
+	jobs := []TaskJob{
+		{Name: "a", Path: t.TempDir(), Command: []string{"sh", "-c", "true"}},
+		{Name: "b", Path: t.TempDir(), Command: []string{"sh", "-c", "true"}},
+		{Name: "c", Path: t.TempDir(), Command: []string{"sh", "-c", "true"}},
+	}
+	starts := make(map[string]int)
+	for ev := range o.RunWithEvents(context.Background(), jobs) {
+		if ev.Type == "start" {
+			starts[ev.Job.Name]++
+		}
+	}
+	for _, job := range jobs {
+		if starts[job.Name] != 1 {
+			t.Errorf("%s started %d times, want once (starts %v)", job.Name, starts[job.Name], starts)
+		}
+	}
+}
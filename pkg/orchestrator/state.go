@@ -17,7 +17,11 @@ type WorkspaceState struct {
 	// unmerged worktree repo still differs from what main would build.
 	DivergesFromMain bool
 	CommitHash       string
-	TaskResults      map[string]*models.TaskResult
+	// ContentHash is the hash of the workspace's input files (see
+	// HashWorkspaceInputs). Only content-aware providers set it; "" means
+	// unknown and disables content-keyed caching for the workspace.
+	ContentHash string
+	TaskResults map[string]*models.TaskResult
 }
 
 type StateProvider interface {
@@ -1,6 +1,7 @@
 package orchestrator
 
 import (
+	"context"
 	"os"
 	"path/filepath"
 	"runtime"
@@ -120,15 +121,15 @@ func TestVerbKeyCacheKeying(t *testing.T) {
 			},
 		},
 	}
-	if o.isCacheHit(job, states) {
+	if o.isCacheHit(context.Background(), job, states) {
 		t.Error("cross build must not false-hit the native cache entry")
 	}
 	states["grove"].TaskResults["build@plan9_mips"] = &models.TaskResult{ExitCode: 0, CommitHash: "abc"}
-	if !o.isCacheHit(job, states) {
+	if !o.isCacheHit(context.Background(), job, states) {
 		t.Error("cross build should hit its own keyed entry")
 	}
 	// And the native orchestrator keeps hitting the plain key.
-	if !oNative.isCacheHit(job, states) {
+	if !oNative.isCacheHit(context.Background(), job, states) {
 		t.Error("native build should hit the plain verb entry")
 	}
 }
@@ -0,0 +1,313 @@
+package orchestrator
+
+import (
+	"encoding/json"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// TraceRecorder collects the timeline of one or more runs (grove build
+// --trace). Set it as Orchestrator.Trace; RunWithEvents then records every
+// start/finish/cached/skipped event with its wave and worker slot. Runs
+// recorded back to back (runJSONTaskWaves calls RunWithEvents once per wave)
+// are numbered consecutively rather than each starting at wave 1.
+type TraceRecorder struct {
+	mu       sync.Mutex
+	workers  int
+	waveBase int
+	maxWave  int
+	open     map[traceKey]time.Time
+	entries  []traceEntry
+}
+
+// traceEntry is a recorded span or instant in absolute time; offsets are
+// computed when the timeline is read, as events can arrive slightly out of
+// order across workers.
+type traceEntry struct {
+	span       TraceSpan
+	start, end time.Time
+	instant    bool
+}
+
+type traceKey struct {
+	job  string
+	verb string
+}
+
+// TraceSpan is one job verb on the timeline. Start and End are offsets from
+// the first recorded event; instants (cached, skipped, retry, timeout) have
+// Start == End.
+type TraceSpan struct {
+	Job    string
+	Verb   string
+	Status string
+	Wave   int
+	Worker int
+	Start  time.Duration
+	End    time.Duration
+}
+
+// Duration is the span's length.
+func (s TraceSpan) Duration() time.Duration { return s.End - s.Start }
+
+// NewTraceRecorder returns an empty recorder.
+func NewTraceRecorder() *TraceRecorder {
+	return &TraceRecorder{open: make(map[traceKey]time.Time)}
+}
+
+// beginRun marks the start of a RunWithEvents call with workers slots.
+func (r *TraceRecorder) beginRun(workers int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if workers > r.workers {
+		r.workers = workers
+	}
+	r.waveBase = r.maxWave
+}
+
+// Record adds ev to the timeline. Output events are ignored.
+func (r *TraceRecorder) Record(ev TaskEvent) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	t := ev.Time
+	if t.IsZero() {
+		t = time.Now()
+	}
+	wave := ev.Wave + r.waveBase
+	if wave > r.maxWave {
+		r.maxWave = wave
+	}
+	key := traceKey{ev.Job.Name, ev.Verb}
+	span := func(status string) TraceSpan {
+		return TraceSpan{Job: ev.Job.Name, Verb: ev.Verb, Status: status, Wave: wave, Worker: ev.Worker}
+	}
+	instant := func(status string) {
+		r.entries = append(r.entries, traceEntry{span: span(status), start: t, end: t, instant: true})
+	}
+
+	switch ev.Type {
+	case "start":
+		// Retries emit another start; the span covers every attempt.
+		if _, ok := r.open[key]; !ok {
+			r.open[key] = t
+		}
+	case "finish":
+		start, ok := r.open[key]
+		delete(r.open, key)
+		status := "ok"
+		if res := ev.Result; res != nil {
+			switch {
+			case res.Skipped:
+				status = "skipped"
+			case res.Quarantined:
+				status = "quarantined"
+			case res.TimedOut:
+				status = "timeout"
+			case res.Err != nil:
+				status = "failed"
+			}
+		}
+		if !ok {
+			// Never started: skipped pipeline verbs, cancelled jobs.
+			instant(status)
+			return
+		}
+		r.entries = append(r.entries, traceEntry{span: span(status), start: start, end: t})
+	case "cached", "retry", "timeout":
+		instant(ev.Type)
+	}
+}
+
+// timeline returns the recorded spans and instants with offsets from the
+// earliest event. Callers hold r.mu.
+func (r *TraceRecorder) timeline() (spans, instants []TraceSpan) {
+	var epoch time.Time
+	for _, e := range r.entries {
+		if epoch.IsZero() || e.start.Before(epoch) {
+			epoch = e.start
+		}
+	}
+	for _, e := range r.entries {
+		s := e.span
+		s.Start, s.End = e.start.Sub(epoch), e.end.Sub(epoch)
+		if e.instant {
+			instants = append(instants, s)
+		} else {
+			spans = append(spans, s)
+		}
+	}
+	return spans, instants
+}
+
+// chromeTraceEvent is one entry of the Chrome trace-event format
+// (chrome://tracing, Perfetto, speedscope). Timestamps are microseconds.
+type chromeTraceEvent struct {
+	Name  string         `json:"name"`
+	Cat   string         `json:"cat,omitempty"`
+	Ph    string         `json:"ph"`
+	Ts    int64          `json:"ts"`
+	Dur   int64          `json:"dur,omitempty"`
+	Pid   int            `json:"pid"`
+	Tid   int            `json:"tid"`
+	Scope string         `json:"s,omitempty"`
+	Args  map[string]any `json:"args,omitempty"`
+}
+
+// WriteChromeTrace writes the timeline as Chrome trace-event JSON: one thread
+// per worker slot (thread 0 holds work that never took a slot), a complete
+// event per job verb and an instant event per cache hit, skip, retry or
+// timeout.
+func (r *TraceRecorder) WriteChromeTrace(w io.Writer) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	spans, instants := r.timeline()
+	var events []chromeTraceEvent
+	events = append(events, chromeTraceEvent{Name: "process_name", Ph: "M", Pid: 1, Args: map[string]any{"name": "grove"}})
+	for slot := 0; slot <= r.workers; slot++ {
+		name := "worker " + strconv.Itoa(slot)
+		if slot == 0 {
+			name = "no worker"
+		}
+		events = append(events, chromeTraceEvent{Name: "thread_name", Ph: "M", Pid: 1, Tid: slot, Args: map[string]any{"name": name}})
+	}
+	for _, s := range spans {
+		events = append(events, chromeTraceEvent{
+			Name: traceLabel(s.Job, s.Verb), Cat: s.Verb, Ph: "X",
+			Ts: s.Start.Microseconds(), Dur: s.Duration().Microseconds(),
+			Pid: 1, Tid: s.Worker,
+			Args: map[string]any{"job": s.Job, "verb": s.Verb, "status": s.Status, "wave": s.Wave},
+		})
+	}
+	for _, s := range instants {
+		events = append(events, chromeTraceEvent{
+			Name: traceLabel(s.Job, s.Verb, s.Status), Cat: s.Status, Ph: "i", Scope: "t",
+			Ts: s.Start.Microseconds(), Pid: 1, Tid: s.Worker,
+			Args: map[string]any{"job": s.Job, "verb": s.Verb, "status": s.Status, "wave": s.Wave},
+		})
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", " ")
+	return enc.Encode(map[string]any{
+		"traceEvents":     events,
+		"displayTimeUnit": "ms",
+	})
+}
+
+// traceLabel joins the non-empty parts of an event name (whole-job cache hits
+// carry no verb).
+func traceLabel(parts ...string) string {
+	var out []string
+	for _, p := range parts {
+		if p != "" {
+			out = append(out, p)
+		}
+	}
+	return strings.Join(out, " ")
+}
+
+// JobTime is one job's total busy time across its verbs.
+type JobTime struct {
+	Job      string
+	Wave     int
+	Duration time.Duration
+}
+
+// WaveIdle is one wave's utilization: Idle is worker capacity over the wave's
+// span (first start to last finish) not spent running jobs.
+type WaveIdle struct {
+	Wave int
+	Jobs int
+	Span time.Duration
+	Busy time.Duration
+	Idle time.Duration
+}
+
+// TraceSummary is the aggregate view printed after a traced run. Idle is the
+// whole run's unused worker time; under StrategyCriticalPath waves overlap,
+// so it is the figure to compare against a wave-sorted run's per-wave idle.
+type TraceSummary struct {
+	Total   time.Duration
+	Workers int
+	Idle    time.Duration
+	Slowest []JobTime
+	Waves   []WaveIdle
+}
+
+// Summary returns the top slowest jobs (all when top <= 0) and the idle
+// worker time per wave.
+func (r *TraceRecorder) Summary(top int) TraceSummary {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	sum := TraceSummary{Workers: r.workers}
+	byJob := make(map[string]*JobTime)
+	type waveAcc struct {
+		start, end time.Duration
+		busy       time.Duration
+		jobs       map[string]bool
+	}
+	waves := make(map[int]*waveAcc)
+	spans, _ := r.timeline()
+	for _, s := range spans {
+		if s.End > sum.Total {
+			sum.Total = s.End
+		}
+		jt := byJob[s.Job]
+		if jt == nil {
+			jt = &JobTime{Job: s.Job, Wave: s.Wave}
+			byJob[s.Job] = jt
+		}
+		jt.Duration += s.Duration()
+
+		wa := waves[s.Wave]
+		if wa == nil {
+			wa = &waveAcc{start: s.Start, end: s.End, jobs: make(map[string]bool)}
+			waves[s.Wave] = wa
+		}
+		if s.Start < wa.start {
+			wa.start = s.Start
+		}
+		if s.End > wa.end {
+			wa.end = s.End
+		}
+		wa.busy += s.Duration()
+		wa.jobs[s.Job] = true
+	}
+
+	for _, jt := range byJob {
+		sum.Slowest = append(sum.Slowest, *jt)
+	}
+	sort.Slice(sum.Slowest, func(i, j int) bool {
+		if sum.Slowest[i].Duration != sum.Slowest[j].Duration {
+			return sum.Slowest[i].Duration > sum.Slowest[j].Duration
+		}
+		return sum.Slowest[i].Job < sum.Slowest[j].Job
+	})
+	if top > 0 && len(sum.Slowest) > top {
+		sum.Slowest = sum.Slowest[:top]
+	}
+
+	for wave, wa := range waves {
+		span := wa.end - wa.start
+		idle := time.Duration(r.workers)*span - wa.busy
+		if idle < 0 {
+			idle = 0
+		}
+		sum.Waves = append(sum.Waves, WaveIdle{Wave: wave, Jobs: len(wa.jobs), Span: span, Busy: wa.busy, Idle: idle})
+	}
+	sort.Slice(sum.Waves, func(i, j int) bool { return sum.Waves[i].Wave < sum.Waves[j].Wave })
+
+	var busy time.Duration
+	for _, s := range spans {
+		busy += s.Duration()
+	}
+	if idle := time.Duration(r.workers)*sum.Total - busy; idle > 0 {
+		sum.Idle = idle
+	}
+	return sum
+}
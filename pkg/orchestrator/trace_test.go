@@ -0,0 +1,106 @@
+package orchestrator
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"os/exec"
+	"testing"
+	"time"
+)
+
+func TestTraceRecorder_SummaryAndChromeTrace(t *testing.T) {
+	r := NewTraceRecorder()
+	r.beginRun(2)
+	t0 := time.Unix(1000, 0)
+	at := func(d time.Duration) time.Time { return t0.Add(d) }
+	ev := func(job, typ string, wave, worker int, ts time.Duration, res *TaskResult) TaskEvent {
+		return TaskEvent{Job: TaskJob{Name: job}, Verb: "build", Type: typ, Wave: wave, Worker: worker, Time: at(ts), Result: res}
+	}
+
+	// Wave 1: a (4s) and b (1s) on two workers; wave 2: c (2s), d cached.
+	for _, e := range []TaskEvent{
+		ev("a", "start", 1, 1, 0, nil),
+		ev("b", "start", 1, 2, 0, nil),
+		ev("b", "finish", 1, 2, time.Second, &TaskResult{}),
+		ev("a", "finish", 1, 1, 4*time.Second, &TaskResult{Err: errors.New("boom")}),
+		ev("c", "start", 2, 1, 4*time.Second, nil),
+		ev("c", "finish", 2, 1, 6*time.Second, &TaskResult{}),
+		{Job: TaskJob{Name: "d"}, Type: "cached", Wave: 2, Time: at(4 * time.Second)},
+	} {
+		r.Record(e)
+	}
+
+	sum := r.Summary(2)
+	if len(sum.Slowest) != 2 || sum.Slowest[0].Job != "a" || sum.Slowest[1].Job != "c" {
+		t.Fatalf("slowest = %+v, want a then c", sum.Slowest)
+	}
+	if len(sum.Waves) != 2 {
+		t.Fatalf("waves = %+v, want 2", sum.Waves)
+	}
+	// Wave 1 spans 4s on 2 workers (8s capacity) with 5s busy.
+	if w := sum.Waves[0]; w.Span != 4*time.Second || w.Idle != 3*time.Second {
+		t.Errorf("wave 1 = %+v, want 4s span / 3s idle", w)
+	}
+	if sum.Total != 6*time.Second || sum.Idle != 5*time.Second {
+		t.Errorf("total %s idle %s, want 6s / 5s", sum.Total, sum.Idle)
+	}
+
+	var buf bytes.Buffer
+	if err := r.WriteChromeTrace(&buf); err != nil {
+		t.Fatal(err)
+	}
+	var doc struct {
+		TraceEvents []chromeTraceEvent `json:"traceEvents"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatalf("trace is not valid JSON: %v", err)
+	}
+	var complete, instants int
+	for _, e := range doc.TraceEvents {
+		switch e.Ph {
+		case "X":
+			complete++
+			if e.Name == "a build" && (e.Dur != 4e6 || e.Tid != 1 || e.Args["status"] != "failed") {
+				t.Errorf("a's span = %+v", e)
+			}
+		case "i":
+			instants++
+		}
+	}
+	if complete != 3 || instants != 1 {
+		t.Errorf("got %d spans and %d instants, want 3 and 1", complete, instants)
+	}
+}
+
+// TestTrace_EventsCarryWaveAndWorker checks the orchestrator stamps events
+// for the recorder: consecutive waves, worker slots within the pool.
+func TestTrace_EventsCarryWaveAndWorker(t *testing.T) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("sh not on PATH")
+	}
+	o := &Orchestrator{
+		Options: OrchestratorOptions{Verb: "test", Strategy: StrategyFlat, Jobs: 2},
+		Trace:   NewTraceRecorder(),
+	}
+	jobs := []TaskJob{
+		{Name: "a", Path: t.TempDir(), Command: []string{"sh", "-c", "true"}},
+		{Name: "b", Path: t.TempDir(), Command: []string{"sh", "-c", "true"}},
+	}
+	for ev := range o.RunWithEvents(context.Background(), jobs) {
+		if ev.Type != "start" && ev.Type != "finish" {
+			continue
+		}
+		if ev.Wave != 1 || ev.Worker < 1 || ev.Worker > 2 || ev.Time.IsZero() {
+			t.Errorf("%s %s: wave %d worker %d time %v", ev.Job.Name, ev.Type, ev.Wave, ev.Worker, ev.Time)
+		}
+	}
+	// A second run on the same recorder continues the wave numbering.
+	for range o.RunWithEvents(context.Background(), jobs[:1]) {
+	}
+	sum := o.Trace.Summary(0)
+	if len(sum.Waves) != 2 || sum.Waves[1].Wave != 2 {
+		t.Errorf("waves = %+v, want runs recorded as waves 1 and 2", sum.Waves)
+	}
+}
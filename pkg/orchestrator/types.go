@@ -7,6 +7,24 @@ type ConcurrencyStrategy string
 const (
 	StrategyFlat       ConcurrencyStrategy = "flat"
 	StrategyWaveSorted ConcurrencyStrategy = "wave-sorted"
+	// StrategyCriticalPath honors the same build_after ordering as
+	// StrategyWaveSorted but starts each job as soon as its own dependencies
+	// finish, longest estimated downstream path first (see scheduler.go).
+	StrategyCriticalPath ConcurrencyStrategy = "critical-path"
+)
+
+// CacheMode selects how task results are keyed for cache-hit checks.
+type CacheMode string
+
+const (
+	// CacheModeCommit keys results on HEAD plus the dependency closure's
+	// commits (cacheToken). Dirty workspaces never hit. This is the default.
+	CacheModeCommit CacheMode = "commit"
+	// CacheModeContent keys results on a hash of the workspace's input files
+	// plus its dependency closure's (contentToken), looked up in the state
+	// provider's TaskResultStore. Dirty trees hit when their content matches
+	// a previous run.
+	CacheModeContent CacheMode = "content"
 )
 
 type OrchestratorOptions struct {
@@ -31,6 +49,14 @@ type OrchestratorOptions struct {
 	// native builds never invalidate or false-hit each other. A zero or
 	// native target is a no-op.
 	Target Target
+	// CacheMode selects commit-keyed (default) or content-keyed task result
+	// caching. Content mode needs a StateProvider that implements
+	// TaskResultStore; without one every job misses.
+	CacheMode CacheMode
+	// TaskSettings is the ecosystem-level [tasks.<verb>] table: per-verb
+	// timeout/retries defaults (a workspace's own table overrides them) and
+	// the quarantine list (see TaskVerbConfig).
+	TaskSettings map[string]TaskVerbConfig
 }
 
 type TaskJob struct {
@@ -47,12 +73,36 @@ type TaskResult struct {
 	Duration time.Duration
 	Cached   bool
 	Skipped  bool
+	// Attempts is how many times the verb ran (1 + retries used).
+	Attempts int
+	// TimedOut reports that the final attempt hit the verb's timeout.
+	TimedOut bool
+	// Quarantined marks a failure of a quarantined job verb: it is reported
+	// but must not fail the run (callers count it apart from failures).
+	Quarantined bool
+}
+
+// Failed reports whether the result should fail the run: an error that was
+// neither a skip nor a quarantined failure.
+func (r TaskResult) Failed() bool {
+	return r.Err != nil && !r.Skipped && !r.Quarantined
 }
 
 type TaskEvent struct {
-	Job        TaskJob
-	Verb       string
-	Type       string // "start", "finish", "output", "cached"
+	Job  TaskJob
+	Verb string
+	// Type is "start", "finish", "output", "cached", "retry" (another
+	// attempt follows; Attempt is its number) or "timeout" (the attempt
+	// was killed at its deadline).
+	Type       string
 	Result     *TaskResult
 	OutputLine string
+	Attempt    int
+	// Time is when the event was emitted. Wave is the job's 1-based wave
+	// (its dependency level under StrategyCriticalPath) and Worker the
+	// 1-based worker slot that ran it; Worker is 0 for jobs that never
+	// occupied a worker (whole-job cache hits).
+	Time   time.Time
+	Wave   int
+	Worker int
 }
@@ -0,0 +1,239 @@
+package orchestrator
+
+import (
+	"io/fs"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/grovetools/core/config"
+)
+
+// DefaultWatchDebounce is how long a watched workspace must be quiet before
+// its changes trigger a rerun: an editor save or a branch switch touches many
+// files in quick succession.
+const DefaultWatchDebounce = 300 * time.Millisecond
+
+// Watcher reports which workspaces changed on disk (--watch). It watches each
+// workspace tree recursively, skipping .git directories and the workspace's
+// top-level bin/ (build outputs would otherwise retrigger every build).
+// Changes keep accumulating while nobody is receiving, so edits made during a
+// run are delivered as one batch when it finishes.
+type Watcher struct {
+	fs       *fsnotify.Watcher
+	roots    []watchRoot
+	debounce time.Duration
+	changes  chan []string
+	errs     chan error
+	done     chan struct{}
+}
+
+type watchRoot struct {
+	name string
+	path string
+}
+
+// NewWatcher starts watching the workspaces of jobs. debounce <= 0 uses
+// DefaultWatchDebounce.
+func NewWatcher(jobs []TaskJob, debounce time.Duration) (*Watcher, error) {
+	if debounce <= 0 {
+		debounce = DefaultWatchDebounce
+	}
+	fw, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+	w := &Watcher{
+		fs:       fw,
+		debounce: debounce,
+		changes:  make(chan []string),
+		errs:     make(chan error, 1),
+		done:     make(chan struct{}),
+	}
+	for _, job := range jobs {
+		abs, err := filepath.Abs(job.Path)
+		if err != nil {
+			abs = job.Path
+		}
+		w.roots = append(w.roots, watchRoot{name: job.Name, path: filepath.Clean(abs)})
+	}
+	// Longest path first, so a nested workspace claims its own files.
+	sort.Slice(w.roots, func(i, j int) bool { return len(w.roots[i].path) > len(w.roots[j].path) })
+
+	for _, root := range w.roots {
+		if err := w.addTree(root.path); err != nil {
+			fw.Close()
+			return nil, err
+		}
+	}
+	go w.loop()
+	return w, nil
+}
+
+// Changes delivers the names of workspaces with changes, one debounced batch
+// at a time.
+func (w *Watcher) Changes() <-chan []string { return w.changes }
+
+// Errors delivers watcher failures (e.g. the OS watch limit was hit while
+// adding a new directory). Watching continues after an error.
+func (w *Watcher) Errors() <-chan error { return w.errs }
+
+// Close stops watching.
+func (w *Watcher) Close() error {
+	select {
+	case <-w.done:
+	default:
+		close(w.done)
+	}
+	return w.fs.Close()
+}
+
+// addTree adds dir and its subdirectories, skipping ignored directories and
+// other workspaces' trees (they are watched as their own roots).
+func (w *Watcher) addTree(dir string) error {
+	return filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			// A directory that vanished mid-walk is not an error worth
+			// stopping for.
+			if path == dir {
+				return err
+			}
+			return nil
+		}
+		if !d.IsDir() {
+			return nil
+		}
+		if path != dir {
+			if w.ignored(path) {
+				return filepath.SkipDir
+			}
+			if root, ok := w.rootOf(path); ok && root.path == path {
+				return filepath.SkipDir
+			}
+		}
+		return w.fs.Add(path)
+	})
+}
+
+// rootOf returns the workspace containing path.
+func (w *Watcher) rootOf(path string) (watchRoot, bool) {
+	for _, root := range w.roots {
+		if path == root.path || strings.HasPrefix(path, root.path+string(filepath.Separator)) {
+			return root, true
+		}
+	}
+	return watchRoot{}, false
+}
+
+// ignored reports whether path lies in a .git directory or its workspace's
+// top-level bin/.
+func (w *Watcher) ignored(path string) bool {
+	root, ok := w.rootOf(path)
+	if !ok {
+		return true
+	}
+	rel, err := filepath.Rel(root.path, path)
+	if err != nil || rel == "." {
+		return false
+	}
+	parts := strings.Split(rel, string(filepath.Separator))
+	if parts[0] == "bin" {
+		return true
+	}
+	for _, p := range parts {
+		if p == ".git" {
+			return true
+		}
+	}
+	return false
+}
+
+func (w *Watcher) loop() {
+	pending := make(map[string]bool)
+	var (
+		timer *time.Timer
+		fire  <-chan time.Time
+		ready bool
+	)
+	for {
+		// Only offer a batch once the debounce window has passed; until a
+		// receiver takes it, further changes join the same batch.
+		var out chan []string
+		var batch []string
+		if ready && len(pending) > 0 {
+			out = w.changes
+			for name := range pending {
+				batch = append(batch, name)
+			}
+			sort.Strings(batch)
+		}
+
+		select {
+		case <-w.done:
+			return
+		case ev, ok := <-w.fs.Events:
+			if !ok {
+				return
+			}
+			if ev.Op == fsnotify.Chmod || w.ignored(ev.Name) {
+				continue
+			}
+			root, ok := w.rootOf(ev.Name)
+			if !ok {
+				continue
+			}
+			if ev.Op&fsnotify.Create != 0 {
+				// New directories (mkdir, a checkout) need their own watches.
+				if err := w.addTree(ev.Name); err != nil {
+					w.reportErr(err)
+				}
+			}
+			pending[root.name] = true
+			ready = false
+			if timer == nil {
+				timer = time.NewTimer(w.debounce)
+			} else {
+				if !timer.Stop() {
+					select {
+					case <-timer.C:
+					default:
+					}
+				}
+				timer.Reset(w.debounce)
+			}
+			fire = timer.C
+		case err, ok := <-w.fs.Errors:
+			if !ok {
+				return
+			}
+			w.reportErr(err)
+		case <-fire:
+			fire = nil
+			ready = true
+		case out <- batch:
+			pending = make(map[string]bool)
+			ready = false
+		}
+	}
+}
+
+func (w *Watcher) reportErr(err error) {
+	select {
+	case w.errs <- err:
+	default:
+	}
+}
+
+// WatchAffected returns the jobs a watch-mode rerun must cover after the
+// workspaces in changed were edited: the changed ones plus, unless strategy
+// is flat, everything depending on them through build_after or the import
+// graph — the same expansion FilterAffected applies to dirty workspaces.
+func WatchAffected(jobs []TaskJob, changed []string, configs map[string]*config.Config, graph *DepGraph, strategy ConcurrencyStrategy) []TaskJob {
+	states := make(map[string]WorkspaceState, len(changed))
+	for _, name := range changed {
+		states[name] = WorkspaceState{IsDirty: true}
+	}
+	return FilterAffected(jobs, states, configs, graph, strategy)
+}
@@ -0,0 +1,109 @@
+package orchestrator
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/grovetools/core/config"
+)
+
+func TestWatchAffected_RerunsDependents(t *testing.T) {
+	jobs := []TaskJob{{Name: "core"}, {Name: "grove"}, {Name: "flow"}, {Name: "nav"}}
+	configs := map[string]*config.Config{
+		"grove": {Name: "grove", BuildAfter: []string{"core"}},
+	}
+	// flow imports grove; only the import graph knows.
+	graph := &DepGraph{deps: map[string][]string{"flow": {"grove"}}}
+
+	names := func(jobs []TaskJob) []string {
+		var out []string
+		for _, j := range jobs {
+			out = append(out, j.Name)
+		}
+		return out
+	}
+	if got, want := names(WatchAffected(jobs, []string{"core"}, configs, graph, StrategyWaveSorted)), []string{"core", "grove", "flow"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("wave-sorted rerun = %v, want %v", got, want)
+	}
+	if got, want := names(WatchAffected(jobs, []string{"core"}, configs, graph, StrategyFlat)), []string{"core"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("flat rerun = %v, want %v", got, want)
+	}
+	if got := WatchAffected(jobs, []string{"unknown"}, configs, graph, StrategyWaveSorted); len(got) != 0 {
+		t.Errorf("change outside the job set reran %v", names(got))
+	}
+}
+
+func TestWatcher_DebouncesAndIgnoresOutputs(t *testing.T) {
+	root := t.TempDir()
+	core := filepath.Join(root, "core")
+	nav := filepath.Join(root, "nav")
+	for _, dir := range []string{
+		filepath.Join(core, "bin"),
+		filepath.Join(core, ".git"),
+		filepath.Join(core, "pkg"),
+		nav,
+	} {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	w, err := NewWatcher([]TaskJob{{Name: "core", Path: core}, {Name: "nav", Path: nav}}, 50*time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer w.Close()
+
+	write := func(path string) {
+		t.Helper()
+		if err := os.WriteFile(path, []byte("x"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	expectNone := func() {
+		t.Helper()
+		select {
+		case batch := <-w.Changes():
+			t.Fatalf("unexpected change batch %v", batch)
+		case <-time.After(300 * time.Millisecond):
+		}
+	}
+
+	// Build outputs and git metadata never trigger a rerun.
+	write(filepath.Join(core, "bin", "core"))
+	write(filepath.Join(core, ".git", "index"))
+	expectNone()
+
+	// A burst of edits, including in a directory created mid-burst, arrives
+	// as a single batch.
+	write(filepath.Join(core, "pkg", "a.go"))
+	if err := os.MkdirAll(filepath.Join(core, "pkg", "sub"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(20 * time.Millisecond)
+	write(filepath.Join(core, "pkg", "sub", "b.go"))
+	write(filepath.Join(core, "go.mod"))
+	select {
+	case batch := <-w.Changes():
+		if !reflect.DeepEqual(batch, []string{"core"}) {
+			t.Fatalf("batch = %v, want [core]", batch)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("no change batch delivered")
+	}
+	expectNone()
+
+	// The new subdirectory is watched too.
+	write(filepath.Join(core, "pkg", "sub", "c.go"))
+	write(filepath.Join(nav, "main.go"))
+	select {
+	case batch := <-w.Changes():
+		if !reflect.DeepEqual(batch, []string{"core", "nav"}) {
+			t.Fatalf("batch = %v, want [core nav]", batch)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("no change batch delivered")
+	}
+}
@@ -0,0 +1,599 @@
+package project
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pelletier/go-toml/v2"
+)
+
+// cargoDependencySections are the Cargo.toml tables holding version
+// requirements, at the top level, under [target.'cfg(..)'] and, for
+// "dependencies", under [workspace].
+var cargoDependencySections = []string{"dependencies", "dev-dependencies", "build-dependencies"}
+
+// CargoHandler manages plain Rust crates and Cargo workspaces: Cargo.toml
+// plus, for workspaces, the manifests of its [workspace] members.
+//
+// A dependency is a workspace dependency when it is a `path =` dependency on
+// a crate outside the Cargo workspace, i.e. a sibling checkout in the
+// ecosystem. Path dependencies between members of the same Cargo workspace
+// are internal and not reported at all.
+type CargoHandler struct{}
+
+func NewCargoHandler() *CargoHandler {
+	return &CargoHandler{}
+}
+
+// cargoManifest is the subset of Cargo.toml the handler reads.
+type cargoManifest struct {
+	Package struct {
+		Name    string      `toml:"name"`
+		Version interface{} `toml:"version"` // a string, or {workspace = true}
+	} `toml:"package"`
+	Workspace struct {
+		Members []string `toml:"members"`
+		Exclude []string `toml:"exclude"`
+		Package struct {
+			Version string `toml:"version"`
+		} `toml:"package"`
+		Dependencies map[string]interface{} `toml:"dependencies"`
+	} `toml:"workspace"`
+	Dependencies      map[string]interface{} `toml:"dependencies"`
+	DevDependencies   map[string]interface{} `toml:"dev-dependencies"`
+	BuildDependencies map[string]interface{} `toml:"build-dependencies"`
+	Target            map[string]struct {
+		Dependencies      map[string]interface{} `toml:"dependencies"`
+		DevDependencies   map[string]interface{} `toml:"dev-dependencies"`
+		BuildDependencies map[string]interface{} `toml:"build-dependencies"`
+	} `toml:"target"`
+}
+
+// dependencyTables returns every dependency table of the manifest, in a
+// stable order.
+func (m *cargoManifest) dependencyTables() []map[string]interface{} {
+	tables := []map[string]interface{}{m.Dependencies, m.DevDependencies, m.BuildDependencies, m.Workspace.Dependencies}
+	targets := make([]string, 0, len(m.Target))
+	for cfg := range m.Target {
+		targets = append(targets, cfg)
+	}
+	sort.Strings(targets)
+	for _, cfg := range targets {
+		t := m.Target[cfg]
+		tables = append(tables, t.Dependencies, t.DevDependencies, t.BuildDependencies)
+	}
+	return tables
+}
+
+func readCargoManifest(path string) (*cargoManifest, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var m cargoManifest
+	if err := toml.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	return &m, nil
+}
+
+// cargoDependency is a single dependency entry: `kit = "1.2"` or
+// `kit = { version = "1.2", path = "../kit", package = "grove-kit" }`.
+type cargoDependency struct {
+	crate     string // the depended-on crate, honouring `package =` renames
+	version   string
+	path      string
+	inherited bool // `kit = { workspace = true }`
+}
+
+func parseCargoDependency(key string, value interface{}) cargoDependency {
+	dep := cargoDependency{crate: key}
+	switch v := value.(type) {
+	case string:
+		dep.version = v
+	case map[string]interface{}:
+		if s, ok := v["package"].(string); ok && s != "" {
+			dep.crate = s
+		}
+		dep.version, _ = v["version"].(string)
+		dep.path, _ = v["path"].(string)
+		dep.inherited, _ = v["workspace"].(bool)
+	}
+	return dep
+}
+
+func (h *CargoHandler) HasProjectFile(workspacePath string) bool {
+	return fileExists(filepath.Join(workspacePath, "Cargo.toml"))
+}
+
+// PackageName returns `package.name` from Cargo.toml. Virtual workspace
+// manifests have no package name.
+func (h *CargoHandler) PackageName(workspacePath string) (string, error) {
+	m, err := readCargoManifest(filepath.Join(workspacePath, "Cargo.toml"))
+	if err != nil {
+		return "", err
+	}
+	if m.Package.Name == "" {
+		return "", fmt.Errorf("no [package] name in Cargo.toml")
+	}
+	return m.Package.Name, nil
+}
+
+// manifests returns the root Cargo.toml followed by those of the workspace
+// members, if any.
+func (h *CargoHandler) manifests(workspacePath string) ([]string, error) {
+	root := filepath.Join(workspacePath, "Cargo.toml")
+	m, err := readCargoManifest(root)
+	if err != nil {
+		return nil, err
+	}
+	excluded := make(map[string]bool)
+	for _, pattern := range m.Workspace.Exclude {
+		dirs, _ := filepath.Glob(filepath.Join(workspacePath, filepath.FromSlash(pattern)))
+		for _, dir := range dirs {
+			excluded[dir] = true
+		}
+	}
+	paths := []string{root}
+	seen := map[string]bool{root: true}
+	for _, pattern := range m.Workspace.Members {
+		dirs, err := filepath.Glob(filepath.Join(workspacePath, filepath.FromSlash(pattern)))
+		if err != nil {
+			return nil, fmt.Errorf("invalid workspace member pattern %q: %w", pattern, err)
+		}
+		sort.Strings(dirs)
+		for _, dir := range dirs {
+			manifest := filepath.Join(dir, "Cargo.toml")
+			if excluded[dir] || seen[manifest] || !fileExists(manifest) {
+				continue
+			}
+			seen[manifest] = true
+			paths = append(paths, manifest)
+		}
+	}
+	return paths, nil
+}
+
+// ManifestFiles returns every Cargo.toml UpdateDependency edits plus
+// Cargo.lock, when present.
+func (h *CargoHandler) ManifestFiles(workspacePath string) ([]string, error) {
+	manifests, err := h.manifests(workspacePath)
+	if err != nil {
+		return nil, err
+	}
+	return relativeManifestFiles(workspacePath, manifests, "Cargo.lock")
+}
+
+// insideDir reports whether path is dir or below it.
+func insideDir(dir, path string) bool {
+	rel, err := filepath.Rel(dir, path)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+func (h *CargoHandler) ParseDependencies(workspacePath string) ([]Dependency, error) {
+	manifests, err := h.manifests(workspacePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			// No Cargo.toml file, return empty dependencies
+			return []Dependency{}, nil
+		}
+		return nil, fmt.Errorf("reading Cargo.toml: %w", err)
+	}
+
+	root, err := filepath.Abs(workspacePath)
+	if err != nil {
+		return nil, err
+	}
+	var parsed []*cargoManifest
+	members := make(map[string]bool)
+	for _, path := range manifests {
+		m, err := readCargoManifest(path)
+		if err != nil {
+			return nil, err
+		}
+		parsed = append(parsed, m)
+		if m.Package.Name != "" {
+			members[m.Package.Name] = true
+		}
+	}
+
+	var deps []Dependency
+	seen := make(map[string]bool)
+	for i, m := range parsed {
+		manifestDir, err := filepath.Abs(filepath.Dir(manifests[i]))
+		if err != nil {
+			return nil, err
+		}
+		for _, table := range m.dependencyTables() {
+			keys := make([]string, 0, len(table))
+			for key := range table {
+				keys = append(keys, key)
+			}
+			sort.Strings(keys)
+			for _, key := range keys {
+				dep := parseCargoDependency(key, table[key])
+				// Inherited entries are reported from [workspace.dependencies]
+				if dep.inherited || members[dep.crate] || seen[dep.crate] {
+					continue
+				}
+				sibling := false
+				if dep.path != "" {
+					target := filepath.Join(manifestDir, filepath.FromSlash(dep.path))
+					if insideDir(root, target) {
+						// A member that is not listed in [workspace] members
+						continue
+					}
+					sibling = true
+				}
+				seen[dep.crate] = true
+				deps = append(deps, Dependency{
+					Name:      dep.crate,
+					Version:   dep.version,
+					Type:      DependencyTypeLibrary,
+					Workspace: sibling,
+				})
+			}
+		}
+	}
+	return deps, nil
+}
+
+// UpdateDependency points every version requirement on dep.Name, in the root
+// Cargo.toml and all members, at dep.Version, keeping each requirement's
+// operator ("1.2" becomes "1.3.0", "~1.2.0" becomes "~1.3.0"). Wildcards and
+// multi-clause requirements are left alone, as is the rest of the file.
+// Cargo.lock, when present, is refreshed for the crate afterwards.
+func (h *CargoHandler) UpdateDependency(workspacePath string, dep Dependency) error {
+	manifests, err := h.manifests(workspacePath)
+	if err != nil {
+		return fmt.Errorf("reading Cargo.toml: %w", err)
+	}
+	version := strings.TrimPrefix(dep.Version, "v")
+	changed := false
+	for _, path := range manifests {
+		m, err := readCargoManifest(path)
+		if err != nil {
+			return err
+		}
+		// Keys that name dep.Name, directly or through `package =`
+		keys := map[string]bool{dep.Name: true}
+		for _, table := range m.dependencyTables() {
+			for key, value := range table {
+				if parseCargoDependency(key, value).crate == dep.Name {
+					keys[key] = true
+				}
+			}
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("reading %s: %w", path, err)
+		}
+		updated := editTOMLStrings(data, func(keyPath []string, value string) (string, bool) {
+			key, ok := cargoDependencyKey(keyPath)
+			if !ok || !keys[key] {
+				return "", false
+			}
+			return retargetCargoRequirement(value, version)
+		})
+		if bytes.Equal(updated, data) {
+			continue
+		}
+		if err := os.WriteFile(path, updated, 0o600); err != nil {
+			return fmt.Errorf("writing %s: %w", path, err)
+		}
+		changed = true
+	}
+	if !changed {
+		return nil
+	}
+	return refreshCargoLockfile(workspacePath, "--package", dep.Name)
+}
+
+// cargoDependencyKey returns the dependency key when keyPath addresses a
+// version requirement: [<section>] key = "..." or key.version = "...", where
+// <section> is a dependency table at the top level, under target.<cfg> or
+// (for "dependencies") under workspace.
+func cargoDependencyKey(keyPath []string) (string, bool) {
+	n := len(keyPath)
+	if n >= 3 && keyPath[n-1] == "version" {
+		keyPath, n = keyPath[:n-1], n-1
+	}
+	if n < 2 || !containsString(cargoDependencySections, keyPath[n-2]) {
+		return "", false
+	}
+	switch parent := keyPath[:n-2]; {
+	case len(parent) == 0:
+	case len(parent) == 1 && parent[0] == "workspace" && keyPath[n-2] == "dependencies":
+	case len(parent) == 2 && parent[0] == "target":
+	default:
+		return "", false
+	}
+	return keyPath[n-1], true
+}
+
+// retargetCargoRequirement rewrites a single-clause version requirement to
+// version, keeping its operator. Cargo reads a bare "1.2" as "^1.2", so
+// bare requirements stay bare.
+func retargetCargoRequirement(req, version string) (string, bool) {
+	req = strings.TrimSpace(req)
+	op := ""
+	for _, candidate := range []string{">=", "^", "~", "="} {
+		if strings.HasPrefix(req, candidate) {
+			op = candidate
+			break
+		}
+	}
+	current := strings.TrimSpace(strings.TrimPrefix(req, op))
+	if current == "" || strings.ContainsAny(current, " ,<>*xX") || !strings.ContainsAny(current[:1], "0123456789") {
+		return "", false
+	}
+	if current == version {
+		return "", false
+	}
+	return op + version, true
+}
+
+// refreshCargoLockfile runs `cargo update` with args so Cargo.lock matches
+// the edited manifests. Workspaces without a Cargo.lock are left alone.
+func refreshCargoLockfile(workspacePath string, args ...string) error {
+	if !fileExists(filepath.Join(workspacePath, "Cargo.lock")) {
+		return nil
+	}
+	args = append([]string{"update"}, args...)
+	cmd := exec.CommandContext(context.Background(), "cargo", args...) //nolint:gosec // G204: fixed cargo invocation
+	cmd.Dir = workspacePath
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("cargo %s failed: %w (output: %s)", strings.Join(args, " "), err, output)
+	}
+	return nil
+}
+
+// GetVersion returns `package.version`, or `workspace.package.version` for
+// virtual workspaces and crates that inherit it.
+func (h *CargoHandler) GetVersion(workspacePath string) (string, error) {
+	m, err := readCargoManifest(filepath.Join(workspacePath, "Cargo.toml"))
+	if err != nil {
+		return "", fmt.Errorf("reading Cargo.toml: %w", err)
+	}
+	if v, ok := m.Package.Version.(string); ok && v != "" {
+		return v, nil
+	}
+	if m.Workspace.Package.Version != "" {
+		return m.Workspace.Package.Version, nil
+	}
+	return "", fmt.Errorf("version not found in Cargo.toml")
+}
+
+// SetVersion writes version (without its "v") to `package.version` and
+// `workspace.package.version` in the root Cargo.toml, preserving the file's
+// formatting, and refreshes the workspace's own entries in Cargo.lock.
+func (h *CargoHandler) SetVersion(workspacePath, version string) error {
+	path := filepath.Join(workspacePath, "Cargo.toml")
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("reading Cargo.toml: %w", err)
+	}
+	version = strings.TrimPrefix(version, "v")
+	found := false
+	updated := editTOMLStrings(data, func(keyPath []string, value string) (string, bool) {
+		switch strings.Join(keyPath, ".") {
+		case "package.version", "workspace.package.version":
+			found = true
+			return version, value != version
+		}
+		return "", false
+	})
+	if !found {
+		return fmt.Errorf("no package version in Cargo.toml")
+	}
+	if bytes.Equal(updated, data) {
+		return nil
+	}
+	if err := os.WriteFile(path, updated, 0o600); err != nil {
+		return fmt.Errorf("writing Cargo.toml: %w", err)
+	}
+	return refreshCargoLockfile(workspacePath, "--workspace")
+}
+
+// Leverage Makefile contract
+func (h *CargoHandler) GetBuildCommand() string  { return "make build" }
+func (h *CargoHandler) GetTestCommand() string   { return "make test" }
+func (h *CargoHandler) GetVerifyCommand() string { return "make verify" }
+
+// Helper functions
+
+// editTOMLStrings rewrites string values of a TOML document in place, line
+// by line, so comments, ordering and alignment are untouched. edit sees the
+// full key path of every single-line string value, including those inside
+// single-line inline tables: `kit = { version = "1.2" }` under
+// [dependencies] is ["dependencies", "kit", "version"]. Values inside arrays
+// and multi-line strings are never passed to edit.
+func editTOMLStrings(data []byte, edit func(keyPath []string, value string) (string, bool)) []byte {
+	lines := strings.SplitAfter(string(data), "\n")
+	var (
+		table     []string
+		multiline string // the open `"""` or `'''` delimiter, if any
+		depth     int    // open brackets of a multi-line array
+	)
+	for i, line := range lines {
+		if multiline != "" {
+			if strings.Count(line, multiline)%2 == 1 {
+				multiline = ""
+			}
+			continue
+		}
+		if depth > 0 {
+			depth += tomlBracketDelta(line)
+			continue
+		}
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+		if strings.HasPrefix(trimmed, "[") {
+			header := strings.TrimPrefix(strings.TrimPrefix(trimmed, "["), "[")
+			if end := strings.Index(header, "]"); end >= 0 {
+				table = splitTOMLKey(header[:end])
+			}
+			continue
+		}
+		eq := tomlIndexOutsideQuotes(line, '=')
+		if eq < 0 {
+			continue
+		}
+		keyPath := append(append([]string(nil), table...), splitTOMLKey(line[:eq])...)
+		rest := line[eq+1:]
+		valueStart := eq + 1 + len(rest) - len(strings.TrimLeft(rest, " \t"))
+		value := line[valueStart:]
+		switch {
+		case strings.HasPrefix(value, `"""`), strings.HasPrefix(value, "'''"):
+			if strings.Count(value, value[:3]) == 1 {
+				multiline = value[:3]
+			}
+		case strings.HasPrefix(value, "["):
+			depth = tomlBracketDelta(value)
+		case strings.HasPrefix(value, "{"):
+			lines[i] = line[:valueStart] + editTOMLInlineTable(value, keyPath, edit)
+		default:
+			lines[i] = line[:valueStart] + editTOMLString(value, keyPath, edit)
+		}
+	}
+	return []byte(strings.Join(lines, ""))
+}
+
+// editTOMLString rewrites the string literal at the start of s.
+func editTOMLString(s string, keyPath []string, edit func([]string, string) (string, bool)) string {
+	if s == "" || (s[0] != '"' && s[0] != '\'') {
+		return s
+	}
+	end := strings.IndexByte(s[1:], s[0])
+	if end < 0 {
+		return s
+	}
+	literal := s[1 : end+1]
+	if s[0] == '"' && strings.Contains(literal, `\`) {
+		return s // escapes never occur in versions; leave them alone
+	}
+	replacement, ok := edit(keyPath, literal)
+	if !ok {
+		return s
+	}
+	return string(s[0]) + replacement + string(s[0]) + s[end+2:]
+}
+
+// editTOMLInlineTable rewrites the string values of the single-line inline
+// table at the start of s.
+func editTOMLInlineTable(s string, keyPath []string, edit func([]string, string) (string, bool)) string {
+	var out strings.Builder
+	out.WriteByte('{')
+	rest := s[1:]
+	for {
+		eq := tomlIndexOutsideQuotes(rest, '=')
+		closing := tomlIndexOutsideQuotes(rest, '}')
+		if eq < 0 || (closing >= 0 && closing < eq) {
+			break
+		}
+		key := splitTOMLKey(rest[:eq])
+		out.WriteString(rest[:eq+1])
+		rest = rest[eq+1:]
+		pad := len(rest) - len(strings.TrimLeft(rest, " \t"))
+		out.WriteString(rest[:pad])
+		rest = rest[pad:]
+		if strings.HasPrefix(rest, "{") || strings.HasPrefix(rest, "[") {
+			break // nested values are not edited
+		}
+		path := append(append([]string(nil), keyPath...), key...)
+		edited := editTOMLString(rest, path, edit)
+		comma := tomlIndexOutsideQuotes(edited, ',')
+		if comma < 0 {
+			rest = edited
+			break
+		}
+		out.WriteString(edited[:comma+1])
+		rest = edited[comma+1:]
+	}
+	out.WriteString(rest)
+	return out.String()
+}
+
+// splitTOMLKey splits a dotted key (`target.'cfg(unix)'.dependencies`) into
+// its unquoted parts.
+func splitTOMLKey(key string) []string {
+	var parts []string
+	var current strings.Builder
+	var quote byte
+	for i := 0; i < len(key); i++ {
+		c := key[i]
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			} else {
+				current.WriteByte(c)
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '.':
+			parts = append(parts, strings.TrimSpace(current.String()))
+			current.Reset()
+		case c != ' ' && c != '\t':
+			current.WriteByte(c)
+		}
+	}
+	return append(parts, strings.TrimSpace(current.String()))
+}
+
+// tomlIndexOutsideQuotes returns the index of the first c in s that is not
+// inside a string literal, or -1. A comment ends the search.
+func tomlIndexOutsideQuotes(s string, c byte) int {
+	var quote byte
+	for i := 0; i < len(s); i++ {
+		switch {
+		case quote != 0:
+			if s[i] == '\\' && quote == '"' {
+				i++
+			} else if s[i] == quote {
+				quote = 0
+			}
+		case s[i] == '"' || s[i] == '\'':
+			quote = s[i]
+		case s[i] == '#':
+			return -1
+		case s[i] == c:
+			return i
+		}
+	}
+	return -1
+}
+
+// tomlBracketDelta returns the change in array nesting across line.
+func tomlBracketDelta(line string) int {
+	delta := 0
+	var quote byte
+	for i := 0; i < len(line); i++ {
+		switch c := line[i]; {
+		case quote != 0:
+			if c == '\\' && quote == '"' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '#':
+			return delta
+		case c == '[':
+			delta++
+		case c == ']':
+			delta--
+		}
+	}
+	return delta
+}
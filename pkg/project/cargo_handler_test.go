@@ -0,0 +1,181 @@
+package project
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const cargoRootManifest = `[package]
+name = "panels"
+version = "1.4.0"   # bumped by grove release
+edition = "2021"
+description = """
+Panels for the
+acme dashboard
+"""
+
+[workspace]
+members = [
+    "crates/*",
+]
+
+[workspace.dependencies]
+theme = { path = "../theme", version = "2.0" }
+
+[dependencies]
+kit     = { path = "../kit", version = "~1.2.0", features = ["serde"] }
+serde   = "1.0"
+panels-core = { path = "crates/core", version = "1.4.0" }
+
+[target.'cfg(unix)'.dependencies]
+nix = "0.27"
+
+[dev-dependencies.testkit]
+path = "../testkit"
+version = "0.3"
+`
+
+func writeCargoWorkspace(t *testing.T) string {
+	t.Helper()
+	ws := filepath.Join(t.TempDir(), "panels")
+	writeFile(t, filepath.Join(ws, "Cargo.toml"), cargoRootManifest)
+	writeFile(t, filepath.Join(ws, "crates", "core", "Cargo.toml"), `[package]
+name = "panels-core"
+version.workspace = true
+
+[dependencies]
+acme-kit = { package = "kit", path = "../../../kit", version = "=1.2.0" }
+theme = { workspace = true }
+`)
+	return ws
+}
+
+func TestCargoHandlerParseDependencies(t *testing.T) {
+	ws := writeCargoWorkspace(t)
+	h := NewCargoHandler()
+	if !h.HasProjectFile(ws) {
+		t.Fatal("Cargo.toml not detected")
+	}
+	deps, err := h.ParseDependencies(ws)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := map[string]Dependency{}
+	for _, d := range deps {
+		got[d.Name] = d
+	}
+	want := map[string]bool{
+		"kit":     true,
+		"theme":   true,
+		"testkit": true,
+		"serde":   false,
+		"nix":     false,
+	}
+	if len(got) != len(want) {
+		t.Fatalf("deps = %+v", deps)
+	}
+	for name, workspace := range want {
+		d, ok := got[name]
+		if !ok || d.Workspace != workspace {
+			t.Errorf("%s: got %+v, want workspace=%v", name, d, workspace)
+		}
+	}
+	if got["kit"].Version != "~1.2.0" {
+		t.Errorf("root requirement should win: %+v", got["kit"])
+	}
+	if name, _ := h.PackageName(ws); name != "panels" {
+		t.Errorf("PackageName = %q", name)
+	}
+}
+
+func TestCargoHandlerUpdateDependencyKeepsFormatting(t *testing.T) {
+	ws := writeCargoWorkspace(t)
+	h := NewCargoHandler()
+	for _, name := range []string{"kit", "testkit"} {
+		if err := h.UpdateDependency(ws, Dependency{Name: name, Version: "v1.3.0"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	data, _ := os.ReadFile(filepath.Join(ws, "Cargo.toml"))
+	want := strings.NewReplacer(
+		`version = "~1.2.0"`, `version = "~1.3.0"`,
+		"version = \"0.3\"\n", "version = \"1.3.0\"\n",
+	).Replace(cargoRootManifest)
+	if string(data) != want {
+		t.Errorf("root Cargo.toml =\n%s", data)
+	}
+	member, _ := os.ReadFile(filepath.Join(ws, "crates", "core", "Cargo.toml"))
+	if !strings.Contains(string(member), `acme-kit = { package = "kit", path = "../../../kit", version = "=1.3.0" }`) {
+		t.Errorf("renamed member dependency not updated:\n%s", member)
+	}
+
+	if err := h.SetVersion(ws, "v1.5.0"); err != nil {
+		t.Fatal(err)
+	}
+	if v, _ := h.GetVersion(ws); v != "1.5.0" {
+		t.Errorf("GetVersion = %q", v)
+	}
+	data, _ = os.ReadFile(filepath.Join(ws, "Cargo.toml"))
+	stamped := strings.Replace(want, `version = "1.4.0"   #`, `version = "1.5.0"   #`, 1)
+	if string(data) != stamped {
+		t.Errorf("SetVersion disturbed the file:\n%s", data)
+	}
+}
+
+func TestCargoHandlerVirtualWorkspaceVersion(t *testing.T) {
+	ws := t.TempDir()
+	writeFile(t, filepath.Join(ws, "Cargo.toml"), "[workspace]\nmembers = []\n\n[workspace.package]\nversion = '0.9.1'\n")
+	h := NewCargoHandler()
+	if err := h.SetVersion(ws, "0.10.0"); err != nil {
+		t.Fatal(err)
+	}
+	data, _ := os.ReadFile(filepath.Join(ws, "Cargo.toml"))
+	if !strings.Contains(string(data), "version = '0.10.0'\n") {
+		t.Errorf("workspace.package.version not stamped:\n%s", data)
+	}
+	if _, err := h.PackageName(ws); err == nil {
+		t.Error("a virtual manifest has no package name")
+	}
+}
+
+func TestRetargetCargoRequirement(t *testing.T) {
+	tests := []struct {
+		req  string
+		want string
+		ok   bool
+	}{
+		{"1.2", "2.0.0", true},
+		{"^1.2.0", "^2.0.0", true},
+		{"~1.2", "~2.0.0", true},
+		{"=1.2.0", "=2.0.0", true},
+		{">=1.2", ">=2.0.0", true},
+		{"2.0.0", "", false},
+		{"*", "", false},
+		{"1.*", "", false},
+		{">=1.2, <2", "", false},
+	}
+	for _, tt := range tests {
+		got, ok := retargetCargoRequirement(tt.req, "2.0.0")
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("retargetCargoRequirement(%q) = %q, %v; want %q, %v", tt.req, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestCargoHandlerManifestFiles(t *testing.T) {
+	ws := writeCargoWorkspace(t)
+	files, err := NewCargoHandler().ManifestFiles(ws)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"Cargo.toml", filepath.Join("crates", "core", "Cargo.toml")}
+	if strings.Join(files, ",") != strings.Join(want, ",") {
+		t.Errorf("ManifestFiles = %v, want %v", files, want)
+	}
+	writeFile(t, filepath.Join(ws, "Cargo.lock"), "version = 3\n")
+	if files, _ := NewCargoHandler().ManifestFiles(ws); len(files) != 3 || files[2] != "Cargo.lock" {
+		t.Errorf("ManifestFiles with lockfile = %v", files)
+	}
+}
@@ -13,10 +13,19 @@ import (
 	"golang.org/x/mod/modfile"
 )
 
-type GoHandler struct{}
+type GoHandler struct {
+	prefixes ModulePrefixes
+}
 
+// NewGoHandler returns a handler for the upstream grove module namespace.
 func NewGoHandler() *GoHandler {
-	return &GoHandler{}
+	return NewGoHandlerFor(DefaultModulePrefixes())
+}
+
+// NewGoHandlerFor returns a handler treating modules under prefixes as
+// workspace dependencies.
+func NewGoHandlerFor(prefixes ModulePrefixes) *GoHandler {
+	return &GoHandler{prefixes: prefixes}
 }
 
 func (h *GoHandler) HasProjectFile(workspacePath string) bool {
@@ -56,9 +65,9 @@ func (h *GoHandler) ParseDependencies(workspacePath string) ([]Dependency, error
 
 		// Check if this is a workspace dependency used in production code
 		// Test-only dependencies should not affect release ordering
-		if strings.HasPrefix(req.Mod.Path, "github.com/grovetools/") {
+		if h.prefixes.Match(req.Mod.Path) {
 			// Only mark as workspace dep if it's imported in production code
-			if productionImports[req.Mod.Path] {
+			if importsModule(productionImports, req.Mod.Path) {
 				dep.Workspace = true
 			}
 		}
@@ -69,12 +78,23 @@ func (h *GoHandler) ParseDependencies(workspacePath string) ([]Dependency, error
 	return deps, nil
 }
 
+// importsModule reports whether any import path lies in modulePath.
+func importsModule(imports map[string]bool, modulePath string) bool {
+	for imp := range imports {
+		if imp == modulePath || strings.HasPrefix(imp, modulePath+"/") {
+			return true
+		}
+	}
+	return false
+}
+
 // getProductionImports scans Go source files (excluding tests) and returns
-// a set of imported grove-* module paths. This is used to determine which
-// dependencies are production vs test-only for release ordering.
+// the set of imported package paths under the ecosystem's module prefixes.
+// This is used to determine which dependencies are production vs test-only
+// for release ordering.
 func (h *GoHandler) getProductionImports(workspacePath string) map[string]bool {
 	imports := make(map[string]bool)
-	importRegex := regexp.MustCompile(`"(github\.com/grovetools/[^"]+)"`)
+	importRegex := regexp.MustCompile(`"([^"\s]+)"`)
 
 	_ = filepath.Walk(workspacePath, func(path string, info os.FileInfo, err error) error {
 		if err != nil {
@@ -104,7 +124,7 @@ func (h *GoHandler) getProductionImports(workspacePath string) map[string]bool {
 			return nil
 		}
 
-		// Scan file for grove-* imports
+		// Scan file for ecosystem imports
 		file, err := os.Open(path)
 		if err != nil {
 			return nil
@@ -126,18 +146,11 @@ func (h *GoHandler) getProductionImports(workspacePath string) map[string]bool {
 				continue
 			}
 
-			// Check for grove imports
-			if strings.Contains(line, "github.com/grovetools/") {
-				matches := importRegex.FindAllStringSubmatch(line, -1)
-				for _, match := range matches {
-					if len(match) > 1 {
-						// Extract module path (first two parts after github.com/grovetools/)
-						parts := strings.Split(match[1], "/")
-						if len(parts) >= 3 {
-							modulePath := strings.Join(parts[:3], "/")
-							imports[modulePath] = true
-						}
-					}
+			// Check for ecosystem imports. Package paths are kept whole:
+			// with vanity domains the module root is not at a fixed depth.
+			for _, match := range importRegex.FindAllStringSubmatch(line, -1) {
+				if h.prefixes.Match(match[1]) {
+					imports[match[1]] = true
 				}
 			}
 		}
@@ -161,7 +174,7 @@ func (h *GoHandler) UpdateDependency(workspacePath string, dep Dependency) error
 	cmd := exec.CommandContext(ctx, "go", "get", fmt.Sprintf("%s@%s", dep.Name, dep.Version)) //nolint:gosec // args are from trusted config
 	cmd.Dir = workspacePath
 	cmd.Env = append(os.Environ(),
-		"GOPRIVATE=github.com/grovetools/*",
+		"GOPRIVATE="+h.prefixes.GoPrivate(),
 		"GOPROXY=direct",
 		"GOWORK=off",
 	)
@@ -175,7 +188,7 @@ func (h *GoHandler) UpdateDependency(workspacePath string, dep Dependency) error
 	tidyCmd := exec.CommandContext(ctx, "go", "mod", "tidy")
 	tidyCmd.Dir = workspacePath
 	tidyCmd.Env = append(os.Environ(),
-		"GOPRIVATE=github.com/grovetools/*",
+		"GOPRIVATE="+h.prefixes.GoPrivate(),
 		"GOPROXY=direct",
 		"GOWORK=off",
 	)
@@ -6,6 +6,7 @@ const (
 	TypeGo       Type = "go"
 	TypeMaturin  Type = "maturin"
 	TypeNode     Type = "node"
+	TypeCargo    Type = "cargo"
 	TypeTemplate Type = "template"
 )
 
@@ -28,6 +29,22 @@ type ProjectHandler interface {
 	HasProjectFile(workspacePath string) bool
 }
 
+// PackageNamer is implemented by handlers whose packages are depended on by
+// a manifest name rather than the workspace name (package.json "name"). The
+// dependency graph uses it to map dependencies back to workspaces.
+type PackageNamer interface {
+	PackageName(workspacePath string) (string, error)
+}
+
+// ManifestLister is implemented by handlers whose dependency edits can touch
+// several files (monorepo or workspace members, lockfiles). Release commits
+// stage exactly these files rather than every tracked change.
+type ManifestLister interface {
+	// ManifestFiles returns the existing manifests and lockfile that
+	// UpdateDependency may write, relative to workspacePath.
+	ManifestFiles(workspacePath string) ([]string, error)
+}
+
 type Dependency struct {
 	Name      string
 	Version   string
@@ -0,0 +1,164 @@
+package project
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/grovetools/core/config"
+	"golang.org/x/mod/modfile"
+	"golang.org/x/mod/module"
+)
+
+// DefaultModulePrefix is the module namespace of the upstream grove ecosystem.
+const DefaultModulePrefix = "github.com/grovetools/"
+
+// EcosystemConfig is the `[ecosystem]` table of an ecosystem's grove.toml:
+//
+//	[ecosystem]
+//	module_prefixes = ["github.com/acme/", "go.acme.dev/"]
+//	github_owner = "acme"
+//
+// Both keys are optional; see LoadModulePrefixes and ReleaseOwner.
+type EcosystemConfig struct {
+	ModulePrefixes []string `yaml:"module_prefixes"`
+	GitHubOwner    string   `yaml:"github_owner"`
+}
+
+// ModulePrefixes are the Go module path prefixes whose modules belong to the
+// ecosystem: requirements under them are workspace dependencies, and they are
+// what release and `deps sync` bump. Each prefix ends in "/" and matches by
+// whole path elements: "github.com/acme/api/" matches github.com/acme/api
+// itself and every path below it.
+type ModulePrefixes []string
+
+// DefaultModulePrefixes returns the upstream grove namespace.
+func DefaultModulePrefixes() ModulePrefixes {
+	return ModulePrefixes{DefaultModulePrefix}
+}
+
+// NewModulePrefixes normalizes prefixes: each gets a trailing "/", duplicates
+// and prefixes covered by a shorter one are dropped. An empty list yields the
+// defaults.
+func NewModulePrefixes(prefixes ...string) ModulePrefixes {
+	var cleaned []string
+	for _, p := range prefixes {
+		p = strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(p), "*"))
+		if p == "" || p == "/" {
+			continue
+		}
+		if !strings.HasSuffix(p, "/") {
+			p += "/"
+		}
+		cleaned = append(cleaned, p)
+	}
+	if len(cleaned) == 0 {
+		return DefaultModulePrefixes()
+	}
+	sort.Strings(cleaned) // a prefix sorts before everything it covers
+	var out ModulePrefixes
+	for _, p := range cleaned {
+		if len(out) > 0 && strings.HasPrefix(p, out[len(out)-1]) {
+			continue
+		}
+		out = append(out, p)
+	}
+	return out
+}
+
+// Match reports whether modulePath belongs to the ecosystem.
+func (p ModulePrefixes) Match(modulePath string) bool {
+	if len(p) == 0 {
+		p = DefaultModulePrefixes()
+	}
+	for _, prefix := range p {
+		if strings.HasPrefix(modulePath+"/", prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// GoPrivate returns the prefixes as a GOPRIVATE value ("github.com/acme,...").
+// GOPRIVATE patterns match leading path elements, so each prefix covers the
+// same modules it does in Match.
+func (p ModulePrefixes) GoPrivate() string {
+	if len(p) == 0 {
+		p = DefaultModulePrefixes()
+	}
+	patterns := make([]string, len(p))
+	for i, prefix := range p {
+		patterns[i] = strings.TrimSuffix(prefix, "/")
+	}
+	return strings.Join(patterns, ",")
+}
+
+// ReleaseOwner returns the GitHub org the ecosystem's tools are released
+// under: github_owner, or "" for the upstream default. It is never inferred
+// from module_prefixes: a mixed ecosystem still installs the upstream tools
+// from their own org.
+func (ec EcosystemConfig) ReleaseOwner() string {
+	return ec.GitHubOwner
+}
+
+// LoadEcosystemConfig reads the [ecosystem] table from the grove config in
+// rootDir. A missing config or table yields the zero value.
+func LoadEcosystemConfig(rootDir string) EcosystemConfig {
+	var ec EcosystemConfig
+	cfg, err := config.LoadFrom(rootDir)
+	if err != nil {
+		return ec
+	}
+	_ = cfg.UnmarshalExtension("ecosystem", &ec)
+	return ec
+}
+
+// LoadModulePrefixes returns the ecosystem rooted at rootDir's module
+// prefixes: `module_prefixes` from its manifest when set, otherwise the
+// go.work members' own module paths (github.com/acme/api/v2 contributes
+// github.com/acme/api/), otherwise DefaultModulePrefixes.
+func LoadModulePrefixes(rootDir string) ModulePrefixes {
+	if ec := LoadEcosystemConfig(rootDir); len(ec.ModulePrefixes) > 0 {
+		return NewModulePrefixes(ec.ModulePrefixes...)
+	}
+	return NewModulePrefixes(goWorkModulePrefixes(rootDir)...)
+}
+
+// goWorkModulePrefixes infers prefixes from the modules rootDir/go.work uses:
+// each member's module path without its /vN major-version suffix, so every
+// major version of a member matches but its siblings under the same parent
+// path do not.
+func goWorkModulePrefixes(rootDir string) []string {
+	workPath := filepath.Join(rootDir, "go.work")
+	data, err := os.ReadFile(workPath)
+	if err != nil {
+		return nil
+	}
+	work, err := modfile.ParseWork(workPath, data, nil)
+	if err != nil {
+		return nil
+	}
+	var prefixes []string
+	for _, use := range work.Use {
+		modulePath := goModulePath(filepath.Join(rootDir, use.Path))
+		if modulePath == "" {
+			continue
+		}
+		// gopkg.in's ".vN" is part of the path element and stays.
+		if prefix, major, ok := module.SplitPathVersion(modulePath); ok && strings.HasPrefix(major, "/") {
+			modulePath = prefix
+		}
+		prefixes = append(prefixes, modulePath+"/")
+	}
+	return prefixes
+}
+
+// goModulePath returns the module path declared in dir/go.mod, or "".
+func goModulePath(dir string) string {
+	data, err := os.ReadFile(filepath.Join(dir, "go.mod"))
+	if err != nil {
+		return ""
+	}
+	return modfile.ModulePath(data)
+}
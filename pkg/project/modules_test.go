@@ -0,0 +1,98 @@
+package project
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestNewModulePrefixes(t *testing.T) {
+	got := NewModulePrefixes("go.acme.dev", "github.com/acme/*", "github.com/acme/tools/", " ")
+	want := ModulePrefixes{"github.com/acme/", "go.acme.dev/"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("prefixes = %v, want %v", got, want)
+	}
+	if got.GoPrivate() != "github.com/acme,go.acme.dev" {
+		t.Errorf("GoPrivate = %q", got.GoPrivate())
+	}
+	if !got.Match("go.acme.dev/api") || got.Match("github.com/grovetools/core") || got.Match("github.com/acmecorp/api") {
+		t.Error("Match disagrees with the prefixes")
+	}
+	if !reflect.DeepEqual(NewModulePrefixes(), DefaultModulePrefixes()) {
+		t.Error("an empty list should fall back to the defaults")
+	}
+}
+
+func TestLoadModulePrefixesFromGoWork(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	root := t.TempDir()
+	writeFile(t, filepath.Join(root, "go.work"), "go 1.24\n\nuse (\n\t./api\n\t./cli\n\t./yaml\n)\n")
+	writeFile(t, filepath.Join(root, "api", "go.mod"), "module go.acme.dev/api/v2\n\ngo 1.24\n")
+	writeFile(t, filepath.Join(root, "cli", "go.mod"), "module github.com/acme/cli\n\ngo 1.24\n")
+	writeFile(t, filepath.Join(root, "yaml", "go.mod"), "module gopkg.in/acme/yaml.v3\n\ngo 1.24\n")
+
+	got := LoadModulePrefixes(root)
+	want := ModulePrefixes{"github.com/acme/cli/", "go.acme.dev/api/", "gopkg.in/acme/yaml.v3/"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("prefixes = %v, want %v", got, want)
+	}
+	for _, path := range []string{"go.acme.dev/api", "go.acme.dev/api/v2", "go.acme.dev/api/v3", "github.com/acme/cli", "gopkg.in/acme/yaml.v3"} {
+		if !got.Match(path) {
+			t.Errorf("%s should match a go.work member", path)
+		}
+	}
+	for _, path := range []string{"go.acme.dev/billing", "github.com/acme/client", "github.com/acme/cli-tools"} {
+		if got.Match(path) {
+			t.Errorf("%s is not a go.work member and must not match", path)
+		}
+	}
+
+	if got := LoadModulePrefixes(t.TempDir()); !reflect.DeepEqual(got, DefaultModulePrefixes()) {
+		t.Fatalf("no go.work: prefixes = %v, want the defaults", got)
+	}
+}
+
+func TestGoHandlerVanityWorkspaceDeps(t *testing.T) {
+	ws := t.TempDir()
+	writeFile(t, filepath.Join(ws, "go.mod"), `module go.acme.dev/cli
+
+go 1.24
+
+require (
+	github.com/grovetools/core v0.6.3
+	go.acme.dev/api v1.2.0
+	go.acme.dev/testkit v0.1.0
+)
+`)
+	writeFile(t, filepath.Join(ws, "main.go"), "package main\n\nimport (\n\t\"go.acme.dev/api/client\"\n\n\t\"github.com/grovetools/core/config\"\n)\n")
+	writeFile(t, filepath.Join(ws, "main_test.go"), "package main\n\nimport \"go.acme.dev/testkit\"\n")
+
+	deps, err := NewGoHandlerFor(NewModulePrefixes("go.acme.dev/")).ParseDependencies(ws)
+	if err != nil {
+		t.Fatal(err)
+	}
+	workspace := map[string]bool{}
+	for _, d := range deps {
+		workspace[d.Name] = d.Workspace
+	}
+	want := map[string]bool{
+		"github.com/grovetools/core": false, // outside the ecosystem
+		"go.acme.dev/api":            true,
+		"go.acme.dev/testkit":        false, // test-only import
+	}
+	if !reflect.DeepEqual(workspace, want) {
+		t.Fatalf("workspace deps = %v, want %v", workspace, want)
+	}
+}
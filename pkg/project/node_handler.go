@@ -1,36 +1,481 @@
 package project
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
 )
 
+// nodeDependencySections are the package.json tables whose ranges are
+// rewritten when an ecosystem dependency is released.
+var nodeDependencySections = []string{"dependencies", "devDependencies", "peerDependencies", "optionalDependencies"}
+
+// NodeHandler manages Node.js packages: package.json plus, for monorepos, the
+// members listed under "workspaces" or in pnpm-workspace.yaml.
+//
+// A dependency is a workspace dependency when it shares an npm scope with the
+// package itself (or one of its members): @acme/panel depending on @acme/kit.
+// Members of the same monorepo are internal and not reported at all.
 type NodeHandler struct{}
 
 func NewNodeHandler() *NodeHandler {
 	return &NodeHandler{}
 }
 
+// packageJSON is the subset of package.json the handler reads.
+type packageJSON struct {
+	Name                 string            `json:"name"`
+	Version              string            `json:"version"`
+	Workspaces           json.RawMessage   `json:"workspaces"`
+	Dependencies         map[string]string `json:"dependencies"`
+	DevDependencies      map[string]string `json:"devDependencies"`
+	PeerDependencies     map[string]string `json:"peerDependencies"`
+	OptionalDependencies map[string]string `json:"optionalDependencies"`
+}
+
+func (p *packageJSON) section(name string) map[string]string {
+	switch name {
+	case "dependencies":
+		return p.Dependencies
+	case "devDependencies":
+		return p.DevDependencies
+	case "peerDependencies":
+		return p.PeerDependencies
+	case "optionalDependencies":
+		return p.OptionalDependencies
+	}
+	return nil
+}
+
+func readPackageJSON(path string) (*packageJSON, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var pkg packageJSON
+	if err := json.Unmarshal(data, &pkg); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	return &pkg, nil
+}
+
 func (h *NodeHandler) HasProjectFile(workspacePath string) bool {
-	// Check for package.json
-	return false
+	_, err := os.Stat(filepath.Join(workspacePath, "package.json"))
+	return err == nil
+}
+
+// PackageName returns the "name" from package.json.
+func (h *NodeHandler) PackageName(workspacePath string) (string, error) {
+	pkg, err := readPackageJSON(filepath.Join(workspacePath, "package.json"))
+	if err != nil {
+		return "", err
+	}
+	return pkg.Name, nil
+}
+
+// manifests returns the root package.json followed by those of the monorepo
+// members, if any.
+func (h *NodeHandler) manifests(workspacePath string) ([]string, error) {
+	root := filepath.Join(workspacePath, "package.json")
+	pkg, err := readPackageJSON(root)
+	if err != nil {
+		return nil, err
+	}
+	patterns, err := nodeWorkspacePatterns(workspacePath, pkg)
+	if err != nil {
+		return nil, err
+	}
+	paths := []string{root}
+	seen := map[string]bool{root: true}
+	for _, pattern := range patterns {
+		if strings.HasPrefix(pattern, "!") {
+			continue
+		}
+		dirs, err := filepath.Glob(filepath.Join(workspacePath, filepath.FromSlash(pattern)))
+		if err != nil {
+			return nil, fmt.Errorf("invalid workspace pattern %q: %w", pattern, err)
+		}
+		sort.Strings(dirs)
+		for _, dir := range dirs {
+			manifest := filepath.Join(dir, "package.json")
+			if seen[manifest] {
+				continue
+			}
+			if _, err := os.Stat(manifest); err == nil {
+				seen[manifest] = true
+				paths = append(paths, manifest)
+			}
+		}
+	}
+	return paths, nil
+}
+
+// ManifestFiles returns every package.json UpdateDependency edits plus the
+// npm or pnpm lockfile it refreshes.
+func (h *NodeHandler) ManifestFiles(workspacePath string) ([]string, error) {
+	manifests, err := h.manifests(workspacePath)
+	if err != nil {
+		return nil, err
+	}
+	return relativeManifestFiles(workspacePath, manifests, "pnpm-lock.yaml", "package-lock.json")
+}
+
+// relativeManifestFiles makes manifests relative to workspacePath and
+// appends those lockfiles that exist.
+func relativeManifestFiles(workspacePath string, manifests []string, lockfiles ...string) ([]string, error) {
+	var files []string
+	for _, path := range manifests {
+		rel, err := filepath.Rel(workspacePath, path)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, rel)
+	}
+	for _, lockfile := range lockfiles {
+		if fileExists(filepath.Join(workspacePath, lockfile)) {
+			files = append(files, lockfile)
+		}
+	}
+	return files, nil
+}
+
+// nodeWorkspacePatterns returns the member globs from package.json
+// "workspaces" (array or {"packages": [...]}) or pnpm-workspace.yaml.
+func nodeWorkspacePatterns(workspacePath string, pkg *packageJSON) ([]string, error) {
+	if len(pkg.Workspaces) > 0 {
+		var patterns []string
+		if err := json.Unmarshal(pkg.Workspaces, &patterns); err == nil {
+			return patterns, nil
+		}
+		var object struct {
+			Packages []string `json:"packages"`
+		}
+		if err := json.Unmarshal(pkg.Workspaces, &object); err != nil {
+			return nil, fmt.Errorf("parsing package.json workspaces: %w", err)
+		}
+		return object.Packages, nil
+	}
+	data, err := os.ReadFile(filepath.Join(workspacePath, "pnpm-workspace.yaml"))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var pnpm struct {
+		Packages []string `yaml:"packages"`
+	}
+	if err := yaml.Unmarshal(data, &pnpm); err != nil {
+		return nil, fmt.Errorf("parsing pnpm-workspace.yaml: %w", err)
+	}
+	return pnpm.Packages, nil
+}
+
+func npmScope(name string) string {
+	if !strings.HasPrefix(name, "@") {
+		return ""
+	}
+	scope, _, _ := strings.Cut(name, "/")
+	return scope
 }
 
 func (h *NodeHandler) ParseDependencies(workspacePath string) ([]Dependency, error) {
-	return nil, fmt.Errorf("Node.js handler not implemented yet")
+	manifests, err := h.manifests(workspacePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			// No package.json file, return empty dependencies
+			return []Dependency{}, nil
+		}
+		return nil, fmt.Errorf("reading package.json: %w", err)
+	}
+
+	var pkgs []*packageJSON
+	members := make(map[string]bool)
+	scopes := make(map[string]bool)
+	for _, path := range manifests {
+		pkg, err := readPackageJSON(path)
+		if err != nil {
+			return nil, err
+		}
+		pkgs = append(pkgs, pkg)
+		if pkg.Name != "" {
+			members[pkg.Name] = true
+		}
+		if scope := npmScope(pkg.Name); scope != "" {
+			scopes[scope] = true
+		}
+	}
+
+	var deps []Dependency
+	seen := make(map[string]bool)
+	for _, pkg := range pkgs {
+		for _, section := range nodeDependencySections {
+			ranges := pkg.section(section)
+			names := make([]string, 0, len(ranges))
+			for name := range ranges {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+			for _, name := range names {
+				if members[name] || seen[name] {
+					continue
+				}
+				seen[name] = true
+				deps = append(deps, Dependency{
+					Name:      name,
+					Version:   ranges[name],
+					Type:      DependencyTypeLibrary,
+					Workspace: scopes[npmScope(name)],
+				})
+			}
+		}
+	}
+	return deps, nil
 }
 
+// UpdateDependency points every range on dep.Name, in the root package.json
+// and all members, at dep.Version, keeping each range's operator ("^1.2.0"
+// becomes "^1.3.0", "workspace:~1.2.0" becomes "workspace:~1.3.0"). Bare
+// "workspace:*"-style ranges and non-semver specs (git URLs, tags) are left
+// alone. The npm or pnpm lockfile is refreshed afterwards.
 func (h *NodeHandler) UpdateDependency(workspacePath string, dep Dependency) error {
-	return fmt.Errorf("Node.js handler not implemented yet")
+	manifests, err := h.manifests(workspacePath)
+	if err != nil {
+		return fmt.Errorf("reading package.json: %w", err)
+	}
+	version := strings.TrimPrefix(dep.Version, "v")
+	changed := false
+	for _, path := range manifests {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("reading %s: %w", path, err)
+		}
+		updated, err := editJSONStrings(data, func(keys []string, value string) (string, bool) {
+			if len(keys) != 2 || keys[1] != dep.Name || !containsString(nodeDependencySections, keys[0]) {
+				return "", false
+			}
+			return retargetNodeRange(value, version)
+		})
+		if err != nil {
+			return fmt.Errorf("updating %s: %w", path, err)
+		}
+		if bytes.Equal(updated, data) {
+			continue
+		}
+		if err := os.WriteFile(path, updated, 0o600); err != nil {
+			return fmt.Errorf("writing %s: %w", path, err)
+		}
+		changed = true
+	}
+	if !changed {
+		return nil
+	}
+	return refreshNodeLockfile(workspacePath)
+}
+
+// retargetNodeRange rewrites a simple semver range to version, keeping its
+// operator and any "workspace:"/"npm:<name>@" prefix.
+func retargetNodeRange(spec, version string) (string, bool) {
+	prefix, rng := "", spec
+	if strings.HasPrefix(rng, "workspace:") {
+		prefix, rng = "workspace:", strings.TrimPrefix(rng, "workspace:")
+	} else if strings.HasPrefix(rng, "npm:") {
+		i := strings.LastIndex(rng, "@")
+		if i <= len("npm:") {
+			return "", false
+		}
+		prefix, rng = rng[:i+1], rng[i+1:]
+	}
+	op := ""
+	for _, candidate := range []string{">=", "^", "~", "="} {
+		if strings.HasPrefix(rng, candidate) {
+			op = candidate
+			break
+		}
+	}
+	current := strings.TrimPrefix(strings.TrimPrefix(rng, op), "v")
+	if current == "" || strings.ContainsAny(current, " |<>*xX") || !strings.ContainsAny(current[:1], "0123456789") {
+		return "", false
+	}
+	if current == version {
+		return "", false
+	}
+	return prefix + op + version, true
+}
+
+// refreshNodeLockfile brings the lockfile in line with package.json without
+// running install scripts. Workspaces without an npm or pnpm lockfile are
+// left alone.
+func refreshNodeLockfile(workspacePath string) error {
+	var args []string
+	switch {
+	case fileExists(filepath.Join(workspacePath, "pnpm-lock.yaml")):
+		args = []string{"pnpm", "install", "--lockfile-only", "--ignore-scripts"}
+	case fileExists(filepath.Join(workspacePath, "package-lock.json")):
+		args = []string{"npm", "install", "--package-lock-only", "--ignore-scripts", "--no-audit", "--no-fund"}
+	default:
+		return nil
+	}
+	cmd := exec.CommandContext(context.Background(), args[0], args[1:]...) //nolint:gosec // G204: fixed package manager invocation
+	cmd.Dir = workspacePath
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("%s failed: %w (output: %s)", strings.Join(args, " "), err, output)
+	}
+	return nil
 }
 
 func (h *NodeHandler) GetVersion(workspacePath string) (string, error) {
-	return "", fmt.Errorf("Node.js handler not implemented yet")
+	pkg, err := readPackageJSON(filepath.Join(workspacePath, "package.json"))
+	if err != nil {
+		return "", fmt.Errorf("reading package.json: %w", err)
+	}
+	if pkg.Version == "" {
+		return "", fmt.Errorf("version not found in package.json")
+	}
+	return pkg.Version, nil
 }
 
+// SetVersion writes version (without its "v") to the root package.json,
+// preserving the file's formatting and key order.
 func (h *NodeHandler) SetVersion(workspacePath, version string) error {
-	return fmt.Errorf("Node.js handler not implemented yet")
+	path := filepath.Join(workspacePath, "package.json")
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("reading package.json: %w", err)
+	}
+	version = strings.TrimPrefix(version, "v")
+	found := false
+	updated, err := editJSONStrings(data, func(keys []string, value string) (string, bool) {
+		if len(keys) != 1 || keys[0] != "version" {
+			return "", false
+		}
+		found = true
+		return version, value != version
+	})
+	if err != nil {
+		return fmt.Errorf("updating package.json: %w", err)
+	}
+	if !found {
+		return fmt.Errorf("package.json has no version field")
+	}
+	if err := os.WriteFile(path, updated, 0o600); err != nil {
+		return fmt.Errorf("writing package.json: %w", err)
+	}
+	return nil
 }
 
+// Leverage Makefile contract
 func (h *NodeHandler) GetBuildCommand() string  { return "make build" }
 func (h *NodeHandler) GetTestCommand() string   { return "make test" }
 func (h *NodeHandler) GetVerifyCommand() string { return "make verify" }
+
+// Helper functions
+
+// editJSONStrings rewrites string values of a JSON document in place, so the
+// rest of the file (key order, indentation, trailing newline) is untouched.
+// edit sees the object keys leading to every string value, e.g.
+// ["dependencies", "@acme/kit"], and returns a replacement and true to
+// rewrite it.
+func editJSONStrings(data []byte, edit func(keys []string, value string) (string, bool)) ([]byte, error) {
+	type frame struct {
+		object    bool
+		key       string
+		expectKey bool
+	}
+	type replacement struct {
+		start, end int
+		value      string
+	}
+	var (
+		stack   []*frame
+		edits   []replacement
+		dec     = json.NewDecoder(bytes.NewReader(data))
+		keyPath = func() []string {
+			keys := make([]string, 0, len(stack))
+			for _, f := range stack {
+				keys = append(keys, f.key)
+			}
+			return keys
+		}
+		valueDone = func() {
+			if len(stack) > 0 && stack[len(stack)-1].object {
+				stack[len(stack)-1].expectKey = true
+			}
+		}
+	)
+	for {
+		before := int(dec.InputOffset())
+		tok, err := dec.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		var top *frame
+		if len(stack) > 0 {
+			top = stack[len(stack)-1]
+		}
+		switch t := tok.(type) {
+		case json.Delim:
+			switch t {
+			case '{', '[':
+				stack = append(stack, &frame{object: t == '{', expectKey: t == '{'})
+			default:
+				stack = stack[:len(stack)-1]
+				valueDone()
+			}
+		case string:
+			if top != nil && top.object && top.expectKey {
+				top.key = t
+				top.expectKey = false
+				continue
+			}
+			if top != nil && top.object {
+				if value, ok := edit(keyPath(), t); ok {
+					start := before + bytes.IndexByte(data[before:], '"')
+					edits = append(edits, replacement{start: start, end: int(dec.InputOffset()), value: value})
+				}
+			}
+			valueDone()
+		default:
+			valueDone()
+		}
+	}
+	out := append([]byte(nil), data...)
+	for i := len(edits) - 1; i >= 0; i-- {
+		var buf bytes.Buffer
+		enc := json.NewEncoder(&buf)
+		enc.SetEscapeHTML(false)
+		if err := enc.Encode(edits[i].value); err != nil {
+			return nil, err
+		}
+		quoted := bytes.TrimRight(buf.Bytes(), "\n")
+		out = append(out[:edits[i].start], append(quoted, out[edits[i].end:]...)...)
+	}
+	return out, nil
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
@@ -0,0 +1,165 @@
+package project
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const nodeRootManifest = `{
+  "name": "@acme/panels",
+  "version": "1.4.0",
+  "private": true,
+  "workspaces": ["packages/*"],
+  "dependencies": {
+    "@acme/kit": "^1.2.0",
+    "react": "^18.2.0"
+  },
+  "devDependencies": {
+    "@acme/lint-config": "workspace:*"
+  }
+}
+`
+
+func writeNodeMonorepo(t *testing.T) string {
+	t.Helper()
+	ws := t.TempDir()
+	writeFile(t, filepath.Join(ws, "package.json"), nodeRootManifest)
+	writeFile(t, filepath.Join(ws, "packages", "chart", "package.json"), `{
+  "name": "@acme/chart",
+  "version": "1.4.0",
+  "dependencies": {
+    "@acme/kit": "~1.2.0",
+    "@acme/panels-core": "workspace:^",
+    "d3": "7.8.5"
+  },
+  "peerDependencies": {"@acme/theme": ">=2.0.0"}
+}
+`)
+	writeFile(t, filepath.Join(ws, "packages", "core", "package.json"), `{"name": "@acme/panels-core", "version": "1.4.0"}`)
+	return ws
+}
+
+func TestNodeHandlerParseDependencies(t *testing.T) {
+	ws := writeNodeMonorepo(t)
+	h := NewNodeHandler()
+	if !h.HasProjectFile(ws) {
+		t.Fatal("package.json not detected")
+	}
+	deps, err := h.ParseDependencies(ws)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := map[string]Dependency{}
+	for _, d := range deps {
+		got[d.Name] = d
+	}
+	want := map[string]bool{
+		"@acme/kit":         true,
+		"@acme/lint-config": true,
+		"@acme/theme":       true,
+		"react":             false,
+		"d3":                false,
+	}
+	if len(got) != len(want) {
+		t.Fatalf("deps = %+v", deps)
+	}
+	for name, workspace := range want {
+		d, ok := got[name]
+		if !ok || d.Workspace != workspace {
+			t.Errorf("%s: got %+v, want workspace=%v", name, d, workspace)
+		}
+	}
+	if got["@acme/kit"].Version != "^1.2.0" {
+		t.Errorf("root range should win: %+v", got["@acme/kit"])
+	}
+	if name, _ := h.PackageName(ws); name != "@acme/panels" {
+		t.Errorf("PackageName = %q", name)
+	}
+}
+
+func TestNodeHandlerUpdateDependencyKeepsFormatting(t *testing.T) {
+	ws := writeNodeMonorepo(t)
+	h := NewNodeHandler()
+	if err := h.UpdateDependency(ws, Dependency{Name: "@acme/kit", Version: "v1.3.0"}); err != nil {
+		t.Fatal(err)
+	}
+	data, _ := os.ReadFile(filepath.Join(ws, "package.json"))
+	want := `{
+  "name": "@acme/panels",
+  "version": "1.4.0",
+  "private": true,
+  "workspaces": ["packages/*"],
+  "dependencies": {
+    "@acme/kit": "^1.3.0",
+    "react": "^18.2.0"
+  },
+  "devDependencies": {
+    "@acme/lint-config": "workspace:*"
+  }
+}
+`
+	if string(data) != want {
+		t.Errorf("root package.json =\n%s", data)
+	}
+	member, err := readPackageJSON(filepath.Join(ws, "packages", "chart", "package.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if member.Dependencies["@acme/kit"] != "~1.3.0" {
+		t.Errorf("member range = %q", member.Dependencies["@acme/kit"])
+	}
+
+	if err := h.SetVersion(ws, "v1.5.0"); err != nil {
+		t.Fatal(err)
+	}
+	if v, _ := h.GetVersion(ws); v != "1.5.0" {
+		t.Errorf("GetVersion = %q", v)
+	}
+	data, _ = os.ReadFile(filepath.Join(ws, "package.json"))
+	if string(data) != strings.Replace(want, `"version": "1.4.0"`, `"version": "1.5.0"`, 1) {
+		t.Errorf("SetVersion disturbed the file:\n%s", data)
+	}
+}
+
+func TestRetargetNodeRange(t *testing.T) {
+	tests := []struct {
+		spec string
+		want string
+		ok   bool
+	}{
+		{"^1.2.0", "^2.0.0", true},
+		{"~1.2.0", "~2.0.0", true},
+		{"1.2.0", "2.0.0", true},
+		{">=1.2.0", ">=2.0.0", true},
+		{"workspace:^1.2.0", "workspace:^2.0.0", true},
+		{"npm:@acme/kit@^1.2.0", "npm:@acme/kit@^2.0.0", true},
+		{"workspace:*", "", false},
+		{"workspace:^", "", false},
+		{"^2.0.0", "", false},
+		{">=1.0.0 <2.0.0", "", false},
+		{"github:acme/kit#main", "", false},
+		{"latest", "", false},
+	}
+	for _, tt := range tests {
+		got, ok := retargetNodeRange(tt.spec, "2.0.0")
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("retargetNodeRange(%q) = %q, %v; want %q, %v", tt.spec, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestNodeHandlerManifestFiles(t *testing.T) {
+	ws := writeNodeMonorepo(t)
+	writeFile(t, filepath.Join(ws, "package-lock.json"), "{}")
+	writeFile(t, filepath.Join(ws, "README.md"), "unrelated")
+	files, err := NewNodeHandler().ManifestFiles(ws)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"package.json", filepath.Join("packages", "chart", "package.json"), filepath.Join("packages", "core", "package.json"), "package-lock.json"}
+	if strings.Join(files, ",") != strings.Join(want, ",") {
+		t.Errorf("ManifestFiles = %v, want %v", files, want)
+	}
+}
@@ -6,15 +6,23 @@ type Registry struct {
 	handlers map[Type]ProjectHandler
 }
 
+// NewRegistry returns a registry for the upstream grove module namespace.
 func NewRegistry() *Registry {
+	return NewRegistryFor(DefaultModulePrefixes())
+}
+
+// NewRegistryFor returns a registry whose handlers treat modules under
+// prefixes as workspace dependencies.
+func NewRegistryFor(prefixes ModulePrefixes) *Registry {
 	r := &Registry{
 		handlers: make(map[Type]ProjectHandler),
 	}
 
 	// Register default handlers
-	r.Register(TypeGo, NewGoHandler())
+	r.Register(TypeGo, NewGoHandlerFor(prefixes))
 	r.Register(TypeMaturin, NewMaturinHandler())
 	r.Register(TypeNode, NewNodeHandler())
+	r.Register(TypeCargo, NewCargoHandler())
 	r.Register(TypeTemplate, NewTemplateHandler())
 
 	return r
@@ -66,6 +66,31 @@ func (r *Reconciler) ReconcileAll(tools []string) error {
 
 // Reconcile reconciles the symlink for a specific tool
 func (r *Reconciler) Reconcile(toolName string) error {
+	symlinkPath, targetPath := r.resolve(toolName)
+	if targetPath == "" {
+		// Remove the symlink if it exists
+		os.Remove(symlinkPath)
+		return nil
+	}
+	return createOrUpdateSymlink(symlinkPath, targetPath)
+}
+
+// ReconcileAtomic reconciles the symlinks of all tools as one switch, for
+// flipping a whole dev-link profile: every new symlink is staged next to its
+// final path first, and only when all are staged are they renamed into place.
+// If staging fails no symlink has changed.
+func (r *Reconciler) ReconcileAtomic(tools []string) error {
+	targets := make(map[string]string, len(tools))
+	for _, toolName := range tools {
+		symlinkPath, targetPath := r.resolve(toolName)
+		targets[symlinkPath] = targetPath
+	}
+	return swapSymlinks(targets)
+}
+
+// resolve returns the tool's symlink path and what it should point at: the
+// active dev link, else the released binary, else "" (no symlink).
+func (r *Reconciler) resolve(toolName string) (symlinkPath, targetPath string) {
 	// Get tool info using FindTool - toolName could be repo name or alias
 	repoName, _, effectiveAlias, found := sdk.FindTool(toolName)
 	if !found {
@@ -75,7 +100,7 @@ func (r *Reconciler) Reconcile(toolName string) error {
 	}
 
 	binDir := paths.BinDir()
-	symlinkPath := filepath.Join(binDir, effectiveAlias)
+	symlinkPath = filepath.Join(binDir, effectiveAlias)
 
 	// Check if a dev override is active - dev links are stored by tool alias
 	// Try both the effectiveAlias and repoName for backward compatibility
@@ -85,7 +110,7 @@ func (r *Reconciler) Reconcile(toolName string) error {
 			// Dev override is active
 			if linkInfo, ok := binLinks.Links[binLinks.Current]; ok {
 				r.logger.Infof("'%s' is using dev link '%s' (%s)", effectiveAlias, binLinks.Current, linkInfo.Path)
-				return createOrUpdateSymlink(symlinkPath, linkInfo.Path)
+				return symlinkPath, linkInfo.Path
 			}
 		}
 	}
@@ -94,22 +119,19 @@ func (r *Reconciler) Reconcile(toolName string) error {
 	toolVersion := r.toolVersions.GetToolVersion(repoName)
 	if toolVersion == "" {
 		r.logger.Debugf("No active version for %s and no dev override, removing symlink", repoName)
-		// Remove the symlink if it exists
-		os.Remove(symlinkPath)
-		return nil
+		return symlinkPath, ""
 	}
 
 	// Check if the tool exists in the active version
 	releasedBinPath := filepath.Join(r.groveHome, "versions", toolVersion, "bin", effectiveAlias)
 	if _, err := os.Stat(releasedBinPath); err == nil {
 		r.logger.Infof("'%s' is using released version '%s'", effectiveAlias, toolVersion)
-		return createOrUpdateSymlink(symlinkPath, releasedBinPath)
+		return symlinkPath, releasedBinPath
 	}
 
 	// Tool doesn't exist in the active version
 	r.logger.Debugf("%s not found in version %s", effectiveAlias, toolVersion)
-	os.Remove(symlinkPath)
-	return nil
+	return symlinkPath, ""
 }
 
 // GetEffectiveSource returns the effective source (dev or release) for a tool
@@ -162,3 +184,84 @@ func createOrUpdateSymlink(symlinkPath, targetPath string) error {
 
 	return nil
 }
+
+// swapSymlinks points every symlink in targets (symlink path -> target, ""
+// to remove) at its target. New symlinks are staged under temporary names and
+// renamed into place only after all of them were created; rename replaces a
+// symlink atomically, so each link is always either old or new. If a rename
+// fails partway, the links already switched are pointed back at their
+// previous targets (or removed when they did not exist before).
+func swapSymlinks(targets map[string]string) error {
+	staged := make(map[string]string)   // temp path -> final path
+	previous := make(map[string]string) // final path -> target before the swap
+	cleanup := func() {
+		for tmp := range staged {
+			os.Remove(tmp)
+		}
+	}
+	for symlinkPath, targetPath := range targets {
+		if targetPath == "" {
+			continue
+		}
+		if err := os.MkdirAll(filepath.Dir(symlinkPath), 0o755); err != nil {
+			cleanup()
+			return fmt.Errorf("failed to create bin directory: %w", err)
+		}
+		if old, err := os.Readlink(symlinkPath); err == nil {
+			previous[symlinkPath] = old
+		}
+		tmp, err := stageSymlink(symlinkPath, targetPath)
+		if err != nil {
+			cleanup()
+			return fmt.Errorf("failed to stage symlink for %s: %w", filepath.Base(symlinkPath), err)
+		}
+		staged[tmp] = symlinkPath
+	}
+
+	var swapped []string
+	for tmp, symlinkPath := range staged {
+		if err := os.Rename(tmp, symlinkPath); err != nil {
+			cleanup()
+			restoreSymlinks(swapped, previous)
+			return fmt.Errorf("failed to switch symlink %s: %w", filepath.Base(symlinkPath), err)
+		}
+		delete(staged, tmp)
+		swapped = append(swapped, symlinkPath)
+	}
+	for symlinkPath, targetPath := range targets {
+		if targetPath == "" {
+			os.Remove(symlinkPath)
+		}
+	}
+	return nil
+}
+
+// stageSymlink creates a symlink to targetPath under a temporary name next to
+// symlinkPath and returns that name.
+func stageSymlink(symlinkPath, targetPath string) (string, error) {
+	tmp := filepath.Join(filepath.Dir(symlinkPath), fmt.Sprintf(".%s.%d.tmp", filepath.Base(symlinkPath), os.Getpid()))
+	os.Remove(tmp)
+	if err := os.Symlink(targetPath, tmp); err != nil {
+		return "", err
+	}
+	return tmp, nil
+}
+
+// restoreSymlinks rolls back links switched by a failed swapSymlinks. Best
+// effort: the swap's own error is what gets reported.
+func restoreSymlinks(swapped []string, previous map[string]string) {
+	for _, symlinkPath := range swapped {
+		old, existed := previous[symlinkPath]
+		if !existed {
+			os.Remove(symlinkPath)
+			continue
+		}
+		tmp, err := stageSymlink(symlinkPath, old)
+		if err != nil {
+			continue
+		}
+		if err := os.Rename(tmp, symlinkPath); err != nil {
+			os.Remove(tmp)
+		}
+	}
+}
@@ -0,0 +1,103 @@
+package reconciler
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSwapSymlinks(t *testing.T) {
+	binDir := t.TempDir()
+	flow := filepath.Join(binDir, "flow")
+	cx := filepath.Join(binDir, "cx")
+	stale := filepath.Join(binDir, "nb")
+	for _, link := range []string{flow, stale} {
+		if err := os.Symlink("/old/"+filepath.Base(link), link); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	err := swapSymlinks(map[string]string{
+		flow:  "/wt-a/bin/flow",
+		cx:    "/wt-b/bin/cx",
+		stale: "",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for link, want := range map[string]string{flow: "/wt-a/bin/flow", cx: "/wt-b/bin/cx"} {
+		if got, err := os.Readlink(link); err != nil || got != want {
+			t.Errorf("%s -> %q, %v; want %q", link, got, err, want)
+		}
+	}
+	if _, err := os.Lstat(stale); !os.IsNotExist(err) {
+		t.Errorf("nb should have been removed: %v", err)
+	}
+	entries, _ := os.ReadDir(binDir)
+	if len(entries) != 2 {
+		t.Errorf("staging files left behind: %v", entries)
+	}
+}
+
+func TestSwapSymlinksStagingFailureChangesNothing(t *testing.T) {
+	binDir := t.TempDir()
+	flow := filepath.Join(binDir, "flow")
+	if err := os.Symlink("/old/flow", flow); err != nil {
+		t.Fatal(err)
+	}
+	// A file where a directory is needed makes staging the second link fail
+	blocker := filepath.Join(binDir, "blocker")
+	if err := os.WriteFile(blocker, nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	err := swapSymlinks(map[string]string{
+		flow:                                "/wt-a/bin/flow",
+		filepath.Join(blocker, "sub", "cx"): "/wt-b/bin/cx",
+	})
+	if err == nil {
+		t.Fatal("expected a staging error")
+	}
+	if got, _ := os.Readlink(flow); got != "/old/flow" {
+		t.Errorf("flow was switched to %q despite the failure", got)
+	}
+	entries, _ := os.ReadDir(binDir)
+	if len(entries) != 2 {
+		t.Errorf("staging files left behind: %v", entries)
+	}
+}
+
+func TestSwapSymlinksRenameFailureRestoresSwitchedLinks(t *testing.T) {
+	binDir := t.TempDir()
+	flow := filepath.Join(binDir, "flow")
+	if err := os.Symlink("/old/flow", flow); err != nil {
+		t.Fatal(err)
+	}
+	cx := filepath.Join(binDir, "cx")
+	// A non-empty directory in place of a link makes its rename fail, after
+	// the other links may already have been switched
+	blocked := filepath.Join(binDir, "nb")
+	if err := os.MkdirAll(filepath.Join(blocked, "keep"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	err := swapSymlinks(map[string]string{
+		flow:    "/wt-a/bin/flow",
+		cx:      "/wt-b/bin/cx",
+		blocked: "/wt-b/bin/nb",
+	})
+	if err == nil {
+		t.Fatal("expected a rename error")
+	}
+	if got, _ := os.Readlink(flow); got != "/old/flow" {
+		t.Errorf("flow -> %q after the failure; want /old/flow", got)
+	}
+	if _, err := os.Lstat(cx); !os.IsNotExist(err) {
+		t.Errorf("cx did not exist before and should have been removed: %v", err)
+	}
+	entries, _ := os.ReadDir(binDir)
+	if len(entries) != 2 {
+		t.Errorf("staging files left behind: %v", entries)
+	}
+}
@@ -0,0 +1,461 @@
+package release
+
+import (
+	"fmt"
+	"os/exec"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Version bump levels, in increasing order of impact.
+const (
+	BumpNone  = ""
+	BumpPatch = "patch"
+	BumpMinor = "minor"
+	BumpMajor = "major"
+)
+
+// Commit is a commit message parsed per the Conventional Commits 1.0 spec.
+// Commits that do not follow the spec keep their subject with an empty Type.
+type Commit struct {
+	Hash     string
+	Subject  string // description after "type(scope): ", or the raw header
+	Type     string // lowercased; "" for non-conventional commits
+	Scope    string
+	Breaking bool
+	// BreakingNote is the BREAKING CHANGE footer text, or the description for
+	// a "!" header without one.
+	BreakingNote string
+	Body         string
+	Footers      []Footer
+	// Reverts lists the hashes this commit reverts ("This reverts commit
+	// <hash>." bodies and "Refs:" footers on revert commits).
+	Reverts []string
+}
+
+// Footer is one "Token: value" or "Token #value" trailer.
+type Footer struct {
+	Token string
+	Value string
+}
+
+// Conventional reports whether the header followed the spec.
+func (c Commit) Conventional() bool { return c.Type != "" }
+
+// Header reassembles the "type(scope)!: subject" header.
+func (c Commit) Header() string {
+	if !c.Conventional() {
+		return c.Subject
+	}
+	h := c.Type
+	if c.Scope != "" {
+		h += "(" + c.Scope + ")"
+	}
+	if c.Breaking && !c.hasBreakingFooter() {
+		h += "!"
+	}
+	return h + ": " + c.Subject
+}
+
+func (c Commit) hasBreakingFooter() bool {
+	for _, f := range c.Footers {
+		if isBreakingToken(f.Token) {
+			return true
+		}
+	}
+	return false
+}
+
+// Short returns the abbreviated hash.
+func (c Commit) Short() string {
+	if len(c.Hash) > 7 {
+		return c.Hash[:7]
+	}
+	return c.Hash
+}
+
+var (
+	headerRe = regexp.MustCompile(`^([A-Za-z][\w-]*)(?:\(([^()\r\n]*)\))?(!)?: (.+)$`)
+	// gitRevertRe matches the header `git revert` writes.
+	gitRevertRe = regexp.MustCompile(`^Revert "(.*)"$`)
+	revertsRe   = regexp.MustCompile(`(?i)this reverts commit ([0-9a-f]{7,40})`)
+	footerRe    = regexp.MustCompile(`^(BREAKING CHANGE|BREAKING-CHANGE|[A-Za-z][\w-]*)(: | #)(.*)$`)
+	hashRe      = regexp.MustCompile(`^[0-9a-f]{7,40}$`)
+)
+
+func isBreakingToken(token string) bool {
+	return token == "BREAKING CHANGE" || token == "BREAKING-CHANGE"
+}
+
+// ParseCommit parses a full commit message.
+func ParseCommit(hash, message string) Commit {
+	message = strings.TrimSpace(strings.ReplaceAll(message, "\r\n", "\n"))
+	header, rest, _ := strings.Cut(message, "\n")
+	c := Commit{Hash: hash, Subject: strings.TrimSpace(header)}
+
+	if m := headerRe.FindStringSubmatch(c.Subject); m != nil {
+		c.Type = strings.ToLower(m[1])
+		c.Scope = strings.TrimSpace(m[2])
+		c.Breaking = m[3] == "!"
+		c.Subject = strings.TrimSpace(m[4])
+	} else if m := gitRevertRe.FindStringSubmatch(c.Subject); m != nil {
+		c.Type = "revert"
+		c.Subject = m[1]
+	}
+
+	c.Body, c.Footers = splitFooters(strings.TrimSpace(rest))
+	for _, f := range c.Footers {
+		if isBreakingToken(f.Token) {
+			c.Breaking = true
+			c.BreakingNote = f.Value
+		}
+	}
+	if c.Breaking && c.BreakingNote == "" {
+		c.BreakingNote = c.Subject
+	}
+
+	if c.Type == "revert" {
+		for _, m := range revertsRe.FindAllStringSubmatch(rest, -1) {
+			c.Reverts = append(c.Reverts, strings.ToLower(m[1]))
+		}
+		for _, f := range c.Footers {
+			if strings.EqualFold(f.Token, "Refs") {
+				for _, ref := range strings.FieldsFunc(f.Value, func(r rune) bool { return r == ',' || r == ' ' }) {
+					if hashRe.MatchString(strings.ToLower(ref)) {
+						c.Reverts = append(c.Reverts, strings.ToLower(ref))
+					}
+				}
+			}
+		}
+	}
+	return c
+}
+
+// splitFooters separates the trailing footer paragraph from the body. A
+// paragraph counts as footers only when every line follows the footer
+// grammar: a footer, or (as in git trailers) a whitespace-indented line
+// continuing the previous footer's value. Anything else makes the whole
+// paragraph body text, so prose that happens to start with "Note: " is not
+// mistaken for footers.
+func splitFooters(text string) (string, []Footer) {
+	if text == "" {
+		return "", nil
+	}
+	paragraphs := strings.Split(text, "\n\n")
+	last := paragraphs[len(paragraphs)-1]
+	var footers []Footer
+	for _, line := range strings.Split(last, "\n") {
+		if m := footerRe.FindStringSubmatch(line); m != nil {
+			footers = append(footers, Footer{Token: m[1], Value: strings.TrimSpace(m[3])})
+			continue
+		}
+		if len(footers) == 0 || strings.TrimSpace(line) == "" || (line[0] != ' ' && line[0] != '\t') {
+			return text, nil
+		}
+		f := &footers[len(footers)-1]
+		f.Value = strings.TrimSpace(f.Value + "\n" + strings.TrimSpace(line))
+	}
+	body := strings.TrimSpace(strings.Join(paragraphs[:len(paragraphs)-1], "\n\n"))
+	return body, footers
+}
+
+// Record and field separators for the git log format below.
+const (
+	logRecordSep = "\x1e"
+	logFieldSep  = "\x1f"
+)
+
+// ParseLog parses `git log --format=%H%x1f%B%x1e` output, newest first.
+func ParseLog(out string) []Commit {
+	var commits []Commit
+	for _, record := range strings.Split(out, logRecordSep) {
+		record = strings.TrimLeft(record, "\n")
+		if strings.TrimSpace(record) == "" {
+			continue
+		}
+		hash, message, ok := strings.Cut(record, logFieldSep)
+		if !ok {
+			continue
+		}
+		commits = append(commits, ParseCommit(strings.TrimSpace(hash), message))
+	}
+	return commits
+}
+
+// LoadCommits returns the non-merge commits in repoPath since lastTag (all
+// history when lastTag is empty), newest first.
+func LoadCommits(repoPath, lastTag string) ([]Commit, error) {
+	commitRange := "HEAD"
+	if lastTag != "" {
+		commitRange = lastTag + "..HEAD"
+	}
+	cmd := exec.Command("git", "log", commitRange, "--no-merges", "--format=%H"+logFieldSep+"%B"+logRecordSep) //nolint:gosec // G204: range comes from git describe
+	cmd.Dir = repoPath
+	out, err := cmd.Output()
+	if err != nil {
+		if ee, ok := err.(*exec.ExitError); ok && len(ee.Stderr) > 0 {
+			return nil, fmt.Errorf("git log %s: %s", commitRange, strings.TrimSpace(string(ee.Stderr)))
+		}
+		return nil, fmt.Errorf("git log %s: %w", commitRange, err)
+	}
+	return ParseLog(string(out)), nil
+}
+
+// BumpRules are a repo's version bump rules, read from grove.toml:
+//
+//	[release.bump]
+//	pre_1_0 = true                    # while at v0.x, breaking changes bump minor
+//	minor_types = ["feat"]
+//	ignore_types = ["docs", "test", "ci", "style", "chore"]
+//	patch_types = ["chore(deps)"]     # "type(scope)" beats a bare "type"
+//
+// A commit matching neither list (a fix, a refactor, a non-conventional
+// commit) bumps patch.
+type BumpRules struct {
+	// PreMajor applies 0.x semantics while the current major version is 0:
+	// breaking changes bump minor instead of cutting 1.0.
+	PreMajor    bool     `yaml:"pre_1_0"`
+	MinorTypes  []string `yaml:"minor_types"`
+	PatchTypes  []string `yaml:"patch_types"`
+	IgnoreTypes []string `yaml:"ignore_types"`
+}
+
+// DefaultBumpRules: features are minor, docs/tests/CI/style changes do not
+// drive a bump on their own, everything else is a patch.
+func DefaultBumpRules() BumpRules {
+	return BumpRules{
+		MinorTypes:  []string{"feat"},
+		IgnoreTypes: []string{"docs", "test", "ci", "style"},
+	}
+}
+
+// WithDefaults fills unset lists from DefaultBumpRules.
+func (r BumpRules) WithDefaults() BumpRules {
+	d := DefaultBumpRules()
+	if r.MinorTypes == nil {
+		r.MinorTypes = d.MinorTypes
+	}
+	if r.IgnoreTypes == nil {
+		r.IgnoreTypes = d.IgnoreTypes
+	}
+	return r
+}
+
+// classify returns the bump a single non-breaking commit asks for. Scoped
+// entries ("chore(deps)") win over bare types ("chore").
+func (r BumpRules) classify(c Commit) string {
+	if !c.Conventional() {
+		return BumpPatch
+	}
+	scoped := c.Type + "(" + c.Scope + ")"
+	lists := []struct {
+		types []string
+		bump  string
+	}{
+		{r.MinorTypes, BumpMinor},
+		{r.PatchTypes, BumpPatch},
+		{r.IgnoreTypes, BumpNone},
+	}
+	if c.Scope != "" {
+		for _, l := range lists {
+			if containsFold(l.types, scoped) {
+				return l.bump
+			}
+		}
+	}
+	for _, l := range lists {
+		if containsFold(l.types, c.Type) {
+			return l.bump
+		}
+	}
+	return BumpPatch
+}
+
+func containsFold(list []string, s string) bool {
+	for _, v := range list {
+		if strings.EqualFold(v, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// BumpDecision is the outcome of DecideBump.
+type BumpDecision struct {
+	Bump string
+	// Reasoning is a one-line explanation suitable for SuggestionReasoning.
+	Reasoning string
+	// Breaking, Features and Others are the commits that counted, by impact.
+	Breaking []Commit
+	Features []Commit
+	Others   []Commit
+	// Ignored are commits whose type the rules ignore.
+	Ignored []Commit
+	// Reverted are commits cancelled out by a revert in the same range (the
+	// revert commits themselves included).
+	Reverted []Commit
+}
+
+// DecideBump computes the version bump for commits (newest first) on top of
+// currentVersion. A commit reverted within the range cancels out together
+// with its revert. When every commit is ignored the release still gets a
+// patch: it has changes, just none that the rules consider significant.
+func DecideBump(commits []Commit, currentVersion string, rules BumpRules) BumpDecision {
+	rules = rules.WithDefaults()
+	var d BumpDecision
+
+	reverted := make(map[string]bool)
+	for _, c := range commits {
+		for _, h := range c.Reverts {
+			for _, target := range commits {
+				if strings.HasPrefix(target.Hash, h) {
+					reverted[target.Hash] = true
+					reverted[c.Hash] = true
+				}
+			}
+		}
+	}
+
+	for _, c := range commits {
+		switch {
+		case reverted[c.Hash]:
+			d.Reverted = append(d.Reverted, c)
+		case c.Breaking:
+			d.Breaking = append(d.Breaking, c)
+		default:
+			switch rules.classify(c) {
+			case BumpMinor:
+				d.Features = append(d.Features, c)
+			case BumpPatch:
+				d.Others = append(d.Others, c)
+			default:
+				d.Ignored = append(d.Ignored, c)
+			}
+		}
+	}
+
+	preMajor := rules.PreMajor && majorVersion(currentVersion) == 0
+	switch {
+	case len(d.Breaking) > 0 && !preMajor:
+		d.Bump = BumpMajor
+	case len(d.Breaking) > 0 || len(d.Features) > 0:
+		d.Bump = BumpMinor
+	default:
+		d.Bump = BumpPatch
+	}
+	d.Reasoning = d.reasoning(preMajor)
+	return d
+}
+
+func (d BumpDecision) reasoning(preMajor bool) string {
+	var parts []string
+	if n := len(d.Breaking); n > 0 {
+		s := fmt.Sprintf("%s (%s)", plural(n, "breaking change"), d.Breaking[0].Header())
+		if preMajor {
+			s += ", minor while pre-1.0"
+		}
+		parts = append(parts, s)
+	}
+	if n := len(d.Features); n > 0 {
+		parts = append(parts, plural(n, "feature"))
+	}
+	if len(d.Others) > 0 {
+		parts = append(parts, typeCounts(d.Others))
+	}
+	if len(d.Ignored) > 0 {
+		parts = append(parts, "ignored "+typeCounts(d.Ignored))
+	}
+	if n := len(d.Reverted); n > 0 {
+		parts = append(parts, fmt.Sprintf("%d reverted", n))
+	}
+	if len(parts) == 0 {
+		return d.Bump + ": no commits"
+	}
+	return d.Bump + ": " + strings.Join(parts, "; ")
+}
+
+// typeCounts summarizes commits as "2 fix, 1 chore, 1 other".
+func typeCounts(commits []Commit) string {
+	counts := make(map[string]int)
+	for _, c := range commits {
+		key := c.Type
+		if key == "" {
+			key = "other"
+		}
+		counts[key]++
+	}
+	keys := make([]string, 0, len(counts))
+	for k := range counts {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if counts[keys[i]] != counts[keys[j]] {
+			return counts[keys[i]] > counts[keys[j]]
+		}
+		return keys[i] < keys[j]
+	})
+	parts := make([]string, len(keys))
+	for i, k := range keys {
+		parts[i] = fmt.Sprintf("%d %s", counts[k], k)
+	}
+	return strings.Join(parts, ", ")
+}
+
+func plural(n int, noun string) string {
+	if n == 1 {
+		return "1 " + noun
+	}
+	return fmt.Sprintf("%d %ss", n, noun)
+}
+
+// majorVersion returns the major component of "v1.2.3"; -1 when unparsable.
+func majorVersion(version string) int {
+	v := strings.TrimPrefix(version, "v")
+	major, _, _ := strings.Cut(v, ".")
+	n, err := strconv.Atoi(major)
+	if err != nil {
+		return -1
+	}
+	return n
+}
+
+// FormatCommits renders commits as the structured commit list fed to the
+// changelog prompt: one entry per commit with its hash, parsed header,
+// breaking note, body and footers, grouped under the bump decision.
+func FormatCommits(d BumpDecision) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "SEMVER ANALYSIS: %s\n", d.Reasoning)
+	section := func(title string, commits []Commit) {
+		if len(commits) == 0 {
+			return
+		}
+		fmt.Fprintf(&b, "\n%s:\n", title)
+		for _, c := range commits {
+			fmt.Fprintf(&b, "- %s %s\n", c.Short(), c.Header())
+			if c.Breaking && c.BreakingNote != c.Subject {
+				fmt.Fprintf(&b, "    BREAKING CHANGE: %s\n", indentContinuation(c.BreakingNote))
+			}
+			if c.Body != "" {
+				fmt.Fprintf(&b, "    %s\n", indentContinuation(c.Body))
+			}
+			for _, f := range c.Footers {
+				if isBreakingToken(f.Token) {
+					continue
+				}
+				fmt.Fprintf(&b, "    %s: %s\n", f.Token, indentContinuation(f.Value))
+			}
+		}
+	}
+	section("BREAKING CHANGES", d.Breaking)
+	section("FEATURES", d.Features)
+	section("OTHER CHANGES", d.Others)
+	section("IGNORED BY BUMP RULES", d.Ignored)
+	section("REVERTED WITHIN THIS RELEASE (omit from the changelog)", d.Reverted)
+	return b.String()
+}
+
+func indentContinuation(s string) string {
+	return strings.ReplaceAll(s, "\n", "\n    ")
+}
@@ -0,0 +1,187 @@
+package release
+
+import (
+	"os/exec"
+	"strings"
+	"testing"
+)
+
+func TestParseCommit(t *testing.T) {
+	tests := []struct {
+		name         string
+		message      string
+		wantType     string
+		wantScope    string
+		wantSubject  string
+		wantBreaking bool
+		wantNote     string
+		wantFooters  int
+	}{
+		{
+			name:        "scoped feature",
+			message:     "feat(cli): add --watch",
+			wantType:    "feat",
+			wantScope:   "cli",
+			wantSubject: "add --watch",
+		},
+		{
+			name:         "bang header",
+			message:      "refactor(api)!: drop v1 endpoints",
+			wantType:     "refactor",
+			wantScope:    "api",
+			wantSubject:  "drop v1 endpoints",
+			wantBreaking: true,
+			wantNote:     "drop v1 endpoints",
+		},
+		{
+			name:         "breaking footer continues over lines",
+			message:      "fix: rename flag\n\nThe old name clashed.\n\nBREAKING CHANGE: --out is now --output;\n  scripts must be updated.\nRefs: #42",
+			wantType:     "fix",
+			wantSubject:  "rename flag",
+			wantBreaking: true,
+			wantNote:     "--out is now --output;\nscripts must be updated.",
+			wantFooters:  2,
+		},
+		{
+			name:        "prose after a footer-like line is body",
+			message:     "fix: retry uploads\n\nNote: uploads could hang.\nThey now time out after 30s.",
+			wantType:    "fix",
+			wantSubject: "retry uploads",
+		},
+		{
+			name:        "uppercase type is normalized",
+			message:     "Fix: handle empty tags",
+			wantType:    "fix",
+			wantSubject: "handle empty tags",
+		},
+		{
+			name:        "non-conventional",
+			message:     "Update README",
+			wantSubject: "Update README",
+		},
+		{
+			name:        "colon without space is not a header",
+			message:     "feat:missing space",
+			wantSubject: "feat:missing space",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := ParseCommit("abc1234", tt.message)
+			if c.Type != tt.wantType || c.Scope != tt.wantScope || c.Subject != tt.wantSubject {
+				t.Errorf("got type=%q scope=%q subject=%q", c.Type, c.Scope, c.Subject)
+			}
+			if c.Breaking != tt.wantBreaking || c.BreakingNote != tt.wantNote {
+				t.Errorf("got breaking=%v note=%q", c.Breaking, c.BreakingNote)
+			}
+			if len(c.Footers) != tt.wantFooters {
+				t.Errorf("got %d footers: %+v", len(c.Footers), c.Footers)
+			}
+		})
+	}
+
+	c := ParseCommit("abc1234", "fix: rename flag\n\nThe old name clashed.\n\nBREAKING CHANGE: renamed")
+	if c.Body != "The old name clashed." {
+		t.Errorf("body = %q", c.Body)
+	}
+	c = ParseCommit("abc1234", "fix: retry uploads\n\nNote: uploads could hang.\nThey now time out after 30s.")
+	if c.Body != "Note: uploads could hang.\nThey now time out after 30s." {
+		t.Errorf("body = %q", c.Body)
+	}
+}
+
+func TestDecideBump(t *testing.T) {
+	feat := ParseCommit("1111111aaaa", "feat: add export")
+	revert := ParseCommit("2222222bbbb", "Revert \"feat: add export\"\n\nThis reverts commit 1111111aaaa.")
+	fix := ParseCommit("3333333cccc", "fix: nil deref")
+	docs := ParseCommit("4444444dddd", "docs: typo")
+	deps := ParseCommit("5555555eeee", "chore(deps): bump cobra")
+	chore := ParseCommit("6666666ffff", "chore: tidy")
+	breaking := ParseCommit("7777777aaaa", "feat!: new config format")
+	plain := ParseCommit("8888888bbbb", "Update README")
+
+	tests := []struct {
+		name    string
+		commits []Commit
+		version string
+		rules   BumpRules
+		want    string
+	}{
+		{"feature is minor", []Commit{fix, feat}, "v1.2.0", BumpRules{}, BumpMinor},
+		{"revert cancels the feature", []Commit{revert, fix, feat}, "v1.2.0", BumpRules{}, BumpPatch},
+		{"breaking is major", []Commit{breaking, fix}, "v1.2.0", BumpRules{}, BumpMajor},
+		{"breaking is minor while pre-1.0", []Commit{breaking}, "v0.4.1", BumpRules{PreMajor: true}, BumpMinor},
+		{"pre-1.0 rule stops applying at 1.0", []Commit{breaking}, "v1.0.0", BumpRules{PreMajor: true}, BumpMajor},
+		{"ignored only still patches", []Commit{docs}, "v1.2.0", BumpRules{}, BumpPatch},
+		{"non-conventional is patch", []Commit{plain}, "v1.2.0", BumpRules{}, BumpPatch},
+		{
+			name:    "scoped patch type beats ignored bare type",
+			commits: []Commit{deps, chore},
+			version: "v1.2.0",
+			rules:   BumpRules{IgnoreTypes: []string{"chore"}, PatchTypes: []string{"chore(deps)"}},
+			want:    BumpPatch,
+		},
+		{
+			name:    "configured minor types",
+			commits: []Commit{fix},
+			version: "v1.2.0",
+			rules:   BumpRules{MinorTypes: []string{"feat", "fix"}},
+			want:    BumpMinor,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := DecideBump(tt.commits, tt.version, tt.rules)
+			if d.Bump != tt.want {
+				t.Errorf("bump = %q, want %q (%s)", d.Bump, tt.want, d.Reasoning)
+			}
+		})
+	}
+
+	d := DecideBump([]Commit{revert, deps, chore, fix, feat}, "v1.2.0", BumpRules{IgnoreTypes: []string{"chore"}, PatchTypes: []string{"chore(deps)"}})
+	if len(d.Reverted) != 2 || len(d.Ignored) != 1 || len(d.Others) != 2 {
+		t.Fatalf("decision = %+v", d)
+	}
+	if want := "patch: 1 chore, 1 fix; ignored 1 chore; 2 reverted"; d.Reasoning != want {
+		t.Errorf("reasoning = %q, want %q", d.Reasoning, want)
+	}
+	out := FormatCommits(d)
+	for _, want := range []string{"SEMVER ANALYSIS: patch", "OTHER CHANGES:", "- 5555555 chore(deps): bump cobra", "REVERTED WITHIN THIS RELEASE"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("FormatCommits output lacks %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestLoadCommits(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	dir := t.TempDir()
+	git := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		cmd.Env = append(cmd.Environ(), "GIT_AUTHOR_NAME=t", "GIT_AUTHOR_EMAIL=t@example.com", "GIT_COMMITTER_NAME=t", "GIT_COMMITTER_EMAIL=t@example.com")
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	git("init", "-q")
+	git("commit", "-q", "--allow-empty", "-m", "chore: initial")
+	git("tag", "v0.1.0")
+	git("commit", "-q", "--allow-empty", "-m", "feat(api): add list\n\nBREAKING CHANGE: list replaces ls")
+	git("commit", "-q", "--allow-empty", "-m", "fix: off by one")
+
+	commits, err := LoadCommits(dir, "v0.1.0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(commits) != 2 || commits[0].Type != "fix" || !commits[1].Breaking || len(commits[1].Hash) != 40 {
+		t.Fatalf("commits = %+v", commits)
+	}
+
+	if _, err := LoadCommits(dir, "v9.9.9"); err == nil {
+		t.Fatal("expected an error for an unknown tag instead of a silent default")
+	}
+}
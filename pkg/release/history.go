@@ -0,0 +1,236 @@
+package release
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// Outcomes recorded in the release history.
+const (
+	OutcomeReleased    = "released"     // tag pushed
+	OutcomeFailed      = "failed"       // a stage failed; see Error / FailedOperation
+	OutcomeNotReleased = "not-released" // selected but never reached (an earlier level failed)
+)
+
+// StageTiming is how long one apply stage took for a repository.
+type StageTiming struct {
+	Name    string  `json:"name"`
+	Seconds float64 `json:"seconds"`
+}
+
+// AddStageTiming adds d to the repo's time in stage, keeping stages in the
+// order they first ran.
+func (r *RepoReleasePlan) AddStageTiming(stage string, d time.Duration) {
+	for i := range r.Stages {
+		if r.Stages[i].Name == stage {
+			r.Stages[i].Seconds += d.Seconds()
+			return
+		}
+	}
+	r.Stages = append(r.Stages, StageTiming{Name: stage, Seconds: d.Seconds()})
+}
+
+// HistoryEntry is one `grove release apply` run as recorded in the release
+// history ledger: the plan that was applied and what became of each repo.
+type HistoryEntry struct {
+	ParentVersion         string                  `json:"parent_version"`
+	ParentPreviousVersion string                  `json:"parent_previous_version,omitempty"`
+	Type                  string                  `json:"type,omitempty"` // "full" or "rc"
+	RootDir               string                  `json:"root_dir"`
+	PlannedAt             time.Time               `json:"planned_at"`
+	StartedAt             time.Time               `json:"started_at"`
+	CompletedAt           time.Time               `json:"completed_at"`
+	Outcome               string                  `json:"outcome"` // OutcomeReleased or OutcomeFailed
+	Error                 string                  `json:"error,omitempty"`
+	Repos                 map[string]*HistoryRepo `json:"repos"`
+}
+
+// HistoryRepo is the ledger record of one repository in a release.
+type HistoryRepo struct {
+	PreviousVersion     string        `json:"previous_version"`
+	Version             string        `json:"version"`
+	SuggestedBump       string        `json:"suggested_bump,omitempty"`
+	SelectedBump        string        `json:"selected_bump,omitempty"`
+	SuggestionReasoning string        `json:"suggestion_reasoning,omitempty"`
+	Outcome             string        `json:"outcome"`
+	FailedOperation     string        `json:"failed_operation,omitempty"`
+	HeadBefore          string        `json:"head_before,omitempty"`     // commit checked out before apply touched the repo
+	ReleasedCommit      string        `json:"released_commit,omitempty"` // commit the release tag points at
+	Stages              []StageTiming `json:"stages,omitempty"`
+	GenEstCostUSD       float64       `json:"gen_est_cost_usd,omitempty"`
+	CacheWriteTokens    int64         `json:"cache_write_tokens,omitempty"`
+	CacheReadTokens     int64         `json:"cache_read_tokens,omitempty"`
+}
+
+// NewHistoryEntry starts a ledger entry for applying plan: one HistoryRepo per
+// selected repo, marked OutcomeNotReleased until RecordOutcomes runs.
+func NewHistoryEntry(plan *ReleasePlan, startedAt time.Time) *HistoryEntry {
+	entry := &HistoryEntry{
+		ParentVersion:         plan.ParentVersion,
+		ParentPreviousVersion: plan.ParentCurrentVersion,
+		Type:                  plan.Type,
+		RootDir:               plan.RootDir,
+		PlannedAt:             plan.CreatedAt,
+		StartedAt:             startedAt,
+		Repos:                 make(map[string]*HistoryRepo),
+	}
+	for name, repo := range plan.Repos {
+		if !repo.Selected {
+			continue
+		}
+		entry.Repos[name] = &HistoryRepo{
+			PreviousVersion:     repo.CurrentVersion,
+			Version:             repo.NextVersion,
+			SuggestedBump:       repo.SuggestedBump,
+			SelectedBump:        repo.SelectedBump,
+			SuggestionReasoning: repo.SuggestionReasoning,
+			Outcome:             OutcomeNotReleased,
+			GenEstCostUSD:       repo.GenEstCostUSD,
+			CacheWriteTokens:    repo.CacheWriteTokens,
+			CacheReadTokens:     repo.CacheReadTokens,
+		}
+	}
+	return entry
+}
+
+// RecordOutcomes completes the entry from the plan's progress tracking once
+// apply has finished; applyErr is the error apply failed with, if any.
+func (e *HistoryEntry) RecordOutcomes(plan *ReleasePlan, applyErr error, completedAt time.Time) {
+	e.CompletedAt = completedAt
+	e.Outcome = OutcomeReleased
+	if applyErr != nil {
+		e.Outcome = OutcomeFailed
+		e.Error = applyErr.Error()
+	}
+	for name, repo := range e.Repos {
+		planned, ok := plan.Repos[name]
+		if !ok {
+			continue
+		}
+		repo.Stages = planned.Stages
+		switch {
+		case planned.TagPushed:
+			repo.Outcome = OutcomeReleased
+		case planned.LastFailedOperation != "":
+			repo.Outcome = OutcomeFailed
+			repo.FailedOperation = planned.LastFailedOperation
+		}
+	}
+}
+
+// Duration is the wall-clock time of the apply run.
+func (e *HistoryEntry) Duration() time.Duration {
+	if e.CompletedAt.IsZero() {
+		return 0
+	}
+	return e.CompletedAt.Sub(e.StartedAt)
+}
+
+// GenCostUSD is the estimated LLM cost of generating the release's docs and
+// changelogs.
+func (e *HistoryEntry) GenCostUSD() float64 {
+	var total float64
+	for _, repo := range e.Repos {
+		total += repo.GenEstCostUSD
+	}
+	return total
+}
+
+// RepoNames returns the entry's repositories in name order.
+func (e *HistoryEntry) RepoNames() []string {
+	names := make([]string, 0, len(e.Repos))
+	for name := range e.Repos {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Count returns how many repositories ended with outcome.
+func (e *HistoryEntry) Count(outcome string) int {
+	n := 0
+	for _, repo := range e.Repos {
+		if repo.Outcome == outcome {
+			n++
+		}
+	}
+	return n
+}
+
+func getHistoryPath() string {
+	return filepath.Join(getReleaseStateDir(), "history.jsonl")
+}
+
+// AppendHistory appends entry to the release history ledger, one JSON object
+// per line. Entries are never rewritten; ClearPlan leaves the ledger alone.
+func AppendHistory(entry *HistoryEntry) error {
+	return appendHistoryTo(getHistoryPath(), entry)
+}
+
+// LoadHistory returns every ledger entry, oldest first. A missing ledger is
+// empty.
+func LoadHistory() ([]*HistoryEntry, error) {
+	return loadHistoryFrom(getHistoryPath())
+}
+
+// FindHistory returns the most recent entry for parentVersion, or nil.
+func FindHistory(entries []*HistoryEntry, parentVersion string) *HistoryEntry {
+	for i := len(entries) - 1; i >= 0; i-- {
+		if entries[i].ParentVersion == parentVersion {
+			return entries[i]
+		}
+	}
+	return nil
+}
+
+func appendHistoryTo(path string, entry *HistoryEntry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func loadHistoryFrom(path string) ([]*HistoryEntry, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer f.Close()
+
+	var entries []*HistoryEntry
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	line := 0
+	for scanner.Scan() {
+		line++
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var entry HistoryEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			return nil, fmt.Errorf("%s:%d: %w", path, line, err)
+		}
+		entries = append(entries, &entry)
+	}
+	return entries, scanner.Err()
+}
@@ -0,0 +1,99 @@
+package release
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestHistoryEntryOutcomes(t *testing.T) {
+	plan := &ReleasePlan{
+		ParentVersion: "v0.8.0",
+		Type:          "full",
+		Repos: map[string]*RepoReleasePlan{
+			"core":  {Selected: true, CurrentVersion: "v0.6.3", NextVersion: "v0.7.0", SelectedBump: "minor", GenEstCostUSD: 0.25},
+			"flow":  {Selected: true, CurrentVersion: "v0.4.0", NextVersion: "v0.4.1", SelectedBump: "patch", GenEstCostUSD: 0.5},
+			"nb":    {Selected: true, CurrentVersion: "v1.0.0", NextVersion: "v1.0.1"},
+			"other": {Selected: false, CurrentVersion: "v2.0.0"},
+		},
+	}
+	start := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	entry := NewHistoryEntry(plan, start)
+	if len(entry.Repos) != 3 {
+		t.Fatalf("unselected repos must not be recorded: %v", entry.RepoNames())
+	}
+
+	plan.Repos["core"].TagPushed = true
+	plan.Repos["core"].AddStageTiming("dependencies", 2*time.Second)
+	plan.Repos["core"].AddStageTiming("tag", time.Second)
+	plan.Repos["core"].AddStageTiming("dependencies", time.Second)
+	plan.Repos["flow"].LastFailedOperation = "tag_creation"
+	entry.RecordOutcomes(plan, errors.New("push rejected"), start.Add(90*time.Second))
+
+	if entry.Outcome != OutcomeFailed || entry.Error != "push rejected" {
+		t.Errorf("entry outcome = %q (%q)", entry.Outcome, entry.Error)
+	}
+	want := map[string]string{"core": OutcomeReleased, "flow": OutcomeFailed, "nb": OutcomeNotReleased}
+	for name, outcome := range want {
+		if got := entry.Repos[name].Outcome; got != outcome {
+			t.Errorf("%s outcome = %q, want %q", name, got, outcome)
+		}
+	}
+	if entry.Repos["flow"].FailedOperation != "tag_creation" {
+		t.Errorf("flow failed operation = %q", entry.Repos["flow"].FailedOperation)
+	}
+	stages := entry.Repos["core"].Stages
+	if len(stages) != 2 || stages[0].Name != "dependencies" || stages[0].Seconds != 3 || stages[1].Name != "tag" {
+		t.Errorf("stages = %+v", stages)
+	}
+	if entry.Duration() != 90*time.Second || entry.GenCostUSD() != 0.75 {
+		t.Errorf("duration %v, cost %v", entry.Duration(), entry.GenCostUSD())
+	}
+}
+
+func TestHistoryLedgerAppendOnly(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "release", "history.jsonl")
+	if entries, err := loadHistoryFrom(path); err != nil || len(entries) != 0 {
+		t.Fatalf("missing ledger: %v, %v", entries, err)
+	}
+	for _, version := range []string{"v0.7.0", "v0.8.0", "v0.8.0"} {
+		entry := &HistoryEntry{ParentVersion: version, Outcome: OutcomeReleased, Repos: map[string]*HistoryRepo{}}
+		if version == "v0.8.0" && len(mustLoadHistory(t, path)) == 1 {
+			entry.Outcome = OutcomeFailed // the first attempt at v0.8.0
+		}
+		if err := appendHistoryTo(path, entry); err != nil {
+			t.Fatal(err)
+		}
+	}
+	entries := mustLoadHistory(t, path)
+	if len(entries) != 3 {
+		t.Fatalf("entries = %d", len(entries))
+	}
+	if got := FindHistory(entries, "v0.8.0"); got == nil || got.Outcome != OutcomeReleased {
+		t.Errorf("FindHistory should return the latest attempt: %+v", got)
+	}
+	if FindHistory(entries, "v9.9.9") != nil {
+		t.Error("unknown version found")
+	}
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _ = f.WriteString("{not json\n")
+	_ = f.Close()
+	if _, err := loadHistoryFrom(path); err == nil {
+		t.Error("a corrupt line should be reported")
+	}
+}
+
+func mustLoadHistory(t *testing.T, path string) []*HistoryEntry {
+	t.Helper()
+	entries, err := loadHistoryFrom(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return entries
+}
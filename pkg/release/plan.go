@@ -56,7 +56,8 @@ type RepoReleasePlan struct {
 	CheckStatus      string    `json:"check_status,omitempty"`       // "skipped" when no check command; placeholder for future check integration
 
 	// Release operation tracking
-	LastFailedOperation string `json:"last_failed_operation,omitempty"` // Track which operation failed for better recovery
+	LastFailedOperation string        `json:"last_failed_operation,omitempty"` // Track which operation failed for better recovery
+	Stages              []StageTiming `json:"stages,omitempty"`                // Wall-clock time per apply stage, summed across attempts
 
 	// Git status information
 	Branch              string `json:"branch,omitempty"`
@@ -105,6 +106,19 @@ func LoadPlan() (*ReleasePlan, error) {
 	return &plan, nil
 }
 
+// Clone returns a deep copy of the plan.
+func (p *ReleasePlan) Clone() (*ReleasePlan, error) {
+	data, err := json.Marshal(p)
+	if err != nil {
+		return nil, err
+	}
+	var clone ReleasePlan
+	if err := json.Unmarshal(data, &clone); err != nil {
+		return nil, err
+	}
+	return &clone, nil
+}
+
 // SavePlan marshals and writes the plan to disk. The write is atomic (temp
 // file in the same directory + rename) so a crash mid-write can never leave a
 // truncated plan behind — the plan is the release's checkpoint state, and both
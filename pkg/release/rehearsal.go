@@ -0,0 +1,497 @@
+package release
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/mod/module"
+	"golang.org/x/mod/zip"
+)
+
+// rehearsalManifests are the dependency manifests whose edits a rehearsal
+// reports line by line.
+var rehearsalManifests = []string{"go.mod", "package.json", "Cargo.toml", "pyproject.toml"}
+
+// Rehearsal is a throwaway copy of the repositories in a release plan, used by
+// `grove release apply --rehearse`. Every repo is cloned from a local bare
+// "origin" holding the real origin's branches (as of the checkout's last
+// fetch) and the checkout's tags, so the apply path can commit, tag and push
+// for real without anything leaving the machine, and local commits that were
+// never pushed show up in the report as the push would carry them. Tags of Go modules
+// are served from a file:// module proxy inside the sandbox (see
+// PublishModule and GoEnv), so downstream `go get` resolves the rehearsed
+// versions.
+type Rehearsal struct {
+	Dir string // sandbox root; repo clones live in Dir/<repo>
+
+	remotesDir string
+	proxyDir   string
+	modCache   string
+	goProxy    string // the GOPROXY to fall back to after the sandbox proxy
+
+	repos map[string]*rehearsalRepo
+
+	mu    sync.Mutex
+	notes map[string][]string
+}
+
+type rehearsalRepo struct {
+	source string
+	clone  string
+	bare   string
+	head   string            // commit the clone started at
+	refs   map[string]string // origin refs before the rehearsal
+}
+
+// RehearsalRef is a branch the rehearsal pushed to.
+type RehearsalRef struct {
+	Branch  string
+	Created bool
+	Commits []string // "<short sha> <subject>", oldest first
+}
+
+// RehearsalTag is a tag the rehearsal pushed.
+type RehearsalTag struct {
+	Name   string
+	Commit string // short sha
+}
+
+// RehearsalResult is what the real apply would have done to one repository.
+type RehearsalResult struct {
+	Repo      string
+	Branches  []RehearsalRef
+	Tags      []RehearsalTag
+	Manifests map[string][]string // manifest → changed lines ("-..." / "+...")
+	Notes     []string            // steps that were stubbed out, e.g. CI waits
+}
+
+// Changed reports whether the rehearsal pushed anything for the repository.
+func (r RehearsalResult) Changed() bool {
+	return len(r.Branches) > 0 || len(r.Tags) > 0
+}
+
+// NewRehearsal creates a sandbox under the system temp dir and clones repos
+// (names of directories under rootDir) into it. Each clone is checked out at
+// the checkout's HEAD, and uncommitted changes, untracked files included, are
+// carried over, as the real apply would commit them.
+func NewRehearsal(ctx context.Context, rootDir string, repos []string) (*Rehearsal, error) {
+	dir, err := os.MkdirTemp("", "grove-release-rehearsal-")
+	if err != nil {
+		return nil, err
+	}
+	r := &Rehearsal{
+		Dir:        dir,
+		remotesDir: filepath.Join(dir, ".remotes"),
+		proxyDir:   filepath.Join(dir, ".goproxy"),
+		modCache:   filepath.Join(dir, ".gomodcache"),
+		repos:      make(map[string]*rehearsalRepo),
+		notes:      make(map[string][]string),
+	}
+	r.goProxy = r.fallbackGoProxy(ctx)
+
+	for _, name := range repos {
+		if err := r.addRepo(ctx, name, filepath.Join(rootDir, name)); err != nil {
+			_ = r.Cleanup()
+			return nil, fmt.Errorf("preparing rehearsal clone of %s: %w", name, err)
+		}
+	}
+	return r, nil
+}
+
+func (r *Rehearsal) addRepo(ctx context.Context, name, source string) error {
+	repo := &rehearsalRepo{
+		source: source,
+		clone:  filepath.Join(r.Dir, name),
+		bare:   filepath.Join(r.remotesDir, name+".git"),
+	}
+	if _, err := rehearsalGit(ctx, "", "init", "--quiet", "--bare", repo.bare); err != nil {
+		return err
+	}
+	refspecs, err := originRefspecs(ctx, source)
+	if err != nil {
+		return err
+	}
+	if _, err := rehearsalGit(ctx, repo.bare, append([]string{"fetch", "--quiet", source}, refspecs...)...); err != nil {
+		return err
+	}
+
+	// The clone starts where the checkout is, unpushed commits included
+	branch, err := rehearsalGit(ctx, source, "rev-parse", "--abbrev-ref", "HEAD")
+	if err != nil {
+		return err
+	}
+	if repo.head, err = rehearsalGit(ctx, source, "rev-parse", "HEAD"); err != nil {
+		return err
+	}
+	if _, err := rehearsalGit(ctx, "", "clone", "--quiet", "--no-checkout", "file://"+repo.bare, repo.clone); err != nil {
+		return err
+	}
+	if _, err := rehearsalGit(ctx, repo.clone, "fetch", "--quiet", source, repo.head); err != nil {
+		return err
+	}
+	if branch == "HEAD" {
+		if _, err := rehearsalGit(ctx, repo.clone, "checkout", "--quiet", "--detach", repo.head); err != nil {
+			return err
+		}
+	} else {
+		if _, err := rehearsalGit(ctx, repo.clone, "checkout", "--quiet", "-B", branch, repo.head); err != nil {
+			return err
+		}
+		if _, err := rehearsalGit(ctx, repo.clone, "rev-parse", "--verify", "--quiet", "refs/remotes/origin/"+branch); err == nil {
+			if _, err := rehearsalGit(ctx, repo.clone, "branch", "--quiet", "--set-upstream-to=origin/"+branch); err != nil {
+				return err
+			}
+		}
+	}
+
+	// Carry over uncommitted edits (a written CHANGELOG.md, docgen output)
+	diff, err := exec.CommandContext(ctx, "git", "-C", source, "diff", "HEAD", "--binary").Output()
+	if err != nil {
+		return fmt.Errorf("git diff HEAD: %w", err)
+	}
+	if len(bytes.TrimSpace(diff)) > 0 {
+		apply := exec.CommandContext(ctx, "git", "-C", repo.clone, "apply", "--whitespace=nowarn")
+		apply.Stdin = bytes.NewReader(diff)
+		if output, err := apply.CombinedOutput(); err != nil {
+			return fmt.Errorf("carrying over uncommitted changes: %w (output: %s)", err, output)
+		}
+	}
+	if err := copyUntracked(ctx, source, repo.clone); err != nil {
+		return fmt.Errorf("carrying over untracked files: %w", err)
+	}
+
+	if repo.refs, err = remoteRefs(ctx, repo.bare); err != nil {
+		return err
+	}
+	r.repos[name] = repo
+	return nil
+}
+
+// originRefspecs maps the checkout's remote-tracking branches for origin onto
+// the sandbox origin's branches, along with its tags. A checkout that has no
+// origin falls back to its local branches.
+func originRefspecs(ctx context.Context, source string) ([]string, error) {
+	out, err := rehearsalGit(ctx, source, "for-each-ref", "--format=%(refname)", "refs/remotes/origin/")
+	if err != nil {
+		return nil, err
+	}
+	refspecs := []string{"+refs/tags/*:refs/tags/*"}
+	for _, ref := range strings.Split(out, "\n") {
+		branch := strings.TrimPrefix(ref, "refs/remotes/origin/")
+		if branch == ref || branch == "HEAD" {
+			continue
+		}
+		refspecs = append(refspecs, "+"+ref+":refs/heads/"+branch)
+	}
+	if len(refspecs) == 1 {
+		refspecs = append(refspecs, "+refs/heads/*:refs/heads/*")
+	}
+	return refspecs, nil
+}
+
+// copyUntracked copies the checkout's untracked, non-ignored files (a new
+// CHANGELOG.md) into the clone.
+func copyUntracked(ctx context.Context, source, clone string) error {
+	out, err := exec.CommandContext(ctx, "git", "-C", source, "ls-files", "--others", "--exclude-standard", "-z").Output()
+	if err != nil {
+		return fmt.Errorf("git ls-files: %w", err)
+	}
+	for _, rel := range strings.Split(string(out), "\x00") {
+		if rel == "" {
+			continue
+		}
+		src, dst := filepath.Join(source, rel), filepath.Join(clone, rel)
+		info, err := os.Lstat(src)
+		if err != nil {
+			return err
+		}
+		if err := os.MkdirAll(filepath.Dir(dst), 0o755); err != nil {
+			return err
+		}
+		if info.Mode()&os.ModeSymlink != 0 {
+			target, err := os.Readlink(src)
+			if err != nil {
+				return err
+			}
+			if err := os.Symlink(target, dst); err != nil {
+				return err
+			}
+			continue
+		}
+		data, err := os.ReadFile(src)
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(dst, data, info.Mode().Perm()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RepoDir returns the sandbox clone of repo, or "" if it was not cloned.
+func (r *Rehearsal) RepoDir(repo string) string {
+	if rr, ok := r.repos[repo]; ok {
+		return rr.clone
+	}
+	return ""
+}
+
+// Note records a step that was skipped for repo, such as a CI wait.
+func (r *Rehearsal) Note(repo, note string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.notes[repo] = append(r.notes[repo], note)
+}
+
+// GoEnv returns environment overrides for go commands run during the
+// rehearsal. Module downloads go to a sandbox module cache and consult the
+// sandbox proxy first — also for the ecosystem's private modules, which
+// otherwise bypass proxies — then the developer's module cache and usual
+// proxy. Nothing is written to the real module cache.
+func (r *Rehearsal) GoEnv() []string {
+	return []string{
+		"GOPROXY=" + r.goProxy,
+		"GONOPROXY=none.invalid", // GOPRIVATE still skips the checksum database
+		"GOMODCACHE=" + r.modCache,
+		"GOFLAGS=-modcacherw",
+	}
+}
+
+// fallbackGoProxy builds the GOPROXY list: the sandbox proxy, the developer's
+// download cache, then their configured proxy.
+func (r *Rehearsal) fallbackGoProxy(ctx context.Context) string {
+	proxies := []string{fileURL(r.proxyDir)}
+	out, err := exec.CommandContext(ctx, "go", "env", "GOMODCACHE", "GOPROXY").Output()
+	configured := "https://proxy.golang.org,direct"
+	if err == nil {
+		lines := strings.Split(strings.TrimSpace(string(out)), "\n")
+		if len(lines) > 0 && lines[0] != "" {
+			proxies = append(proxies, fileURL(filepath.Join(lines[0], "cache", "download")))
+		}
+		if len(lines) > 1 && lines[1] != "" && lines[1] != "off" {
+			configured = lines[1]
+		}
+	}
+	return strings.Join(append(proxies, configured), ",")
+}
+
+func fileURL(path string) string {
+	return "file://" + filepath.ToSlash(path)
+}
+
+// PublishModule makes modulePath@version, tagged in repoDir, downloadable
+// from the sandbox proxy. It stands in for the module proxy picking up a
+// freshly pushed tag.
+func (r *Rehearsal) PublishModule(ctx context.Context, modulePath, version, repoDir string) error {
+	escapedPath, err := module.EscapePath(modulePath)
+	if err != nil {
+		return err
+	}
+	escapedVersion, err := module.EscapeVersion(version)
+	if err != nil {
+		return err
+	}
+	dir := filepath.Join(r.proxyDir, filepath.FromSlash(escapedPath), "@v")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	base := filepath.Join(dir, escapedVersion)
+
+	goMod, err := rehearsalGit(ctx, repoDir, "show", version+":go.mod")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(base+".mod", []byte(goMod+"\n"), 0o644); err != nil {
+		return err
+	}
+
+	committed, err := rehearsalGit(ctx, repoDir, "log", "-1", "--format=%cI", version)
+	if err != nil {
+		return err
+	}
+	commitTime, err := time.Parse(time.RFC3339, committed)
+	if err != nil {
+		return err
+	}
+	info, err := json.Marshal(struct {
+		Version string
+		Time    time.Time
+	}{version, commitTime.UTC()})
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(base+".info", info, 0o644); err != nil {
+		return err
+	}
+
+	f, err := os.Create(base + ".zip")
+	if err != nil {
+		return err
+	}
+	if err := zip.CreateFromVCS(f, module.Version{Path: modulePath, Version: version}, repoDir, version, ""); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("zipping %s@%s: %w", modulePath, version, err)
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+
+	list, err := os.OpenFile(filepath.Join(dir, "list"), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintln(list, version); err != nil {
+		_ = list.Close()
+		return err
+	}
+	return list.Close()
+}
+
+// Report compares every local origin with its state before the rehearsal
+// and returns, per repository in name order, the commits, tags and manifest
+// edits the real apply would push.
+func (r *Rehearsal) Report(ctx context.Context) ([]RehearsalResult, error) {
+	names := make([]string, 0, len(r.repos))
+	for name := range r.repos {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var results []RehearsalResult
+	for _, name := range names {
+		repo := r.repos[name]
+		after, err := remoteRefs(ctx, repo.bare)
+		if err != nil {
+			return nil, err
+		}
+		result := RehearsalResult{Repo: name, Manifests: make(map[string][]string)}
+		r.mu.Lock()
+		result.Notes = append(result.Notes, r.notes[name]...)
+		r.mu.Unlock()
+
+		// Everything origin already had is excluded from the new commits
+		var known []string
+		for ref, sha := range repo.refs {
+			if strings.HasPrefix(ref, "refs/heads/") {
+				known = append(known, "^"+sha)
+			}
+		}
+		sort.Strings(known)
+
+		refs := make([]string, 0, len(after))
+		for ref := range after {
+			refs = append(refs, ref)
+		}
+		sort.Strings(refs)
+		for _, ref := range refs {
+			sha := after[ref]
+			before, existed := repo.refs[ref]
+			if existed && before == sha {
+				continue
+			}
+			switch {
+			case strings.HasPrefix(ref, "refs/heads/"):
+				args := append([]string{"log", "--reverse", "--format=%h %s", sha}, known...)
+				log, err := rehearsalGit(ctx, repo.bare, args...)
+				if err != nil {
+					return nil, err
+				}
+				update := RehearsalRef{Branch: strings.TrimPrefix(ref, "refs/heads/"), Created: !existed}
+				if log != "" {
+					update.Commits = strings.Split(log, "\n")
+				}
+				result.Branches = append(result.Branches, update)
+
+				base := before
+				if !existed {
+					base = repo.head
+				}
+				if err := collectManifestEdits(ctx, repo.bare, base, sha, result.Manifests); err != nil {
+					return nil, err
+				}
+			case strings.HasPrefix(ref, "refs/tags/"):
+				commit, err := rehearsalGit(ctx, repo.bare, "rev-parse", "--short", sha+"^{commit}")
+				if err != nil {
+					return nil, err
+				}
+				result.Tags = append(result.Tags, RehearsalTag{Name: strings.TrimPrefix(ref, "refs/tags/"), Commit: commit})
+			}
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// collectManifestEdits adds the changed lines of each manifest between base
+// and head to edits.
+func collectManifestEdits(ctx context.Context, gitDir, base, head string, edits map[string][]string) error {
+	args := append([]string{"diff", "--unified=0", "--no-color", base, head, "--"}, rehearsalManifests...)
+	args = append(args, prefixEach("*/", rehearsalManifests)...)
+	diff, err := rehearsalGit(ctx, gitDir, args...)
+	if err != nil {
+		return err
+	}
+	var file string
+	for _, line := range strings.Split(diff, "\n") {
+		switch {
+		case strings.HasPrefix(line, "+++ "):
+			file = strings.TrimPrefix(strings.TrimPrefix(line, "+++ "), "b/")
+		case strings.HasPrefix(line, "--- "), strings.HasPrefix(line, "diff "), strings.HasPrefix(line, "index "), strings.HasPrefix(line, "@@"):
+		case file != "" && (strings.HasPrefix(line, "+") || strings.HasPrefix(line, "-")):
+			if strings.TrimSpace(line[1:]) != "" {
+				edits[file] = append(edits[file], line)
+			}
+		}
+	}
+	return nil
+}
+
+func prefixEach(prefix string, list []string) []string {
+	out := make([]string, len(list))
+	for i, s := range list {
+		out[i] = prefix + s
+	}
+	return out
+}
+
+// Cleanup removes the sandbox.
+func (r *Rehearsal) Cleanup() error {
+	return os.RemoveAll(r.Dir)
+}
+
+// remoteRefs returns the branches and tags of a bare repository.
+func remoteRefs(ctx context.Context, gitDir string) (map[string]string, error) {
+	out, err := rehearsalGit(ctx, gitDir, "for-each-ref", "--format=%(refname) %(objectname)", "refs/heads", "refs/tags")
+	if err != nil {
+		return nil, err
+	}
+	refs := make(map[string]string)
+	for _, line := range strings.Split(out, "\n") {
+		if ref, sha, ok := strings.Cut(line, " "); ok {
+			refs[ref] = sha
+		}
+	}
+	return refs, nil
+}
+
+func rehearsalGit(ctx context.Context, dir string, args ...string) (string, error) {
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = dir
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("git %s: %w (%s)", strings.Join(args, " "), err, strings.TrimSpace(stderr.String()))
+	}
+	return strings.TrimSpace(string(out)), nil
+}
@@ -0,0 +1,162 @@
+package release
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// rehearsalGitEnv skips t without git and returns helpers that run git in a
+// directory and write a file.
+func rehearsalGitEnv(t *testing.T) (git func(dir string, args ...string), write func(path, content string)) {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	for _, kv := range []string{"GIT_AUTHOR_NAME=t", "GIT_AUTHOR_EMAIL=t@example.com", "GIT_COMMITTER_NAME=t", "GIT_COMMITTER_EMAIL=t@example.com"} {
+		k, v, _ := strings.Cut(kv, "=")
+		t.Setenv(k, v)
+	}
+	git = func(dir string, args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	write = func(path, content string) {
+		t.Helper()
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return git, write
+}
+
+func TestRehearsalReport(t *testing.T) {
+	git, write := rehearsalGitEnv(t)
+
+	root := t.TempDir()
+	source := filepath.Join(root, "kit")
+	if err := os.MkdirAll(source, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	git(source, "init", "-q", "-b", "main")
+	write(filepath.Join(source, "go.mod"), "module example.com/kit\n\ngo 1.24\n\nrequire example.com/base v1.0.0\n")
+	write(filepath.Join(source, "kit.go"), "package kit\n")
+	write(filepath.Join(source, "CHANGELOG.md"), "# Changelog\n")
+	git(source, "add", ".")
+	git(source, "commit", "-q", "-m", "feat: initial")
+	git(source, "tag", "v1.0.0")
+	write(filepath.Join(source, "CHANGELOG.md"), "# Changelog\n\n## v1.1.0\n")
+
+	ctx := context.Background()
+	r, err := NewRehearsal(ctx, root, []string{"kit"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = r.Cleanup() }()
+
+	clone := r.RepoDir("kit")
+	if data, _ := os.ReadFile(filepath.Join(clone, "CHANGELOG.md")); !strings.Contains(string(data), "v1.1.0") {
+		t.Fatalf("uncommitted changelog not carried over: %q", data)
+	}
+
+	// What apply does: bump a dependency, commit, tag and push
+	write(filepath.Join(clone, "go.mod"), "module example.com/kit\n\ngo 1.24\n\nrequire example.com/base v1.2.0\n")
+	git(clone, "commit", "-q", "-am", "chore(deps): update Grove dependencies to latest versions")
+	git(clone, "tag", "-a", "v1.1.0", "-m", "Release v1.1.0")
+	git(clone, "push", "-q", "origin", "HEAD:main")
+	git(clone, "push", "-q", "origin", "v1.1.0")
+	r.Note("kit", "CI wait skipped")
+
+	if err := r.PublishModule(ctx, "example.com/kit", "v1.1.0", clone); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"v1.1.0.info", "v1.1.0.mod", "v1.1.0.zip", "list"} {
+		if _, err := os.Stat(filepath.Join(r.proxyDir, "example.com", "kit", "@v", name)); err != nil {
+			t.Errorf("proxy is missing %s: %v", name, err)
+		}
+	}
+
+	results, err := r.Report(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 1 {
+		t.Fatalf("results = %+v", results)
+	}
+	got := results[0]
+	if len(got.Branches) != 1 || got.Branches[0].Branch != "main" || got.Branches[0].Created ||
+		len(got.Branches[0].Commits) != 1 || !strings.HasSuffix(got.Branches[0].Commits[0], " chore(deps): update Grove dependencies to latest versions") {
+		t.Errorf("branches = %+v", got.Branches)
+	}
+	if len(got.Tags) != 1 || got.Tags[0].Name != "v1.1.0" {
+		t.Errorf("tags = %+v", got.Tags)
+	}
+	wantEdits := map[string][]string{"go.mod": {"-require example.com/base v1.0.0", "+require example.com/base v1.2.0"}}
+	if !reflect.DeepEqual(got.Manifests, wantEdits) {
+		t.Errorf("manifest edits = %v, want %v", got.Manifests, wantEdits)
+	}
+	if !reflect.DeepEqual(got.Notes, []string{"CI wait skipped"}) {
+		t.Errorf("notes = %v", got.Notes)
+	}
+
+	// The real checkout is untouched
+	if out, _ := exec.Command("git", "-C", source, "tag", "-l", "v1.1.0").Output(); len(out) != 0 {
+		t.Error("rehearsal tag leaked into the source repository")
+	}
+}
+
+// TestRehearsalSeedsFromRealOrigin: the sandbox origin holds what the real
+// origin has, so an unpushed local commit is reported as part of the push,
+// and an untracked new file reaches the clone.
+func TestRehearsalSeedsFromRealOrigin(t *testing.T) {
+	git, write := rehearsalGitEnv(t)
+	root := t.TempDir()
+	upstream := filepath.Join(t.TempDir(), "kit.git")
+	git(root, "init", "-q", "--bare", upstream)
+	source := filepath.Join(root, "kit")
+	git(root, "clone", "-q", upstream, source)
+	git(source, "checkout", "-q", "-b", "main")
+	write(filepath.Join(source, "kit.go"), "package kit\n")
+	git(source, "add", ".")
+	git(source, "commit", "-q", "-m", "feat: initial")
+	git(source, "push", "-q", "origin", "main")
+	write(filepath.Join(source, "extra.go"), "package kit\n\nconst Extra = 1\n")
+	git(source, "add", ".")
+	git(source, "commit", "-q", "-m", "feat: not pushed yet")
+	write(filepath.Join(source, "CHANGELOG.md"), "# Changelog\n\n## v1.1.0\n")
+
+	ctx := context.Background()
+	r, err := NewRehearsal(ctx, root, []string{"kit"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = r.Cleanup() }()
+
+	clone := r.RepoDir("kit")
+	if data, err := os.ReadFile(filepath.Join(clone, "CHANGELOG.md")); err != nil || !strings.Contains(string(data), "v1.1.0") {
+		t.Fatalf("untracked changelog not carried over: %q, %v", data, err)
+	}
+	git(clone, "add", "CHANGELOG.md")
+	git(clone, "commit", "-q", "-m", "docs(changelog): v1.1.0")
+	git(clone, "push", "-q", "origin", "HEAD:main")
+
+	results, err := r.Report(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 1 || len(results[0].Branches) != 1 {
+		t.Fatalf("results = %+v", results)
+	}
+	commits := results[0].Branches[0].Commits
+	if len(commits) != 2 || !strings.HasSuffix(commits[0], " feat: not pushed yet") || !strings.HasSuffix(commits[1], " docs(changelog): v1.1.0") {
+		t.Errorf("pushed commits = %q, want the unpushed commit and the changelog", commits)
+	}
+}
@@ -123,9 +123,9 @@ func gitTagVisibleOnRemote(ctx context.Context, repoDir, version string) error {
 func checkModuleAvailable(ctx context.Context, modulePath, version string) error {
 	cmd := exec.CommandContext(ctx, "go", "list", "-m", fmt.Sprintf("%s@%s", modulePath, version)) //nolint:gosec // G204: args are not user-controlled
 
-	// Set up environment for private modules
+	// Fetch the module straight from its origin, whatever its namespace
 	cmd.Env = append(os.Environ(),
-		"GOPRIVATE=github.com/grovetools/*",
+		"GOPRIVATE="+modulePath,
 		"GOPROXY=direct",
 		"GOWORK=off",
 	)
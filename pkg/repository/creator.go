@@ -1,6 +1,7 @@
 package repository
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"os/exec"
@@ -14,6 +15,7 @@ import (
 	"github.com/grovetools/core/util/delegation"
 	"github.com/sirupsen/logrus"
 
+	"github.com/grovetools/grove/pkg/forge"
 	"github.com/grovetools/grove/pkg/gh"
 	"github.com/grovetools/grove/pkg/templates"
 )
@@ -36,10 +38,13 @@ type CreateOptions struct {
 	Public       bool
 }
 
-// GitHubInitOptions contains options for initializing GitHub integration
+// GitHubInitOptions contains options for initializing forge integration
+// (GitHub, GitLab or Gitea)
 type GitHubInitOptions struct {
 	Visibility string // "public" or "private" (default: "private")
 	DryRun     bool
+	Host       string // forge host (default: "github.com")
+	Owner      string // user, org or group to create the repository under (default: "grovetools")
 }
 
 type creationState struct {
@@ -270,14 +275,22 @@ func (c *Creator) CreateLocal(opts CreateOptions) (string, error) {
 	return targetPath, nil
 }
 
-// InitializeGitHub adds GitHub integration to an existing local Grove repository.
-// This should be called from within the repository directory (where the grove
-// manifest lives).
+// InitializeGitHub adds forge integration to an existing local Grove
+// repository: it creates the repository on the forge serving opts.Host
+// (GitHub unless configured otherwise), sets secrets, pushes, and watches the
+// release build. This should be called from within the repository directory
+// (where the grove manifest lives).
 func (c *Creator) InitializeGitHub(opts GitHubInitOptions) error {
-	// Set default visibility
+	// Set defaults
 	if opts.Visibility == "" {
 		opts.Visibility = "private"
 	}
+	if opts.Host == "" {
+		opts.Host = "github.com"
+	}
+	if opts.Owner == "" {
+		opts.Owner = "grovetools"
+	}
 
 	// Get current directory (must be the repo root)
 	cwd, err := os.Getwd()
@@ -301,49 +314,55 @@ func (c *Creator) InitializeGitHub(opts GitHubInitOptions) error {
 		return fmt.Errorf("repository already has a remote 'origin' configured\n\nTo change the remote, use 'git remote remove origin' first")
 	}
 
-	// Validate GitHub prerequisites
-	if err := c.validateGitHubPrereqs(repoName, opts.Visibility == "public"); err != nil {
+	// Resolve the forge serving the host
+	f, err := forge.New(opts.Host, forge.LoadConfig(cwd))
+	if err != nil {
+		return err
+	}
+	repo := forge.Repo{Host: opts.Host, Owner: opts.Owner, Name: repoName}
+
+	// Validate forge prerequisites
+	if err := c.validateGitHubPrereqs(f, repo, opts.Visibility == "public"); err != nil {
 		return err
 	}
 
 	// Handle dry-run mode
 	if opts.DryRun {
-		return c.dryRunGitHubInit(repoName, opts)
+		return c.dryRunGitHubInit(f, repo, opts)
 	}
 
-	// Create GitHub repository
-	c.logger.Infof("Creating GitHub repository grovetools/%s...", repoName)
-	if err := c.createGitHubRepoFromCwd(repoName, opts.Visibility == "public"); err != nil {
+	// Create the forge repository
+	c.logger.Infof("Creating %s repository %s...", f.Kind(), repo)
+	if err := c.createGitHubRepoFromCwd(f, repo, opts.Visibility == "public"); err != nil {
 		return err
 	}
 
 	// Set up secrets (only for private repos)
 	if opts.Visibility != "public" {
-		if err := c.setupSecretsFromCwd(repoName); err != nil {
+		if err := c.setupSecretsFromCwd(f, repo); err != nil {
 			c.logger.Warnf("Failed to set up secrets: %v", err)
 			c.logger.Warn("You may need to manually set up GROVE_PAT secret")
 		}
 	}
 
 	// Push code and tags
-	c.logger.Info("Pushing code to GitHub...")
+	c.logger.Infof("Pushing code to %s...", repo.Host)
 	if err := c.pushToGitHubFromCwd(); err != nil {
 		return err
 	}
 
-	// Wait for CI if .github directory exists
-	githubDir := filepath.Join(cwd, ".github")
-	if _, err := os.Stat(githubDir); err == nil {
+	// Wait for CI if the repository defines any for this forge
+	if f.CIConfigured(cwd) {
 		c.logger.Info("Waiting for CI to complete...")
-		if err := c.waitForCIFromCwd(repoName); err != nil {
+		if err := c.waitForCIFromCwd(f, repo); err != nil {
 			c.logger.Warnf("Could not monitor CI build: %v", err)
-			c.logger.Infof("Check CI status at: https://github.com/grovetools/%s/actions", repoName)
+			c.logger.Infof("Check CI status at: %s", repo.WebURL())
 		}
 	}
 
 	// Show success summary
-	fmt.Println("\n GitHub integration complete!")
-	fmt.Printf("Repository URL: https://github.com/grovetools/%s\n", repoName)
+	fmt.Printf("\n %s integration complete!\n", f.Kind())
+	fmt.Printf("Repository URL: %s\n", repo.WebURL())
 
 	return nil
 }
@@ -382,28 +401,33 @@ func (c *Creator) validateLocal(opts CreateOptions) error {
 	return nil
 }
 
-// validateGitHubPrereqs validates GitHub prerequisites for InitializeGitHub
-func (c *Creator) validateGitHubPrereqs(repoName string, isPublic bool) error {
-	// Check for gh CLI
-	if _, err := exec.LookPath("gh"); err != nil {
-		return fmt.Errorf("GitHub CLI (gh) is not installed or not in PATH")
-	}
+// validateGitHubPrereqs validates forge prerequisites for InitializeGitHub
+func (c *Creator) validateGitHubPrereqs(f forge.Forge, repo forge.Repo, isPublic bool) error {
+	if f.Kind() == forge.KindGitHub {
+		// Check for gh CLI
+		if _, err := exec.LookPath("gh"); err != nil {
+			return fmt.Errorf("GitHub CLI (gh) is not installed or not in PATH")
+		}
 
-	// Check GitHub authentication
-	cmd := exec.Command("gh", "auth", "status")
-	if err := cmd.Run(); err != nil {
-		return fmt.Errorf("not authenticated with GitHub. Run 'gh auth login' first")
+		// Check GitHub authentication
+		cmd := exec.Command("gh", "auth", "status")
+		if err := cmd.Run(); err != nil {
+			return fmt.Errorf("not authenticated with GitHub. Run 'gh auth login' first")
+		}
 	}
 
-	// Check if repo already exists on GitHub
-	checkCmd := exec.Command("gh", "repo", "view", fmt.Sprintf("grovetools/%s", repoName)) //nolint:gosec // repoName from trusted input
-	if err := checkCmd.Run(); err == nil {
-		return fmt.Errorf("repository grovetools/%s already exists on GitHub", repoName)
+	// Check if repo already exists on the forge
+	exists, err := f.RepoExists(context.Background(), repo)
+	if err != nil {
+		return fmt.Errorf("failed to check for %s on %s: %w", repo.Slug(), repo.Host, err)
+	}
+	if exists {
+		return fmt.Errorf("repository %s already exists on %s", repo.Slug(), repo.Host)
 	}
 
 	// Check for GROVE_PAT (only for private repos)
 	if !isPublic && os.Getenv("GROVE_PAT") == "" {
-		return fmt.Errorf("GROVE_PAT environment variable not set. This is required for setting up GitHub Actions secrets for private repositories.\n\nOptions:\n  1. Set GROVE_PAT and try again\n  2. Use --visibility=public for a public repository")
+		return fmt.Errorf("GROVE_PAT environment variable not set. This is required for setting up CI secrets for private repositories.\n\nOptions:\n  1. Set GROVE_PAT and try again\n  2. Use --visibility=public for a public repository")
 	}
 
 	return nil
@@ -480,55 +504,59 @@ func (c *Creator) dryRunLocal(opts CreateOptions) error {
 	return nil
 }
 
-// dryRunGitHubInit shows what would be done for GitHub initialization
-func (c *Creator) dryRunGitHubInit(repoName string, opts GitHubInitOptions) error {
+// dryRunGitHubInit shows what would be done for forge initialization
+func (c *Creator) dryRunGitHubInit(f forge.Forge, repo forge.Repo, opts GitHubInitOptions) error {
 	c.logger.Info("DRY RUN MODE - No changes will be made")
 
-	c.logger.Infof("\nWould initialize GitHub for repository: %s", repoName)
+	c.logger.Infof("\nWould initialize %s for repository: %s", f.Kind(), repo)
 	c.logger.Infof("Visibility: %s", opts.Visibility)
 
-	c.logger.Info("\nCommands that would be executed:")
-	if opts.Visibility == "public" {
-		c.logger.Infof("  gh repo create grovetools/%s --public --source=. --remote=origin", repoName)
-	} else {
-		c.logger.Infof("  gh repo create grovetools/%s --private --source=. --remote=origin", repoName)
-		c.logger.Info("  gh secret set GROVE_PAT --body <GROVE_PAT>")
+	if f.Kind() == forge.KindGitHub {
+		c.logger.Info("\nCommands that would be executed:")
+		c.logger.Infof("  gh repo create %s --%s", repo.Slug(), opts.Visibility)
+		c.logger.Infof("  git remote add origin %s.git", repo.WebURL())
+		if opts.Visibility != "public" {
+			c.logger.Info("  gh secret set GROVE_PAT --body <GROVE_PAT>")
+		}
+		c.logger.Info("  git push -u origin main")
+		c.logger.Info("  git push origin --tags")
+		c.logger.Info("  gh run watch (if .github workflows exist)")
+		return nil
+	}
+
+	c.logger.Infof("\nOperations that would be performed against %s:", repo.Host)
+	c.logger.Infof("  create repository %s (%s)", repo.Slug(), opts.Visibility)
+	c.logger.Info("  git remote add origin <clone URL>")
+	if opts.Visibility != "public" {
+		c.logger.Info("  set CI secret GROVE_PAT")
 	}
 	c.logger.Info("  git push -u origin main")
 	c.logger.Info("  git push origin --tags")
-	c.logger.Info("  gh run watch (if .github workflows exist)")
+	c.logger.Info("  wait for the release pipeline (if CI is configured)")
 
 	return nil
 }
 
-// createGitHubRepoFromCwd creates GitHub repo when running from the repo directory
-func (c *Creator) createGitHubRepoFromCwd(repoName string, isPublic bool) error {
-	createArgs := []string{"repo", "create", fmt.Sprintf("grovetools/%s", repoName)}
-	if isPublic {
-		createArgs = append(createArgs, "--public")
-	} else {
-		createArgs = append(createArgs, "--private")
-	}
-	createArgs = append(createArgs, "--source=.", "--remote=origin")
-	createCmd := exec.Command("gh", createArgs...)
-
-	if output, err := createCmd.CombinedOutput(); err != nil {
-		return fmt.Errorf("failed to create GitHub repository: %w\nOutput: %s", err, string(output))
+// createGitHubRepoFromCwd creates the forge repo when running from the repo
+// directory and adds it as origin
+func (c *Creator) createGitHubRepoFromCwd(f forge.Forge, repo forge.Repo, isPublic bool) error {
+	cloneURL, err := f.CreateRepo(context.Background(), repo, forge.CreateRepoOptions{Public: isPublic})
+	if err != nil {
+		return fmt.Errorf("failed to create %s repository: %w", f.Kind(), err)
 	}
 
-	// Ensure the remote URL is clean
-	setUrlCmd := exec.Command("git", "remote", "set-url", "origin", //nolint:gosec // repoName from trusted input
-		fmt.Sprintf("https://github.com/grovetools/%s.git", repoName))
-	if err := setUrlCmd.Run(); err != nil {
-		c.logger.Warnf("Failed to set clean remote URL: %v", err)
+	// Use the plain HTTPS URL (no embedded tokens) as origin
+	addRemoteCmd := exec.Command("git", "remote", "add", "origin", cloneURL) //nolint:gosec // URL from the forge API
+	if output, err := addRemoteCmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to add origin remote: %w\nOutput: %s", err, string(output))
 	}
 
-	c.logger.Info(" GitHub repository created")
+	c.logger.Infof(" %s repository created", f.Kind())
 	return nil
 }
 
-// setupSecretsFromCwd sets up secrets when running from the repo directory
-func (c *Creator) setupSecretsFromCwd(repoName string) error {
+// setupSecretsFromCwd sets up secrets for the repo created from the repo directory
+func (c *Creator) setupSecretsFromCwd(f forge.Forge, repo forge.Repo) error {
 	c.logger.Info("Setting up repository secrets...")
 
 	grovePAT := os.Getenv("GROVE_PAT")
@@ -536,8 +564,7 @@ func (c *Creator) setupSecretsFromCwd(repoName string) error {
 		return fmt.Errorf("GROVE_PAT not set")
 	}
 
-	secretCmd := exec.Command("gh", "secret", "set", "GROVE_PAT", "--body", grovePAT)
-	if err := secretCmd.Run(); err != nil {
+	if err := f.SetSecret(context.Background(), repo, "GROVE_PAT", grovePAT); err != nil {
 		return fmt.Errorf("failed to set GROVE_PAT secret: %w", err)
 	}
 
@@ -559,41 +586,21 @@ func (c *Creator) pushToGitHubFromCwd() error {
 		c.logger.Warnf("Failed to push tags: %v\nOutput: %s", err, string(output))
 	}
 
-	c.logger.Info(" Code pushed to GitHub")
+	c.logger.Info(" Code pushed to origin")
 	return nil
 }
 
-// waitForCIFromCwd waits for CI when running from the repo directory
-func (c *Creator) waitForCIFromCwd(repoName string) error {
-	// Wait a moment for the workflow to start
-	time.Sleep(2 * time.Second)
-
-	// Get the workflow run ID
-	cmd := exec.Command("gh", "run", "list", //nolint:gosec // args from trusted config
-		"--repo", fmt.Sprintf("grovetools/%s", repoName),
-		"--workflow", "release.yml",
-		"--limit", "1",
-		"--json", "databaseId",
-		"--jq", ".[0].databaseId")
-
-	output, err := cmd.Output()
+// waitForCIFromCwd waits for the release build of the latest tag when
+// running from the repo directory
+func (c *Creator) waitForCIFromCwd(f forge.Forge, repo forge.Repo) error {
+	tagOutput, err := exec.Command("git", "describe", "--tags", "--abbrev=0").Output()
 	if err != nil {
-		return fmt.Errorf("failed to get workflow run: %w", err)
-	}
-
-	runID := strings.TrimSpace(string(output))
-	if runID == "" {
-		c.logger.Warn("No release workflow found yet, it may still be starting...")
+		c.logger.Info("No tags pushed, no release build to watch")
 		return nil
 	}
+	tag := strings.TrimSpace(string(tagOutput))
 
-	// Watch the workflow
-	watchCmd := exec.Command("gh", "run", "watch", runID,
-		"--repo", fmt.Sprintf("grovetools/%s", repoName))
-	watchCmd.Stdout = os.Stdout
-	watchCmd.Stderr = os.Stderr
-
-	if err := watchCmd.Run(); err != nil {
+	if err := f.WaitForRelease(context.Background(), repo, tag); err != nil {
 		return fmt.Errorf("release build failed: %w", err)
 	}
 
@@ -9,6 +9,7 @@ import (
 	"os/exec"
 	"path/filepath"
 	"strings"
+	"sync"
 
 	"github.com/sirupsen/logrus"
 )
@@ -151,7 +152,9 @@ func RunScript(opts Options, script string) error {
 	return Run(scriptOpts)
 }
 
-// StreamOutput streams command output with workspace prefixes
+// StreamOutput streams command output with workspace prefixes. Both streams
+// are drained before cmd.Wait, which closes the pipes — waiting first could
+// drop the command's last lines.
 func StreamOutput(cmd *exec.Cmd, prefix string, output io.Writer) error {
 	stdout, err := cmd.StdoutPipe()
 	if err != nil {
@@ -167,16 +170,28 @@ func StreamOutput(cmd *exec.Cmd, prefix string, output io.Writer) error {
 		return err
 	}
 
-	// Stream stdout
-	go prefixLines(stdout, prefix, output)
-	go prefixLines(stderr, prefix, output)
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() { defer wg.Done(); prefixLines(stdout, prefix, output) }()
+	go func() { defer wg.Done(); prefixLines(stderr, prefix, output) }()
+	wg.Wait()
 
 	return cmd.Wait()
 }
 
+// prefixLines copies input to output line by line, each prefixed with
+// "[prefix] ". It reads until EOF whatever the line length (a bufio.Scanner
+// would stop at its 64 KiB token limit and leave the pipe unread, blocking
+// the writing process and with it cmd.Wait).
 func prefixLines(input io.Reader, prefix string, output io.Writer) {
-	scanner := bufio.NewScanner(input)
-	for scanner.Scan() {
-		fmt.Fprintf(output, "[%s] %s\n", prefix, scanner.Text())
+	reader := bufio.NewReader(input)
+	for {
+		line, err := reader.ReadString('\n')
+		if line != "" {
+			fmt.Fprintf(output, "[%s] %s\n", prefix, strings.TrimSuffix(strings.TrimSuffix(line, "\n"), "\r"))
+		}
+		if err != nil {
+			return
+		}
 	}
 }
@@ -0,0 +1,66 @@
+package runner
+
+import (
+	"bytes"
+	"os/exec"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// lockedBuffer serializes the two stream readers' writes.
+type lockedBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *lockedBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+// TestStreamOutputDrainsBeforeWait: every line of both streams is prefixed
+// and delivered before StreamOutput returns, including the last ones.
+func TestStreamOutputDrainsBeforeWait(t *testing.T) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("no sh")
+	}
+	var out lockedBuffer
+	cmd := exec.Command("sh", "-c", "i=0; while [ $i -lt 200 ]; do echo out$i; i=$((i+1)); done; echo err >&2; echo last")
+	if err := StreamOutput(cmd, "ws", &out); err != nil {
+		t.Fatal(err)
+	}
+	got := out.buf.String()
+	for _, want := range []string{"[ws] out0\n", "[ws] out199\n", "[ws] err\n", "[ws] last\n"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("output lacks %q", want)
+		}
+	}
+	if n := strings.Count(got, "\n"); n != 202 {
+		t.Errorf("got %d lines, want 202", n)
+	}
+}
+
+// TestStreamOutputLongLine: a line past bufio.Scanner's 64 KiB limit (minified
+// JSON, a long log line) is delivered whole, and the output after it too,
+// instead of the reader stopping and the command blocking on a full pipe.
+func TestStreamOutputLongLine(t *testing.T) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("no sh")
+	}
+	var out lockedBuffer
+	cmd := exec.Command("sh", "-c", "head -c 200000 /dev/zero | tr '\\0' x; echo; head -c 1000000 /dev/zero | tr '\\0' y; echo; echo after")
+	if err := StreamOutput(cmd, "ws", &out); err != nil {
+		t.Fatal(err)
+	}
+	got := out.buf.String()
+	for _, want := range []string{"[ws] " + strings.Repeat("x", 200000) + "\n", "[ws] after\n"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("output lacks a %d-byte line", len(want))
+		}
+	}
+	if n := strings.Count(got, "\n"); n != 3 {
+		t.Errorf("got %d lines, want 3", n)
+	}
+}
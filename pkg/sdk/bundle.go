@@ -0,0 +1,436 @@
+package sdk
+
+import (
+	"archive/tar"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/grovetools/core/pkg/paths"
+)
+
+const (
+	// BundleManifestName is the first member of every install bundle.
+	BundleManifestName = "manifest.json"
+	// bundleFormat is bumped when the bundle layout changes incompatibly.
+	bundleFormat = 1
+	// maxBundleSidecar caps the checksums/signature members read into memory.
+	maxBundleSidecar = 1 << 20
+)
+
+// bundleVersionPattern is the release tag shape a bundle entry may carry. The
+// version names a directory under versions/, so anything else is refused.
+var bundleVersionPattern = regexp.MustCompile(`^v?\d+\.\d+\.\d+(?:[-+][0-9A-Za-z.+-]+)?$`)
+
+// Platform is an os/arch pair release binaries are published for.
+type Platform struct {
+	OS   string
+	Arch string
+}
+
+// CurrentPlatform returns the platform grove is running on.
+func CurrentPlatform() Platform {
+	return Platform{OS: runtime.GOOS, Arch: runtime.GOARCH}
+}
+
+// ParsePlatform parses "os/arch", e.g. "linux/arm64".
+func ParsePlatform(s string) (Platform, error) {
+	osName, arch, ok := strings.Cut(strings.TrimSpace(s), "/")
+	if !ok || osName == "" || arch == "" || strings.Contains(arch, "/") {
+		return Platform{}, fmt.Errorf("invalid platform %q (want os/arch, e.g. linux/amd64)", s)
+	}
+	return Platform{OS: osName, Arch: arch}, nil
+}
+
+func (p Platform) String() string { return p.OS + "/" + p.Arch }
+
+// BundleManifest describes the contents of an offline install bundle.
+type BundleManifest struct {
+	Format  int           `json:"format"`
+	Created time.Time     `json:"created"`
+	Tools   []BundleEntry `json:"tools"`
+}
+
+// BundleEntry is one release binary packed in a bundle.
+type BundleEntry struct {
+	Repo    string `json:"repo"`
+	Version string `json:"version"`
+	OS      string `json:"os"`
+	Arch    string `json:"arch"`
+	// Path is the binary's member name inside the bundle.
+	Path   string `json:"path"`
+	SHA256 string `json:"sha256"`
+	// Checksums and Signature are the member names of the release's
+	// checksums.txt and its minisign signature, empty when the release has
+	// none. They let an offline install apply the same verification as an
+	// online one.
+	Checksums string `json:"checksums,omitempty"`
+	Signature string `json:"signature,omitempty"`
+}
+
+// sidecars returns the distinct checksums and signature members the bundle
+// references.
+func (bm *BundleManifest) sidecars() []string {
+	seen := make(map[string]bool)
+	var out []string
+	for _, e := range bm.Tools {
+		for _, name := range []string{e.Checksums, e.Signature} {
+			if name != "" && !seen[name] {
+				seen[name] = true
+				out = append(out, name)
+			}
+		}
+	}
+	return out
+}
+
+// Platforms returns the distinct platforms the bundle carries binaries for.
+func (bm *BundleManifest) Platforms() []string {
+	seen := make(map[string]bool)
+	var out []string
+	for _, e := range bm.Tools {
+		p := Platform{OS: e.OS, Arch: e.Arch}.String()
+		if !seen[p] {
+			seen[p] = true
+			out = append(out, p)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+// resolveVersionTag turns "latest"/"nightly" into a concrete release tag.
+func (m *Manager) resolveVersionTag(repoName, version string) (string, error) {
+	switch version {
+	case "", "latest":
+		return m.GetLatestVersionTag(repoName)
+	case "nightly":
+		return m.GetLatestPrereleaseVersionTag(repoName)
+	case "source":
+		return "", fmt.Errorf("%s@source cannot be bundled: bundles carry release binaries only", repoName)
+	}
+	return version, nil
+}
+
+// ExportBundle downloads the release binaries for specs (tool[@version], as
+// returned by ResolveDependencies) on every platform and packs them with a
+// manifest into a tar at bundlePath. Downloads are verified exactly as
+// InstallTool verifies them, so a bundle never carries a binary an online
+// install would have refused, and each release's checksums.txt (and its
+// signature, if published) is packed alongside for InstallFromBundle to check
+// again. progress, if non-nil, is called per binary.
+func (m *Manager) ExportBundle(bundlePath string, specs []string, platforms []Platform, progress func(BundleEntry)) (*BundleManifest, error) {
+	if len(platforms) == 0 {
+		platforms = []Platform{CurrentPlatform()}
+	}
+	staging, err := os.MkdirTemp("", "grove-bundle-*")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(staging)
+
+	manifest := &BundleManifest{Format: bundleFormat, Created: time.Now().UTC()}
+	staged := make(map[string]string) // member path -> staged file
+	for _, spec := range specs {
+		identifier, version, _ := strings.Cut(spec, "@")
+		repoName, toolInfo, _, found := FindTool(identifier)
+		if !found {
+			return nil, fmt.Errorf("unknown tool: %s", identifier)
+		}
+		tag, err := m.resolveVersionTag(repoName, version)
+		if err != nil {
+			return nil, fmt.Errorf("resolve %s version: %w", repoName, err)
+		}
+		release, err := m.GetRelease(repoName, tag)
+		if err != nil {
+			return nil, err
+		}
+		sums, sig, err := m.fetchChecksums(release, staging, true)
+		if err != nil {
+			return nil, err
+		}
+		var sumsMember, sigMember string
+		for member, data := range map[string][]byte{ChecksumsAsset: sums, SignatureAsset: sig} {
+			if data == nil {
+				continue
+			}
+			name := path.Join("tools", repoName, tag, member)
+			local := filepath.Join(staging, repoName+"-"+tag+"-"+member)
+			if err := os.WriteFile(local, data, 0o644); err != nil {
+				return nil, err
+			}
+			staged[name] = local
+			if member == ChecksumsAsset {
+				sumsMember = name
+			} else {
+				sigMember = name
+			}
+		}
+		for _, p := range platforms {
+			assetName := fmt.Sprintf("%s-%s-%s", toolInfo.Alias, p.OS, p.Arch)
+			url := release.assetURL(assetName)
+			if url == "" {
+				return nil, fmt.Errorf("no binary found for %s %s on %s", repoName, tag, p)
+			}
+			local := filepath.Join(staging, assetName+"-"+tag)
+			if err := m.downloadFile(url, local); err != nil {
+				return nil, fmt.Errorf("failed to download %s: %w", assetName, err)
+			}
+			if err := m.verifyAgainstChecksums(tag, sums, sig, assetName, local); err != nil {
+				return nil, fmt.Errorf("refusing to bundle %s %s: %w", repoName, tag, err)
+			}
+			sum, err := fileSHA256(local)
+			if err != nil {
+				return nil, err
+			}
+			entry := BundleEntry{
+				Repo:      repoName,
+				Version:   tag,
+				OS:        p.OS,
+				Arch:      p.Arch,
+				Path:      path.Join("tools", repoName, tag, assetName),
+				SHA256:    sum,
+				Checksums: sumsMember,
+				Signature: sigMember,
+			}
+			manifest.Tools = append(manifest.Tools, entry)
+			staged[entry.Path] = local
+			if progress != nil {
+				progress(entry)
+			}
+		}
+	}
+
+	if err := writeBundle(bundlePath, manifest, staged); err != nil {
+		return nil, err
+	}
+	return manifest, nil
+}
+
+// writeBundle writes the tar atomically: manifest first, then the checksums
+// and signatures, then the binaries, so a streaming reader has every
+// binary's checksums in hand before the binary itself.
+func writeBundle(bundlePath string, manifest *BundleManifest, staged map[string]string) error {
+	dir := filepath.Dir(bundlePath)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(dir, "."+filepath.Base(bundlePath)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	tmpName := tmp.Name()
+	defer os.Remove(tmpName)
+
+	tw := tar.NewWriter(tmp)
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := writeTarFile(tw, BundleManifestName, 0o644, manifest.Created, bytes.NewReader(data), int64(len(data))); err != nil {
+		tmp.Close()
+		return err
+	}
+	for _, name := range manifest.sidecars() {
+		if err := addTarFile(tw, name, staged[name], 0o644, manifest.Created); err != nil {
+			tmp.Close()
+			return err
+		}
+	}
+	for _, entry := range manifest.Tools {
+		if err := addTarFile(tw, entry.Path, staged[entry.Path], 0o755, manifest.Created); err != nil {
+			tmp.Close()
+			return err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmpName, bundlePath)
+}
+
+func addTarFile(tw *tar.Writer, name, src string, mode int64, modTime time.Time) error {
+	f, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	return writeTarFile(tw, name, mode, modTime, f, info.Size())
+}
+
+func writeTarFile(tw *tar.Writer, name string, mode int64, modTime time.Time, r io.Reader, size int64) error {
+	hdr := &tar.Header{
+		Name:     name,
+		Mode:     mode,
+		Size:     size,
+		ModTime:  modTime,
+		Typeflag: tar.TypeReg,
+	}
+	if err := tw.WriteHeader(hdr); err != nil {
+		return err
+	}
+	_, err := io.Copy(tw, r)
+	return err
+}
+
+func readBundleManifest(tr *tar.Reader) (*BundleManifest, error) {
+	hdr, err := tr.Next()
+	if err != nil || hdr.Name != BundleManifestName {
+		return nil, errors.New("not a grove install bundle (missing manifest.json)")
+	}
+	var manifest BundleManifest
+	if err := json.NewDecoder(tr).Decode(&manifest); err != nil {
+		return nil, fmt.Errorf("invalid bundle manifest: %w", err)
+	}
+	if manifest.Format != bundleFormat {
+		return nil, fmt.Errorf("unsupported bundle format %d (this grove reads format %d)", manifest.Format, bundleFormat)
+	}
+	for _, e := range manifest.Tools {
+		if !filepath.IsLocal(e.Version) || !bundleVersionPattern.MatchString(e.Version) {
+			return nil, fmt.Errorf("invalid bundle manifest: %s has malformed version %q", e.Repo, e.Version)
+		}
+	}
+	return &manifest, nil
+}
+
+// InstallFromBundle places the bundle's binaries for the current platform in
+// versions/<tag>/bin, checking each against the manifest's SHA-256 and the
+// bundled checksums.txt. It needs no network access; activation is left to
+// the caller (UseToolVersion and the reconciler), as with InstallTool.
+//
+// The manifest and checksums travel in the same tarball as the binaries, so
+// they only detect corruption. A bundle is authenticated only when trusted
+// keys are configured: then every release in it must carry a checksums.txt
+// signed by one of them, exactly as for an online install.
+func (m *Manager) InstallFromBundle(bundlePath string) ([]BundleEntry, error) {
+	if err := m.EnsureDirs(); err != nil {
+		return nil, err
+	}
+	f, err := os.Open(bundlePath)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	tr := tar.NewReader(f)
+	manifest, err := readBundleManifest(tr)
+	if err != nil {
+		return nil, err
+	}
+	current := CurrentPlatform()
+	wanted := make(map[string]BundleEntry)
+	for _, e := range manifest.Tools {
+		if e.OS == current.OS && e.Arch == current.Arch {
+			wanted[e.Path] = e
+		}
+	}
+	if len(wanted) == 0 {
+		return nil, fmt.Errorf("bundle has no binaries for %s (it carries %s)", current, strings.Join(manifest.Platforms(), ", "))
+	}
+	if !m.verify.Skip && len(m.verify.TrustedKeys) == 0 {
+		m.verify.warn("%s is not authenticated: without [install] trusted_keys its checksums only detect corruption", filepath.Base(bundlePath))
+	}
+	isSidecar := make(map[string]bool)
+	for _, name := range manifest.sidecars() {
+		isSidecar[name] = true
+	}
+	sidecars := make(map[string][]byte)
+
+	var installed []BundleEntry
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return installed, fmt.Errorf("read bundle: %w", err)
+		}
+		if isSidecar[hdr.Name] {
+			data, err := io.ReadAll(io.LimitReader(tr, maxBundleSidecar))
+			if err != nil {
+				return installed, fmt.Errorf("read bundle: %w", err)
+			}
+			sidecars[hdr.Name] = data
+			continue
+		}
+		entry, ok := wanted[hdr.Name]
+		if !ok {
+			continue
+		}
+		if err := m.installBundleEntry(entry, tr, sidecars); err != nil {
+			return installed, err
+		}
+		installed = append(installed, entry)
+		delete(wanted, hdr.Name)
+	}
+	if len(wanted) > 0 {
+		var missing []string
+		for name := range wanted {
+			missing = append(missing, name)
+		}
+		sort.Strings(missing)
+		return installed, fmt.Errorf("bundle is truncated: missing %s", strings.Join(missing, ", "))
+	}
+	return installed, nil
+}
+
+// installBundleEntry writes one binary under its effective alias, moving it
+// into place only after its checksum matches the manifest and it passes the
+// release verification against the bundled checksums.txt.
+func (m *Manager) installBundleEntry(entry BundleEntry, r io.Reader, sidecars map[string][]byte) error {
+	_, _, effectiveAlias, found := FindTool(entry.Repo)
+	if !found {
+		return fmt.Errorf("unknown tool in bundle: %s", entry.Repo)
+	}
+	versionBinDir := filepath.Join(paths.DataDir(), VersionsDir, entry.Version, BinDir)
+	if err := os.MkdirAll(versionBinDir, 0o755); err != nil {
+		return fmt.Errorf("failed to create version directory: %w", err)
+	}
+	targetPath := filepath.Join(versionBinDir, effectiveAlias)
+	tmpPath := filepath.Join(versionBinDir, "."+effectiveAlias+".download")
+	defer os.Remove(tmpPath)
+
+	out, err := os.OpenFile(tmpPath, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0o755) //nolint:gosec // G302: installed binaries must be executable
+	if err != nil {
+		return err
+	}
+	h := sha256.New()
+	if _, err := io.Copy(io.MultiWriter(out, h), r); err != nil {
+		out.Close()
+		return fmt.Errorf("extract %s: %w", entry.Path, err)
+	}
+	if err := out.Close(); err != nil {
+		return err
+	}
+	if got := hex.EncodeToString(h.Sum(nil)); got != entry.SHA256 {
+		return fmt.Errorf("%w: checksum mismatch for %s in bundle (expected %s, got %s)", ErrVerification, entry.Path, entry.SHA256, got)
+	}
+	if err := m.verifyAgainstChecksums(entry.Version, sidecars[entry.Checksums], sidecars[entry.Signature], path.Base(entry.Path), tmpPath); err != nil {
+		return fmt.Errorf("refusing to install %s %s from bundle: %w", entry.Repo, entry.Version, err)
+	}
+	if err := os.Chmod(tmpPath, 0o755); err != nil {
+		return fmt.Errorf("failed to make %s executable: %w", effectiveAlias, err)
+	}
+	return os.Rename(tmpPath, targetPath)
+}
@@ -0,0 +1,174 @@
+package sdk
+
+import (
+	"archive/tar"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestBundle_ExportThenInstallOffline(t *testing.T) {
+	isolateGroveHome(t)
+	other := Platform{OS: "plan9", Arch: "mips"}
+	if other == CurrentPlatform() {
+		t.Skip("need a foreign platform")
+	}
+	native := fmt.Sprintf("git-viewer-%s-%s", runtime.GOOS, runtime.GOARCH)
+	foreign := "git-viewer-plan9-mips"
+	nativeBin := []byte("native binary")
+	foreignBin := []byte("foreign binary")
+	rel := newFakeRelease(t, "v2.0.0", map[string][]byte{
+		native:         nativeBin,
+		foreign:        foreignBin,
+		ChecksumsAsset: []byte(sha256Line(native, nativeBin) + sha256Line(foreign, foreignBin)),
+	})
+
+	bundle := filepath.Join(t.TempDir(), "out", "grove.tar")
+	m := &Manager{apiBase: rel.srv.URL}
+	manifest, err := m.ExportBundle(bundle, []string{"git-viewer@v2.0.0"}, []Platform{CurrentPlatform(), other}, nil)
+	if err != nil {
+		t.Fatalf("ExportBundle: %v", err)
+	}
+	if len(manifest.Tools) != 2 || len(manifest.Platforms()) != 2 {
+		t.Fatalf("manifest = %+v", manifest)
+	}
+
+	// From here on there is no network: the stand-in is gone.
+	rel.srv.Close()
+	offline := &Manager{apiBase: "http://127.0.0.1:1"}
+	installed, err := offline.InstallFromBundle(bundle)
+	if err != nil {
+		t.Fatalf("InstallFromBundle: %v", err)
+	}
+	if len(installed) != 1 || installed[0].Version != "v2.0.0" || installed[0].OS != runtime.GOOS {
+		t.Fatalf("installed = %+v", installed)
+	}
+	got, err := os.ReadFile(installedBinary("v2.0.0"))
+	if err != nil || !bytes.Equal(got, nativeBin) {
+		t.Fatalf("installed binary = %q, %v", got, err)
+	}
+	if err := offline.UseToolVersion("git-viewer", "v2.0.0"); err != nil {
+		t.Fatalf("UseToolVersion: %v", err)
+	}
+}
+
+func TestInstallFromBundle_RejectsTamperedBinary(t *testing.T) {
+	isolateGroveHome(t)
+	p := CurrentPlatform()
+	entry := BundleEntry{
+		Repo: "git-viewer", Version: "v3.0.0", OS: p.OS, Arch: p.Arch,
+		Path:   "tools/git-viewer/v3.0.0/git-viewer",
+		SHA256: sha256Line("git-viewer", []byte("original"))[:64],
+	}
+	data, _ := json.Marshal(BundleManifest{Format: bundleFormat, Tools: []BundleEntry{entry}})
+	bundle := writeTestBundle(t, bundleMember{BundleManifestName, data}, bundleMember{entry.Path, []byte("tampered")})
+
+	m := &Manager{}
+	if _, err := m.InstallFromBundle(bundle); !errors.Is(err, ErrVerification) {
+		t.Fatalf("err = %v, want a verification failure", err)
+	}
+	if _, err := os.Stat(installedBinary("v3.0.0")); !os.IsNotExist(err) {
+		t.Fatalf("tampered binary was installed: %v", err)
+	}
+
+	// A file that is not a bundle is rejected up front.
+	notBundle := filepath.Join(t.TempDir(), "x.tar")
+	_ = os.WriteFile(notBundle, []byte("nope"), 0o644)
+	if _, err := m.InstallFromBundle(notBundle); err == nil {
+		t.Fatal("expected an error for a non-bundle file")
+	}
+}
+
+type bundleMember struct {
+	name string
+	body []byte
+}
+
+// writeTestBundle writes members, in order, to a tar in a temp dir.
+func writeTestBundle(t *testing.T, members ...bundleMember) string {
+	t.Helper()
+	bundle := filepath.Join(t.TempDir(), "grove.tar")
+	f, err := os.Create(bundle)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	tw := tar.NewWriter(f)
+	for _, member := range members {
+		if err := tw.WriteHeader(&tar.Header{Name: member.name, Mode: 0o644, Size: int64(len(member.body))}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tw.Write(member.body); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return bundle
+}
+
+func TestInstallFromBundle_RejectsEscapingVersion(t *testing.T) {
+	isolateGroveHome(t)
+	p := CurrentPlatform()
+	for _, version := range []string{"../../../../tmp/evil", "/abs/v1.0.0", "v1.0.0/../../x", "latest"} {
+		entry := BundleEntry{Repo: "git-viewer", Version: version, OS: p.OS, Arch: p.Arch, Path: "tools/git-viewer/x/git-viewer"}
+		data, _ := json.Marshal(BundleManifest{Format: bundleFormat, Tools: []BundleEntry{entry}})
+		bundle := writeTestBundle(t, bundleMember{BundleManifestName, data}, bundleMember{entry.Path, []byte("x")})
+		if _, err := (&Manager{}).InstallFromBundle(bundle); err == nil || !strings.Contains(err.Error(), "malformed version") {
+			t.Errorf("version %q: err = %v, want a malformed version error", version, err)
+		}
+	}
+}
+
+// TestBundle_TrustedKeysAuthenticateOffline: the signed checksums.txt rides
+// in the bundle, so with trusted keys a rebuilt bundle whose manifest agrees
+// with a swapped binary is still refused.
+func TestBundle_TrustedKeysAuthenticateOffline(t *testing.T) {
+	isolateGroveHome(t)
+	asset := fmt.Sprintf("git-viewer-%s-%s", runtime.GOOS, runtime.GOARCH)
+	binary := []byte("signed binary")
+	sums := []byte(sha256Line(asset, binary))
+	sig, key := minisignSign(t, sums)
+	rel := newFakeRelease(t, "v4.0.0", map[string][]byte{asset: binary, ChecksumsAsset: sums, SignatureAsset: sig})
+
+	bundle := filepath.Join(t.TempDir(), "grove.tar")
+	signed := VerifyOptions{TrustedKeys: []string{key}}
+	m := &Manager{apiBase: rel.srv.URL, verify: signed}
+	manifest, err := m.ExportBundle(bundle, []string{"git-viewer@v4.0.0"}, nil, nil)
+	if err != nil {
+		t.Fatalf("ExportBundle: %v", err)
+	}
+	entry := manifest.Tools[0]
+	if entry.Checksums == "" || entry.Signature == "" {
+		t.Fatalf("bundle entry lacks sidecars: %+v", entry)
+	}
+	if _, err := (&Manager{verify: signed}).InstallFromBundle(bundle); err != nil {
+		t.Fatalf("InstallFromBundle: %v", err)
+	}
+
+	swapped := []byte("swapped binary")
+	entry.SHA256 = sha256Line(asset, swapped)[:64]
+	forged, _ := json.Marshal(BundleManifest{Format: bundleFormat, Tools: []BundleEntry{entry}})
+	rebuilt := writeTestBundle(t,
+		bundleMember{BundleManifestName, forged},
+		bundleMember{entry.Checksums, sums},
+		bundleMember{entry.Signature, sig},
+		bundleMember{entry.Path, swapped})
+	if _, err := (&Manager{verify: signed}).InstallFromBundle(rebuilt); !errors.Is(err, ErrVerification) {
+		t.Fatalf("rebuilt bundle: err = %v, want a verification failure", err)
+	}
+
+	entry.Checksums, entry.Signature = "", ""
+	stripped, _ := json.Marshal(BundleManifest{Format: bundleFormat, Tools: []BundleEntry{entry}})
+	unsigned := writeTestBundle(t, bundleMember{BundleManifestName, stripped}, bundleMember{entry.Path, swapped})
+	if _, err := (&Manager{verify: signed}).InstallFromBundle(unsigned); !errors.Is(err, ErrVerification) {
+		t.Fatalf("bundle without checksums: err = %v, want a verification failure", err)
+	}
+}
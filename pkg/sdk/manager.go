@@ -116,7 +116,10 @@ func GetToolRegistry() map[string]ToolInfo {
 
 // Manager handles SDK installation and version management
 type Manager struct {
-	useGH bool
+	useGH   bool
+	apiBase string // GitHub API root; tests point it at a local stand-in
+	owner   string // GitHub org releases come from; GitHubOwner when empty
+	verify  VerifyOptions
 }
 
 // NewManager creates a new SDK manager instance
@@ -125,7 +128,8 @@ func NewManager() (*Manager, error) {
 	_ = MigrateFromSingleVersion()
 
 	return &Manager{
-		useGH: false,
+		useGH:   false,
+		apiBase: GitHubAPI,
 	}, nil
 }
 
@@ -134,6 +138,34 @@ func (m *Manager) SetUseGH(useGH bool) {
 	m.useGH = useGH
 }
 
+// SetVerifyOptions sets how release downloads are verified
+func (m *Manager) SetVerifyOptions(opts VerifyOptions) {
+	m.verify = opts
+}
+
+// SetOwner sets the GitHub org tools are installed from, for ecosystems
+// published outside GitHubOwner. An empty owner restores the default.
+func (m *Manager) SetOwner(owner string) {
+	m.owner = owner
+}
+
+// repoSlug returns "owner/repo" for a tool repository.
+func (m *Manager) repoSlug(repoName string) string {
+	owner := m.owner
+	if owner == "" {
+		owner = GitHubOwner
+	}
+	return owner + "/" + repoName
+}
+
+// api returns the GitHub API root
+func (m *Manager) api() string {
+	if m.apiBase == "" {
+		return GitHubAPI
+	}
+	return m.apiBase
+}
+
 // ResolveDependencies takes a list of user-specified tools and returns a complete
 // list of tools to install, including all dependencies
 func (m *Manager) ResolveDependencies(initialToolSpecs []string) ([]string, error) {
@@ -269,6 +301,17 @@ type GitHubRelease struct {
 	} `json:"assets"`
 }
 
+// assetURL returns the download URL of the named asset, or "" when the
+// release has none.
+func (r *GitHubRelease) assetURL(name string) string {
+	for _, asset := range r.Assets {
+		if asset.Name == name {
+			return asset.BrowserDownloadURL
+		}
+	}
+	return ""
+}
+
 // resolveRepoName resolves a tool name to its repository name
 // It uses the FindTool function to handle aliases and canonical names
 func resolveRepoName(toolName string) (string, error) {
@@ -297,7 +340,7 @@ func (m *Manager) GetLatestVersionTag(toolName string) (string, error) {
 		return m.getLatestVersionTagWithGH(repoName)
 	}
 
-	url := fmt.Sprintf("%s/repos/%s/%s/releases/latest", GitHubAPI, GitHubOwner, repoName)
+	url := fmt.Sprintf("%s/repos/%s/releases/latest", m.api(), m.repoSlug(repoName))
 
 	resp, err := http.Get(url) //nolint:gosec // G107: URL constructed from trusted config
 	if err != nil {
@@ -319,7 +362,7 @@ func (m *Manager) GetLatestVersionTag(toolName string) (string, error) {
 
 // getLatestVersionTagWithGH fetches the latest release tag using gh CLI
 func (m *Manager) getLatestVersionTagWithGH(repoName string) (string, error) {
-	cmd := exec.Command("gh", "release", "view", "--repo", fmt.Sprintf("%s/%s", GitHubOwner, repoName), "--json", "tagName") //nolint:gosec // G204: args are not user-controlled
+	cmd := exec.Command("gh", "release", "view", "--repo", m.repoSlug(repoName), "--json", "tagName") //nolint:gosec // G204: args are not user-controlled
 
 	output, err := cmd.Output()
 	if err != nil {
@@ -346,7 +389,7 @@ func (m *Manager) GetLatestPrereleaseVersionTag(toolName string) (string, error)
 
 	if m.useGH {
 		// Use gh CLI to list releases including pre-releases
-		cmd := exec.Command("gh", "release", "list", "--repo", fmt.Sprintf("%s/%s", GitHubOwner, repoName), "--limit", "20", "--json", "tagName,isPrerelease") //nolint:gosec // G204: args are not user-controlled
+		cmd := exec.Command("gh", "release", "list", "--repo", m.repoSlug(repoName), "--limit", "20", "--json", "tagName,isPrerelease") //nolint:gosec // G204: args are not user-controlled
 		output, err := cmd.Output()
 		if err != nil {
 			return "", fmt.Errorf("gh CLI failed to list releases: %w", err)
@@ -372,7 +415,7 @@ func (m *Manager) GetLatestPrereleaseVersionTag(toolName string) (string, error)
 	}
 
 	// Use GitHub API to list releases
-	url := fmt.Sprintf("%s/repos/%s/%s/releases", GitHubAPI, GitHubOwner, repoName)
+	url := fmt.Sprintf("%s/repos/%s/releases", m.api(), m.repoSlug(repoName))
 
 	resp, err := http.Get(url) //nolint:gosec // G107: URL constructed from trusted config
 	if err != nil {
@@ -410,7 +453,7 @@ func (m *Manager) GetRelease(toolName, version string) (*GitHubRelease, error) {
 		return m.getReleaseWithGH(repoName, version)
 	}
 
-	url := fmt.Sprintf("%s/repos/%s/%s/releases/tags/%s", GitHubAPI, GitHubOwner, repoName, version)
+	url := fmt.Sprintf("%s/repos/%s/releases/tags/%s", m.api(), m.repoSlug(repoName), version)
 
 	resp, err := http.Get(url) //nolint:gosec // G107: URL constructed from trusted config
 	if err != nil {
@@ -432,7 +475,7 @@ func (m *Manager) GetRelease(toolName, version string) (*GitHubRelease, error) {
 
 // getReleaseWithGH fetches release information using gh CLI
 func (m *Manager) getReleaseWithGH(repoName, version string) (*GitHubRelease, error) {
-	cmd := exec.Command("gh", "release", "view", version, "--repo", fmt.Sprintf("%s/%s", GitHubOwner, repoName), "--json", "tagName,assets") //nolint:gosec // G204: args are not user-controlled
+	cmd := exec.Command("gh", "release", "view", version, "--repo", m.repoSlug(repoName), "--json", "tagName,assets") //nolint:gosec // G204: args are not user-controlled
 
 	output, err := cmd.Output()
 	if err != nil {
@@ -463,7 +506,7 @@ func (m *Manager) getReleaseWithGH(repoName, version string) (*GitHubRelease, er
 			BrowserDownloadURL string `json:"browser_download_url"`
 		}{
 			Name:               asset.Name,
-			BrowserDownloadURL: fmt.Sprintf("https://github.com/%s/%s/releases/download/%s/%s", GitHubOwner, repoName, version, asset.Name),
+			BrowserDownloadURL: fmt.Sprintf("https://github.com/%s/releases/download/%s/%s", m.repoSlug(repoName), version, asset.Name),
 		})
 	}
 
@@ -516,14 +559,7 @@ func (m *Manager) InstallTool(toolName, versionTag string) error {
 	binaryAssetName := fmt.Sprintf("%s-%s-%s", toolInfo.Alias, osName, archName)
 
 	// Find the asset URL
-	var downloadURL string
-	for _, asset := range release.Assets {
-		if asset.Name == binaryAssetName {
-			downloadURL = asset.BrowserDownloadURL
-			break
-		}
-	}
-
+	downloadURL := release.assetURL(binaryAssetName)
 	if downloadURL == "" {
 		return fmt.Errorf("no binary found for %s on %s/%s", toolName, osName, archName)
 	}
@@ -534,20 +570,118 @@ func (m *Manager) InstallTool(toolName, versionTag string) error {
 		return fmt.Errorf("failed to create version directory: %w", err)
 	}
 
-	// Download the binary - use effective alias as the binary name
+	// Download next to the target and only move it into place once verified,
+	// so a truncated or tampered download never becomes the active binary.
 	targetPath := filepath.Join(versionBinDir, effectiveAlias)
-	if err := m.downloadFile(downloadURL, targetPath); err != nil {
+	tmpPath := filepath.Join(versionBinDir, "."+effectiveAlias+".download")
+	defer os.Remove(tmpPath)
+	if err := m.downloadFile(downloadURL, tmpPath); err != nil {
 		return fmt.Errorf("failed to download %s: %w", repoName, err)
 	}
+	if err := m.verifyDownload(release, binaryAssetName, tmpPath); err != nil {
+		return fmt.Errorf("refusing to install %s %s: %w", repoName, versionTag, err)
+	}
 
 	// Make executable
-	if err := os.Chmod(targetPath, 0o755); err != nil {
+	if err := os.Chmod(tmpPath, 0o755); err != nil {
 		return fmt.Errorf("failed to make %s executable: %w", effectiveAlias, err)
 	}
+	if err := os.Rename(tmpPath, targetPath); err != nil {
+		return fmt.Errorf("failed to install %s: %w", effectiveAlias, err)
+	}
 
 	return nil
 }
 
+// verifyDownload checks the downloaded asset at path against the release's
+// checksums.txt and, when trusted keys are configured, the checksums file's
+// minisign signature. See VerifyOptions for what is fatal.
+func (m *Manager) verifyDownload(release *GitHubRelease, assetName, path string) error {
+	if m.verify.Skip {
+		m.verify.warn("skipping verification of %s (--insecure-skip-verify)", assetName)
+		return nil
+	}
+	sums, sig, err := m.fetchChecksums(release, filepath.Dir(path), len(m.verify.TrustedKeys) > 0)
+	if err != nil {
+		return err
+	}
+	return m.verifyAgainstChecksums(release.TagName, sums, sig, assetName, path)
+}
+
+// fetchChecksums downloads the release's checksums.txt and, when withSig is
+// set, its minisign signature, staging them in dir. Either is nil when the
+// release does not carry it.
+func (m *Manager) fetchChecksums(release *GitHubRelease, dir string, withSig bool) (sums, sig []byte, err error) {
+	if url := release.assetURL(ChecksumsAsset); url != "" {
+		if sums, err = m.fetchAsset(url, dir); err != nil {
+			return nil, nil, fmt.Errorf("failed to download %s: %w", ChecksumsAsset, err)
+		}
+	}
+	if url := release.assetURL(SignatureAsset); withSig && sums != nil && url != "" {
+		if sig, err = m.fetchAsset(url, dir); err != nil {
+			return nil, nil, fmt.Errorf("failed to download %s: %w", SignatureAsset, err)
+		}
+	}
+	return sums, sig, nil
+}
+
+// verifyAgainstChecksums checks the file at path against sums, the
+// checksums.txt of release tag (nil if it has none), after checking sig over
+// sums when trusted keys are configured. Downloads and bundles share it.
+func (m *Manager) verifyAgainstChecksums(tag string, sums, sig []byte, assetName, path string) error {
+	if m.verify.Skip {
+		m.verify.warn("skipping verification of %s (--insecure-skip-verify)", assetName)
+		return nil
+	}
+	var trusted []minisignKey
+	for _, s := range m.verify.TrustedKeys {
+		k, err := parseMinisignKey(s)
+		if err != nil {
+			return err
+		}
+		trusted = append(trusted, k)
+	}
+
+	if sums == nil {
+		if len(trusted) > 0 {
+			return fmt.Errorf("%w: release %s has no %s to check against the trusted keys", ErrVerification, tag, ChecksumsAsset)
+		}
+		m.verify.warn("release %s has no %s - skipping verification", tag, ChecksumsAsset)
+		return nil
+	}
+	if len(trusted) > 0 {
+		if sig == nil {
+			return fmt.Errorf("%w: release %s is not signed (no %s)", ErrVerification, tag, SignatureAsset)
+		}
+		if err := verifyMinisign(sums, sig, trusted); err != nil {
+			return err
+		}
+	}
+
+	parsed, err := ParseChecksums(sums)
+	if err != nil {
+		return fmt.Errorf("%w: %s: %v", ErrVerification, ChecksumsAsset, err)
+	}
+	return verifyAsset(parsed, assetName, path)
+}
+
+// fetchAsset downloads a small release asset (checksums, signatures) into
+// memory, staging it in dir.
+func (m *Manager) fetchAsset(url, dir string) ([]byte, error) {
+	tmp, err := os.CreateTemp(dir, ".asset-*")
+	if err != nil {
+		return nil, err
+	}
+	tmpPath := tmp.Name()
+	tmp.Close()
+	defer os.Remove(tmpPath)
+
+	if err := m.downloadFile(url, tmpPath); err != nil {
+		return nil, err
+	}
+	return os.ReadFile(tmpPath)
+}
+
 // InstallAllToolsFromSource clones all Grove tools and builds them in a shared workspace
 func (m *Manager) InstallAllToolsFromSource() error {
 	// Ensure directories exist
@@ -599,7 +733,7 @@ func (m *Manager) InstallAllToolsFromSource() error {
 		}
 
 		fmt.Printf("  Cloning %s...\n", repo)
-		repoSlug := fmt.Sprintf("%s/%s", GitHubOwner, repo)
+		repoSlug := m.repoSlug(repo)
 		cloneCmd := exec.Command("gh", "repo", "clone", repoSlug, repoPath, "--", "--depth=1") //nolint:gosec // G204: args are not user-controlled
 		if output, err := cloneCmd.CombinedOutput(); err != nil {
 			return fmt.Errorf("failed to clone %s: %w\nOutput: %s", repo, err, string(output))
@@ -650,7 +784,7 @@ func (m *Manager) InstallAllToolsFromSource() error {
 		buildCmd := exec.Command("make", "build") //nolint:gosec // G204: args are not user-controlled
 		buildCmd.Dir = buildDir
 		buildCmd.Env = append(os.Environ(),
-			"GOPRIVATE=github.com/grovetools/*",
+			"GOPRIVATE=github.com/"+m.repoSlug("*"),
 			fmt.Sprintf("GOWORK=%s", goWorkPath))
 
 		if output, err := buildCmd.CombinedOutput(); err != nil {
@@ -766,7 +900,7 @@ func (m *Manager) InstallToolFromSource(toolName string) (string, error) {
 		}
 
 		// Clone the repository
-		repoSlug := fmt.Sprintf("%s/%s", GitHubOwner, repo)
+		repoSlug := m.repoSlug(repo)
 		cloneCmd := exec.Command("gh", "repo", "clone", repoSlug, repoPath, "--", "--depth=1") //nolint:gosec // G204: args are not user-controlled
 		if output, err := cloneCmd.CombinedOutput(); err != nil {
 			return "", fmt.Errorf("failed to clone %s: %w\nOutput: %s", repo, err, string(output))
@@ -803,7 +937,7 @@ func (m *Manager) InstallToolFromSource(toolName string) (string, error) {
 	buildCmd := exec.Command("make", "build") //nolint:gosec // G204: args are not user-controlled
 	buildCmd.Dir = buildDir
 	buildCmd.Env = append(os.Environ(),
-		"GOPRIVATE=github.com/grovetools/*",
+		"GOPRIVATE=github.com/"+m.repoSlug("*"),
 		fmt.Sprintf("GOWORK=%s", goWorkPath))
 	if output, err := buildCmd.CombinedOutput(); err != nil {
 		return "", fmt.Errorf("build failed: %w\nOutput: %s", err, string(output))
@@ -3,6 +3,8 @@ package sdk
 import (
 	"path/filepath"
 	"testing"
+
+	"github.com/grovetools/grove/pkg/project"
 )
 
 // TestGitViewerIsRegisteredForDelegation guards the generated registry: the
@@ -25,3 +27,24 @@ func TestGitViewerIsRegisteredForDelegation(t *testing.T) {
 		t.Fatalf("git-viewer resolved oddly: repo=%q info=%+v alias=%q", repoName, info, alias)
 	}
 }
+
+// TestMixedEcosystemInstallsUpstreamTools pins that module_prefixes alone
+// never redirect installs: an ecosystem with its own github.com/acme/ modules
+// still resolves the upstream tools to grovetools, and only an explicit
+// github_owner moves them.
+func TestMixedEcosystemInstallsUpstreamTools(t *testing.T) {
+	mixed := project.EcosystemConfig{ModulePrefixes: []string{"github.com/acme/", "github.com/grovetools/"}}
+	m := &Manager{}
+	m.SetOwner(mixed.ReleaseOwner())
+	for _, tool := range []string{"grove", "cx", "flow"} {
+		if got := m.repoSlug(tool); got != GitHubOwner+"/"+tool {
+			t.Errorf("repoSlug(%s) = %q, want %s/%s", tool, got, GitHubOwner, tool)
+		}
+	}
+
+	mixed.GitHubOwner = "acme"
+	m.SetOwner(mixed.ReleaseOwner())
+	if got := m.repoSlug("grove"); got != "acme/grove" {
+		t.Errorf("explicit github_owner: repoSlug(grove) = %q, want acme/grove", got)
+	}
+}
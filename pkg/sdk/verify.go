@@ -0,0 +1,175 @@
+package sdk
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"golang.org/x/crypto/blake2b"
+)
+
+const (
+	// ChecksumsAsset is the release asset listing the SHA-256 of every other
+	// asset, in `sha256sum` output format (the release workflow writes it).
+	ChecksumsAsset = "checksums.txt"
+	// SignatureAsset is the optional minisign signature over ChecksumsAsset.
+	SignatureAsset = ChecksumsAsset + ".minisig"
+)
+
+// ErrVerification marks a download that failed integrity or signature checks.
+// Install refuses such downloads unless verification is explicitly skipped.
+var ErrVerification = errors.New("verification failed")
+
+// VerifyOptions controls how release downloads are checked before they are
+// placed in versions/<tag>/bin.
+//
+// A SHA-256 mismatch, or an asset missing from a release's checksums.txt, is
+// always fatal. A release with no checksums.txt at all (older releases) only
+// warns — unless TrustedKeys is set, in which case every release must carry a
+// checksums.txt signed by one of them.
+type VerifyOptions struct {
+	// TrustedKeys are minisign public keys: the base64 "RW..." line of a
+	// minisign .pub file.
+	TrustedKeys []string
+	// Skip disables verification entirely (--insecure-skip-verify).
+	Skip bool
+	// Warn receives non-fatal notices; nil discards them.
+	Warn func(msg string)
+}
+
+func (o VerifyOptions) warn(format string, args ...interface{}) {
+	if o.Warn != nil {
+		o.Warn(fmt.Sprintf(format, args...))
+	}
+}
+
+// ParseChecksums parses `sha256sum` output into asset name -> lowercase hex
+// digest. Both the text ("<sum>  name") and binary ("<sum> *name") forms are
+// accepted.
+func ParseChecksums(data []byte) (map[string]string, error) {
+	sums := make(map[string]string)
+	sc := bufio.NewScanner(bytes.NewReader(data))
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("malformed checksums line %q", line)
+		}
+		sum := strings.ToLower(fields[0])
+		if b, err := hex.DecodeString(sum); err != nil || len(b) != sha256.Size {
+			return nil, fmt.Errorf("malformed sha256 %q", fields[0])
+		}
+		sums[strings.TrimPrefix(fields[1], "*")] = sum
+	}
+	return sums, sc.Err()
+}
+
+// fileSHA256 returns the lowercase hex SHA-256 of the file at path.
+func fileSHA256(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// verifyAsset checks the downloaded file at path against the checksums
+// entry for assetName.
+func verifyAsset(sums map[string]string, assetName, path string) error {
+	want, ok := sums[assetName]
+	if !ok {
+		return fmt.Errorf("%w: %s is not listed in %s", ErrVerification, assetName, ChecksumsAsset)
+	}
+	got, err := fileSHA256(path)
+	if err != nil {
+		return err
+	}
+	if got != want {
+		return fmt.Errorf("%w: checksum mismatch for %s (expected %s, got %s)", ErrVerification, assetName, want, got)
+	}
+	return nil
+}
+
+// minisignKey is a decoded minisign public key.
+type minisignKey struct {
+	id  [8]byte
+	key ed25519.PublicKey
+}
+
+// parseMinisignKey decodes the base64 line of a minisign public key. A full
+// .pub file (with its "untrusted comment:" line) is accepted too.
+func parseMinisignKey(s string) (minisignKey, error) {
+	var k minisignKey
+	s = strings.TrimSpace(s)
+	if i := strings.LastIndexByte(s, '\n'); i >= 0 {
+		s = strings.TrimSpace(s[i+1:])
+	}
+	raw, err := base64.StdEncoding.DecodeString(s)
+	if err != nil || len(raw) != 2+8+ed25519.PublicKeySize || string(raw[:2]) != "Ed" {
+		return k, fmt.Errorf("invalid minisign public key %q", s)
+	}
+	copy(k.id[:], raw[2:10])
+	k.key = ed25519.PublicKey(raw[10:])
+	return k, nil
+}
+
+// verifyMinisign checks a minisign signature over message against the
+// trusted keys. Both legacy ("Ed", signs the message) and pre-hashed ("ED",
+// signs its BLAKE2b-512) signatures are accepted; the trusted comment must
+// carry a valid global signature as well, so it cannot be swapped.
+func verifyMinisign(message, sig []byte, trusted []minisignKey) error {
+	lines := strings.Split(strings.ReplaceAll(string(sig), "\r\n", "\n"), "\n")
+	if len(lines) < 4 || !strings.HasPrefix(lines[0], "untrusted comment:") || !strings.HasPrefix(lines[2], "trusted comment: ") {
+		return fmt.Errorf("%w: malformed minisign signature", ErrVerification)
+	}
+	raw, err := base64.StdEncoding.DecodeString(strings.TrimSpace(lines[1]))
+	if err != nil || len(raw) != 2+8+ed25519.SignatureSize {
+		return fmt.Errorf("%w: malformed minisign signature", ErrVerification)
+	}
+	global, err := base64.StdEncoding.DecodeString(strings.TrimSpace(lines[3]))
+	if err != nil || len(global) != ed25519.SignatureSize {
+		return fmt.Errorf("%w: malformed minisign trusted comment signature", ErrVerification)
+	}
+
+	alg, keyID, signature := string(raw[:2]), raw[2:10], raw[10:]
+	signed := message
+	switch alg {
+	case "Ed":
+	case "ED":
+		sum := blake2b.Sum512(message)
+		signed = sum[:]
+	default:
+		return fmt.Errorf("%w: unsupported minisign algorithm %q", ErrVerification, alg)
+	}
+
+	for _, k := range trusted {
+		if !bytes.Equal(k.id[:], keyID) {
+			continue
+		}
+		if !ed25519.Verify(k.key, signed, signature) {
+			return fmt.Errorf("%w: bad signature on %s (key %X)", ErrVerification, ChecksumsAsset, keyID)
+		}
+		comment := strings.TrimPrefix(lines[2], "trusted comment: ")
+		if !ed25519.Verify(k.key, append(append([]byte{}, signature...), comment...), global) {
+			return fmt.Errorf("%w: bad trusted comment signature on %s", ErrVerification, ChecksumsAsset)
+		}
+		return nil
+	}
+	return fmt.Errorf("%w: %s is signed by untrusted key %X", ErrVerification, ChecksumsAsset, keyID)
+}
@@ -0,0 +1,208 @@
+package sdk
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"golang.org/x/crypto/blake2b"
+
+	"github.com/grovetools/core/pkg/paths"
+)
+
+// fakeRelease is a local stand-in for the GitHub releases API serving one
+// release of git-viewer.
+type fakeRelease struct {
+	srv    *httptest.Server
+	assets map[string][]byte
+}
+
+func newFakeRelease(t *testing.T, tag string, assets map[string][]byte) *fakeRelease {
+	t.Helper()
+	f := &fakeRelease{assets: assets}
+	f.srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == fmt.Sprintf("/repos/%s/git-viewer/releases/tags/%s", GitHubOwner, tag) {
+			rel := GitHubRelease{TagName: tag}
+			for name := range f.assets {
+				rel.Assets = append(rel.Assets, struct {
+					Name               string `json:"name"`
+					BrowserDownloadURL string `json:"browser_download_url"`
+				}{Name: name, BrowserDownloadURL: f.srv.URL + "/download/" + name})
+			}
+			_ = json.NewEncoder(w).Encode(rel)
+			return
+		}
+		if name, ok := strings.CutPrefix(r.URL.Path, "/download/"); ok {
+			if data, ok := f.assets[name]; ok {
+				_, _ = w.Write(data)
+				return
+			}
+		}
+		http.NotFound(w, r)
+	}))
+	t.Cleanup(f.srv.Close)
+	return f
+}
+
+func isolateGroveHome(t *testing.T) {
+	t.Helper()
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("GROVE_HOME", "")
+	t.Setenv("XDG_DATA_HOME", filepath.Join(home, ".local", "share"))
+	t.Setenv("XDG_STATE_HOME", filepath.Join(home, ".local", "state"))
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(home, ".config"))
+}
+
+func sha256Line(name string, data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]) + "  " + name + "\n"
+}
+
+// minisignSign produces a pre-hashed ("ED") minisign signature and the
+// matching public key line, as `minisign -S` would.
+func minisignSign(t *testing.T, message []byte) (sig []byte, pubKey string) {
+	t.Helper()
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyID := []byte{1, 2, 3, 4, 5, 6, 7, 8}
+	pubKey = base64.StdEncoding.EncodeToString(append(append([]byte("Ed"), keyID...), pub...))
+
+	hash := blake2b.Sum512(message)
+	signature := ed25519.Sign(priv, hash[:])
+	comment := "timestamp:1760000000\tfile:checksums.txt\thashed"
+	global := ed25519.Sign(priv, append(append([]byte{}, signature...), comment...))
+	sig = []byte("untrusted comment: signature from minisign secret key\n" +
+		base64.StdEncoding.EncodeToString(append(append([]byte("ED"), keyID...), signature...)) + "\n" +
+		"trusted comment: " + comment + "\n" +
+		base64.StdEncoding.EncodeToString(global) + "\n")
+	return sig, pubKey
+}
+
+func installedBinary(tag string) string {
+	return filepath.Join(paths.DataDir(), VersionsDir, tag, BinDir, "git-viewer")
+}
+
+func TestInstallTool_VerifiesChecksums(t *testing.T) {
+	isolateGroveHome(t)
+	asset := fmt.Sprintf("git-viewer-%s-%s", runtime.GOOS, runtime.GOARCH)
+	binary := []byte("#!/bin/sh\necho git-viewer\n")
+
+	t.Run("match installs", func(t *testing.T) {
+		rel := newFakeRelease(t, "v1.0.0", map[string][]byte{
+			asset:          binary,
+			ChecksumsAsset: []byte(sha256Line(asset, binary)),
+		})
+		m := &Manager{apiBase: rel.srv.URL}
+		if err := m.InstallTool("git-viewer", "v1.0.0"); err != nil {
+			t.Fatalf("InstallTool: %v", err)
+		}
+		info, err := os.Stat(installedBinary("v1.0.0"))
+		if err != nil || info.Mode()&0o111 == 0 {
+			t.Fatalf("binary not installed executable: %v", err)
+		}
+	})
+
+	t.Run("truncated download is refused", func(t *testing.T) {
+		rel := newFakeRelease(t, "v1.0.1", map[string][]byte{
+			asset:          binary[:5],
+			ChecksumsAsset: []byte(sha256Line(asset, binary)),
+		})
+		m := &Manager{apiBase: rel.srv.URL}
+		err := m.InstallTool("git-viewer", "v1.0.1")
+		if !errors.Is(err, ErrVerification) || !strings.Contains(err.Error(), "checksum mismatch") {
+			t.Fatalf("err = %v, want a checksum mismatch", err)
+		}
+		if _, err := os.Stat(installedBinary("v1.0.1")); !os.IsNotExist(err) {
+			t.Fatalf("refused download was placed in bin: %v", err)
+		}
+		entries, _ := os.ReadDir(filepath.Dir(installedBinary("v1.0.1")))
+		if len(entries) != 0 {
+			t.Fatalf("temporary files left behind: %v", entries)
+		}
+
+		// The escape hatch installs it anyway.
+		m.SetVerifyOptions(VerifyOptions{Skip: true})
+		if err := m.InstallTool("git-viewer", "v1.0.1"); err != nil {
+			t.Fatalf("InstallTool with Skip: %v", err)
+		}
+	})
+
+	t.Run("missing checksums warns unless keys are trusted", func(t *testing.T) {
+		rel := newFakeRelease(t, "v0.9.0", map[string][]byte{asset: binary})
+		var warnings []string
+		m := &Manager{apiBase: rel.srv.URL}
+		m.SetVerifyOptions(VerifyOptions{Warn: func(msg string) { warnings = append(warnings, msg) }})
+		if err := m.InstallTool("git-viewer", "v0.9.0"); err != nil {
+			t.Fatalf("InstallTool: %v", err)
+		}
+		if len(warnings) != 1 || !strings.Contains(warnings[0], ChecksumsAsset) {
+			t.Fatalf("warnings = %v", warnings)
+		}
+
+		_, key := minisignSign(t, nil)
+		m.SetVerifyOptions(VerifyOptions{TrustedKeys: []string{key}})
+		if err := m.InstallTool("git-viewer", "v0.9.0"); !errors.Is(err, ErrVerification) {
+			t.Fatalf("err = %v, want refusal without checksums", err)
+		}
+	})
+
+	t.Run("signature is checked against trusted keys", func(t *testing.T) {
+		sums := []byte(sha256Line(asset, binary))
+		sig, key := minisignSign(t, sums)
+		rel := newFakeRelease(t, "v1.1.0", map[string][]byte{
+			asset:          binary,
+			ChecksumsAsset: sums,
+			SignatureAsset: sig,
+		})
+		m := &Manager{apiBase: rel.srv.URL}
+		m.SetVerifyOptions(VerifyOptions{TrustedKeys: []string{key}})
+		if err := m.InstallTool("git-viewer", "v1.1.0"); err != nil {
+			t.Fatalf("InstallTool with a valid signature: %v", err)
+		}
+
+		_, otherKey := minisignSign(t, sums)
+		m.SetVerifyOptions(VerifyOptions{TrustedKeys: []string{otherKey}})
+		err := m.InstallTool("git-viewer", "v1.1.0")
+		if !errors.Is(err, ErrVerification) {
+			t.Fatalf("err = %v, want refusal for a key that is not trusted", err)
+		}
+
+		// A checksums file rewritten after signing fails the signature.
+		rel.assets[ChecksumsAsset] = []byte(sha256Line(asset, []byte("evil")))
+		rel.assets[asset] = []byte("evil")
+		m.SetVerifyOptions(VerifyOptions{TrustedKeys: []string{key}})
+		if err := m.InstallTool("git-viewer", "v1.1.0"); !errors.Is(err, ErrVerification) || !strings.Contains(err.Error(), "bad signature") {
+			t.Fatalf("err = %v, want a bad signature", err)
+		}
+	})
+}
+
+func TestParseChecksums(t *testing.T) {
+	sums, err := ParseChecksums([]byte(
+		"E3B0C44298FC1C149AFBF4C8996FB92427AE41E4649B934CA495991B7852B855  grove-linux-amd64\n" +
+			"e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855 *grove-darwin-arm64\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sums) != 2 || sums["grove-darwin-arm64"] != sums["grove-linux-amd64"] {
+		t.Fatalf("sums = %v", sums)
+	}
+	if _, err := ParseChecksums([]byte("nothex  grove\n")); err == nil {
+		t.Fatal("expected an error for a malformed digest")
+	}
+}